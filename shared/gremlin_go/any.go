@@ -0,0 +1,65 @@
+package gremlin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnyTypeURLPrefix is the prefix of the type URLs packed messages get, the default of protobuf implementations.
+const AnyTypeURLPrefix = "type.googleapis.com/"
+
+// AnyMessage is implemented by the google.protobuf.Any reader and struct of the wkt package.
+type AnyMessage interface {
+	GetTypeUrl() string
+	GetValue() []byte
+}
+
+// RawAny is a packed message of a type that is not in the registry.
+type RawAny struct {
+	TypeURL string
+	Value   []byte
+}
+
+func (r *RawAny) Unmarshal(data []byte) error {
+	r.Value = data
+	return nil
+}
+
+func (r *RawAny) SourceBytes() []byte {
+	return r.Value
+}
+
+// AnyTypeURL returns the type URL of a message type, AnyTypeURLPrefix followed by the full name.
+func AnyTypeURL(m *MessageDescriptor) string {
+	return AnyTypeURLPrefix + m.FullName
+}
+
+// AnyMessageName returns the full message name a type URL refers to, the part after the last '/'.
+func AnyMessageName(typeURL string) string {
+	return typeURL[strings.LastIndexByte(typeURL, '/')+1:]
+}
+
+// FindMessageByURL returns the registered message a type URL refers to, or nil.
+// Only the name is used, whatever host the URL has.
+func FindMessageByURL(typeURL string) *MessageDescriptor {
+	return FindMessage(AnyMessageName(typeURL))
+}
+
+// UnpackAny returns the packed message as the generated reader of its type, found in the registry by the
+// type URL. Like any reader it only records offsets, fields are parsed when they are read. Messages of types
+// which are not registered come back as a *RawAny with the packed bytes, an Any without a type URL as nil.
+func UnpackAny(a AnyMessage) (ProtoReader, error) {
+	typeURL := a.GetTypeUrl()
+	if typeURL == "" {
+		return nil, nil
+	}
+	desc := FindMessageByURL(typeURL)
+	if desc == nil || desc.NewReader == nil {
+		return &RawAny{TypeURL: typeURL, Value: a.GetValue()}, nil
+	}
+	reader := desc.NewReader()
+	if err := reader.Unmarshal(a.GetValue()); err != nil {
+		return nil, fmt.Errorf("any %v: %w", typeURL, err)
+	}
+	return reader, nil
+}
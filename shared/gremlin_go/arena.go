@@ -0,0 +1,147 @@
+package gremlin
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+const (
+	arenaChunkBytes = 16 * 1024
+	// bigger slices are allocated as usual, they would waste most of a chunk
+	arenaMaxSliceBytes = arenaChunkBytes / 4
+)
+
+// Arena hands out child readers, slices and maps of a decoded message tree, so the whole
+// tree is released with a single Reset instead of thousands of small allocations.
+// Pass it to readers with ReaderOptions.Arena. An Arena is not safe for concurrent use.
+type Arena struct {
+	pools map[reflect.Type]arenaPool // keyed by the pool type, one pool per element type
+
+	// most readers of a tree have a handful of types, a short list beats hashing reflect.Type
+	recentKeys  [8]reflect.Type
+	recentPools [8]arenaPool
+	recentNext  int
+}
+
+type arenaPool interface {
+	reset()
+}
+
+func NewArena() *Arena {
+	return &Arena{
+		pools: map[reflect.Type]arenaPool{},
+	}
+}
+
+// Reset makes all memory handed out by the arena available again. Readers, slices and maps
+// taken from the arena must not be used afterwards.
+func (a *Arena) Reset() {
+	for _, pool := range a.pools {
+		pool.reset()
+	}
+}
+
+func arenaPoolFor[P arenaPool](a *Arena, create func() P) P {
+	key := reflect.TypeFor[P]()
+	for i := range a.recentKeys {
+		if a.recentKeys[i] == key {
+			return a.recentPools[i].(P)
+		}
+	}
+	pool, ok := a.pools[key]
+	if !ok {
+		pool = create()
+		a.pools[key] = pool
+	}
+	a.recentKeys[a.recentNext] = key
+	a.recentPools[a.recentNext] = pool
+	a.recentNext = (a.recentNext + 1) % len(a.recentKeys)
+	return pool.(P)
+}
+
+type arenaSlab[T any] struct {
+	chunks [][]T
+	chunk  int // chunk currently in use
+	used   int // used elements of the current chunk
+}
+
+func (s *arenaSlab[T]) alloc(n int) []T {
+	if len(s.chunks) > 0 && s.used+n > len(s.chunks[s.chunk]) {
+		s.chunk++
+		s.used = 0
+	}
+	for s.chunk < len(s.chunks) && n > len(s.chunks[s.chunk]) {
+		s.chunk++
+	}
+	if s.chunk >= len(s.chunks) {
+		var zero T
+		size := arenaChunkBytes / max(int(unsafe.Sizeof(zero)), 1)
+		s.chunks = append(s.chunks, make([]T, max(size, n)))
+		s.chunk = len(s.chunks) - 1
+	}
+	res := s.chunks[s.chunk][s.used : s.used+n : s.used+n]
+	s.used += n
+	return res
+}
+
+func (s *arenaSlab[T]) reset() {
+	for i := 0; i < s.chunk && i < len(s.chunks); i++ {
+		clear(s.chunks[i])
+	}
+	if s.chunk < len(s.chunks) {
+		clear(s.chunks[s.chunk][:s.used])
+	}
+	s.chunk = 0
+	s.used = 0
+}
+
+type arenaMaps[K comparable, V any] struct {
+	used []map[K]V
+	free []map[K]V
+}
+
+func (m *arenaMaps[K, V]) reset() {
+	for _, used := range m.used {
+		clear(used)
+	}
+	m.free = append(m.free, m.used...)
+	clear(m.used)
+	m.used = m.used[:0]
+}
+
+// New returns a zeroed T, taken from the arena if it is not nil.
+func New[T any](a *Arena) *T {
+	if a == nil {
+		return new(T)
+	}
+	slab := arenaPoolFor(a, func() *arenaSlab[T] { return &arenaSlab[T]{} })
+	return &slab.alloc(1)[0]
+}
+
+// MakeSlice is make([]T, length, capacity), taken from the arena if it is not nil.
+func MakeSlice[T any](a *Arena, length int, capacity int) []T {
+	var zero T
+	if a == nil || capacity == 0 || capacity*int(unsafe.Sizeof(zero)) > arenaMaxSliceBytes {
+		return make([]T, length, capacity)
+	}
+	slab := arenaPoolFor(a, func() *arenaSlab[T] { return &arenaSlab[T]{} })
+	return slab.alloc(capacity)[:length]
+}
+
+// MakeMap is make(map[K]V, size). With an arena, maps released by Reset are reused.
+func MakeMap[K comparable, V any](a *Arena, size int) map[K]V {
+	if a == nil {
+		return make(map[K]V, size)
+	}
+	maps := arenaPoolFor(a, func() *arenaMaps[K, V] { return &arenaMaps[K, V]{} })
+	var res map[K]V
+	if n := len(maps.free); n > 0 {
+		res = maps.free[n-1]
+		maps.free[n-1] = nil
+		maps.free = maps.free[:n-1]
+	} else {
+		res = make(map[K]V, size)
+	}
+	maps.used = append(maps.used, res)
+	return res
+}
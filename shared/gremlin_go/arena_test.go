@@ -0,0 +1,77 @@
+package gremlin
+
+import "testing"
+
+type arenaTestEntry struct {
+	value int
+	next  *arenaTestEntry
+}
+
+func TestArenaNilFallback(t *testing.T) {
+	if v := New[arenaTestEntry](nil); v == nil || v.value != 0 {
+		t.Errorf("expected zeroed value, got %v", v)
+	}
+	if s := MakeSlice[int](nil, 2, 5); len(s) != 2 || cap(s) != 5 {
+		t.Errorf("unexpected slice len %v cap %v", len(s), cap(s))
+	}
+	if m := MakeMap[string, int](nil, 1); m == nil {
+		t.Errorf("expected a map")
+	}
+}
+
+func TestArenaReset(t *testing.T) {
+	arena := NewArena()
+
+	first := New[arenaTestEntry](arena)
+	first.value = 1
+	second := New[arenaTestEntry](arena)
+	second.next = first
+	if first == second {
+		t.Fatalf("arena returned the same value twice")
+	}
+
+	slice := MakeSlice[int](arena, 2, 4)
+	slice = append(slice, 3, 4)
+	slice = append(slice, 5) // over capacity, must not overwrite the next slice
+	other := MakeSlice[int](arena, 4, 4)
+	for i := range other {
+		if other[i] != 0 {
+			t.Fatalf("slice from arena is not zeroed: %v", other)
+		}
+	}
+
+	m := MakeMap[string, int](arena, 0)
+	m["a"] = 1
+
+	arena.Reset()
+
+	reused := New[arenaTestEntry](arena)
+	if reused != first {
+		t.Errorf("expected memory to be reused after reset")
+	}
+	if reused.value != 0 || second.next != nil {
+		t.Errorf("expected memory to be zeroed after reset")
+	}
+	reusedMap := MakeMap[string, int](arena, 0)
+	if len(reusedMap) != 0 || len(m) != 0 {
+		t.Errorf("expected maps to be cleared after reset")
+	}
+	reusedMap["b"] = 2
+	if m["b"] != 2 {
+		t.Errorf("expected map to be reused after reset")
+	}
+}
+
+func TestArenaLargeSlices(t *testing.T) {
+	arena := NewArena()
+	large := MakeSlice[byte](arena, 0, arenaChunkBytes)
+	if cap(large) != arenaChunkBytes {
+		t.Errorf("unexpected capacity %v", cap(large))
+	}
+	for i := 0; i < 100; i++ {
+		s := MakeSlice[int64](arena, 10, 10)
+		if len(s) != 10 {
+			t.Fatalf("unexpected length %v", len(s))
+		}
+	}
+}
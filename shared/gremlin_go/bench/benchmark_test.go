@@ -1,13 +1,17 @@
 package bench_test
 
 import (
+	"encoding/json"
+	"io"
 	"testing"
 
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
 	"github.com/norma-core/norma-core/shared/gremlin_go/bench"
 	google_benchmark "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/benchmark"
 	google_unittest "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/unittest"
 	gremlin_pb "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/benchmark"
 	unittest_gremlin "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/protobuf_unittest"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -348,3 +352,258 @@ func BenchmarkRoundTrip_Google_GoldenMessage(b *testing.B) {
 		}
 	}
 }
+
+// ============================================================================
+// Struct Decoding Benchmarks (mutable messages)
+// ============================================================================
+
+// Benchmark: Reader Unmarshal + ToStruct
+func BenchmarkToStruct_Gremlin_GoldenMessage(b *testing.B) {
+	content := bench.GetTestFileContent("golden_message")
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		reader := unittest_gremlin.NewTestAllTypesReader()
+		if err := reader.Unmarshal(content); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		_ = reader.ToStruct()
+	}
+}
+
+// Benchmark: Single pass struct Unmarshal
+func BenchmarkStructUnmarshal_Gremlin_GoldenMessage(b *testing.B) {
+	content := bench.GetTestFileContent("golden_message")
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		msg := &unittest_gremlin.TestAllTypes{}
+		if err := msg.Unmarshal(content); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+	}
+}
+
+func BenchmarkToStruct_Gremlin_DeepNested(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		reader := gremlin_pb.NewDeepNestedReader()
+		if err := reader.Unmarshal(data); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		_ = reader.ToStruct()
+	}
+}
+
+func BenchmarkStructUnmarshal_Gremlin_DeepNested(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		msg := &gremlin_pb.DeepNested{}
+		if err := msg.Unmarshal(data); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+	}
+}
+
+// ============================================================================
+// Arena Benchmarks
+// ============================================================================
+
+func BenchmarkFullAccess_Gremlin_DeepNested_Arena(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	arena := gremlin.NewArena()
+	opts := gremlin.ReaderOptions{Arena: arena}
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		arena.Reset()
+		reader := gremlin.New[gremlin_pb.DeepNestedReader](arena)
+		if err := reader.UnmarshalWithOptions(data, opts); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		reader.Freeze()
+	}
+}
+
+func BenchmarkToStruct_Gremlin_GoldenMessage_Arena(b *testing.B) {
+	content := bench.GetTestFileContent("golden_message")
+	arena := gremlin.NewArena()
+	opts := gremlin.ReaderOptions{Arena: arena}
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		arena.Reset()
+		reader := gremlin.New[unittest_gremlin.TestAllTypesReader](arena)
+		if err := reader.UnmarshalWithOptions(content, opts); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		_ = reader.ToStruct()
+	}
+}
+
+func BenchmarkToStruct_Gremlin_DeepNested_Arena(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	arena := gremlin.NewArena()
+	opts := gremlin.ReaderOptions{Arena: arena}
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		arena.Reset()
+		reader := gremlin.New[gremlin_pb.DeepNestedReader](arena)
+		if err := reader.UnmarshalWithOptions(data, opts); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		_ = reader.ToStruct()
+	}
+}
+
+// ============================================================================
+// JSON Benchmarks
+// ============================================================================
+
+func BenchmarkMarshalJSON_Gremlin_GoldenMessage(b *testing.B) {
+	msg := bench.CreateGoldenMessageGremlin()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = msg.MarshalJSON()
+	}
+}
+
+func BenchmarkMarshalJSON_Google_GoldenMessage(b *testing.B) {
+	msg := bench.CreateGoldenMessageGoogle()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = protojson.Marshal(msg)
+	}
+}
+
+func BenchmarkUnmarshalJSON_Gremlin_GoldenMessage(b *testing.B) {
+	data, _ := bench.CreateGoldenMessageGremlin().MarshalJSON()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var msg unittest_gremlin.TestAllTypes
+		if err := msg.UnmarshalJSON(data); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSON_Google_GoldenMessage(b *testing.B) {
+	data, _ := protojson.Marshal(bench.CreateGoldenMessageGoogle())
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var msg google_unittest.TestAllTypes
+		if err := protojson.Unmarshal(data, &msg); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+	}
+}
+
+// Wire to JSON: single pass transcoder against Unmarshal + ToStruct + encoding/json
+
+func BenchmarkTranscodeJSON_Gremlin_GoldenMessage(b *testing.B) {
+	data := bench.CreateGoldenMessageGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := unittest_gremlin.TranscodeTestAllTypesJSON(io.Discard, data); err != nil {
+			b.Fatalf("failed to transcode: %v", err)
+		}
+	}
+}
+
+func BenchmarkStructJSON_Gremlin_GoldenMessage(b *testing.B) {
+	data := bench.CreateGoldenMessageGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		reader := unittest_gremlin.NewTestAllTypesReader()
+		if err := reader.Unmarshal(data); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		if err := json.NewEncoder(io.Discard).Encode(reader.ToStruct()); err != nil {
+			b.Fatalf("failed to encode: %v", err)
+		}
+	}
+}
+
+func BenchmarkTranscodeJSON_Google_GoldenMessage(b *testing.B) {
+	data := bench.CreateGoldenMessageGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var msg google_unittest.TestAllTypes
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		if _, err := protojson.Marshal(&msg); err != nil {
+			b.Fatalf("failed to marshal: %v", err)
+		}
+	}
+}
+
+func BenchmarkTranscodeJSON_Gremlin_DeepNested(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := gremlin_pb.TranscodeDeepNestedJSON(io.Discard, data); err != nil {
+			b.Fatalf("failed to transcode: %v", err)
+		}
+	}
+}
+
+func BenchmarkStructJSON_Gremlin_DeepNested(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		reader := gremlin_pb.NewDeepNestedReader()
+		if err := reader.Unmarshal(data); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		if err := json.NewEncoder(io.Discard).Encode(reader.ToStruct()); err != nil {
+			b.Fatalf("failed to encode: %v", err)
+		}
+	}
+}
+
+func BenchmarkTranscodeJSON_Google_DeepNested(b *testing.B) {
+	data := bench.CreateDeepNestedGremlin().Marshal()
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var msg google_benchmark.DeepNested
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			b.Fatalf("failed to unmarshal: %v", err)
+		}
+		if _, err := protojson.Marshal(&msg); err != nil {
+			b.Fatalf("failed to marshal: %v", err)
+		}
+	}
+}
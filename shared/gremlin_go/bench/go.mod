@@ -3,6 +3,7 @@ module github.com/norma-core/norma-core/shared/gremlin_go/bench
 go 1.25
 
 require (
+	github.com/google/go-cmp v0.7.0
 	github.com/norma-core/norma-core/shared/gremlin_go v0.0.0
 	google.golang.org/protobuf v1.36.8
 )
@@ -3,7 +3,11 @@
 
 package google_pb
 
-import gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+import (
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+	io "io"
+	fmt "fmt"
+)
 
 const (
 	wireLevel4_Value gremlin.ProtoWireNumber = 1
@@ -11,21 +15,52 @@ const (
 	wireLevel4_Numbers gremlin.ProtoWireNumber = 3
 )
 
+const (
+	FieldLevel4_Value gremlin.FieldIndex = 0
+	FieldLevel4_Data gremlin.FieldIndex = 1
+	FieldLevel4_Numbers gremlin.FieldIndex = 2
+)
+
+var singularFieldsLevel4 = gremlin.NewFieldSet(FieldLevel4_Value, FieldLevel4_Data)
+
+var descriptorLevel4 = &gremlin.MessageDescriptor{
+	FullName: "benchmark.Level4",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "value", JSONName: "value", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "data", JSONName: "data", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "numbers", JSONName: "numbers", Number: 3, Kind: gremlin.KindInt32, Label: gremlin.LabelRepeated},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &Level4{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewLevel4Reader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorLevel4)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *Level4Reader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel4
+}
+
 type Level4Reader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataValue     int32
 	dataData     string
 	dataNumbers     []int32
 
-	offsetValue   int
-	offsetData   int
-	offsetNumbers   []int
-	wireTypeNumbers []gremlin.ProtoWireType
-
-	parsedValue   bool
-	parsedData   bool
-	parsedNumbers   bool
+	offsetValue   int32
+	offsetData   int32
 }
 
 func NewLevel4Reader() *Level4Reader {
@@ -40,10 +75,10 @@ func (m *Level4Reader) GetValue() int32 {
 }
 
 func (m *Level4Reader) readValue() int32 {
-	if m.parsedValue {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataValue
 	}
-	wOffset := m.offsetValue
+	wOffset := int(m.offsetValue)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -51,7 +86,7 @@ func (m *Level4Reader) readValue() int32 {
 	}
 	
 	m.dataValue = entry
-	m.parsedValue = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -63,10 +98,10 @@ func (m *Level4Reader) GetData() string {
 }
 
 func (m *Level4Reader) readData() string {
-	if m.parsedData {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataData
 	}
-	wOffset := m.offsetData
+	wOffset := int(m.offsetData)
 	
 	var entry string
 	if wOffset > 0 {
@@ -74,7 +109,7 @@ func (m *Level4Reader) readData() string {
 	}
 	
 	m.dataData = entry
-	m.parsedData = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -86,49 +121,109 @@ func (m *Level4Reader) GetNumbers() []int32 {
 }
 
 func (m *Level4Reader) readNumbers() []int32 {
-	if m.parsedNumbers {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataNumbers
 	}
-	wOffset := m.offsetNumbers
-	var wType = m.wireTypeNumbers
+	wField := FieldLevel4_Numbers
 	
 	var entry []int32
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		wType := wType[i]
-		if wType == gremlin.BytesType {
-			size, sizeSize := m.buf.SizedReadVarInt(wOffset)
-			offset := 0
-			for offset < int(size) {
-				wOffset := wOffset + sizeSize + offset
+	if count := m.buf.CountPacked(m.repeated, wField, 1); count > 0 {
+		entry = gremlin.MakeSlice[int32](m.buf.Arena(), 0, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			if fieldOffset.Wire == gremlin.BytesType {
+				size, sizeSize := m.buf.SizedReadVarInt(wOffset)
+				offset := 0
+				for offset < int(size) {
+					wOffset := wOffset + sizeSize + offset
+					
+					var listEntry int32
+					var listEntrySize int
+					if wOffset > 0 {
+						listEntry, listEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					if listEntrySize <= 0 {
+						break
+					}
+					entry = append(entry, listEntry)
+					offset += listEntrySize
+				}
+			} else {
 				
 				var listEntry int32
-				var listEntrySize int
 				if wOffset > 0 {
-					listEntry, listEntrySize = m.buf.SizedReadInt32(wOffset)
+					listEntry = m.buf.ReadInt32(wOffset)
 				}
 				
 				entry = append(entry, listEntry)
-				offset += listEntrySize
-			}
-		} else {
-			
-			var listEntry int32
-			if wOffset > 0 {
-				listEntry = m.buf.ReadInt32(wOffset)
 			}
-			
-			entry = append(entry, listEntry)
 		}
 	}
 	
 	m.dataNumbers = entry
-	m.parsedNumbers = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
 func (m *Level4Reader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *Level4Reader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel4_Value:
+			m.offsetValue = int32(offset)
+		case wireLevel4_Data:
+			m.offsetData = int32(offset)
+		case wireLevel4_Numbers:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel4_Numbers, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *Level4Reader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *Level4Reader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = Level4Reader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsLevel4) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -139,12 +234,29 @@ func (m *Level4Reader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireLevel4_Value:
-			m.offsetValue = offset
+			if fields.Has(FieldLevel4_Value) {
+				if m.offsetValue == 0 {
+					found++
+				}
+				m.offsetValue = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel4_Data:
-			m.offsetData = offset
+			if fields.Has(FieldLevel4_Data) {
+				if m.offsetData == 0 {
+					found++
+				}
+				m.offsetData = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel4_Numbers:
-			m.offsetNumbers = append(m.offsetNumbers, offset)
-			m.wireTypeNumbers = append(m.wireTypeNumbers, wire)
+			if fields.Has(FieldLevel4_Numbers) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel4_Numbers, Offset: int32(offset), Wire: wire})
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -159,7 +271,7 @@ func (m *Level4Reader) ToStruct() *Level4 {
 	if m == nil {
 		return nil
 	}
-	res := &Level4{}
+	res := gremlin.New[Level4](m.buf.Arena())
 	res.Value = m.GetValue()
 	res.Data = m.GetData()
 	res.Numbers = m.GetNumbers()
@@ -167,6 +279,45 @@ func (m *Level4Reader) ToStruct() *Level4 {
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *Level4Reader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readValue()
+	m.readData()
+	m.readNumbers()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *Level4Reader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *Level4Reader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataData = d.RebaseString(m.dataData)
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *Level4Reader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -174,12 +325,284 @@ func (s *Level4Reader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *Level4Reader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetValue(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetData(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"data\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetNumbers(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"numbers\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONInt(b, int64(entry))
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *Level4Reader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeLevel4JSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeLevel4JSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeLevel4JSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeLevel4JSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeLevel4JSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var last [3]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel4_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		case wireLevel4_Data:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
+		case wireLevel4_Numbers:
+			if wire != gremlin.VarIntType && wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel4_Value:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"value\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		case wireLevel4_Data:
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"data\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
+		case wireLevel4_Numbers:
+			if written[0]&(1 << 2) != 0 {
+				break
+			}
+			written[0] |= 1 << 2
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"numbers\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				if entryWire == gremlin.BytesType {
+					size, sizeSize := buf.SizedReadVarInt(entryOffset)
+					packedOffset := entryOffset + sizeSize
+					packedEnd := packedOffset + int(size)
+					for packedOffset < packedEnd {
+						listEntry, listEntrySize := buf.SizedReadInt32(packedOffset)
+						if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+							return gremlin.ErrTruncated
+						}
+						s.B = append(s.B, entrySep)
+						entrySep = ','
+						s.B = gremlin.AppendJSONInt(s.B, int64(listEntry))
+						packedOffset += listEntrySize
+					}
+				} else {
+					s.B = append(s.B, entrySep)
+					entrySep = ','
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(entryOffset)))
+				}
+			}
+			if entrySep == '[' {
+				s.B = append(s.B, entrySep)
+			}
+			s.B = append(s.B, ']')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *Level4Reader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetValue(); value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetData(); value != "" {
+		w.WriteName("data")
+		w.WriteString(value)
+	}
+	if value := m.GetNumbers(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("numbers")
+			w.WriteInt(int64(entry))
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *Level4Reader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *Level4Reader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *Level4Reader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type Level4 struct {
 	Value	int32	`json:"value,omitempty"`
 	Data	string	`json:"data,omitempty"`
 	Numbers	[]int32	`json:"numbers,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *Level4) Unmarshal(data []byte) error {
+	*s = Level4{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel4_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Value = buf.ReadInt32(offset)
+		case wireLevel4_Data:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Data = buf.ReadString(offset)
+		case wireLevel4_Numbers:
+			if wire != gremlin.VarIntType && wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Numbers == nil {
+				s.Numbers = make([]int32, 0, buf.CountField(offset, wire, tag, 1))
+			}
+			if wire == gremlin.BytesType {
+				size, sizeSize := buf.SizedReadVarInt(offset)
+				packedOffset := offset + sizeSize
+				packedEnd := packedOffset + int(size)
+				for packedOffset < packedEnd {
+					listEntry, listEntrySize := buf.SizedReadInt32(packedOffset)
+					if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+						return gremlin.ErrTruncated
+					}
+					s.Numbers = append(s.Numbers, listEntry)
+					packedOffset += listEntrySize
+				}
+			} else {
+				var listEntry int32
+				listEntry = buf.ReadInt32(offset)
+				s.Numbers = append(s.Numbers, listEntry)
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *Level4) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -275,6 +698,246 @@ func (s *Level4) XXX_PbContentSize() int {
 	return size
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *Level4) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Value))
+	}
+	if s.Data != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"data\":"...)
+		b = gremlin.AppendJSONString(b, s.Data)
+	}
+	if len(s.Numbers) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"numbers\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Numbers {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONInt(b, int64(entry))
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *Level4) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *Level4) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *Level4) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = Level4{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		case "data":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Data = value
+		case "numbers":
+			if err := d.ReadArray(func() error {
+				var listEntry int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Numbers = append(s.Numbers, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *Level4) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.Value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(s.Value))
+	}
+	if s.Data != "" {
+		w.WriteName("data")
+		w.WriteString(s.Data)
+	}
+	if len(s.Numbers) > 0 {
+		for _, entry := range s.Numbers {
+			w.WriteName("numbers")
+			w.WriteInt(int64(entry))
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *Level4) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *Level4) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *Level4) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *Level4) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *Level4) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = Level4{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		case "data":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Data = value
+		case "numbers":
+			if err := d.ReadRepeated(func() error {
+				var listEntry int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Numbers = append(s.Numbers, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *Level4) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel4
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *Level4) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireLevel4_Value:
+		return s.Value
+	case wireLevel4_Data:
+		return s.Data
+	case wireLevel4_Numbers:
+		return s.Numbers
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *Level4) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireLevel4_Value:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel4, number, value)
+		}
+		s.Value = v
+	case wireLevel4_Data:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel4, number, value)
+		}
+		s.Data = v
+	case wireLevel4_Numbers:
+		v, ok := value.([]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel4, number, value)
+		}
+		s.Numbers = v
+	default:
+		return gremlin.UnknownFieldError(descriptorLevel4, number)
+	}
+	return nil
+}
+
+// Level4Paths are the field mask paths of benchmark.Level4.
+var Level4Paths = XXX_NewLevel4PathNames("")
+
+type Level4PathNames struct {
+	path string
+	Value string
+	Data string
+	Numbers string
+}
+
+func XXX_NewLevel4PathNames(path string) Level4PathNames {
+	return Level4PathNames{
+		path: path,
+		Value: gremlin.JoinPath(path, "value"),
+		Data: gremlin.JoinPath(path, "data"),
+		Numbers: gremlin.JoinPath(path, "numbers"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p Level4PathNames) Path() string {
+	return p.path
+}
+
 const (
 	wireLevel3_Id gremlin.ProtoWireNumber = 1
 	wireLevel3_Name gremlin.ProtoWireNumber = 2
@@ -282,23 +945,58 @@ const (
 	wireLevel3_Items gremlin.ProtoWireNumber = 4
 )
 
+const (
+	FieldLevel3_Id gremlin.FieldIndex = 0
+	FieldLevel3_Name gremlin.FieldIndex = 1
+	FieldLevel3_Nested gremlin.FieldIndex = 2
+	FieldLevel3_Items gremlin.FieldIndex = 3
+)
+
+var singularFieldsLevel3 = gremlin.NewFieldSet(FieldLevel3_Id, FieldLevel3_Name, FieldLevel3_Nested)
+
+var descriptorLevel3 = &gremlin.MessageDescriptor{
+	FullName: "benchmark.Level3",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "id", JSONName: "id", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "name", JSONName: "name", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "nested", JSONName: "nested", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "benchmark.Level4"},
+		{Name: "items", JSONName: "items", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated, TypeName: "benchmark.Level4"},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &Level3{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewLevel3Reader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorLevel3)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *Level3Reader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel3
+}
+
 type Level3Reader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataId     int32
 	dataName     string
 	dataNested     *Level4Reader
 	dataItems     []*Level4Reader
 
-	offsetId   int
-	offsetName   int
-	offsetNested   int
-	offsetItems   []int
+	offsetId   int32
+	offsetName   int32
+	offsetNested   int32
 
-	parsedId   bool
-	parsedName   bool
-	parsedNested   bool
-	parsedItems   bool
+	inlineNested   Level4Reader
 }
 
 func NewLevel3Reader() *Level3Reader {
@@ -313,10 +1011,10 @@ func (m *Level3Reader) GetId() int32 {
 }
 
 func (m *Level3Reader) readId() int32 {
-	if m.parsedId {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataId
 	}
-	wOffset := m.offsetId
+	wOffset := int(m.offsetId)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -324,7 +1022,7 @@ func (m *Level3Reader) readId() int32 {
 	}
 	
 	m.dataId = entry
-	m.parsedId = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -336,10 +1034,10 @@ func (m *Level3Reader) GetName() string {
 }
 
 func (m *Level3Reader) readName() string {
-	if m.parsedName {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataName
 	}
-	wOffset := m.offsetName
+	wOffset := int(m.offsetName)
 	
 	var entry string
 	if wOffset > 0 {
@@ -347,7 +1045,7 @@ func (m *Level3Reader) readName() string {
 	}
 	
 	m.dataName = entry
-	m.parsedName = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -359,22 +1057,19 @@ func (m *Level3Reader) GetNested() *Level4Reader {
 }
 
 func (m *Level3Reader) readNested() *Level4Reader {
-	if m.parsedNested {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataNested
 	}
-	wOffset := m.offsetNested
+	wOffset := int(m.offsetNested)
 	
 	var entry *Level4Reader
 	if wOffset > 0 {
-		var entryData = m.buf.ReadBytes(wOffset)
-		if len(entryData) > 0 {
-			entry = NewLevel4Reader()
-			entry.Unmarshal(entryData)
-		}
+		entry = &m.inlineNested
+		entry.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 	}
 	
 	m.dataNested = entry
-	m.parsedNested = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -386,34 +1081,96 @@ func (m *Level3Reader) GetItems() []*Level4Reader {
 }
 
 func (m *Level3Reader) readItems() []*Level4Reader {
-	if m.parsedItems {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataItems
 	}
-	wOffset := m.offsetItems
+	wField := FieldLevel3_Items
 	
 	var entry []*Level4Reader
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry *Level4Reader
-		if wOffset > 0 {
-			var listEntryData = m.buf.ReadBytes(wOffset)
-			if len(listEntryData) > 0 {
-				listEntry = NewLevel4Reader()
-				listEntry.Unmarshal(listEntryData)
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[*Level4Reader](m.buf.Arena(), 0, count)
+		listReaders := gremlin.MakeSlice[Level4Reader](m.buf.Arena(), count, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry *Level4Reader
+			if wOffset > 0 {
+				var listEntryData = m.buf.ReadMessage(wOffset)
+				if len(listEntryData) > 0 {
+					listEntry = &listReaders[len(entry)]
+					listEntry.UnmarshalWithOptions(listEntryData, m.buf.Options())
+				}
 			}
+			
+			entry = append(entry, listEntry)
 		}
-		
-		entry = append(entry, listEntry)
 	}
 	
 	m.dataItems = entry
-	m.parsedItems = true
+	m.parsed[0] |= 1 << 3
 	return entry
 }
 
 func (m *Level3Reader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *Level3Reader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel3_Id:
+			m.offsetId = int32(offset)
+		case wireLevel3_Name:
+			m.offsetName = int32(offset)
+		case wireLevel3_Nested:
+			m.offsetNested = int32(offset)
+		case wireLevel3_Items:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel3_Items, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *Level3Reader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *Level3Reader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = Level3Reader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsLevel3) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -424,13 +1181,39 @@ func (m *Level3Reader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireLevel3_Id:
-			m.offsetId = offset
+			if fields.Has(FieldLevel3_Id) {
+				if m.offsetId == 0 {
+					found++
+				}
+				m.offsetId = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel3_Name:
-			m.offsetName = offset
+			if fields.Has(FieldLevel3_Name) {
+				if m.offsetName == 0 {
+					found++
+				}
+				m.offsetName = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel3_Nested:
-			m.offsetNested = offset
+			if fields.Has(FieldLevel3_Nested) {
+				if m.offsetNested == 0 {
+					found++
+				}
+				m.offsetNested = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel3_Items:
-			m.offsetItems = append(m.offsetItems, offset)
+			if fields.Has(FieldLevel3_Items) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel3_Items, Offset: int32(offset), Wire: wire})
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -445,7 +1228,7 @@ func (m *Level3Reader) ToStruct() *Level3 {
 	if m == nil {
 		return nil
 	}
-	res := &Level3{}
+	res := gremlin.New[Level3](m.buf.Arena())
 	res.Id = m.GetId()
 	res.Name = m.GetName()
 
@@ -462,7 +1245,7 @@ func (m *Level3Reader) ToStruct() *Level3 {
 		var data = m.GetItems()
 		var structData []*Level4
 		if len(data) > 0 {
-			structData = make([]*Level4, len(data))
+			structData = gremlin.MakeSlice[*Level4](m.buf.Arena(), len(data), len(data))
 			for i := range data {
 				if data[i] != nil {
 					structData[i] = data[i].ToStruct()
@@ -475,6 +1258,56 @@ func (m *Level3Reader) ToStruct() *Level3 {
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *Level3Reader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readId()
+	m.readName()
+	m.readNested().Freeze()
+	for _, entry := range m.readItems() {
+		entry.Freeze()
+	}
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *Level3Reader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *Level3Reader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataName = d.RebaseString(m.dataName)
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		m.dataNested.XXX_Rebase(d)
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		for i := range m.dataItems {
+			m.dataItems[i].XXX_Rebase(d)
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *Level3Reader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -482,6 +1315,230 @@ func (s *Level3Reader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *Level3Reader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetId(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetName(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"name\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetNested(); value != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = value.AppendJSON(b)
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *Level3Reader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeLevel3JSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeLevel3JSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeLevel3JSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeLevel3JSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeLevel3JSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var first, last [4]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel3_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		case wireLevel3_Name:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
+		case wireLevel3_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if first[2] == 0 {
+				first[2] = offset
+			}
+			last[2] = offset
+		case wireLevel3_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel3_Id:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"id\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		case wireLevel3_Name:
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"name\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
+		case wireLevel3_Nested:
+			if offset != last[2] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"nested\":"...)
+			buf, offset := buf.MergeMessage(first[2], offset, tag)
+			if err := XXX_TranscodeLevel4JSON(s, buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+		case wireLevel3_Items:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"items\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				if err := XXX_TranscodeLevel4JSON(s, buf.ReadMessage(entryOffset)); err != nil {
+					return err
+				}
+			}
+			s.B = append(s.B, ']')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *Level3Reader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetId(); value != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetName(); value != "" {
+		w.WriteName("name")
+		w.WriteString(value)
+	}
+	if value := m.GetNested(); value != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		value.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *Level3Reader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *Level3Reader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *Level3Reader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type Level3 struct {
 	Id	int32	`json:"id,omitempty"`
 	Name	string	`json:"name,omitempty"`
@@ -489,6 +1546,75 @@ type Level3 struct {
 	Items	[]*Level4	`json:"items,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *Level3) Unmarshal(data []byte) error {
+	*s = Level3{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel3_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Id = buf.ReadInt32(offset)
+		case wireLevel3_Name:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Name = buf.ReadString(offset)
+		case wireLevel3_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			var entry = &Level4{}
+			if err := entry.Unmarshal(buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+			s.Nested = entry
+		case wireLevel3_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Items == nil {
+				s.Items = make([]*Level4, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry *Level4
+				if entryData := buf.ReadMessage(offset); len(entryData) > 0 {
+					var entry = &Level4{}
+					if err := entry.Unmarshal(entryData); err != nil {
+						return err
+					}
+					listEntry = entry
+				} else {
+					listEntry = nil
+				}
+				s.Items = append(s.Items, listEntry)
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *Level3) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -590,110 +1716,419 @@ func (s *Level3) XXX_PbContentSize() int {
 	return size
 }
 
-const (
-	wireLevel2_Id gremlin.ProtoWireNumber = 1
-	wireLevel2_Description gremlin.ProtoWireNumber = 2
-	wireLevel2_Nested gremlin.ProtoWireNumber = 3
-	wireLevel2_Items gremlin.ProtoWireNumber = 4
-	wireLevel2_Payload gremlin.ProtoWireNumber = 5
-)
-
-type Level2Reader struct {
-	buf *gremlin.Reader
-
-	dataId     int32
-	dataDescription     string
-	dataNested     *Level3Reader
-	dataItems     []*Level3Reader
-	dataPayload     []byte
-
-	offsetId   int
-	offsetDescription   int
-	offsetNested   int
-	offsetItems   []int
-	offsetPayload   int
-
-	parsedId   bool
-	parsedDescription   bool
-	parsedNested   bool
-	parsedItems   bool
-	parsedPayload   bool
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *Level3) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Id != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Id))
+	}
+	if s.Name != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"name\":"...)
+		b = gremlin.AppendJSONString(b, s.Name)
+	}
+	if s.Nested != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = s.Nested.AppendJSON(b)
+	}
+	if len(s.Items) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Items {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
 }
 
-func NewLevel2Reader() *Level2Reader {
-	return &Level2Reader{}
+func (s *Level3) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
 }
 
-func (m *Level2Reader) GetId() int32 {
-	if m == nil {
-		return 0
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *Level3) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
 	}
-	return m.readId()
+	return d.End()
 }
 
-func (m *Level2Reader) readId() int32 {
-	if m.parsedId {
-		return m.dataId
-	}
-	wOffset := m.offsetId
-	
-	var entry int32
-	if wOffset > 0 {
-		entry = m.buf.ReadInt32(wOffset)
-	}
-	
-	m.dataId = entry
-	m.parsedId = true
-	return entry
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *Level3) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = Level3{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Name = value
+		case "nested":
+			value := &Level4{}
+			if err := value.XXX_DecodeJSON(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadArray(func() error {
+				var listEntry *Level4
+				value := &Level4{}
+				if err := value.XXX_DecodeJSON(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
 }
 
-func (m *Level2Reader) GetDescription() string {
-	if m == nil {
-		return ""
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *Level3) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
 	}
-	return m.readDescription()
-}
-
-func (m *Level2Reader) readDescription() string {
-	if m.parsedDescription {
-		return m.dataDescription
+	if s.Id != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(s.Id))
 	}
-	wOffset := m.offsetDescription
-	
-	var entry string
-	if wOffset > 0 {
-		entry = m.buf.ReadString(wOffset)
+	if s.Name != "" {
+		w.WriteName("name")
+		w.WriteString(s.Name)
+	}
+	if s.Nested != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		s.Nested.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if len(s.Items) > 0 {
+		for _, entry := range s.Items {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
 	}
-	
-	m.dataDescription = entry
-	m.parsedDescription = true
-	return entry
 }
 
-func (m *Level2Reader) GetNested() *Level3Reader {
-	if m == nil {
-		return nil
-	}
-	return m.readNested()
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *Level3) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *Level3) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *Level3) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *Level3) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *Level3) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = Level3{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Name = value
+		case "nested":
+			value := &Level4{}
+			if err := value.XXX_DecodeText(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadRepeated(func() error {
+				var listEntry *Level4
+				value := &Level4{}
+				if err := value.XXX_DecodeText(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *Level3) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel3
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *Level3) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireLevel3_Id:
+		return s.Id
+	case wireLevel3_Name:
+		return s.Name
+	case wireLevel3_Nested:
+		return s.Nested
+	case wireLevel3_Items:
+		return s.Items
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *Level3) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireLevel3_Id:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel3, number, value)
+		}
+		s.Id = v
+	case wireLevel3_Name:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel3, number, value)
+		}
+		s.Name = v
+	case wireLevel3_Nested:
+		v, ok := value.(*Level4)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel3, number, value)
+		}
+		s.Nested = v
+	case wireLevel3_Items:
+		v, ok := value.([]*Level4)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel3, number, value)
+		}
+		s.Items = v
+	default:
+		return gremlin.UnknownFieldError(descriptorLevel3, number)
+	}
+	return nil
+}
+
+// Level3Paths are the field mask paths of benchmark.Level3.
+var Level3Paths = XXX_NewLevel3PathNames("")
+
+type Level3PathNames struct {
+	path string
+	Id string
+	Name string
+	Nested Level4PathNames
+	Items string
+}
+
+func XXX_NewLevel3PathNames(path string) Level3PathNames {
+	return Level3PathNames{
+		path: path,
+		Id: gremlin.JoinPath(path, "id"),
+		Name: gremlin.JoinPath(path, "name"),
+		Nested: XXX_NewLevel4PathNames(gremlin.JoinPath(path, "nested")),
+		Items: gremlin.JoinPath(path, "items"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p Level3PathNames) Path() string {
+	return p.path
+}
+
+const (
+	wireLevel2_Id gremlin.ProtoWireNumber = 1
+	wireLevel2_Description gremlin.ProtoWireNumber = 2
+	wireLevel2_Nested gremlin.ProtoWireNumber = 3
+	wireLevel2_Items gremlin.ProtoWireNumber = 4
+	wireLevel2_Payload gremlin.ProtoWireNumber = 5
+)
+
+const (
+	FieldLevel2_Id gremlin.FieldIndex = 0
+	FieldLevel2_Description gremlin.FieldIndex = 1
+	FieldLevel2_Nested gremlin.FieldIndex = 2
+	FieldLevel2_Items gremlin.FieldIndex = 3
+	FieldLevel2_Payload gremlin.FieldIndex = 4
+)
+
+var singularFieldsLevel2 = gremlin.NewFieldSet(FieldLevel2_Id, FieldLevel2_Description, FieldLevel2_Nested, FieldLevel2_Payload)
+
+var descriptorLevel2 = &gremlin.MessageDescriptor{
+	FullName: "benchmark.Level2",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "id", JSONName: "id", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "description", JSONName: "description", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "nested", JSONName: "nested", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "benchmark.Level3"},
+		{Name: "items", JSONName: "items", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated, TypeName: "benchmark.Level3"},
+		{Name: "payload", JSONName: "payload", Number: 5, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &Level2{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewLevel2Reader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorLevel2)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *Level2Reader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel2
+}
+
+type Level2Reader struct {
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
+
+	dataId     int32
+	dataDescription     string
+	dataNested     *Level3Reader
+	dataItems     []*Level3Reader
+	dataPayload     []byte
+
+	offsetId   int32
+	offsetDescription   int32
+	offsetNested   int32
+	offsetPayload   int32
+
+	inlineNested   Level3Reader
+}
+
+func NewLevel2Reader() *Level2Reader {
+	return &Level2Reader{}
+}
+
+func (m *Level2Reader) GetId() int32 {
+	if m == nil {
+		return 0
+	}
+	return m.readId()
+}
+
+func (m *Level2Reader) readId() int32 {
+	if m.parsed[0]&(1 << 0) != 0 {
+		return m.dataId
+	}
+	wOffset := int(m.offsetId)
+	
+	var entry int32
+	if wOffset > 0 {
+		entry = m.buf.ReadInt32(wOffset)
+	}
+	
+	m.dataId = entry
+	m.parsed[0] |= 1 << 0
+	return entry
+}
+
+func (m *Level2Reader) GetDescription() string {
+	if m == nil {
+		return ""
+	}
+	return m.readDescription()
+}
+
+func (m *Level2Reader) readDescription() string {
+	if m.parsed[0]&(1 << 1) != 0 {
+		return m.dataDescription
+	}
+	wOffset := int(m.offsetDescription)
+	
+	var entry string
+	if wOffset > 0 {
+		entry = m.buf.ReadString(wOffset)
+	}
+	
+	m.dataDescription = entry
+	m.parsed[0] |= 1 << 1
+	return entry
+}
+
+func (m *Level2Reader) GetNested() *Level3Reader {
+	if m == nil {
+		return nil
+	}
+	return m.readNested()
 }
 
 func (m *Level2Reader) readNested() *Level3Reader {
-	if m.parsedNested {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataNested
 	}
-	wOffset := m.offsetNested
+	wOffset := int(m.offsetNested)
 	
 	var entry *Level3Reader
 	if wOffset > 0 {
-		var entryData = m.buf.ReadBytes(wOffset)
-		if len(entryData) > 0 {
-			entry = NewLevel3Reader()
-			entry.Unmarshal(entryData)
-		}
+		entry = &m.inlineNested
+		entry.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 	}
 	
 	m.dataNested = entry
-	m.parsedNested = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -705,29 +2140,36 @@ func (m *Level2Reader) GetItems() []*Level3Reader {
 }
 
 func (m *Level2Reader) readItems() []*Level3Reader {
-	if m.parsedItems {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataItems
 	}
-	wOffset := m.offsetItems
+	wField := FieldLevel2_Items
 	
 	var entry []*Level3Reader
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry *Level3Reader
-		if wOffset > 0 {
-			var listEntryData = m.buf.ReadBytes(wOffset)
-			if len(listEntryData) > 0 {
-				listEntry = NewLevel3Reader()
-				listEntry.Unmarshal(listEntryData)
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[*Level3Reader](m.buf.Arena(), 0, count)
+		listReaders := gremlin.MakeSlice[Level3Reader](m.buf.Arena(), count, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry *Level3Reader
+			if wOffset > 0 {
+				var listEntryData = m.buf.ReadMessage(wOffset)
+				if len(listEntryData) > 0 {
+					listEntry = &listReaders[len(entry)]
+					listEntry.UnmarshalWithOptions(listEntryData, m.buf.Options())
+				}
 			}
+			
+			entry = append(entry, listEntry)
 		}
-		
-		entry = append(entry, listEntry)
 	}
 	
 	m.dataItems = entry
-	m.parsedItems = true
+	m.parsed[0] |= 1 << 3
 	return entry
 }
 
@@ -739,10 +2181,10 @@ func (m *Level2Reader) GetPayload() []byte {
 }
 
 func (m *Level2Reader) readPayload() []byte {
-	if m.parsedPayload {
+	if m.parsed[0]&(1 << 4) != 0 {
 		return m.dataPayload
 	}
-	wOffset := m.offsetPayload
+	wOffset := int(m.offsetPayload)
 	
 	var entry []byte
 	if wOffset > 0 {
@@ -750,12 +2192,20 @@ func (m *Level2Reader) readPayload() []byte {
 	}
 	
 	m.dataPayload = entry
-	m.parsedPayload = true
+	m.parsed[0] |= 1 << 4
 	return entry
 }
 
 func (m *Level2Reader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *Level2Reader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -766,15 +2216,98 @@ func (m *Level2Reader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireLevel2_Id:
-			m.offsetId = offset
+			m.offsetId = int32(offset)
 		case wireLevel2_Description:
-			m.offsetDescription = offset
+			m.offsetDescription = int32(offset)
 		case wireLevel2_Nested:
-			m.offsetNested = offset
+			m.offsetNested = int32(offset)
 		case wireLevel2_Items:
-			m.offsetItems = append(m.offsetItems, offset)
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel2_Items, Offset: int32(offset), Wire: wire})
 		case wireLevel2_Payload:
-			m.offsetPayload = offset
+			m.offsetPayload = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *Level2Reader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *Level2Reader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = Level2Reader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsLevel2) {
+		stopAfter = fields.Len()
+	}
+	found := 0
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel2_Id:
+			if fields.Has(FieldLevel2_Id) {
+				if m.offsetId == 0 {
+					found++
+				}
+				m.offsetId = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireLevel2_Description:
+			if fields.Has(FieldLevel2_Description) {
+				if m.offsetDescription == 0 {
+					found++
+				}
+				m.offsetDescription = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireLevel2_Nested:
+			if fields.Has(FieldLevel2_Nested) {
+				if m.offsetNested == 0 {
+					found++
+				}
+				m.offsetNested = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireLevel2_Items:
+			if fields.Has(FieldLevel2_Items) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel2_Items, Offset: int32(offset), Wire: wire})
+			}
+		case wireLevel2_Payload:
+			if fields.Has(FieldLevel2_Payload) {
+				if m.offsetPayload == 0 {
+					found++
+				}
+				m.offsetPayload = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -789,7 +2322,7 @@ func (m *Level2Reader) ToStruct() *Level2 {
 	if m == nil {
 		return nil
 	}
-	res := &Level2{}
+	res := gremlin.New[Level2](m.buf.Arena())
 	res.Id = m.GetId()
 	res.Description = m.GetDescription()
 
@@ -806,7 +2339,7 @@ func (m *Level2Reader) ToStruct() *Level2 {
 		var data = m.GetItems()
 		var structData []*Level3
 		if len(data) > 0 {
-			structData = make([]*Level3, len(data))
+			structData = gremlin.MakeSlice[*Level3](m.buf.Arena(), len(data), len(data))
 			for i := range data {
 				if data[i] != nil {
 					structData[i] = data[i].ToStruct()
@@ -820,98 +2353,473 @@ func (m *Level2Reader) ToStruct() *Level2 {
 	return res
 }
 
-func (s *Level2Reader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
-}
-
-type Level2 struct {
-	Id	int32	`json:"id,omitempty"`
-	Description	string	`json:"description,omitempty"`
-	Nested	*Level3	`json:"nested,omitempty"`
-	Items	[]*Level3	`json:"items,omitempty"`
-	Payload	[]byte	`json:"payload,omitempty"`
-}
-
-func (s *Level2) Marshal() []byte {
-	if s == nil {
-		return nil
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *Level2Reader) Freeze() {
+	if m == nil {
+		return
 	}
-	size := s.XXX_PbContentSize()
-	if size == 0 {
-		return nil
+	m.readId()
+	m.readDescription()
+	m.readNested().Freeze()
+	for _, entry := range m.readItems() {
+		entry.Freeze()
 	}
-	res := gremlin.NewWriter(size)
-	s.MarshalTo(res)
-	return res.Bytes()
+	m.readPayload()
 }
 
-func (s *Level2) MarshalTo(res *gremlin.Writer) {
-	if s == nil {
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *Level2Reader) Detach() {
+	if m == nil {
 		return
 	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
 
-	if s.Id != 0 {
-		res.AppendInt32(wireLevel2_Id, s.Id)
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *Level2Reader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
 	}
-	if s.Description != "" {
-		res.AppendString(wireLevel2_Description, s.Description)
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataDescription = d.RebaseString(m.dataDescription)
 	}
-	if s.Nested != nil {
-		structSize := s.Nested.XXX_PbContentSize()
-		res.AppendBytesTag(wireLevel2_Nested, structSize)
-		s.Nested.MarshalTo(res)
+	if m.parsed[0]&(1 << 2) != 0 {
+		m.dataNested.XXX_Rebase(d)
 	}
-	if len(s.Items) > 0 {
-		for _, entry := range s.Items {
-			structSize := entry.XXX_PbContentSize()
-			res.AppendBytesTag(wireLevel2_Items, structSize)
-			entry.MarshalTo(res)
+	if m.parsed[0]&(1 << 3) != 0 {
+		for i := range m.dataItems {
+			m.dataItems[i].XXX_Rebase(d)
 		}
 	}
-	if len(s.Payload) != 0 {
-		res.AppendBytes(wireLevel2_Payload, s.Payload)
+	if m.parsed[0]&(1 << 4) != 0 {
+		m.dataPayload = d.RebaseBytes(m.dataPayload)
 	}
 }
 
-func (s *Level2) Copy() *Level2 {
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *Level2Reader) SourceBytes() []byte {
 	if s == nil {
 		return nil
 	}
-	res := &Level2{}
-	res.Id = s.Id
-	res.Description = s.Description
-	if s.Nested != nil {
-		res.Nested = s.Nested.Copy()
-	}
-	res.Items = make([]*Level3, len(s.Items))
-	for i := range s.Items {
-		if s.Items[i] != nil {
-			res.Items[i] = s.Items[i].Copy()
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *Level2Reader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetId(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetDescription(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"description\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetNested(); value != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = value.AppendJSON(b)
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
 		}
+		b = append(b, ']')
 	}
-	res.Payload = s.Payload
+	if value := m.GetPayload(); len(value) != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"payload\":"...)
+		b = gremlin.AppendJSONBytes(b, value)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
 
-	return res
+func (m *Level2Reader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
 }
 
-func (s *Level2) XXX_PbContentSize() int {
-	if s == nil {
-		return 0
-	}
-	var size = 0
+// TranscodeLevel2JSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeLevel2JSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeLevel2JSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
 
-	if s.Id != 0 {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireLevel2_Id) + gremlin.SizeInt32(s.Id)
-		size += entrySize
+// XXX_TranscodeLevel2JSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeLevel2JSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
 	}
+	var first, last [5]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
 
-	if s.Description != "" {
-		var entrySize = 0
-		entrySize = gremlin.SizeString(s.Description)
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel2_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		case wireLevel2_Description:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
+		case wireLevel2_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if first[2] == 0 {
+				first[2] = offset
+			}
+			last[2] = offset
+		case wireLevel2_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireLevel2_Payload:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[4] = offset
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel2_Id:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"id\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		case wireLevel2_Description:
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"description\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
+		case wireLevel2_Nested:
+			if offset != last[2] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"nested\":"...)
+			buf, offset := buf.MergeMessage(first[2], offset, tag)
+			if err := XXX_TranscodeLevel3JSON(s, buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+		case wireLevel2_Items:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"items\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				if err := XXX_TranscodeLevel3JSON(s, buf.ReadMessage(entryOffset)); err != nil {
+					return err
+				}
+			}
+			s.B = append(s.B, ']')
+		case wireLevel2_Payload:
+			if offset != last[4] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"payload\":"...)
+			s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(offset))
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *Level2Reader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetId(); value != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetDescription(); value != "" {
+		w.WriteName("description")
+		w.WriteString(value)
+	}
+	if value := m.GetNested(); value != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		value.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+	if value := m.GetPayload(); len(value) != 0 {
+		w.WriteName("payload")
+		w.WriteBytes(value)
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *Level2Reader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *Level2Reader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *Level2Reader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
+type Level2 struct {
+	Id	int32	`json:"id,omitempty"`
+	Description	string	`json:"description,omitempty"`
+	Nested	*Level3	`json:"nested,omitempty"`
+	Items	[]*Level3	`json:"items,omitempty"`
+	Payload	[]byte	`json:"payload,omitempty"`
+}
+
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *Level2) Unmarshal(data []byte) error {
+	*s = Level2{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel2_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Id = buf.ReadInt32(offset)
+		case wireLevel2_Description:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Description = buf.ReadString(offset)
+		case wireLevel2_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			var entry = &Level3{}
+			if err := entry.Unmarshal(buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+			s.Nested = entry
+		case wireLevel2_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Items == nil {
+				s.Items = make([]*Level3, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry *Level3
+				if entryData := buf.ReadMessage(offset); len(entryData) > 0 {
+					var entry = &Level3{}
+					if err := entry.Unmarshal(entryData); err != nil {
+						return err
+					}
+					listEntry = entry
+				} else {
+					listEntry = nil
+				}
+				s.Items = append(s.Items, listEntry)
+			}
+		case wireLevel2_Payload:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Payload = buf.ReadBytes(offset)
+		}
+		offset = end
+	}
+	return nil
+}
+
+func (s *Level2) Marshal() []byte {
+	if s == nil {
+		return nil
+	}
+	size := s.XXX_PbContentSize()
+	if size == 0 {
+		return nil
+	}
+	res := gremlin.NewWriter(size)
+	s.MarshalTo(res)
+	return res.Bytes()
+}
+
+func (s *Level2) MarshalTo(res *gremlin.Writer) {
+	if s == nil {
+		return
+	}
+
+	if s.Id != 0 {
+		res.AppendInt32(wireLevel2_Id, s.Id)
+	}
+	if s.Description != "" {
+		res.AppendString(wireLevel2_Description, s.Description)
+	}
+	if s.Nested != nil {
+		structSize := s.Nested.XXX_PbContentSize()
+		res.AppendBytesTag(wireLevel2_Nested, structSize)
+		s.Nested.MarshalTo(res)
+	}
+	if len(s.Items) > 0 {
+		for _, entry := range s.Items {
+			structSize := entry.XXX_PbContentSize()
+			res.AppendBytesTag(wireLevel2_Items, structSize)
+			entry.MarshalTo(res)
+		}
+	}
+	if len(s.Payload) != 0 {
+		res.AppendBytes(wireLevel2_Payload, s.Payload)
+	}
+}
+
+func (s *Level2) Copy() *Level2 {
+	if s == nil {
+		return nil
+	}
+	res := &Level2{}
+	res.Id = s.Id
+	res.Description = s.Description
+	if s.Nested != nil {
+		res.Nested = s.Nested.Copy()
+	}
+	res.Items = make([]*Level3, len(s.Items))
+	for i := range s.Items {
+		if s.Items[i] != nil {
+			res.Items[i] = s.Items[i].Copy()
+		}
+	}
+	res.Payload = s.Payload
+
+	return res
+}
+
+func (s *Level2) XXX_PbContentSize() int {
+	if s == nil {
+		return 0
+	}
+	var size = 0
+
+	if s.Id != 0 {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireLevel2_Id) + gremlin.SizeInt32(s.Id)
+		size += entrySize
+	}
+
+	if s.Description != "" {
+		var entrySize = 0
+		entrySize = gremlin.SizeString(s.Description)
 		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireLevel2_Description)
 		size += entrySize
 	}
@@ -947,6 +2855,314 @@ func (s *Level2) XXX_PbContentSize() int {
 	return size
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *Level2) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Id != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Id))
+	}
+	if s.Description != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"description\":"...)
+		b = gremlin.AppendJSONString(b, s.Description)
+	}
+	if s.Nested != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = s.Nested.AppendJSON(b)
+	}
+	if len(s.Items) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Items {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if len(s.Payload) != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"payload\":"...)
+		b = gremlin.AppendJSONBytes(b, s.Payload)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *Level2) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *Level2) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *Level2) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = Level2{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "description":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Description = value
+		case "nested":
+			value := &Level3{}
+			if err := value.XXX_DecodeJSON(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadArray(func() error {
+				var listEntry *Level3
+				value := &Level3{}
+				if err := value.XXX_DecodeJSON(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "payload":
+			value, err := d.ReadBytes()
+			if err != nil {
+				return err
+			}
+			s.Payload = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *Level2) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.Id != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(s.Id))
+	}
+	if s.Description != "" {
+		w.WriteName("description")
+		w.WriteString(s.Description)
+	}
+	if s.Nested != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		s.Nested.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if len(s.Items) > 0 {
+		for _, entry := range s.Items {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+	if len(s.Payload) != 0 {
+		w.WriteName("payload")
+		w.WriteBytes(s.Payload)
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *Level2) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *Level2) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *Level2) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *Level2) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *Level2) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = Level2{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "description":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Description = value
+		case "nested":
+			value := &Level3{}
+			if err := value.XXX_DecodeText(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadRepeated(func() error {
+				var listEntry *Level3
+				value := &Level3{}
+				if err := value.XXX_DecodeText(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "payload":
+			value, err := d.ReadBytes()
+			if err != nil {
+				return err
+			}
+			s.Payload = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *Level2) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel2
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *Level2) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireLevel2_Id:
+		return s.Id
+	case wireLevel2_Description:
+		return s.Description
+	case wireLevel2_Nested:
+		return s.Nested
+	case wireLevel2_Items:
+		return s.Items
+	case wireLevel2_Payload:
+		return s.Payload
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *Level2) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireLevel2_Id:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel2, number, value)
+		}
+		s.Id = v
+	case wireLevel2_Description:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel2, number, value)
+		}
+		s.Description = v
+	case wireLevel2_Nested:
+		v, ok := value.(*Level3)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel2, number, value)
+		}
+		s.Nested = v
+	case wireLevel2_Items:
+		v, ok := value.([]*Level3)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel2, number, value)
+		}
+		s.Items = v
+	case wireLevel2_Payload:
+		v, ok := value.([]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel2, number, value)
+		}
+		s.Payload = v
+	default:
+		return gremlin.UnknownFieldError(descriptorLevel2, number)
+	}
+	return nil
+}
+
+// Level2Paths are the field mask paths of benchmark.Level2.
+var Level2Paths = XXX_NewLevel2PathNames("")
+
+type Level2PathNames struct {
+	path string
+	Id string
+	Description string
+	Nested Level3PathNames
+	Items string
+	Payload string
+}
+
+func XXX_NewLevel2PathNames(path string) Level2PathNames {
+	return Level2PathNames{
+		path: path,
+		Id: gremlin.JoinPath(path, "id"),
+		Description: gremlin.JoinPath(path, "description"),
+		Nested: XXX_NewLevel3PathNames(gremlin.JoinPath(path, "nested")),
+		Items: gremlin.JoinPath(path, "items"),
+		Payload: gremlin.JoinPath(path, "payload"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p Level2PathNames) Path() string {
+	return p.path
+}
+
 const (
 	wireLevel1_Id gremlin.ProtoWireNumber = 1
 	wireLevel1_Title gremlin.ProtoWireNumber = 2
@@ -955,8 +3171,49 @@ const (
 	wireLevel1_Score gremlin.ProtoWireNumber = 5
 )
 
+const (
+	FieldLevel1_Id gremlin.FieldIndex = 0
+	FieldLevel1_Title gremlin.FieldIndex = 1
+	FieldLevel1_Nested gremlin.FieldIndex = 2
+	FieldLevel1_Items gremlin.FieldIndex = 3
+	FieldLevel1_Score gremlin.FieldIndex = 4
+)
+
+var singularFieldsLevel1 = gremlin.NewFieldSet(FieldLevel1_Id, FieldLevel1_Title, FieldLevel1_Nested, FieldLevel1_Score)
+
+var descriptorLevel1 = &gremlin.MessageDescriptor{
+	FullName: "benchmark.Level1",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "id", JSONName: "id", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "title", JSONName: "title", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "nested", JSONName: "nested", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "benchmark.Level2"},
+		{Name: "items", JSONName: "items", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated, TypeName: "benchmark.Level2"},
+		{Name: "score", JSONName: "score", Number: 5, Kind: gremlin.KindDouble, Label: gremlin.LabelOptional},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &Level1{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewLevel1Reader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorLevel1)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *Level1Reader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel1
+}
+
 type Level1Reader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataId     int32
 	dataTitle     string
@@ -964,17 +3221,12 @@ type Level1Reader struct {
 	dataItems     []*Level2Reader
 	dataScore     float64
 
-	offsetId   int
-	offsetTitle   int
-	offsetNested   int
-	offsetItems   []int
-	offsetScore   int
+	offsetId   int32
+	offsetTitle   int32
+	offsetNested   int32
+	offsetScore   int32
 
-	parsedId   bool
-	parsedTitle   bool
-	parsedNested   bool
-	parsedItems   bool
-	parsedScore   bool
+	inlineNested   Level2Reader
 }
 
 func NewLevel1Reader() *Level1Reader {
@@ -989,10 +3241,10 @@ func (m *Level1Reader) GetId() int32 {
 }
 
 func (m *Level1Reader) readId() int32 {
-	if m.parsedId {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataId
 	}
-	wOffset := m.offsetId
+	wOffset := int(m.offsetId)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -1000,7 +3252,7 @@ func (m *Level1Reader) readId() int32 {
 	}
 	
 	m.dataId = entry
-	m.parsedId = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -1012,10 +3264,10 @@ func (m *Level1Reader) GetTitle() string {
 }
 
 func (m *Level1Reader) readTitle() string {
-	if m.parsedTitle {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataTitle
 	}
-	wOffset := m.offsetTitle
+	wOffset := int(m.offsetTitle)
 	
 	var entry string
 	if wOffset > 0 {
@@ -1023,7 +3275,7 @@ func (m *Level1Reader) readTitle() string {
 	}
 	
 	m.dataTitle = entry
-	m.parsedTitle = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -1035,22 +3287,19 @@ func (m *Level1Reader) GetNested() *Level2Reader {
 }
 
 func (m *Level1Reader) readNested() *Level2Reader {
-	if m.parsedNested {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataNested
 	}
-	wOffset := m.offsetNested
+	wOffset := int(m.offsetNested)
 	
 	var entry *Level2Reader
 	if wOffset > 0 {
-		var entryData = m.buf.ReadBytes(wOffset)
-		if len(entryData) > 0 {
-			entry = NewLevel2Reader()
-			entry.Unmarshal(entryData)
-		}
+		entry = &m.inlineNested
+		entry.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 	}
 	
 	m.dataNested = entry
-	m.parsedNested = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -1062,29 +3311,36 @@ func (m *Level1Reader) GetItems() []*Level2Reader {
 }
 
 func (m *Level1Reader) readItems() []*Level2Reader {
-	if m.parsedItems {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataItems
 	}
-	wOffset := m.offsetItems
+	wField := FieldLevel1_Items
 	
 	var entry []*Level2Reader
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry *Level2Reader
-		if wOffset > 0 {
-			var listEntryData = m.buf.ReadBytes(wOffset)
-			if len(listEntryData) > 0 {
-				listEntry = NewLevel2Reader()
-				listEntry.Unmarshal(listEntryData)
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[*Level2Reader](m.buf.Arena(), 0, count)
+		listReaders := gremlin.MakeSlice[Level2Reader](m.buf.Arena(), count, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry *Level2Reader
+			if wOffset > 0 {
+				var listEntryData = m.buf.ReadMessage(wOffset)
+				if len(listEntryData) > 0 {
+					listEntry = &listReaders[len(entry)]
+					listEntry.UnmarshalWithOptions(listEntryData, m.buf.Options())
+				}
 			}
+			
+			entry = append(entry, listEntry)
 		}
-		
-		entry = append(entry, listEntry)
 	}
 	
 	m.dataItems = entry
-	m.parsedItems = true
+	m.parsed[0] |= 1 << 3
 	return entry
 }
 
@@ -1096,10 +3352,10 @@ func (m *Level1Reader) GetScore() float64 {
 }
 
 func (m *Level1Reader) readScore() float64 {
-	if m.parsedScore {
+	if m.parsed[0]&(1 << 4) != 0 {
 		return m.dataScore
 	}
-	wOffset := m.offsetScore
+	wOffset := int(m.offsetScore)
 	
 	var entry float64
 	if wOffset > 0 {
@@ -1107,12 +3363,69 @@ func (m *Level1Reader) readScore() float64 {
 	}
 	
 	m.dataScore = entry
-	m.parsedScore = true
+	m.parsed[0] |= 1 << 4
 	return entry
 }
 
 func (m *Level1Reader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *Level1Reader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel1_Id:
+			m.offsetId = int32(offset)
+		case wireLevel1_Title:
+			m.offsetTitle = int32(offset)
+		case wireLevel1_Nested:
+			m.offsetNested = int32(offset)
+		case wireLevel1_Items:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel1_Items, Offset: int32(offset), Wire: wire})
+		case wireLevel1_Score:
+			m.offsetScore = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *Level1Reader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *Level1Reader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = Level1Reader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsLevel1) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -1123,15 +3436,49 @@ func (m *Level1Reader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireLevel1_Id:
-			m.offsetId = offset
+			if fields.Has(FieldLevel1_Id) {
+				if m.offsetId == 0 {
+					found++
+				}
+				m.offsetId = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel1_Title:
-			m.offsetTitle = offset
+			if fields.Has(FieldLevel1_Title) {
+				if m.offsetTitle == 0 {
+					found++
+				}
+				m.offsetTitle = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel1_Nested:
-			m.offsetNested = offset
+			if fields.Has(FieldLevel1_Nested) {
+				if m.offsetNested == 0 {
+					found++
+				}
+				m.offsetNested = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		case wireLevel1_Items:
-			m.offsetItems = append(m.offsetItems, offset)
+			if fields.Has(FieldLevel1_Items) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldLevel1_Items, Offset: int32(offset), Wire: wire})
+			}
 		case wireLevel1_Score:
-			m.offsetScore = offset
+			if fields.Has(FieldLevel1_Score) {
+				if m.offsetScore == 0 {
+					found++
+				}
+				m.offsetScore = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -1146,7 +3493,7 @@ func (m *Level1Reader) ToStruct() *Level1 {
 	if m == nil {
 		return nil
 	}
-	res := &Level1{}
+	res := gremlin.New[Level1](m.buf.Arena())
 	res.Id = m.GetId()
 	res.Title = m.GetTitle()
 
@@ -1163,7 +3510,7 @@ func (m *Level1Reader) ToStruct() *Level1 {
 		var data = m.GetItems()
 		var structData []*Level2
 		if len(data) > 0 {
-			structData = make([]*Level2, len(data))
+			structData = gremlin.MakeSlice[*Level2](m.buf.Arena(), len(data), len(data))
 			for i := range data {
 				if data[i] != nil {
 					structData[i] = data[i].ToStruct()
@@ -1177,6 +3524,57 @@ func (m *Level1Reader) ToStruct() *Level1 {
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *Level1Reader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readId()
+	m.readTitle()
+	m.readNested().Freeze()
+	for _, entry := range m.readItems() {
+		entry.Freeze()
+	}
+	m.readScore()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *Level1Reader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *Level1Reader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataTitle = d.RebaseString(m.dataTitle)
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		m.dataNested.XXX_Rebase(d)
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		for i := range m.dataItems {
+			m.dataItems[i].XXX_Rebase(d)
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *Level1Reader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -1184,6 +3582,253 @@ func (s *Level1Reader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *Level1Reader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetId(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetTitle(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"title\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetNested(); value != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = value.AppendJSON(b)
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if value := m.GetScore(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"score\":"...)
+		b = gremlin.AppendJSONFloat(b, value, 64)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *Level1Reader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeLevel1JSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeLevel1JSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeLevel1JSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeLevel1JSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeLevel1JSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var first, last [5]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel1_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		case wireLevel1_Title:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
+		case wireLevel1_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if first[2] == 0 {
+				first[2] = offset
+			}
+			last[2] = offset
+		case wireLevel1_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireLevel1_Score:
+			if wire != gremlin.Fixed64Type {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[4] = offset
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireLevel1_Id:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"id\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		case wireLevel1_Title:
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"title\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
+		case wireLevel1_Nested:
+			if offset != last[2] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"nested\":"...)
+			buf, offset := buf.MergeMessage(first[2], offset, tag)
+			if err := XXX_TranscodeLevel2JSON(s, buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+		case wireLevel1_Items:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"items\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				if err := XXX_TranscodeLevel2JSON(s, buf.ReadMessage(entryOffset)); err != nil {
+					return err
+				}
+			}
+			s.B = append(s.B, ']')
+		case wireLevel1_Score:
+			if offset != last[4] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"score\":"...)
+			s.B = gremlin.AppendJSONFloat(s.B, buf.ReadFloat64(offset), 64)
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *Level1Reader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetId(); value != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetTitle(); value != "" {
+		w.WriteName("title")
+		w.WriteString(value)
+	}
+	if value := m.GetNested(); value != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		value.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+	if value := m.GetScore(); value != 0 {
+		w.WriteName("score")
+		w.WriteFloat(value, 64)
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *Level1Reader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *Level1Reader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *Level1Reader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type Level1 struct {
 	Id	int32	`json:"id,omitempty"`
 	Title	string	`json:"title,omitempty"`
@@ -1192,6 +3837,80 @@ type Level1 struct {
 	Score	float64	`json:"score,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *Level1) Unmarshal(data []byte) error {
+	*s = Level1{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireLevel1_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Id = buf.ReadInt32(offset)
+		case wireLevel1_Title:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Title = buf.ReadString(offset)
+		case wireLevel1_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			var entry = &Level2{}
+			if err := entry.Unmarshal(buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+			s.Nested = entry
+		case wireLevel1_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Items == nil {
+				s.Items = make([]*Level2, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry *Level2
+				if entryData := buf.ReadMessage(offset); len(entryData) > 0 {
+					var entry = &Level2{}
+					if err := entry.Unmarshal(entryData); err != nil {
+						return err
+					}
+					listEntry = entry
+				} else {
+					listEntry = nil
+				}
+				s.Items = append(s.Items, listEntry)
+			}
+		case wireLevel1_Score:
+			if wire != gremlin.Fixed64Type {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Score = buf.ReadFloat64(offset)
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *Level1) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -1229,78 +3948,386 @@ func (s *Level1) MarshalTo(res *gremlin.Writer) {
 		}
 	}
 	if s.Score != 0 {
-		res.AppendFloat64(wireLevel1_Score, s.Score)
+		res.AppendFloat64(wireLevel1_Score, s.Score)
+	}
+}
+
+func (s *Level1) Copy() *Level1 {
+	if s == nil {
+		return nil
+	}
+	res := &Level1{}
+	res.Id = s.Id
+	res.Title = s.Title
+	if s.Nested != nil {
+		res.Nested = s.Nested.Copy()
+	}
+	res.Items = make([]*Level2, len(s.Items))
+	for i := range s.Items {
+		if s.Items[i] != nil {
+			res.Items[i] = s.Items[i].Copy()
+		}
+	}
+	res.Score = s.Score
+
+	return res
+}
+
+func (s *Level1) XXX_PbContentSize() int {
+	if s == nil {
+		return 0
+	}
+	var size = 0
+
+	if s.Id != 0 {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireLevel1_Id) + gremlin.SizeInt32(s.Id)
+		size += entrySize
+	}
+
+	if s.Title != "" {
+		var entrySize = 0
+		entrySize = gremlin.SizeString(s.Title)
+		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireLevel1_Title)
+		size += entrySize
+	}
+
+	if s.Nested != nil {
+		var entrySize = 0
+		entrySize = s.Nested.XXX_PbContentSize()
+		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireLevel1_Nested)
+		
+		size += entrySize
+	}
+
+	if len(s.Items) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for _, val := range s.Items {
+			var listEntrySize int
+			listEntrySize = val.XXX_PbContentSize()
+			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireLevel1_Items)
+			
+			entrySize += listEntrySize
+		}
+		size += entrySize
+	}
+
+	if s.Score != 0 {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireLevel1_Score) + gremlin.SizeFloat64(s.Score)
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *Level1) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Id != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Id))
+	}
+	if s.Title != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"title\":"...)
+		b = gremlin.AppendJSONString(b, s.Title)
+	}
+	if s.Nested != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = s.Nested.AppendJSON(b)
+	}
+	if len(s.Items) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Items {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if s.Score != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"score\":"...)
+		b = gremlin.AppendJSONFloat(b, s.Score, 64)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *Level1) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *Level1) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *Level1) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = Level1{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "title":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Title = value
+		case "nested":
+			value := &Level2{}
+			if err := value.XXX_DecodeJSON(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadArray(func() error {
+				var listEntry *Level2
+				value := &Level2{}
+				if err := value.XXX_DecodeJSON(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "score":
+			value, err := d.ReadFloat64()
+			if err != nil {
+				return err
+			}
+			s.Score = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *Level1) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.Id != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(s.Id))
+	}
+	if s.Title != "" {
+		w.WriteName("title")
+		w.WriteString(s.Title)
+	}
+	if s.Nested != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		s.Nested.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if len(s.Items) > 0 {
+		for _, entry := range s.Items {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+	if s.Score != 0 {
+		w.WriteName("score")
+		w.WriteFloat(s.Score, 64)
 	}
 }
 
-func (s *Level1) Copy() *Level1 {
-	if s == nil {
-		return nil
-	}
-	res := &Level1{}
-	res.Id = s.Id
-	res.Title = s.Title
-	if s.Nested != nil {
-		res.Nested = s.Nested.Copy()
-	}
-	res.Items = make([]*Level2, len(s.Items))
-	for i := range s.Items {
-		if s.Items[i] != nil {
-			res.Items[i] = s.Items[i].Copy()
-		}
-	}
-	res.Score = s.Score
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *Level1) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
 
-	return res
+// String returns the message in the single line protobuf text format.
+func (s *Level1) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
 }
 
-func (s *Level1) XXX_PbContentSize() int {
-	if s == nil {
-		return 0
-	}
-	var size = 0
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *Level1) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
 
-	if s.Id != 0 {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireLevel1_Id) + gremlin.SizeInt32(s.Id)
-		size += entrySize
-	}
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *Level1) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
 
-	if s.Title != "" {
-		var entrySize = 0
-		entrySize = gremlin.SizeString(s.Title)
-		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireLevel1_Title)
-		size += entrySize
-	}
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *Level1) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = Level1{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "title":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Title = value
+		case "nested":
+			value := &Level2{}
+			if err := value.XXX_DecodeText(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadRepeated(func() error {
+				var listEntry *Level2
+				value := &Level2{}
+				if err := value.XXX_DecodeText(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "score":
+			value, err := d.ReadFloat64()
+			if err != nil {
+				return err
+			}
+			s.Score = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
 
-	if s.Nested != nil {
-		var entrySize = 0
-		entrySize = s.Nested.XXX_PbContentSize()
-		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireLevel1_Nested)
-		
-		size += entrySize
+// Descriptor returns the descriptor of the message type.
+func (s *Level1) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorLevel1
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *Level1) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireLevel1_Id:
+		return s.Id
+	case wireLevel1_Title:
+		return s.Title
+	case wireLevel1_Nested:
+		return s.Nested
+	case wireLevel1_Items:
+		return s.Items
+	case wireLevel1_Score:
+		return s.Score
 	}
+	return nil
+}
 
-	if len(s.Items) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for _, val := range s.Items {
-			var listEntrySize int
-			listEntrySize = val.XXX_PbContentSize()
-			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireLevel1_Items)
-			
-			entrySize += listEntrySize
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *Level1) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireLevel1_Id:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel1, number, value)
 		}
-		size += entrySize
+		s.Id = v
+	case wireLevel1_Title:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel1, number, value)
+		}
+		s.Title = v
+	case wireLevel1_Nested:
+		v, ok := value.(*Level2)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel1, number, value)
+		}
+		s.Nested = v
+	case wireLevel1_Items:
+		v, ok := value.([]*Level2)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel1, number, value)
+		}
+		s.Items = v
+	case wireLevel1_Score:
+		v, ok := value.(float64)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorLevel1, number, value)
+		}
+		s.Score = v
+	default:
+		return gremlin.UnknownFieldError(descriptorLevel1, number)
 	}
+	return nil
+}
 
-	if s.Score != 0 {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireLevel1_Score) + gremlin.SizeFloat64(s.Score)
-		size += entrySize
+// Level1Paths are the field mask paths of benchmark.Level1.
+var Level1Paths = XXX_NewLevel1PathNames("")
+
+type Level1PathNames struct {
+	path string
+	Id string
+	Title string
+	Nested Level2PathNames
+	Items string
+	Score string
+}
+
+func XXX_NewLevel1PathNames(path string) Level1PathNames {
+	return Level1PathNames{
+		path: path,
+		Id: gremlin.JoinPath(path, "id"),
+		Title: gremlin.JoinPath(path, "title"),
+		Nested: XXX_NewLevel2PathNames(gremlin.JoinPath(path, "nested")),
+		Items: gremlin.JoinPath(path, "items"),
+		Score: gremlin.JoinPath(path, "score"),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p Level1PathNames) Path() string {
+	return p.path
 }
 
 const (
@@ -1312,8 +4339,51 @@ const (
 	wireDeepNested_Tags gremlin.ProtoWireNumber = 6
 )
 
+const (
+	FieldDeepNested_RootId gremlin.FieldIndex = 0
+	FieldDeepNested_RootName gremlin.FieldIndex = 1
+	FieldDeepNested_Nested gremlin.FieldIndex = 2
+	FieldDeepNested_Items gremlin.FieldIndex = 3
+	FieldDeepNested_Active gremlin.FieldIndex = 4
+	FieldDeepNested_Tags gremlin.FieldIndex = 5
+)
+
+var singularFieldsDeepNested = gremlin.NewFieldSet(FieldDeepNested_RootId, FieldDeepNested_RootName, FieldDeepNested_Nested, FieldDeepNested_Active)
+
+var descriptorDeepNested = &gremlin.MessageDescriptor{
+	FullName: "benchmark.DeepNested",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "root_id", JSONName: "rootId", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "root_name", JSONName: "rootName", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "nested", JSONName: "nested", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "benchmark.Level1"},
+		{Name: "items", JSONName: "items", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated, TypeName: "benchmark.Level1"},
+		{Name: "active", JSONName: "active", Number: 5, Kind: gremlin.KindBool, Label: gremlin.LabelOptional},
+		{Name: "tags", JSONName: "tags", Number: 6, Kind: gremlin.KindString, Label: gremlin.LabelRepeated},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &DeepNested{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewDeepNestedReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorDeepNested)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *DeepNestedReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorDeepNested
+}
+
 type DeepNestedReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataRootId     int32
 	dataRootName     string
@@ -1322,19 +4392,12 @@ type DeepNestedReader struct {
 	dataActive     bool
 	dataTags     []string
 
-	offsetRootId   int
-	offsetRootName   int
-	offsetNested   int
-	offsetItems   []int
-	offsetActive   int
-	offsetTags   []int
+	offsetRootId   int32
+	offsetRootName   int32
+	offsetNested   int32
+	offsetActive   int32
 
-	parsedRootId   bool
-	parsedRootName   bool
-	parsedNested   bool
-	parsedItems   bool
-	parsedActive   bool
-	parsedTags   bool
+	inlineNested   Level1Reader
 }
 
 func NewDeepNestedReader() *DeepNestedReader {
@@ -1349,10 +4412,10 @@ func (m *DeepNestedReader) GetRootId() int32 {
 }
 
 func (m *DeepNestedReader) readRootId() int32 {
-	if m.parsedRootId {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataRootId
 	}
-	wOffset := m.offsetRootId
+	wOffset := int(m.offsetRootId)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -1360,7 +4423,7 @@ func (m *DeepNestedReader) readRootId() int32 {
 	}
 	
 	m.dataRootId = entry
-	m.parsedRootId = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -1372,10 +4435,10 @@ func (m *DeepNestedReader) GetRootName() string {
 }
 
 func (m *DeepNestedReader) readRootName() string {
-	if m.parsedRootName {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataRootName
 	}
-	wOffset := m.offsetRootName
+	wOffset := int(m.offsetRootName)
 	
 	var entry string
 	if wOffset > 0 {
@@ -1383,7 +4446,7 @@ func (m *DeepNestedReader) readRootName() string {
 	}
 	
 	m.dataRootName = entry
-	m.parsedRootName = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -1395,22 +4458,19 @@ func (m *DeepNestedReader) GetNested() *Level1Reader {
 }
 
 func (m *DeepNestedReader) readNested() *Level1Reader {
-	if m.parsedNested {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataNested
 	}
-	wOffset := m.offsetNested
+	wOffset := int(m.offsetNested)
 	
 	var entry *Level1Reader
 	if wOffset > 0 {
-		var entryData = m.buf.ReadBytes(wOffset)
-		if len(entryData) > 0 {
-			entry = NewLevel1Reader()
-			entry.Unmarshal(entryData)
-		}
+		entry = &m.inlineNested
+		entry.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 	}
 	
 	m.dataNested = entry
-	m.parsedNested = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -1422,29 +4482,36 @@ func (m *DeepNestedReader) GetItems() []*Level1Reader {
 }
 
 func (m *DeepNestedReader) readItems() []*Level1Reader {
-	if m.parsedItems {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataItems
 	}
-	wOffset := m.offsetItems
+	wField := FieldDeepNested_Items
 	
 	var entry []*Level1Reader
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry *Level1Reader
-		if wOffset > 0 {
-			var listEntryData = m.buf.ReadBytes(wOffset)
-			if len(listEntryData) > 0 {
-				listEntry = NewLevel1Reader()
-				listEntry.Unmarshal(listEntryData)
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[*Level1Reader](m.buf.Arena(), 0, count)
+		listReaders := gremlin.MakeSlice[Level1Reader](m.buf.Arena(), count, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry *Level1Reader
+			if wOffset > 0 {
+				var listEntryData = m.buf.ReadMessage(wOffset)
+				if len(listEntryData) > 0 {
+					listEntry = &listReaders[len(entry)]
+					listEntry.UnmarshalWithOptions(listEntryData, m.buf.Options())
+				}
 			}
+			
+			entry = append(entry, listEntry)
 		}
-		
-		entry = append(entry, listEntry)
 	}
 	
 	m.dataItems = entry
-	m.parsedItems = true
+	m.parsed[0] |= 1 << 3
 	return entry
 }
 
@@ -1456,10 +4523,10 @@ func (m *DeepNestedReader) GetActive() bool {
 }
 
 func (m *DeepNestedReader) readActive() bool {
-	if m.parsedActive {
+	if m.parsed[0]&(1 << 4) != 0 {
 		return m.dataActive
 	}
-	wOffset := m.offsetActive
+	wOffset := int(m.offsetActive)
 	
 	var entry bool
 	if wOffset > 0 {
@@ -1467,7 +4534,7 @@ func (m *DeepNestedReader) readActive() bool {
 	}
 	
 	m.dataActive = entry
-	m.parsedActive = true
+	m.parsed[0] |= 1 << 4
 	return entry
 }
 
@@ -1479,102 +4546,545 @@ func (m *DeepNestedReader) GetTags() []string {
 }
 
 func (m *DeepNestedReader) readTags() []string {
-	if m.parsedTags {
+	if m.parsed[0]&(1 << 5) != 0 {
 		return m.dataTags
 	}
-	wOffset := m.offsetTags
+	wField := FieldDeepNested_Tags
 	
 	var entry []string
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry string
-		if wOffset > 0 {
-			listEntry = m.buf.ReadString(wOffset)
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[string](m.buf.Arena(), 0, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry string
+			if wOffset > 0 {
+				listEntry = m.buf.ReadString(wOffset)
+			}
+			
+			entry = append(entry, listEntry)
 		}
-		
-		entry = append(entry, listEntry)
 	}
 	
 	m.dataTags = entry
-	m.parsedTags = true
+	m.parsed[0] |= 1 << 5
 	return entry
 }
 
-func (m *DeepNestedReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+func (m *DeepNestedReader) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *DeepNestedReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireDeepNested_RootId:
+			m.offsetRootId = int32(offset)
+		case wireDeepNested_RootName:
+			m.offsetRootName = int32(offset)
+		case wireDeepNested_Nested:
+			m.offsetNested = int32(offset)
+		case wireDeepNested_Items:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldDeepNested_Items, Offset: int32(offset), Wire: wire})
+		case wireDeepNested_Active:
+			m.offsetActive = int32(offset)
+		case wireDeepNested_Tags:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldDeepNested_Tags, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *DeepNestedReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *DeepNestedReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = DeepNestedReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsDeepNested) {
+		stopAfter = fields.Len()
+	}
+	found := 0
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireDeepNested_RootId:
+			if fields.Has(FieldDeepNested_RootId) {
+				if m.offsetRootId == 0 {
+					found++
+				}
+				m.offsetRootId = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireDeepNested_RootName:
+			if fields.Has(FieldDeepNested_RootName) {
+				if m.offsetRootName == 0 {
+					found++
+				}
+				m.offsetRootName = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireDeepNested_Nested:
+			if fields.Has(FieldDeepNested_Nested) {
+				if m.offsetNested == 0 {
+					found++
+				}
+				m.offsetNested = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireDeepNested_Items:
+			if fields.Has(FieldDeepNested_Items) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldDeepNested_Items, Offset: int32(offset), Wire: wire})
+			}
+		case wireDeepNested_Active:
+			if fields.Has(FieldDeepNested_Active) {
+				if m.offsetActive == 0 {
+					found++
+				}
+				m.offsetActive = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireDeepNested_Tags:
+			if fields.Has(FieldDeepNested_Tags) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldDeepNested_Tags, Offset: int32(offset), Wire: wire})
+			}
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *DeepNestedReader) ToStruct() *DeepNested {
+	if m == nil {
+		return nil
+	}
+	res := gremlin.New[DeepNested](m.buf.Arena())
+	res.RootId = m.GetRootId()
+	res.RootName = m.GetRootName()
+
+	{
+		var data = m.GetNested()
+		var structData *Level1
+		if data != nil {
+			structData = data.ToStruct()
+		}
+		res.Nested = structData
+	}
+
+	{
+		var data = m.GetItems()
+		var structData []*Level1
+		if len(data) > 0 {
+			structData = gremlin.MakeSlice[*Level1](m.buf.Arena(), len(data), len(data))
+			for i := range data {
+				if data[i] != nil {
+					structData[i] = data[i].ToStruct()
+				}
+			}
+		}
+		res.Items = structData
+	}
+	res.Active = m.GetActive()
+	res.Tags = m.GetTags()
+
+	return res
+}
+
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *DeepNestedReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readRootId()
+	m.readRootName()
+	m.readNested().Freeze()
+	for _, entry := range m.readItems() {
+		entry.Freeze()
+	}
+	m.readActive()
+	m.readTags()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *DeepNestedReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *DeepNestedReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataRootName = d.RebaseString(m.dataRootName)
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		m.dataNested.XXX_Rebase(d)
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		for i := range m.dataItems {
+			m.dataItems[i].XXX_Rebase(d)
+		}
+	}
+	if m.parsed[0]&(1 << 5) != 0 {
+		for i := range m.dataTags {
+			m.dataTags[i] = d.RebaseString(m.dataTags[i])
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *DeepNestedReader) SourceBytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *DeepNestedReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetRootId(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"rootId\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetRootName(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"rootName\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetNested(); value != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = value.AppendJSON(b)
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if value := m.GetActive(); value {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"active\":"...)
+		b = gremlin.AppendJSONBool(b, value)
+	}
+	if value := m.GetTags(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"tags\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, entry)
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *DeepNestedReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeDeepNestedJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeDeepNestedJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeDeepNestedJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeDeepNestedJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeDeepNestedJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var first, last [6]int
 	offset := 0
-	for m.buf.HasNext(offset, 0) {
-		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
 		if err != nil {
 			return err
 		}
 
 		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
 		switch tag {
 		case wireDeepNested_RootId:
-			m.offsetRootId = offset
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
 		case wireDeepNested_RootName:
-			m.offsetRootName = offset
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
 		case wireDeepNested_Nested:
-			m.offsetNested = offset
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if first[2] == 0 {
+				first[2] = offset
+			}
+			last[2] = offset
 		case wireDeepNested_Items:
-			m.offsetItems = append(m.offsetItems, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
 		case wireDeepNested_Active:
-			m.offsetActive = offset
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[4] = offset
 		case wireDeepNested_Tags:
-			m.offsetTags = append(m.offsetTags, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
 		}
+		offset = end
+	}
 
-		offset, err = m.buf.SkipData(offset, wire)
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireDeepNested_RootId:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"rootId\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		case wireDeepNested_RootName:
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"rootName\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
+		case wireDeepNested_Nested:
+			if offset != last[2] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"nested\":"...)
+			buf, offset := buf.MergeMessage(first[2], offset, tag)
+			if err := XXX_TranscodeLevel1JSON(s, buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+		case wireDeepNested_Items:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"items\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				if err := XXX_TranscodeLevel1JSON(s, buf.ReadMessage(entryOffset)); err != nil {
+					return err
+				}
+			}
+			s.B = append(s.B, ']')
+		case wireDeepNested_Active:
+			if offset != last[4] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"active\":"...)
+			s.B = gremlin.AppendJSONBool(s.B, buf.ReadBool(offset))
+		case wireDeepNested_Tags:
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"tags\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				s.B = gremlin.AppendJSONString(s.B, buf.ReadString(entryOffset))
+			}
+			s.B = append(s.B, ']')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
 }
 
-func (m *DeepNestedReader) ToStruct() *DeepNested {
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *DeepNestedReader) XXX_WriteText(w *gremlin.TextWriter) {
 	if m == nil {
-		return nil
+		return
 	}
-	res := &DeepNested{}
-	res.RootId = m.GetRootId()
-	res.RootName = m.GetRootName()
-
-	{
-		var data = m.GetNested()
-		var structData *Level1
-		if data != nil {
-			structData = data.ToStruct()
+	if value := m.GetRootId(); value != 0 {
+		w.WriteName("root_id")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetRootName(); value != "" {
+		w.WriteName("root_name")
+		w.WriteString(value)
+	}
+	if value := m.GetNested(); value != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		value.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if value := m.GetItems(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
 		}
-		res.Nested = structData
 	}
-
-	{
-		var data = m.GetItems()
-		var structData []*Level1
-		if len(data) > 0 {
-			structData = make([]*Level1, len(data))
-			for i := range data {
-				if data[i] != nil {
-					structData[i] = data[i].ToStruct()
-				}
-			}
+	if value := m.GetActive(); value {
+		w.WriteName("active")
+		w.WriteBool(value)
+	}
+	if value := m.GetTags(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("tags")
+			w.WriteString(entry)
 		}
-		res.Items = structData
 	}
-	res.Active = m.GetActive()
-	res.Tags = m.GetTags()
+}
 
-	return res
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *DeepNestedReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
 }
 
-func (s *DeepNestedReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
+// String returns the message in the single line protobuf text format.
+func (m *DeepNestedReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *DeepNestedReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
 }
 
 type DeepNested struct {
@@ -1586,6 +5096,92 @@ type DeepNested struct {
 	Tags	[]string	`json:"tags,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *DeepNested) Unmarshal(data []byte) error {
+	*s = DeepNested{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireDeepNested_RootId:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.RootId = buf.ReadInt32(offset)
+		case wireDeepNested_RootName:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.RootName = buf.ReadString(offset)
+		case wireDeepNested_Nested:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			var entry = &Level1{}
+			if err := entry.Unmarshal(buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+			s.Nested = entry
+		case wireDeepNested_Items:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Items == nil {
+				s.Items = make([]*Level1, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry *Level1
+				if entryData := buf.ReadMessage(offset); len(entryData) > 0 {
+					var entry = &Level1{}
+					if err := entry.Unmarshal(entryData); err != nil {
+						return err
+					}
+					listEntry = entry
+				} else {
+					listEntry = nil
+				}
+				s.Items = append(s.Items, listEntry)
+			}
+		case wireDeepNested_Active:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Active = buf.ReadBool(offset)
+		case wireDeepNested_Tags:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Tags == nil {
+				s.Tags = make([]string, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry string
+				listEntry = buf.ReadString(offset)
+				s.Tags = append(s.Tags, listEntry)
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *DeepNested) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -1623,96 +5219,459 @@ func (s *DeepNested) MarshalTo(res *gremlin.Writer) {
 		}
 	}
 	if s.Active {
-		res.AppendBool(wireDeepNested_Active, s.Active)
+		res.AppendBool(wireDeepNested_Active, s.Active)
+	}
+	if len(s.Tags) > 0 {
+		for _, entry := range s.Tags {
+			res.AppendString(wireDeepNested_Tags, entry)
+		}
+	}
+}
+
+func (s *DeepNested) Copy() *DeepNested {
+	if s == nil {
+		return nil
+	}
+	res := &DeepNested{}
+	res.RootId = s.RootId
+	res.RootName = s.RootName
+	if s.Nested != nil {
+		res.Nested = s.Nested.Copy()
+	}
+	res.Items = make([]*Level1, len(s.Items))
+	for i := range s.Items {
+		if s.Items[i] != nil {
+			res.Items[i] = s.Items[i].Copy()
+		}
+	}
+	res.Active = s.Active
+	res.Tags = s.Tags
+
+	return res
+}
+
+func (s *DeepNested) XXX_PbContentSize() int {
+	if s == nil {
+		return 0
+	}
+	var size = 0
+
+	if s.RootId != 0 {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireDeepNested_RootId) + gremlin.SizeInt32(s.RootId)
+		size += entrySize
+	}
+
+	if s.RootName != "" {
+		var entrySize = 0
+		entrySize = gremlin.SizeString(s.RootName)
+		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireDeepNested_RootName)
+		size += entrySize
+	}
+
+	if s.Nested != nil {
+		var entrySize = 0
+		entrySize = s.Nested.XXX_PbContentSize()
+		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireDeepNested_Nested)
+		
+		size += entrySize
+	}
+
+	if len(s.Items) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for _, val := range s.Items {
+			var listEntrySize int
+			listEntrySize = val.XXX_PbContentSize()
+			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireDeepNested_Items)
+			
+			entrySize += listEntrySize
+		}
+		size += entrySize
+	}
+
+	if s.Active {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireDeepNested_Active) + gremlin.SizeBool(s.Active)
+		size += entrySize
+	}
+
+	if len(s.Tags) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for _, val := range s.Tags {
+			var listEntrySize int
+			listEntrySize = gremlin.SizeString(val)
+			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireDeepNested_Tags)
+			entrySize += listEntrySize
+		}
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *DeepNested) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.RootId != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"rootId\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.RootId))
+	}
+	if s.RootName != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"rootName\":"...)
+		b = gremlin.AppendJSONString(b, s.RootName)
+	}
+	if s.Nested != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"nested\":"...)
+		b = s.Nested.AppendJSON(b)
+	}
+	if len(s.Items) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"items\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Items {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = entry.AppendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	if s.Active {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"active\":"...)
+		b = gremlin.AppendJSONBool(b, s.Active)
+	}
+	if len(s.Tags) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"tags\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Tags {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, entry)
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *DeepNested) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *DeepNested) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *DeepNested) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = DeepNested{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "rootId", "root_id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.RootId = value
+		case "rootName", "root_name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.RootName = value
+		case "nested":
+			value := &Level1{}
+			if err := value.XXX_DecodeJSON(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadArray(func() error {
+				var listEntry *Level1
+				value := &Level1{}
+				if err := value.XXX_DecodeJSON(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "active":
+			value, err := d.ReadBool()
+			if err != nil {
+				return err
+			}
+			s.Active = value
+		case "tags":
+			if err := d.ReadArray(func() error {
+				var listEntry string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Tags = append(s.Tags, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *DeepNested) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.RootId != 0 {
+		w.WriteName("root_id")
+		w.WriteInt(int64(s.RootId))
+	}
+	if s.RootName != "" {
+		w.WriteName("root_name")
+		w.WriteString(s.RootName)
+	}
+	if s.Nested != nil {
+		w.WriteName("nested")
+		w.StartMessage()
+		s.Nested.XXX_WriteText(w)
+		w.EndMessage()
+	}
+	if len(s.Items) > 0 {
+		for _, entry := range s.Items {
+			w.WriteName("items")
+			w.StartMessage()
+			entry.XXX_WriteText(w)
+			w.EndMessage()
+		}
+	}
+	if s.Active {
+		w.WriteName("active")
+		w.WriteBool(s.Active)
 	}
 	if len(s.Tags) > 0 {
 		for _, entry := range s.Tags {
-			res.AppendString(wireDeepNested_Tags, entry)
+			w.WriteName("tags")
+			w.WriteString(entry)
 		}
 	}
 }
 
-func (s *DeepNested) Copy() *DeepNested {
-	if s == nil {
-		return nil
-	}
-	res := &DeepNested{}
-	res.RootId = s.RootId
-	res.RootName = s.RootName
-	if s.Nested != nil {
-		res.Nested = s.Nested.Copy()
-	}
-	res.Items = make([]*Level1, len(s.Items))
-	for i := range s.Items {
-		if s.Items[i] != nil {
-			res.Items[i] = s.Items[i].Copy()
-		}
-	}
-	res.Active = s.Active
-	res.Tags = s.Tags
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *DeepNested) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
 
-	return res
+// String returns the message in the single line protobuf text format.
+func (s *DeepNested) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
 }
 
-func (s *DeepNested) XXX_PbContentSize() int {
-	if s == nil {
-		return 0
-	}
-	var size = 0
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *DeepNested) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
 
-	if s.RootId != 0 {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireDeepNested_RootId) + gremlin.SizeInt32(s.RootId)
-		size += entrySize
-	}
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *DeepNested) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
 
-	if s.RootName != "" {
-		var entrySize = 0
-		entrySize = gremlin.SizeString(s.RootName)
-		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireDeepNested_RootName)
-		size += entrySize
-	}
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *DeepNested) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = DeepNested{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "root_id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.RootId = value
+		case "root_name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.RootName = value
+		case "nested":
+			value := &Level1{}
+			if err := value.XXX_DecodeText(d); err != nil {
+				return err
+			}
+			s.Nested = value
+		case "items":
+			if err := d.ReadRepeated(func() error {
+				var listEntry *Level1
+				value := &Level1{}
+				if err := value.XXX_DecodeText(d); err != nil {
+					return err
+				}
+				listEntry = value
+				s.Items = append(s.Items, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "active":
+			value, err := d.ReadBool()
+			if err != nil {
+				return err
+			}
+			s.Active = value
+		case "tags":
+			if err := d.ReadRepeated(func() error {
+				var listEntry string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Tags = append(s.Tags, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
 
-	if s.Nested != nil {
-		var entrySize = 0
-		entrySize = s.Nested.XXX_PbContentSize()
-		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireDeepNested_Nested)
-		
-		size += entrySize
+// Descriptor returns the descriptor of the message type.
+func (s *DeepNested) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorDeepNested
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *DeepNested) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireDeepNested_RootId:
+		return s.RootId
+	case wireDeepNested_RootName:
+		return s.RootName
+	case wireDeepNested_Nested:
+		return s.Nested
+	case wireDeepNested_Items:
+		return s.Items
+	case wireDeepNested_Active:
+		return s.Active
+	case wireDeepNested_Tags:
+		return s.Tags
 	}
+	return nil
+}
 
-	if len(s.Items) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for _, val := range s.Items {
-			var listEntrySize int
-			listEntrySize = val.XXX_PbContentSize()
-			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireDeepNested_Items)
-			
-			entrySize += listEntrySize
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *DeepNested) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireDeepNested_RootId:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
 		}
-		size += entrySize
+		s.RootId = v
+	case wireDeepNested_RootName:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
+		}
+		s.RootName = v
+	case wireDeepNested_Nested:
+		v, ok := value.(*Level1)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
+		}
+		s.Nested = v
+	case wireDeepNested_Items:
+		v, ok := value.([]*Level1)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
+		}
+		s.Items = v
+	case wireDeepNested_Active:
+		v, ok := value.(bool)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
+		}
+		s.Active = v
+	case wireDeepNested_Tags:
+		v, ok := value.([]string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorDeepNested, number, value)
+		}
+		s.Tags = v
+	default:
+		return gremlin.UnknownFieldError(descriptorDeepNested, number)
 	}
+	return nil
+}
 
-	if s.Active {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireDeepNested_Active) + gremlin.SizeBool(s.Active)
-		size += entrySize
-	}
+// DeepNestedPaths are the field mask paths of benchmark.DeepNested.
+var DeepNestedPaths = XXX_NewDeepNestedPathNames("")
+
+type DeepNestedPathNames struct {
+	path string
+	RootId string
+	RootName string
+	Nested Level1PathNames
+	Items string
+	Active string
+	Tags string
+}
 
-	if len(s.Tags) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for _, val := range s.Tags {
-			var listEntrySize int
-			listEntrySize = gremlin.SizeString(val)
-			listEntrySize += gremlin.SizeUint64(uint64(listEntrySize)) + gremlin.SizeTag(wireDeepNested_Tags)
-			entrySize += listEntrySize
-		}
-		size += entrySize
+func XXX_NewDeepNestedPathNames(path string) DeepNestedPathNames {
+	return DeepNestedPathNames{
+		path: path,
+		RootId: gremlin.JoinPath(path, "root_id"),
+		RootName: gremlin.JoinPath(path, "root_name"),
+		Nested: XXX_NewLevel1PathNames(gremlin.JoinPath(path, "nested")),
+		Items: gremlin.JoinPath(path, "items"),
+		Active: gremlin.JoinPath(path, "active"),
+		Tags: gremlin.JoinPath(path, "tags"),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p DeepNestedPathNames) Path() string {
+	return p.path
 }
 
 const (
@@ -1724,8 +5683,51 @@ const (
 	wireFlatMessage_Tags gremlin.ProtoWireNumber = 6
 )
 
+const (
+	FieldFlatMessage_Id gremlin.FieldIndex = 0
+	FieldFlatMessage_Name gremlin.FieldIndex = 1
+	FieldFlatMessage_Value gremlin.FieldIndex = 2
+	FieldFlatMessage_Score gremlin.FieldIndex = 3
+	FieldFlatMessage_Numbers gremlin.FieldIndex = 4
+	FieldFlatMessage_Tags gremlin.FieldIndex = 5
+)
+
+var singularFieldsFlatMessage = gremlin.NewFieldSet(FieldFlatMessage_Id, FieldFlatMessage_Name, FieldFlatMessage_Value, FieldFlatMessage_Score)
+
+var descriptorFlatMessage = &gremlin.MessageDescriptor{
+	FullName: "benchmark.FlatMessage",
+	File:     "benchmark.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "id", JSONName: "id", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "name", JSONName: "name", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		{Name: "value", JSONName: "value", Number: 3, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		{Name: "score", JSONName: "score", Number: 4, Kind: gremlin.KindDouble, Label: gremlin.LabelOptional},
+		{Name: "numbers", JSONName: "numbers", Number: 5, Kind: gremlin.KindInt32, Label: gremlin.LabelRepeated},
+		{Name: "tags", JSONName: "tags", Number: 6, Kind: gremlin.KindString, Label: gremlin.LabelRepeated},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &FlatMessage{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewFlatMessageReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorFlatMessage)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *FlatMessageReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorFlatMessage
+}
+
 type FlatMessageReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataId     int32
 	dataName     string
@@ -1734,20 +5736,10 @@ type FlatMessageReader struct {
 	dataNumbers     []int32
 	dataTags     []string
 
-	offsetId   int
-	offsetName   int
-	offsetValue   int
-	offsetScore   int
-	offsetNumbers   []int
-	wireTypeNumbers []gremlin.ProtoWireType
-	offsetTags   []int
-
-	parsedId   bool
-	parsedName   bool
-	parsedValue   bool
-	parsedScore   bool
-	parsedNumbers   bool
-	parsedTags   bool
+	offsetId   int32
+	offsetName   int32
+	offsetValue   int32
+	offsetScore   int32
 }
 
 func NewFlatMessageReader() *FlatMessageReader {
@@ -1762,10 +5754,10 @@ func (m *FlatMessageReader) GetId() int32 {
 }
 
 func (m *FlatMessageReader) readId() int32 {
-	if m.parsedId {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataId
 	}
-	wOffset := m.offsetId
+	wOffset := int(m.offsetId)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -1773,7 +5765,7 @@ func (m *FlatMessageReader) readId() int32 {
 	}
 	
 	m.dataId = entry
-	m.parsedId = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -1785,10 +5777,10 @@ func (m *FlatMessageReader) GetName() string {
 }
 
 func (m *FlatMessageReader) readName() string {
-	if m.parsedName {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataName
 	}
-	wOffset := m.offsetName
+	wOffset := int(m.offsetName)
 	
 	var entry string
 	if wOffset > 0 {
@@ -1796,7 +5788,7 @@ func (m *FlatMessageReader) readName() string {
 	}
 	
 	m.dataName = entry
-	m.parsedName = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -1808,10 +5800,10 @@ func (m *FlatMessageReader) GetValue() int32 {
 }
 
 func (m *FlatMessageReader) readValue() int32 {
-	if m.parsedValue {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataValue
 	}
-	wOffset := m.offsetValue
+	wOffset := int(m.offsetValue)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -1819,7 +5811,7 @@ func (m *FlatMessageReader) readValue() int32 {
 	}
 	
 	m.dataValue = entry
-	m.parsedValue = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -1831,10 +5823,10 @@ func (m *FlatMessageReader) GetScore() float64 {
 }
 
 func (m *FlatMessageReader) readScore() float64 {
-	if m.parsedScore {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataScore
 	}
-	wOffset := m.offsetScore
+	wOffset := int(m.offsetScore)
 	
 	var entry float64
 	if wOffset > 0 {
@@ -1842,94 +5834,438 @@ func (m *FlatMessageReader) readScore() float64 {
 	}
 	
 	m.dataScore = entry
-	m.parsedScore = true
+	m.parsed[0] |= 1 << 3
+	return entry
+}
+
+func (m *FlatMessageReader) GetNumbers() []int32 {
+	if m == nil {
+		return nil
+	}
+	return m.readNumbers()
+}
+
+func (m *FlatMessageReader) readNumbers() []int32 {
+	if m.parsed[0]&(1 << 4) != 0 {
+		return m.dataNumbers
+	}
+	wField := FieldFlatMessage_Numbers
+	
+	var entry []int32
+	if count := m.buf.CountPacked(m.repeated, wField, 1); count > 0 {
+		entry = gremlin.MakeSlice[int32](m.buf.Arena(), 0, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			if fieldOffset.Wire == gremlin.BytesType {
+				size, sizeSize := m.buf.SizedReadVarInt(wOffset)
+				offset := 0
+				for offset < int(size) {
+					wOffset := wOffset + sizeSize + offset
+					
+					var listEntry int32
+					var listEntrySize int
+					if wOffset > 0 {
+						listEntry, listEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					if listEntrySize <= 0 {
+						break
+					}
+					entry = append(entry, listEntry)
+					offset += listEntrySize
+				}
+			} else {
+				
+				var listEntry int32
+				if wOffset > 0 {
+					listEntry = m.buf.ReadInt32(wOffset)
+				}
+				
+				entry = append(entry, listEntry)
+			}
+		}
+	}
+	
+	m.dataNumbers = entry
+	m.parsed[0] |= 1 << 4
+	return entry
+}
+
+func (m *FlatMessageReader) GetTags() []string {
+	if m == nil {
+		return nil
+	}
+	return m.readTags()
+}
+
+func (m *FlatMessageReader) readTags() []string {
+	if m.parsed[0]&(1 << 5) != 0 {
+		return m.dataTags
+	}
+	wField := FieldFlatMessage_Tags
+	
+	var entry []string
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeSlice[string](m.buf.Arena(), 0, count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+			
+			var listEntry string
+			if wOffset > 0 {
+				listEntry = m.buf.ReadString(wOffset)
+			}
+			
+			entry = append(entry, listEntry)
+		}
+	}
+	
+	m.dataTags = entry
+	m.parsed[0] |= 1 << 5
 	return entry
 }
 
-func (m *FlatMessageReader) GetNumbers() []int32 {
+func (m *FlatMessageReader) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *FlatMessageReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireFlatMessage_Id:
+			m.offsetId = int32(offset)
+		case wireFlatMessage_Name:
+			m.offsetName = int32(offset)
+		case wireFlatMessage_Value:
+			m.offsetValue = int32(offset)
+		case wireFlatMessage_Score:
+			m.offsetScore = int32(offset)
+		case wireFlatMessage_Numbers:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldFlatMessage_Numbers, Offset: int32(offset), Wire: wire})
+		case wireFlatMessage_Tags:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldFlatMessage_Tags, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *FlatMessageReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *FlatMessageReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = FlatMessageReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsFlatMessage) {
+		stopAfter = fields.Len()
+	}
+	found := 0
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireFlatMessage_Id:
+			if fields.Has(FieldFlatMessage_Id) {
+				if m.offsetId == 0 {
+					found++
+				}
+				m.offsetId = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireFlatMessage_Name:
+			if fields.Has(FieldFlatMessage_Name) {
+				if m.offsetName == 0 {
+					found++
+				}
+				m.offsetName = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireFlatMessage_Value:
+			if fields.Has(FieldFlatMessage_Value) {
+				if m.offsetValue == 0 {
+					found++
+				}
+				m.offsetValue = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireFlatMessage_Score:
+			if fields.Has(FieldFlatMessage_Score) {
+				if m.offsetScore == 0 {
+					found++
+				}
+				m.offsetScore = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
+		case wireFlatMessage_Numbers:
+			if fields.Has(FieldFlatMessage_Numbers) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldFlatMessage_Numbers, Offset: int32(offset), Wire: wire})
+			}
+		case wireFlatMessage_Tags:
+			if fields.Has(FieldFlatMessage_Tags) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldFlatMessage_Tags, Offset: int32(offset), Wire: wire})
+			}
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *FlatMessageReader) ToStruct() *FlatMessage {
+	if m == nil {
+		return nil
+	}
+	res := gremlin.New[FlatMessage](m.buf.Arena())
+	res.Id = m.GetId()
+	res.Name = m.GetName()
+	res.Value = m.GetValue()
+	res.Score = m.GetScore()
+	res.Numbers = m.GetNumbers()
+	res.Tags = m.GetTags()
+
+	return res
+}
+
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *FlatMessageReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readId()
+	m.readName()
+	m.readValue()
+	m.readScore()
+	m.readNumbers()
+	m.readTags()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *FlatMessageReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *FlatMessageReader) XXX_Rebase(d *gremlin.Detacher) {
 	if m == nil {
-		return nil
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		m.dataName = d.RebaseString(m.dataName)
+	}
+	if m.parsed[0]&(1 << 5) != 0 {
+		for i := range m.dataTags {
+			m.dataTags[i] = d.RebaseString(m.dataTags[i])
+		}
 	}
-	return m.readNumbers()
 }
 
-func (m *FlatMessageReader) readNumbers() []int32 {
-	if m.parsedNumbers {
-		return m.dataNumbers
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *FlatMessageReader) SourceBytes() []byte {
+	if s == nil {
+		return nil
 	}
-	wOffset := m.offsetNumbers
-	var wType = m.wireTypeNumbers
-	
-	var entry []int32
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		wType := wType[i]
-		if wType == gremlin.BytesType {
-			size, sizeSize := m.buf.SizedReadVarInt(wOffset)
-			offset := 0
-			for offset < int(size) {
-				wOffset := wOffset + sizeSize + offset
-				
-				var listEntry int32
-				var listEntrySize int
-				if wOffset > 0 {
-					listEntry, listEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				entry = append(entry, listEntry)
-				offset += listEntrySize
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *FlatMessageReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetId(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetName(); value != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"name\":"...)
+		b = gremlin.AppendJSONString(b, value)
+	}
+	if value := m.GetValue(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if value := m.GetScore(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"score\":"...)
+		b = gremlin.AppendJSONFloat(b, value, 64)
+	}
+	if value := m.GetNumbers(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"numbers\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
 			}
-		} else {
-			
-			var listEntry int32
-			if wOffset > 0 {
-				listEntry = m.buf.ReadInt32(wOffset)
+			b = gremlin.AppendJSONInt(b, int64(entry))
+		}
+		b = append(b, ']')
+	}
+	if value := m.GetTags(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"tags\":"...)
+		b = append(b, '[')
+		for i, entry := range value {
+			if i > 0 {
+				b = append(b, ',')
 			}
-			
-			entry = append(entry, listEntry)
+			b = gremlin.AppendJSONString(b, entry)
 		}
+		b = append(b, ']')
 	}
-	
-	m.dataNumbers = entry
-	m.parsedNumbers = true
-	return entry
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
 }
 
-func (m *FlatMessageReader) GetTags() []string {
-	if m == nil {
-		return nil
-	}
-	return m.readTags()
+func (m *FlatMessageReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
 }
 
-func (m *FlatMessageReader) readTags() []string {
-	if m.parsedTags {
-		return m.dataTags
+// TranscodeFlatMessageJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeFlatMessageJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeFlatMessageJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeFlatMessageJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeFlatMessageJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
 	}
-	wOffset := m.offsetTags
-	
-	var entry []string
-	for i := 0; i < len(wOffset); i++ {
-		wOffset := wOffset[i]
-		
-		var listEntry string
-		if wOffset > 0 {
-			listEntry = m.buf.ReadString(wOffset)
+	var last [6]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
 		}
-		
-		entry = append(entry, listEntry)
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireFlatMessage_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		case wireFlatMessage_Name:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[1] = offset
+		case wireFlatMessage_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[2] = offset
+		case wireFlatMessage_Score:
+			if wire != gremlin.Fixed64Type {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[3] = offset
+		case wireFlatMessage_Numbers:
+			if wire != gremlin.VarIntType && wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireFlatMessage_Tags:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
 	}
-	
-	m.dataTags = entry
-	m.parsedTags = true
-	return entry
-}
 
-func (m *FlatMessageReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
-	offset := 0
-	for m.buf.HasNext(offset, 0) {
-		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
 		if err != nil {
 			return err
 		}
@@ -1937,48 +6273,150 @@ func (m *FlatMessageReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireFlatMessage_Id:
-			m.offsetId = offset
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"id\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
 		case wireFlatMessage_Name:
-			m.offsetName = offset
+			if offset != last[1] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"name\":"...)
+			s.B = gremlin.AppendJSONString(s.B, buf.ReadString(offset))
 		case wireFlatMessage_Value:
-			m.offsetValue = offset
+			if offset != last[2] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"value\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
 		case wireFlatMessage_Score:
-			m.offsetScore = offset
+			if offset != last[3] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"score\":"...)
+			s.B = gremlin.AppendJSONFloat(s.B, buf.ReadFloat64(offset), 64)
 		case wireFlatMessage_Numbers:
-			m.offsetNumbers = append(m.offsetNumbers, offset)
-			m.wireTypeNumbers = append(m.wireTypeNumbers, wire)
+			if written[0]&(1 << 4) != 0 {
+				break
+			}
+			written[0] |= 1 << 4
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"numbers\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				if entryWire == gremlin.BytesType {
+					size, sizeSize := buf.SizedReadVarInt(entryOffset)
+					packedOffset := entryOffset + sizeSize
+					packedEnd := packedOffset + int(size)
+					for packedOffset < packedEnd {
+						listEntry, listEntrySize := buf.SizedReadInt32(packedOffset)
+						if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+							return gremlin.ErrTruncated
+						}
+						s.B = append(s.B, entrySep)
+						entrySep = ','
+						s.B = gremlin.AppendJSONInt(s.B, int64(listEntry))
+						packedOffset += listEntrySize
+					}
+				} else {
+					s.B = append(s.B, entrySep)
+					entrySep = ','
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(entryOffset)))
+				}
+			}
+			if entrySep == '[' {
+				s.B = append(s.B, entrySep)
+			}
+			s.B = append(s.B, ']')
 		case wireFlatMessage_Tags:
-			m.offsetTags = append(m.offsetTags, offset)
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"tags\":"...)
+			entrySep := byte('[')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+				s.B = gremlin.AppendJSONString(s.B, buf.ReadString(entryOffset))
+			}
+			s.B = append(s.B, ']')
 		}
 
-		offset, err = m.buf.SkipData(offset, wire)
+		offset, err = buf.SkipData(offset, wire)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
 }
 
-func (m *FlatMessageReader) ToStruct() *FlatMessage {
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *FlatMessageReader) XXX_WriteText(w *gremlin.TextWriter) {
 	if m == nil {
-		return nil
+		return
 	}
-	res := &FlatMessage{}
-	res.Id = m.GetId()
-	res.Name = m.GetName()
-	res.Value = m.GetValue()
-	res.Score = m.GetScore()
-	res.Numbers = m.GetNumbers()
-	res.Tags = m.GetTags()
+	if value := m.GetId(); value != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetName(); value != "" {
+		w.WriteName("name")
+		w.WriteString(value)
+	}
+	if value := m.GetValue(); value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(value))
+	}
+	if value := m.GetScore(); value != 0 {
+		w.WriteName("score")
+		w.WriteFloat(value, 64)
+	}
+	if value := m.GetNumbers(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("numbers")
+			w.WriteInt(int64(entry))
+		}
+	}
+	if value := m.GetTags(); len(value) > 0 {
+		for _, entry := range value {
+			w.WriteName("tags")
+			w.WriteString(entry)
+		}
+	}
+}
 
-	return res
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *FlatMessageReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
 }
 
-func (s *FlatMessageReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
+// String returns the message in the single line protobuf text format.
+func (m *FlatMessageReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *FlatMessageReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
 }
 
 type FlatMessage struct {
@@ -1990,6 +6428,92 @@ type FlatMessage struct {
 	Tags	[]string	`json:"tags,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *FlatMessage) Unmarshal(data []byte) error {
+	*s = FlatMessage{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireFlatMessage_Id:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Id = buf.ReadInt32(offset)
+		case wireFlatMessage_Name:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Name = buf.ReadString(offset)
+		case wireFlatMessage_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Value = buf.ReadInt32(offset)
+		case wireFlatMessage_Score:
+			if wire != gremlin.Fixed64Type {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Score = buf.ReadFloat64(offset)
+		case wireFlatMessage_Numbers:
+			if wire != gremlin.VarIntType && wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Numbers == nil {
+				s.Numbers = make([]int32, 0, buf.CountField(offset, wire, tag, 1))
+			}
+			if wire == gremlin.BytesType {
+				size, sizeSize := buf.SizedReadVarInt(offset)
+				packedOffset := offset + sizeSize
+				packedEnd := packedOffset + int(size)
+				for packedOffset < packedEnd {
+					listEntry, listEntrySize := buf.SizedReadInt32(packedOffset)
+					if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+						return gremlin.ErrTruncated
+					}
+					s.Numbers = append(s.Numbers, listEntry)
+					packedOffset += listEntrySize
+				}
+			} else {
+				var listEntry int32
+				listEntry = buf.ReadInt32(offset)
+				s.Numbers = append(s.Numbers, listEntry)
+			}
+		case wireFlatMessage_Tags:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if s.Tags == nil {
+				s.Tags = make([]string, 0, buf.CountField(offset, wire, tag, 0))
+			}
+			{
+				var listEntry string
+				listEntry = buf.ReadString(offset)
+				s.Tags = append(s.Tags, listEntry)
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *FlatMessage) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -2122,3 +6646,362 @@ func (s *FlatMessage) XXX_PbContentSize() int {
 
 	return size
 }
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *FlatMessage) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Id != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"id\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Id))
+	}
+	if s.Name != "" {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"name\":"...)
+		b = gremlin.AppendJSONString(b, s.Name)
+	}
+	if s.Value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Value))
+	}
+	if s.Score != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"score\":"...)
+		b = gremlin.AppendJSONFloat(b, s.Score, 64)
+	}
+	if len(s.Numbers) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"numbers\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Numbers {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONInt(b, int64(entry))
+		}
+		b = append(b, ']')
+	}
+	if len(s.Tags) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"tags\":"...)
+		b = append(b, '[')
+		for i, entry := range s.Tags {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, entry)
+		}
+		b = append(b, ']')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *FlatMessage) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *FlatMessage) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *FlatMessage) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = FlatMessage{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Name = value
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		case "score":
+			value, err := d.ReadFloat64()
+			if err != nil {
+				return err
+			}
+			s.Score = value
+		case "numbers":
+			if err := d.ReadArray(func() error {
+				var listEntry int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Numbers = append(s.Numbers, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "tags":
+			if err := d.ReadArray(func() error {
+				var listEntry string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Tags = append(s.Tags, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *FlatMessage) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.Id != 0 {
+		w.WriteName("id")
+		w.WriteInt(int64(s.Id))
+	}
+	if s.Name != "" {
+		w.WriteName("name")
+		w.WriteString(s.Name)
+	}
+	if s.Value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(s.Value))
+	}
+	if s.Score != 0 {
+		w.WriteName("score")
+		w.WriteFloat(s.Score, 64)
+	}
+	if len(s.Numbers) > 0 {
+		for _, entry := range s.Numbers {
+			w.WriteName("numbers")
+			w.WriteInt(int64(entry))
+		}
+	}
+	if len(s.Tags) > 0 {
+		for _, entry := range s.Tags {
+			w.WriteName("tags")
+			w.WriteString(entry)
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *FlatMessage) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *FlatMessage) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *FlatMessage) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *FlatMessage) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *FlatMessage) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = FlatMessage{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "id":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Id = value
+		case "name":
+			value, err := d.ReadString()
+			if err != nil {
+				return err
+			}
+			s.Name = value
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		case "score":
+			value, err := d.ReadFloat64()
+			if err != nil {
+				return err
+			}
+			s.Score = value
+		case "numbers":
+			if err := d.ReadRepeated(func() error {
+				var listEntry int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Numbers = append(s.Numbers, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "tags":
+			if err := d.ReadRepeated(func() error {
+				var listEntry string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				listEntry = value
+				s.Tags = append(s.Tags, listEntry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *FlatMessage) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorFlatMessage
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *FlatMessage) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireFlatMessage_Id:
+		return s.Id
+	case wireFlatMessage_Name:
+		return s.Name
+	case wireFlatMessage_Value:
+		return s.Value
+	case wireFlatMessage_Score:
+		return s.Score
+	case wireFlatMessage_Numbers:
+		return s.Numbers
+	case wireFlatMessage_Tags:
+		return s.Tags
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *FlatMessage) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireFlatMessage_Id:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Id = v
+	case wireFlatMessage_Name:
+		v, ok := value.(string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Name = v
+	case wireFlatMessage_Value:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Value = v
+	case wireFlatMessage_Score:
+		v, ok := value.(float64)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Score = v
+	case wireFlatMessage_Numbers:
+		v, ok := value.([]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Numbers = v
+	case wireFlatMessage_Tags:
+		v, ok := value.([]string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorFlatMessage, number, value)
+		}
+		s.Tags = v
+	default:
+		return gremlin.UnknownFieldError(descriptorFlatMessage, number)
+	}
+	return nil
+}
+
+// FlatMessagePaths are the field mask paths of benchmark.FlatMessage.
+var FlatMessagePaths = XXX_NewFlatMessagePathNames("")
+
+type FlatMessagePathNames struct {
+	path string
+	Id string
+	Name string
+	Value string
+	Score string
+	Numbers string
+	Tags string
+}
+
+func XXX_NewFlatMessagePathNames(path string) FlatMessagePathNames {
+	return FlatMessagePathNames{
+		path: path,
+		Id: gremlin.JoinPath(path, "id"),
+		Name: gremlin.JoinPath(path, "name"),
+		Value: gremlin.JoinPath(path, "value"),
+		Score: gremlin.JoinPath(path, "score"),
+		Numbers: gremlin.JoinPath(path, "numbers"),
+		Tags: gremlin.JoinPath(path, "tags"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p FlatMessagePathNames) Path() string {
+	return p.path
+}
@@ -3,7 +3,11 @@
 
 package protobuf_unittest_import
 
-import gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+import (
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+	io "io"
+	fmt "fmt"
+)
 
 type ImportEnum int32
 
@@ -26,6 +30,26 @@ func (e ImportEnum) String() string {
 	}
 }
 
+var ImportEnum_value = map[string]int32{
+	"IMPORT_FOO": 7,
+	"IMPORT_BAR": 8,
+	"IMPORT_BAZ": 9,
+}
+
+var descriptorImportEnum = &gremlin.EnumDescriptor{
+	FullName: "protobuf_unittest_import.ImportEnum",
+	File:     "unittest_import.proto",
+	Values: []gremlin.EnumValueDescriptor{
+		{Name: "IMPORT_FOO", Number: 7},
+		{Name: "IMPORT_BAR", Number: 8},
+		{Name: "IMPORT_BAZ", Number: 9},
+	},
+}
+
+func init() {
+	gremlin.RegisterEnum(descriptorImportEnum)
+}
+
 type ImportEnumForMap int32
 
 const (
@@ -47,18 +71,66 @@ func (e ImportEnumForMap) String() string {
 	}
 }
 
+var ImportEnumForMap_value = map[string]int32{
+	"UNKNOWN": 0,
+	"FOO": 1,
+	"BAR": 2,
+}
+
+var descriptorImportEnumForMap = &gremlin.EnumDescriptor{
+	FullName: "protobuf_unittest_import.ImportEnumForMap",
+	File:     "unittest_import.proto",
+	Values: []gremlin.EnumValueDescriptor{
+		{Name: "UNKNOWN", Number: 0},
+		{Name: "FOO", Number: 1},
+		{Name: "BAR", Number: 2},
+	},
+}
+
+func init() {
+	gremlin.RegisterEnum(descriptorImportEnumForMap)
+}
+
 const (
 	wireImportMessage_D gremlin.ProtoWireNumber = 1
 )
 
+const (
+	FieldImportMessage_D gremlin.FieldIndex = 0
+)
+
+var singularFieldsImportMessage = gremlin.NewFieldSet(FieldImportMessage_D)
+
+var descriptorImportMessage = &gremlin.MessageDescriptor{
+	FullName: "protobuf_unittest_import.ImportMessage",
+	File:     "unittest_import.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "d", JSONName: "d", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &ImportMessage{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewImportMessageReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorImportMessage)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *ImportMessageReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorImportMessage
+}
+
 type ImportMessageReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
 
 	dataD     int32
 
-	offsetD   int
-
-	parsedD   bool
+	offsetD   int32
 }
 
 func NewImportMessageReader() *ImportMessageReader {
@@ -73,10 +145,10 @@ func (m *ImportMessageReader) GetD() int32 {
 }
 
 func (m *ImportMessageReader) readD() int32 {
-	if m.parsedD {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataD
 	}
-	wOffset := m.offsetD
+	wOffset := int(m.offsetD)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -84,12 +156,59 @@ func (m *ImportMessageReader) readD() int32 {
 	}
 	
 	m.dataD = entry
-	m.parsedD = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
 func (m *ImportMessageReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *ImportMessageReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireImportMessage_D:
+			m.offsetD = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *ImportMessageReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *ImportMessageReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = ImportMessageReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsImportMessage) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -100,7 +219,15 @@ func (m *ImportMessageReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireImportMessage_D:
-			m.offsetD = offset
+			if fields.Has(FieldImportMessage_D) {
+				if m.offsetD == 0 {
+					found++
+				}
+				m.offsetD = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -115,12 +242,46 @@ func (m *ImportMessageReader) ToStruct() *ImportMessage {
 	if m == nil {
 		return nil
 	}
-	res := &ImportMessage{}
+	res := gremlin.New[ImportMessage](m.buf.Arena())
 	res.D = m.GetD()
 
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *ImportMessageReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readD()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *ImportMessageReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *ImportMessageReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *ImportMessageReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -128,10 +289,172 @@ func (s *ImportMessageReader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *ImportMessageReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetD(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"d\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *ImportMessageReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeImportMessageJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeImportMessageJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeImportMessageJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeImportMessageJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeImportMessageJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var last [1]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireImportMessage_D:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		}
+		offset = end
+	}
+
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireImportMessage_D:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"d\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *ImportMessageReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetD(); value != 0 {
+		w.WriteName("d")
+		w.WriteInt(int64(value))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *ImportMessageReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *ImportMessageReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *ImportMessageReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type ImportMessage struct {
 	D	int32	`json:"d,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *ImportMessage) Unmarshal(data []byte) error {
+	*s = ImportMessage{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireImportMessage_D:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.D = buf.ReadInt32(offset)
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *ImportMessage) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -179,3 +502,156 @@ func (s *ImportMessage) XXX_PbContentSize() int {
 
 	return size
 }
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *ImportMessage) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.D != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"d\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.D))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *ImportMessage) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *ImportMessage) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *ImportMessage) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = ImportMessage{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "d":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.D = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *ImportMessage) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.D != 0 {
+		w.WriteName("d")
+		w.WriteInt(int64(s.D))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *ImportMessage) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *ImportMessage) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *ImportMessage) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *ImportMessage) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *ImportMessage) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = ImportMessage{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "d":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.D = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *ImportMessage) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorImportMessage
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *ImportMessage) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireImportMessage_D:
+		return s.D
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *ImportMessage) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireImportMessage_D:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorImportMessage, number, value)
+		}
+		s.D = v
+	default:
+		return gremlin.UnknownFieldError(descriptorImportMessage, number)
+	}
+	return nil
+}
+
+// ImportMessagePaths are the field mask paths of protobuf_unittest_import.ImportMessage.
+var ImportMessagePaths = XXX_NewImportMessagePathNames("")
+
+type ImportMessagePathNames struct {
+	path string
+	D string
+}
+
+func XXX_NewImportMessagePathNames(path string) ImportMessagePathNames {
+	return ImportMessagePathNames{
+		path: path,
+		D: gremlin.JoinPath(path, "d"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p ImportMessagePathNames) Path() string {
+	return p.path
+}
@@ -3,20 +3,52 @@
 
 package protobuf_unittest_import
 
-import gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+import (
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+	io "io"
+	fmt "fmt"
+)
 
 const (
 	wirePublicImportMessage_E gremlin.ProtoWireNumber = 1
 )
 
+const (
+	FieldPublicImportMessage_E gremlin.FieldIndex = 0
+)
+
+var singularFieldsPublicImportMessage = gremlin.NewFieldSet(FieldPublicImportMessage_E)
+
+var descriptorPublicImportMessage = &gremlin.MessageDescriptor{
+	FullName: "protobuf_unittest_import.PublicImportMessage",
+	File:     "unittest_import_public.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "e", JSONName: "e", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &PublicImportMessage{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewPublicImportMessageReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorPublicImportMessage)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *PublicImportMessageReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorPublicImportMessage
+}
+
 type PublicImportMessageReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
 
 	dataE     int32
 
-	offsetE   int
-
-	parsedE   bool
+	offsetE   int32
 }
 
 func NewPublicImportMessageReader() *PublicImportMessageReader {
@@ -31,10 +63,10 @@ func (m *PublicImportMessageReader) GetE() int32 {
 }
 
 func (m *PublicImportMessageReader) readE() int32 {
-	if m.parsedE {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataE
 	}
-	wOffset := m.offsetE
+	wOffset := int(m.offsetE)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -42,12 +74,59 @@ func (m *PublicImportMessageReader) readE() int32 {
 	}
 	
 	m.dataE = entry
-	m.parsedE = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
 func (m *PublicImportMessageReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *PublicImportMessageReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wirePublicImportMessage_E:
+			m.offsetE = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *PublicImportMessageReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *PublicImportMessageReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = PublicImportMessageReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsPublicImportMessage) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -58,7 +137,15 @@ func (m *PublicImportMessageReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wirePublicImportMessage_E:
-			m.offsetE = offset
+			if fields.Has(FieldPublicImportMessage_E) {
+				if m.offsetE == 0 {
+					found++
+				}
+				m.offsetE = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -73,12 +160,46 @@ func (m *PublicImportMessageReader) ToStruct() *PublicImportMessage {
 	if m == nil {
 		return nil
 	}
-	res := &PublicImportMessage{}
+	res := gremlin.New[PublicImportMessage](m.buf.Arena())
 	res.E = m.GetE()
 
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *PublicImportMessageReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readE()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *PublicImportMessageReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *PublicImportMessageReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *PublicImportMessageReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -86,10 +207,172 @@ func (s *PublicImportMessageReader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *PublicImportMessageReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetE(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"e\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *PublicImportMessageReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodePublicImportMessageJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodePublicImportMessageJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodePublicImportMessageJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodePublicImportMessageJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodePublicImportMessageJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var last [1]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wirePublicImportMessage_E:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		}
+		offset = end
+	}
+
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wirePublicImportMessage_E:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"e\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *PublicImportMessageReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetE(); value != 0 {
+		w.WriteName("e")
+		w.WriteInt(int64(value))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *PublicImportMessageReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *PublicImportMessageReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *PublicImportMessageReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type PublicImportMessage struct {
 	E	int32	`json:"e,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *PublicImportMessage) Unmarshal(data []byte) error {
+	*s = PublicImportMessage{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wirePublicImportMessage_E:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.E = buf.ReadInt32(offset)
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *PublicImportMessage) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -137,3 +420,156 @@ func (s *PublicImportMessage) XXX_PbContentSize() int {
 
 	return size
 }
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *PublicImportMessage) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.E != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"e\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.E))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *PublicImportMessage) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *PublicImportMessage) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *PublicImportMessage) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = PublicImportMessage{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "e":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.E = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *PublicImportMessage) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.E != 0 {
+		w.WriteName("e")
+		w.WriteInt(int64(s.E))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *PublicImportMessage) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *PublicImportMessage) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *PublicImportMessage) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *PublicImportMessage) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *PublicImportMessage) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = PublicImportMessage{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "e":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.E = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *PublicImportMessage) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorPublicImportMessage
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *PublicImportMessage) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wirePublicImportMessage_E:
+		return s.E
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *PublicImportMessage) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wirePublicImportMessage_E:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorPublicImportMessage, number, value)
+		}
+		s.E = v
+	default:
+		return gremlin.UnknownFieldError(descriptorPublicImportMessage, number)
+	}
+	return nil
+}
+
+// PublicImportMessagePaths are the field mask paths of protobuf_unittest_import.PublicImportMessage.
+var PublicImportMessagePaths = XXX_NewPublicImportMessagePathNames("")
+
+type PublicImportMessagePathNames struct {
+	path string
+	E string
+}
+
+func XXX_NewPublicImportMessagePathNames(path string) PublicImportMessagePathNames {
+	return PublicImportMessagePathNames{
+		path: path,
+		E: gremlin.JoinPath(path, "e"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p PublicImportMessagePathNames) Path() string {
+	return p.path
+}
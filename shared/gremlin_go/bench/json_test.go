@@ -0,0 +1,113 @@
+package bench_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/bench"
+	google_benchmark "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/benchmark"
+	google_unittest "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/unittest"
+	gremlin_pb "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/benchmark"
+	unittest_gremlin "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/protobuf_unittest"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// protojson adds random whitespace to its output, compacting it gives the canonical bytes.
+func protojsonCompact(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		t.Fatalf("protojson marshal failed: %v", err)
+	}
+	var res bytes.Buffer
+	if err := json.Compact(&res, data); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	return res.Bytes()
+}
+
+func TestJSONMatchesProtojson(t *testing.T) {
+	// the golden_message file sets every oneof member, gremlin structs keep all of them
+	content := bench.CreateGoldenMessageGremlin().Marshal()
+	expected := protojsonCompact(t, bench.CreateGoldenMessageGoogle())
+
+	reader := unittest_gremlin.NewTestAllTypesReader()
+	if err := reader.Unmarshal(content); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if got := reader.ToStruct().AppendJSON(nil); !bytes.Equal(got, expected) {
+		t.Fatalf("struct JSON differs from protojson\ngot:  %s\nwant: %s", got, expected)
+	}
+
+	var out bytes.Buffer
+	if err := reader.WriteJSON(&out); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Fatalf("reader JSON differs from protojson\ngot:  %s\nwant: %s", out.Bytes(), expected)
+	}
+
+	out.Reset()
+	if err := unittest_gremlin.TranscodeTestAllTypesJSON(&out, content); err != nil {
+		t.Fatalf("transcode failed: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Fatalf("transcoded JSON differs from protojson\ngot:  %s\nwant: %s", out.Bytes(), expected)
+	}
+
+	deepExpected := protojsonCompact(t, bench.CreateDeepNestedGoogle())
+	if got, _ := bench.CreateDeepNestedGremlin().MarshalJSON(); !bytes.Equal(got, deepExpected) {
+		t.Fatalf("deep nested JSON differs from protojson\ngot:  %s\nwant: %s", got, deepExpected)
+	}
+	out.Reset()
+	if err := gremlin_pb.TranscodeDeepNestedJSON(&out, bench.CreateDeepNestedGremlin().Marshal()); err != nil {
+		t.Fatalf("transcode failed: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), deepExpected) {
+		t.Fatalf("transcoded deep nested JSON differs from protojson\ngot:  %s\nwant: %s", out.Bytes(), deepExpected)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	data, err := bench.CreateGoldenMessageGremlin().MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var googleMsg google_unittest.TestAllTypes
+	if err := protojson.Unmarshal(data, &googleMsg); err != nil {
+		t.Fatalf("protojson rejected gremlin JSON: %v", err)
+	}
+	if !proto.Equal(&googleMsg, bench.CreateGoldenMessageGoogle()) {
+		t.Fatalf("protojson decoded a different message")
+	}
+
+	// protojson output is indented randomly and may use original field names
+	for _, opts := range []protojson.MarshalOptions{{}, {Multiline: true}, {UseProtoNames: true}} {
+		googleData, err := opts.Marshal(bench.CreateGoldenMessageGoogle())
+		if err != nil {
+			t.Fatalf("protojson marshal failed: %v", err)
+		}
+		var msg unittest_gremlin.TestAllTypes
+		if err := msg.UnmarshalJSON(googleData); err != nil {
+			t.Fatalf("failed to decode protojson output: %v", err)
+		}
+		if !bytes.Equal(msg.Marshal(), bench.CreateGoldenMessageGremlin().Marshal()) {
+			t.Fatalf("decoded message differs from the golden message")
+		}
+	}
+
+	var deep gremlin_pb.DeepNested
+	if err := deep.UnmarshalJSON(protojsonCompact(t, bench.CreateDeepNestedGoogle())); err != nil {
+		t.Fatalf("failed to decode deep nested: %v", err)
+	}
+	var googleDeep google_benchmark.DeepNested
+	if err := proto.Unmarshal(deep.Marshal(), &googleDeep); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if !proto.Equal(&googleDeep, bench.CreateDeepNestedGoogle()) {
+		t.Fatalf("deep nested differs after JSON round trip")
+	}
+}
@@ -0,0 +1,106 @@
+package bench_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/bench"
+	google_benchmark "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/benchmark"
+	google_unittest "github.com/norma-core/norma-core/shared/gremlin_go/bench/google_pb/unittest"
+	gremlin_pb "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/benchmark"
+	unittest_gremlin "github.com/norma-core/norma-core/shared/gremlin_go/bench/gremlin_pb/protobuf_unittest"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// prototext randomly adds a second space after names and between fields, removing it gives the canonical text.
+func prototextCanonical(t *testing.T, msg proto.Message, multiline bool) string {
+	t.Helper()
+	data, err := prototext.MarshalOptions{Multiline: multiline}.Marshal(msg)
+	if err != nil {
+		t.Fatalf("prototext marshal failed: %v", err)
+	}
+	if multiline {
+		return strings.ReplaceAll(string(data), ":  ", ": ")
+	}
+	return strings.ReplaceAll(string(data), "  ", " ")
+}
+
+func TestTextMatchesPrototext(t *testing.T) {
+	content := bench.CreateGoldenMessageGremlin().Marshal()
+	expected := prototextCanonical(t, bench.CreateGoldenMessageGoogle(), true)
+
+	reader := unittest_gremlin.NewTestAllTypesReader()
+	if err := reader.Unmarshal(content); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if got, _ := reader.ToStruct().MarshalText(); string(got) != expected {
+		t.Fatalf("struct text differs from prototext\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+	if got, _ := reader.MarshalText(); string(got) != expected {
+		t.Fatalf("reader text differs from prototext\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+	if got := fmt.Sprintf("%+v", reader); got != expected {
+		t.Fatalf("%%+v differs from prototext\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+
+	singleLine := prototextCanonical(t, bench.CreateGoldenMessageGoogle(), false)
+	if got := reader.ToStruct().String(); got != singleLine {
+		t.Fatalf("String differs from prototext\ngot:  %s\nwant: %s", got, singleLine)
+	}
+	if got := fmt.Sprintf("%v", reader); got != singleLine {
+		t.Fatalf("%%v differs from prototext\ngot:  %s\nwant: %s", got, singleLine)
+	}
+
+	deepExpected := prototextCanonical(t, bench.CreateDeepNestedGoogle(), true)
+	if got, _ := bench.CreateDeepNestedGremlin().MarshalText(); string(got) != deepExpected {
+		t.Fatalf("deep nested text differs from prototext\ngot:\n%s\nwant:\n%s", got, deepExpected)
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	data, err := bench.CreateGoldenMessageGremlin().MarshalText()
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var googleMsg google_unittest.TestAllTypes
+	if err := prototext.Unmarshal(data, &googleMsg); err != nil {
+		t.Fatalf("prototext rejected gremlin text: %v", err)
+	}
+	if !proto.Equal(&googleMsg, bench.CreateGoldenMessageGoogle()) {
+		t.Fatalf("prototext decoded a different message")
+	}
+
+	for _, opts := range []prototext.MarshalOptions{{}, {Multiline: true}} {
+		googleData, err := opts.Marshal(bench.CreateGoldenMessageGoogle())
+		if err != nil {
+			t.Fatalf("prototext marshal failed: %v", err)
+		}
+		var msg unittest_gremlin.TestAllTypes
+		if err := msg.UnmarshalText(googleData); err != nil {
+			t.Fatalf("failed to decode prototext output: %v", err)
+		}
+		if !bytes.Equal(msg.Marshal(), bench.CreateGoldenMessageGremlin().Marshal()) {
+			t.Fatalf("decoded message differs from the golden message")
+		}
+	}
+
+	googleDeepData, err := prototext.Marshal(bench.CreateDeepNestedGoogle())
+	if err != nil {
+		t.Fatalf("prototext marshal failed: %v", err)
+	}
+	var deep gremlin_pb.DeepNested
+	if err := deep.UnmarshalText(googleDeepData); err != nil {
+		t.Fatalf("failed to decode deep nested: %v", err)
+	}
+	var googleDeep google_benchmark.DeepNested
+	if err := proto.Unmarshal(deep.Marshal(), &googleDeep); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if !proto.Equal(&googleDeep, bench.CreateDeepNestedGoogle()) {
+		t.Fatalf("deep nested differs after text round trip")
+	}
+}
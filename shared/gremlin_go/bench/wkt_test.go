@@ -0,0 +1,136 @@
+package bench_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/norma-core/norma-core/shared/gremlin_go/wkt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The bundled well-known types must stay wire compatible with the ones compiled into google.golang.org/protobuf.
+func TestWellKnownTypesMatchProtobuf(t *testing.T) {
+	stamp := timestamppb.New(time.Date(2024, 2, 29, 12, 30, 0, 500, time.UTC))
+	st, err := structpb.NewStruct(map[string]any{
+		"name": "gremlin",
+		"tags": []any{"a", 1.5, true, nil},
+		"nested": map[string]any{
+			"ok": false,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	any, err := anypb.New(stamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// descriptor.proto describing itself covers nearly all of its messages
+	desc := protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto)
+
+	cases := []struct {
+		name      string
+		msg       proto.Message
+		roundTrip func(data []byte) ([]byte, error)
+	}{
+		{"Timestamp", stamp, func(data []byte) ([]byte, error) {
+			reader := wkt.NewTimestampReader()
+			if err := reader.Unmarshal(data); err != nil {
+				return nil, err
+			}
+			return reader.ToStruct().Marshal(), nil
+		}},
+		{"Struct", st, func(data []byte) ([]byte, error) {
+			reader := wkt.NewStructReader()
+			if err := reader.Unmarshal(data); err != nil {
+				return nil, err
+			}
+			return reader.ToStruct().Marshal(), nil
+		}},
+		{"Any", any, func(data []byte) ([]byte, error) {
+			var res wkt.Any
+			if err := res.Unmarshal(data); err != nil {
+				return nil, err
+			}
+			return res.Marshal(), nil
+		}},
+		{"FileDescriptorProto", desc, func(data []byte) ([]byte, error) {
+			reader := wkt.NewFileDescriptorProtoReader()
+			if err := reader.Unmarshal(data); err != nil {
+				return nil, err
+			}
+			return reader.ToStruct().Marshal(), nil
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := proto.Marshal(c.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := c.roundTrip(data)
+			if err != nil {
+				t.Fatalf("gremlin failed to unmarshal: %v", err)
+			}
+			got := c.msg.ProtoReflect().New().Interface()
+			if err := proto.Unmarshal(out, got); err != nil {
+				t.Fatalf("protobuf failed to unmarshal gremlin output: %v", err)
+			}
+			// structs don't keep the presence of zero values, like the false and null in st
+			if diff := cmp.Diff(c.msg, got, protocmp.Transform(), protocmp.IgnoreDefaultScalars()); diff != "" {
+				t.Errorf("round trip through gremlin changed the message (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestStructJSONMatchesProtojson(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]any{
+		"name":   "gré\"mlin\n",
+		"zero":   0,
+		"small":  1e-7,
+		"large":  1e21,
+		"nested": map[string]any{"list": []any{nil, true, -2.5, map[string]any{}}},
+		"empty":  []any{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := wkt.NewStructReader()
+	if err := reader.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := protojson.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, want); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(wkt.AppendValueJSON(nil, reader)); got != compact.String() {
+		t.Errorf("JSON differs from protojson\n got: %v\nwant: %v", got, compact.String())
+	}
+	m, err := reader.AsMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(st.AsMap(), m); diff != "" {
+		t.Errorf("AsMap differs from structpb (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,360 @@
+package gremlin
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldKind is the proto type of a field.
+type FieldKind uint8
+
+const (
+	KindDouble FieldKind = iota + 1
+	KindFloat
+	KindInt64
+	KindUint64
+	KindInt32
+	KindFixed64
+	KindFixed32
+	KindBool
+	KindString
+	KindMessage
+	KindBytes
+	KindUint32
+	KindEnum
+	KindSfixed32
+	KindSfixed64
+	KindSint32
+	KindSint64
+)
+
+var fieldKindNames = [...]string{
+	KindDouble:   "double",
+	KindFloat:    "float",
+	KindInt64:    "int64",
+	KindUint64:   "uint64",
+	KindInt32:    "int32",
+	KindFixed64:  "fixed64",
+	KindFixed32:  "fixed32",
+	KindBool:     "bool",
+	KindString:   "string",
+	KindMessage:  "message",
+	KindBytes:    "bytes",
+	KindUint32:   "uint32",
+	KindEnum:     "enum",
+	KindSfixed32: "sfixed32",
+	KindSfixed64: "sfixed64",
+	KindSint32:   "sint32",
+	KindSint64:   "sint64",
+}
+
+func (k FieldKind) String() string {
+	if int(k) < len(fieldKindNames) && fieldKindNames[k] != "" {
+		return fieldKindNames[k]
+	}
+	return fmt.Sprintf("FieldKind(%d)", k)
+}
+
+// FieldLabel is the cardinality of a field, map fields are repeated.
+type FieldLabel uint8
+
+const (
+	LabelOptional FieldLabel = iota + 1
+	LabelRequired
+	LabelRepeated
+)
+
+func (l FieldLabel) String() string {
+	switch l {
+	case LabelOptional:
+		return "optional"
+	case LabelRequired:
+		return "required"
+	case LabelRepeated:
+		return "repeated"
+	default:
+		return fmt.Sprintf("FieldLabel(%d)", l)
+	}
+}
+
+type FieldDescriptor struct {
+	Name     string
+	JSONName string
+	Number   ProtoWireNumber
+	Kind     FieldKind
+	Label    FieldLabel
+	TypeName string // full name of the message or enum type
+	OneOf    string // name of the oneof the field belongs to
+	Default  string // proto2 default value as written in the proto file
+
+	MapKey   *FieldDescriptor // set for map fields only
+	MapValue *FieldDescriptor
+
+	// MessageType and EnumType link the field type directly, set by schemas built at runtime
+	// that are not in the registry. Generated descriptors leave them nil.
+	MessageType *MessageDescriptor
+	EnumType    *EnumDescriptor
+}
+
+func (f *FieldDescriptor) IsMap() bool {
+	return f.MapKey != nil
+}
+
+// Message returns the descriptor of the field type, nil unless the field is a message.
+func (f *FieldDescriptor) Message() *MessageDescriptor {
+	if f.Kind != KindMessage {
+		return nil
+	}
+	if f.MessageType != nil {
+		return f.MessageType
+	}
+	return FindMessage(f.TypeName)
+}
+
+// Enum returns the descriptor of the field type, nil unless the field is an enum.
+func (f *FieldDescriptor) Enum() *EnumDescriptor {
+	if f.Kind != KindEnum {
+		return nil
+	}
+	if f.EnumType != nil {
+		return f.EnumType
+	}
+	return FindEnum(f.TypeName)
+}
+
+type MessageDescriptor struct {
+	FullName string
+	File     string // path of the proto file
+	Fields   []*FieldDescriptor
+
+	Messages []*MessageDescriptor // nested message types
+	Enums    []*EnumDescriptor    // nested enum types
+
+	ExtensionRanges []FieldRange // numbers declared with extensions, for fields of extend blocks
+
+	New       func() ProtoMessage
+	NewReader func() ProtoReader // returns the generated reader, nil for schemas built at runtime
+}
+
+// Name returns the name without the package and parent messages.
+func (m *MessageDescriptor) Name() string {
+	return m.FullName[strings.LastIndexByte(m.FullName, '.')+1:]
+}
+
+func (m *MessageDescriptor) FieldByNumber(number ProtoWireNumber) *FieldDescriptor {
+	for _, f := range m.Fields {
+		if f.Number == number {
+			return f
+		}
+	}
+	return nil
+}
+
+func (m *MessageDescriptor) FieldByName(name string) *FieldDescriptor {
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// IsExtensionNumber reports whether number is in one of the extension ranges of the message.
+func (m *MessageDescriptor) IsExtensionNumber(number ProtoWireNumber) bool {
+	for _, r := range m.ExtensionRanges {
+		if number >= r.From && number <= r.To {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldRange is a range of field numbers, both ends included like in "extensions 100 to 199".
+type FieldRange struct {
+	From ProtoWireNumber
+	To   ProtoWireNumber
+}
+
+type EnumDescriptor struct {
+	FullName string
+	File     string
+	Values   []EnumValueDescriptor
+}
+
+type EnumValueDescriptor struct {
+	Name   string
+	Number int32
+}
+
+func (e *EnumDescriptor) Name() string {
+	return e.FullName[strings.LastIndexByte(e.FullName, '.')+1:]
+}
+
+// ValueByNumber returns the first value with the given number, enums may have aliases.
+func (e *EnumDescriptor) ValueByNumber(number int32) (EnumValueDescriptor, bool) {
+	for _, v := range e.Values {
+		if v.Number == number {
+			return v, true
+		}
+	}
+	return EnumValueDescriptor{}, false
+}
+
+func (e *EnumDescriptor) ValueByName(name string) (EnumValueDescriptor, bool) {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return EnumValueDescriptor{}, false
+}
+
+var registry struct {
+	sync.RWMutex
+	messages   map[string]*MessageDescriptor
+	enums      map[string]*EnumDescriptor
+	extensions map[string]*ExtensionDescriptor
+	extended   map[string]map[ProtoWireNumber]*ExtensionDescriptor // by the extended message and number
+}
+
+// RegistrationConflict selects what happens when a name or extension number is registered twice,
+// which is the case when two generated copies of the same proto file are linked into one binary.
+// The first registration is kept. It is read from the GREMLIN_REGISTRATION_CONFLICT environment
+// variable at startup, like GOLANG_PROTOBUF_REGISTRATION_CONFLICT of protobuf-go.
+var RegistrationConflict = registrationConflictFromEnv()
+
+const (
+	ConflictWarn   = "warn" // print a warning to stderr, the default
+	ConflictIgnore = "ignore"
+	ConflictPanic  = "panic"
+)
+
+func registrationConflictFromEnv() string {
+	switch policy := os.Getenv("GREMLIN_REGISTRATION_CONFLICT"); policy {
+	case ConflictIgnore, ConflictPanic:
+		return policy
+	default:
+		return ConflictWarn
+	}
+}
+
+func reportConflict(format string, args ...any) {
+	msg := "gremlin: " + fmt.Sprintf(format, args...)
+	switch RegistrationConflict {
+	case ConflictIgnore:
+	case ConflictPanic:
+		panic(msg)
+	default:
+		fmt.Fprintf(os.Stderr, "WARNING: %v, keeping the first registration\n", msg)
+	}
+}
+
+// RegisterMessage adds a message to the global registry, generated code calls it from init.
+// A name registered before is kept and the conflict is reported as RegistrationConflict says.
+func RegisterMessage(m *MessageDescriptor) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.messages[m.FullName]; ok {
+		reportConflict("message %v is already registered", m.FullName)
+		return
+	}
+	if registry.messages == nil {
+		registry.messages = map[string]*MessageDescriptor{}
+	}
+	registry.messages[m.FullName] = m
+}
+
+func RegisterEnum(e *EnumDescriptor) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.enums[e.FullName]; ok {
+		reportConflict("enum %v is already registered", e.FullName)
+		return
+	}
+	if registry.enums == nil {
+		registry.enums = map[string]*EnumDescriptor{}
+	}
+	registry.enums[e.FullName] = e
+}
+
+// RegisterExtension adds an extension to the global registry, generated code calls it from init.
+// A full name or a number of the extended message registered before is kept, like for RegisterMessage.
+func RegisterExtension(e *ExtensionDescriptor) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.extensions[e.FullName]; ok {
+		reportConflict("extension %v is already registered", e.FullName)
+		return
+	}
+	if other, ok := registry.extended[e.Extended][e.Field.Number]; ok {
+		reportConflict("extension %v uses number %v of %v, already used by %v", e.FullName, e.Field.Number, e.Extended, other.FullName)
+		return
+	}
+	if registry.extensions == nil {
+		registry.extensions = map[string]*ExtensionDescriptor{}
+		registry.extended = map[string]map[ProtoWireNumber]*ExtensionDescriptor{}
+	}
+	if registry.extended[e.Extended] == nil {
+		registry.extended[e.Extended] = map[ProtoWireNumber]*ExtensionDescriptor{}
+	}
+	registry.extensions[e.FullName] = e
+	registry.extended[e.Extended][e.Field.Number] = e
+}
+
+// FindMessage returns the message with the given full name, e.g. "pkg.Msg.Nested", or nil.
+func FindMessage(fullName string) *MessageDescriptor {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.messages[fullName]
+}
+
+func FindEnum(fullName string) *EnumDescriptor {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.enums[fullName]
+}
+
+// FindExtension returns the extension with the given full name, e.g. "pkg.Scope.name", or nil.
+func FindExtension(fullName string) *ExtensionDescriptor {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.extensions[fullName]
+}
+
+// FindExtensionByNumber returns the extension of the message named extended with the given number, or nil.
+func FindExtensionByNumber(extended string, number ProtoWireNumber) *ExtensionDescriptor {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.extended[extended][number]
+}
+
+// RangeMessages calls f for every registered message in name order until f returns false.
+func RangeMessages(f func(m *MessageDescriptor) bool) {
+	registry.RLock()
+	messages := make([]*MessageDescriptor, 0, len(registry.messages))
+	for _, m := range registry.messages {
+		messages = append(messages, m)
+	}
+	registry.RUnlock()
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].FullName < messages[j].FullName
+	})
+	for _, m := range messages {
+		if !f(m) {
+			return
+		}
+	}
+}
+
+// FieldTypeError is returned by generated SetField when the value does not have the type of the field.
+func FieldTypeError(m *MessageDescriptor, number ProtoWireNumber, value any) error {
+	return fmt.Errorf("%v: cannot set field %v to a %T value", m.FullName, number, value)
+}
+
+// UnknownFieldError is returned by generated SetField for numbers the message does not have.
+func UnknownFieldError(m *MessageDescriptor, number ProtoWireNumber) error {
+	return fmt.Errorf("%v: unknown field %v", m.FullName, number)
+}
@@ -0,0 +1,88 @@
+package gremlin
+
+import (
+	"strings"
+	"testing"
+)
+
+// isolateRegistry gives the test an empty registry, which is restored when the test ends,
+// so registering test types does not clash with other tests or with -count.
+func isolateRegistry(t *testing.T) {
+	registry.Lock()
+	messages, enums, extensions, extended := registry.messages, registry.enums, registry.extensions, registry.extended
+	registry.messages, registry.enums, registry.extensions, registry.extended = nil, nil, nil, nil
+	registry.Unlock()
+	t.Cleanup(func() {
+		registry.Lock()
+		registry.messages, registry.enums, registry.extensions, registry.extended = messages, enums, extensions, extended
+		registry.Unlock()
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	isolateRegistry(t)
+	enum := &EnumDescriptor{FullName: "registry_test.Color", Values: []EnumValueDescriptor{{"RED", 0}, {"CRIMSON", 0}, {"BLUE", 1}}}
+	msg := &MessageDescriptor{
+		FullName: "registry_test.Outer.Inner",
+		Fields: []*FieldDescriptor{
+			{Name: "color", Number: 1, Kind: KindEnum, Label: LabelOptional, TypeName: "registry_test.Color"},
+			{Name: "self", Number: 2, Kind: KindMessage, Label: LabelRepeated, TypeName: "registry_test.Outer.Inner"},
+		},
+	}
+	RegisterEnum(enum)
+	RegisterMessage(msg)
+	RegisterMessage(&MessageDescriptor{FullName: "registry_test.A"})
+
+	if FindMessage("registry_test.Outer.Inner") != msg || FindEnum("registry_test.Color") != enum {
+		t.Fatalf("registered types not found")
+	}
+	if FindMessage("registry_test.Missing") != nil {
+		t.Errorf("unexpected message for an unknown name")
+	}
+	if msg.Name() != "Inner" || enum.Name() != "Color" {
+		t.Errorf("unexpected short names %v, %v", msg.Name(), enum.Name())
+	}
+	if f := msg.FieldByNumber(1); f.Enum() != enum || f.Message() != nil {
+		t.Errorf("unexpected field types")
+	}
+	if f := msg.FieldByName("self"); f.Message() != msg || f.Kind.String() != "message" || f.Label.String() != "repeated" {
+		t.Errorf("unexpected field %+v", f)
+	}
+	if v, ok := enum.ValueByNumber(0); !ok || v.Name != "RED" {
+		t.Errorf("aliases should resolve to the first value, got %+v", v)
+	}
+
+	var names []string
+	RangeMessages(func(m *MessageDescriptor) bool {
+		names = append(names, m.FullName)
+		return len(names) < 2
+	})
+	if len(names) != 2 || names[0] >= names[1] {
+		t.Errorf("RangeMessages should stop early in name order, got %v", names)
+	}
+
+}
+
+func TestRegistrationConflict(t *testing.T) {
+	isolateRegistry(t)
+	policy := RegistrationConflict
+	t.Cleanup(func() { RegistrationConflict = policy })
+
+	first := &MessageDescriptor{FullName: "registry_test.A"}
+	RegisterMessage(first)
+	for _, policy := range []string{ConflictWarn, ConflictIgnore} {
+		RegistrationConflict = policy
+		RegisterMessage(&MessageDescriptor{FullName: "registry_test.A"})
+		if FindMessage("registry_test.A") != first {
+			t.Errorf("%v: the first registration should be kept", policy)
+		}
+	}
+
+	RegistrationConflict = ConflictPanic
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "registry_test.A is already registered") {
+			t.Errorf("registering a message twice should panic, got %v", r)
+		}
+	}()
+	RegisterMessage(&MessageDescriptor{FullName: "registry_test.A"})
+}
@@ -0,0 +1,283 @@
+package dynamic
+
+import (
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+	// registers the structs of the well-known types, which implement their JSON mappings
+	_ "github.com/norma-core/norma-core/shared/gremlin_go/wkt"
+)
+
+// wellKnownMessage is the generated struct of a well-known type.
+type wellKnownMessage interface {
+	gremlin.ProtoMessage
+	AppendJSON(b []byte) []byte
+	XXX_DecodeJSON(d *gremlin.JSONDecoder) error
+}
+
+// wellKnown returns an empty struct of the generated type for messages of the well-known types, nil for
+// others. Some of them have JSON mappings of their own, such as a string for google.protobuf.Timestamp.
+func (m *Message) wellKnown() wellKnownMessage {
+	if !strings.HasPrefix(m.desc.FullName, "google.protobuf.") {
+		return nil
+	}
+	desc := gremlin.FindMessage(m.desc.FullName)
+	if desc == nil || desc.New == nil {
+		return nil
+	}
+	msg, _ := desc.New().(wellKnownMessage)
+	return msg
+}
+
+func (m *Message) MarshalJSON() ([]byte, error) {
+	return m.AppendJSON(nil), nil
+}
+
+// AppendJSON writes the present fields with their JSON names in the protojson mapping.
+func (m *Message) AppendJSON(b []byte) []byte {
+	if msg := m.wellKnown(); msg != nil && msg.Unmarshal(m.Marshal()) == nil {
+		return msg.AppendJSON(b)
+	}
+	sep := byte('{')
+	m.Range(func(fd *gremlin.FieldDescriptor, value any) bool {
+		b = append(b, sep)
+		sep = ','
+		b = gremlin.AppendJSONString(b, fd.JSONName)
+		b = append(b, ':')
+		switch {
+		case fd.IsMap():
+			entries := value.(map[any]any)
+			b = append(b, '{')
+			for i, k := range sortedKeys(entries) {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = appendJSONMapKey(b, k)
+				b = append(b, ':')
+				b = appendJSONValue(b, fd.MapValue, entries[k])
+			}
+			b = append(b, '}')
+		case fd.Label == gremlin.LabelRepeated:
+			b = append(b, '[')
+			for i, v := range value.([]any) {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = appendJSONValue(b, fd, v)
+			}
+			b = append(b, ']')
+		default:
+			b = appendJSONValue(b, fd, value)
+		}
+		return true
+	})
+	if sep == '{' {
+		b = append(b, '{')
+	}
+	return append(b, '}')
+}
+
+func appendJSONMapKey(b []byte, key any) []byte {
+	switch k := key.(type) {
+	case int32:
+		return gremlin.AppendJSONMapKeyInt(b, int64(k))
+	case int64:
+		return gremlin.AppendJSONMapKeyInt(b, k)
+	case uint32:
+		return gremlin.AppendJSONMapKeyUint(b, uint64(k))
+	case uint64:
+		return gremlin.AppendJSONMapKeyUint(b, k)
+	case bool:
+		return gremlin.AppendJSONMapKeyBool(b, k)
+	default:
+		return gremlin.AppendJSONString(b, k.(string))
+	}
+}
+
+func appendJSONValue(b []byte, fd *gremlin.FieldDescriptor, value any) []byte {
+	switch v := value.(type) {
+	case int32:
+		if fd.Kind == gremlin.KindEnum {
+			return gremlin.AppendJSONEnum(b, v, enumName(fd, v))
+		}
+		return gremlin.AppendJSONInt(b, int64(v))
+	case int64:
+		return gremlin.AppendJSONInt64(b, v)
+	case uint32:
+		return gremlin.AppendJSONUint(b, uint64(v))
+	case uint64:
+		return gremlin.AppendJSONUint64(b, v)
+	case float32:
+		return gremlin.AppendJSONFloat(b, float64(v), 32)
+	case float64:
+		return gremlin.AppendJSONFloat(b, v, 64)
+	case bool:
+		return gremlin.AppendJSONBool(b, v)
+	case string:
+		return gremlin.AppendJSONString(b, v)
+	case []byte:
+		return gremlin.AppendJSONBytes(b, v)
+	default:
+		return value.(*Message).AppendJSON(b)
+	}
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := m.DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// DecodeJSON replaces the message with the object read from d.
+func (m *Message) DecodeJSON(d *gremlin.JSONDecoder) error {
+	_ = m.UnmarshalWithOptions(nil, m.buf.Options())
+	if msg := m.wellKnown(); msg != nil {
+		if err := msg.XXX_DecodeJSON(d); err != nil {
+			return err
+		}
+		return m.UnmarshalWithOptions(msg.Marshal(), m.buf.Options())
+	}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		fd := m.fieldByJSONName(key)
+		if fd == nil {
+			return d.UnknownField(key)
+		}
+		switch {
+		case fd.IsMap():
+			entries := map[any]any{}
+			if err := d.ReadObject(func(key string) error {
+				mapKey, err := parseJSONMapKey(fd.MapKey, key)
+				if err != nil {
+					return err
+				}
+				mapValue, err := m.readJSONValue(d, fd.MapValue)
+				if err != nil {
+					return err
+				}
+				entries[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+			return m.setField(fd, entries)
+		case fd.Label == gremlin.LabelRepeated:
+			var list []any
+			if err := d.ReadArray(func() error {
+				v, err := m.readJSONValue(d, fd)
+				if err != nil {
+					return err
+				}
+				list = append(list, v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return m.setField(fd, list)
+		default:
+			v, err := m.readJSONValue(d, fd)
+			if err != nil {
+				return err
+			}
+			return m.setField(fd, v)
+		}
+	})
+}
+
+func (m *Message) fieldByJSONName(key string) *gremlin.FieldDescriptor {
+	for _, fd := range m.desc.Fields {
+		if fd.JSONName == key || fd.Name == key {
+			return fd
+		}
+	}
+	return nil
+}
+
+func (m *Message) readJSONValue(d *gremlin.JSONDecoder, fd *gremlin.FieldDescriptor) (any, error) {
+	if fd.Kind == gremlin.KindMessage {
+		child := m.newNested(fd, nil)
+		if err := child.DecodeJSON(d); err != nil {
+			return nil, err
+		}
+		return child, nil
+	}
+	return readScalar(d, fd)
+}
+
+func parseJSONMapKey(fd *gremlin.FieldDescriptor, key string) (any, error) {
+	switch fd.Kind {
+	case gremlin.KindString:
+		return key, nil
+	case gremlin.KindBool:
+		return gremlin.ParseJSONBool(key)
+	case gremlin.KindInt32, gremlin.KindSint32, gremlin.KindSfixed32:
+		v, err := gremlin.ParseJSONInt(key, 32)
+		return int32(v), err
+	case gremlin.KindInt64, gremlin.KindSint64, gremlin.KindSfixed64:
+		return gremlin.ParseJSONInt(key, 64)
+	case gremlin.KindUint32, gremlin.KindFixed32:
+		v, err := gremlin.ParseJSONUint(key, 32)
+		return uint32(v), err
+	default:
+		return gremlin.ParseJSONUint(key, 64)
+	}
+}
+
+// scalarDecoder is implemented by gremlin.JSONDecoder and gremlin.TextDecoder.
+type scalarDecoder interface {
+	ReadInt32() (int32, error)
+	ReadInt64() (int64, error)
+	ReadUint32() (uint32, error)
+	ReadUint64() (uint64, error)
+	ReadFloat32() (float32, error)
+	ReadFloat64() (float64, error)
+	ReadBool() (bool, error)
+	ReadString() (string, error)
+	ReadBytes() ([]byte, error)
+	ReadEnum(values map[string]int32) (int32, error)
+}
+
+func readScalar(d scalarDecoder, fd *gremlin.FieldDescriptor) (any, error) {
+	switch fd.Kind {
+	case gremlin.KindInt32, gremlin.KindSint32, gremlin.KindSfixed32:
+		return d.ReadInt32()
+	case gremlin.KindInt64, gremlin.KindSint64, gremlin.KindSfixed64:
+		return d.ReadInt64()
+	case gremlin.KindUint32, gremlin.KindFixed32:
+		return d.ReadUint32()
+	case gremlin.KindUint64, gremlin.KindFixed64:
+		return d.ReadUint64()
+	case gremlin.KindFloat:
+		return d.ReadFloat32()
+	case gremlin.KindDouble:
+		return d.ReadFloat64()
+	case gremlin.KindBool:
+		return d.ReadBool()
+	case gremlin.KindString:
+		return d.ReadString()
+	case gremlin.KindBytes:
+		return d.ReadBytes()
+	default:
+		values := map[string]int32{}
+		if enum := fd.Enum(); enum != nil {
+			for _, v := range enum.Values {
+				values[v.Name] = v.Number
+			}
+		}
+		return d.ReadEnum(values)
+	}
+}
+
+func enumName(fd *gremlin.FieldDescriptor, v int32) string {
+	enum := fd.Enum()
+	if enum == nil {
+		return ""
+	}
+	ev, _ := enum.ValueByNumber(v)
+	return ev.Name
+}
@@ -0,0 +1,236 @@
+// Package dynamic decodes and encodes messages whose type is known only at runtime, described
+// by a gremlin.MessageDescriptor from a generated package or from gremlinc/protoparse.
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+type fieldOffset struct {
+	number gremlin.ProtoWireNumber
+	wire   gremlin.ProtoWireType
+	start  int32 // offset of the tag
+	value  int32
+	end    int32
+	known  bool // false for unknown numbers and wire types that do not match the field
+}
+
+// Message is a generic message tree with the lazy semantics of generated readers: Unmarshal only
+// records where fields are, values are decoded on first access and nested messages are parsed
+// when they are read. Errors inside nested messages are ignored like in generated readers.
+//
+// Values have these Go types: int32, int64, uint32, uint64, float32, float64, bool, string and
+// []byte for scalars, int32 for enums, *Message for messages, []any for repeated fields and
+// map[any]any for maps. Lists and maps returned by Get must not be modified, use Set instead.
+// A Message is not safe for concurrent use.
+type Message struct {
+	desc    *gremlin.MessageDescriptor
+	buf     gremlin.Reader
+	offsets []fieldOffset
+
+	cache map[gremlin.ProtoWireNumber]any // decoded values
+	set   map[gremlin.ProtoWireNumber]any // values set by the user, nil clears the field
+}
+
+func NewMessage(desc *gremlin.MessageDescriptor) *Message {
+	return &Message{desc: desc}
+}
+
+func (m *Message) Descriptor() *gremlin.MessageDescriptor {
+	return m.desc
+}
+
+func (m *Message) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions checks the framing of data and records the offsets of all fields,
+// nested messages inherit the buffer mode.
+func (m *Message) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.offsets = m.offsets[:0]
+	clear(m.cache)
+	clear(m.set)
+
+	offset := 0
+	for offset < len(data) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return fmt.Errorf("%v: offset %v: %w", m.desc.FullName, offset, err)
+		}
+		if tag == 0 {
+			return fmt.Errorf("%v: offset %v: invalid field number 0", m.desc.FullName, offset)
+		}
+		end, err := m.buf.SkipData(offset+tagSize, wire)
+		if err != nil {
+			return fmt.Errorf("%v: field %v: %w", m.desc.FullName, tag, err)
+		}
+		if end > len(data) || end <= offset {
+			return fmt.Errorf("%v: field %v: unexpected end of data", m.desc.FullName, tag)
+		}
+		fd := m.desc.FieldByNumber(tag)
+		m.offsets = append(m.offsets, fieldOffset{
+			number: tag,
+			wire:   wire,
+			start:  int32(offset),
+			value:  int32(offset + tagSize),
+			end:    int32(end),
+			known:  fd != nil && wireMatches(fd, wire),
+		})
+		offset = end
+	}
+	return nil
+}
+
+// Has reports whether the field is present on the wire or was set.
+func (m *Message) Has(name string) bool {
+	fd := m.desc.FieldByName(name)
+	return fd != nil && m.has(fd)
+}
+
+func (m *Message) has(fd *gremlin.FieldDescriptor) bool {
+	if v, ok := m.set[fd.Number]; ok {
+		return v != nil
+	}
+	for i := range m.offsets {
+		if m.offsets[i].known && m.offsets[i].number == fd.Number {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the value of the field, its default if the field is not present,
+// or nil for unknown names and absent messages, lists and maps.
+func (m *Message) Get(name string) any {
+	fd := m.desc.FieldByName(name)
+	if fd == nil {
+		return nil
+	}
+	return m.get(fd)
+}
+
+// GetField returns the value of the field with the given number like Get.
+func (m *Message) GetField(number gremlin.ProtoWireNumber) any {
+	fd := m.desc.FieldByNumber(number)
+	if fd == nil {
+		return nil
+	}
+	return m.get(fd)
+}
+
+func (m *Message) get(fd *gremlin.FieldDescriptor) any {
+	if v, ok := m.set[fd.Number]; ok {
+		if v == nil {
+			return defaultValue(fd)
+		}
+		return v
+	}
+	if v, ok := m.cache[fd.Number]; ok {
+		return v
+	}
+	v := m.decode(fd)
+	if v == nil {
+		return defaultValue(fd)
+	}
+	if m.cache == nil {
+		m.cache = map[gremlin.ProtoWireNumber]any{}
+	}
+	m.cache[fd.Number] = v
+	return v
+}
+
+// Set replaces the value of the field, a nil value clears it.
+func (m *Message) Set(name string, value any) error {
+	fd := m.desc.FieldByName(name)
+	if fd == nil {
+		return fmt.Errorf("%v: unknown field %q", m.desc.FullName, name)
+	}
+	return m.setField(fd, value)
+}
+
+// SetField replaces the value of the field with the given number like Set.
+func (m *Message) SetField(number gremlin.ProtoWireNumber, value any) error {
+	fd := m.desc.FieldByNumber(number)
+	if fd == nil {
+		return gremlin.UnknownFieldError(m.desc, number)
+	}
+	return m.setField(fd, value)
+}
+
+func (m *Message) setField(fd *gremlin.FieldDescriptor, value any) error {
+	if value != nil && !validValue(fd, value) {
+		return gremlin.FieldTypeError(m.desc, fd.Number, value)
+	}
+	if m.set == nil {
+		m.set = map[gremlin.ProtoWireNumber]any{}
+	}
+	m.set[fd.Number] = value
+	return nil
+}
+
+func (m *Message) Clear(name string) {
+	if fd := m.desc.FieldByName(name); fd != nil {
+		_ = m.setField(fd, nil)
+	}
+}
+
+// Range calls f for every present field in declaration order until f returns false.
+func (m *Message) Range(f func(fd *gremlin.FieldDescriptor, value any) bool) {
+	for _, fd := range m.desc.Fields {
+		if m.has(fd) && !f(fd, m.get(fd)) {
+			return
+		}
+	}
+}
+
+// Unknown returns the raw bytes of fields the descriptor does not know, or whose wire type
+// does not match the field. Marshal writes them back unchanged.
+func (m *Message) Unknown() []byte {
+	var res []byte
+	data := m.buf.Bytes()
+	for _, o := range m.offsets {
+		if !o.known {
+			res = append(res, data[o.start:o.end]...)
+		}
+	}
+	return res
+}
+
+// Marshal encodes the message. Fields that were neither set nor decoded into messages are
+// copied from the source bytes unchanged, unknown fields are kept.
+func (m *Message) Marshal() []byte {
+	return m.appendTo(nil)
+}
+
+func (m *Message) appendTo(b []byte) []byte {
+	data := m.buf.Bytes()
+	for _, fd := range m.desc.Fields {
+		if v, ok := m.set[fd.Number]; ok {
+			if v != nil {
+				b = appendField(b, fd, v)
+			}
+			continue
+		}
+		// decoded messages may have been modified through the returned pointers
+		if v, ok := m.cache[fd.Number]; ok && holdsMessages(fd) {
+			b = appendField(b, fd, v)
+			continue
+		}
+		for _, o := range m.offsets {
+			if o.known && o.number == fd.Number {
+				b = append(b, data[o.start:o.end]...)
+			}
+		}
+	}
+	for _, o := range m.offsets {
+		if !o.known {
+			b = append(b, data[o.start:o.end]...)
+		}
+	}
+	return b
+}
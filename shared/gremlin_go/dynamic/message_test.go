@@ -0,0 +1,186 @@
+package dynamic
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+var (
+	colorDesc = &gremlin.EnumDescriptor{FullName: "dynamic_test.Color", Values: []gremlin.EnumValueDescriptor{{Name: "RED", Number: 0}, {Name: "BLUE", Number: 2}}}
+	innerDesc = &gremlin.MessageDescriptor{
+		FullName: "dynamic_test.Inner",
+		Fields: []*gremlin.FieldDescriptor{
+			{Name: "id", Number: 1, Kind: gremlin.KindSint64, Label: gremlin.LabelOptional},
+		},
+	}
+	outerDesc = &gremlin.MessageDescriptor{
+		FullName: "dynamic_test.Outer",
+		Fields: []*gremlin.FieldDescriptor{
+			{Name: "name", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional, Default: "anon"},
+			{Name: "color", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: colorDesc.FullName, EnumType: colorDesc},
+			{Name: "inner", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: innerDesc.FullName, MessageType: innerDesc},
+			{Name: "values", Number: 4, Kind: gremlin.KindFixed32, Label: gremlin.LabelRepeated},
+			{Name: "tags", Number: 5, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated, TypeName: "dynamic_test.Outer.TagsEntry",
+				MapKey:   &gremlin.FieldDescriptor{Name: "key", Number: 1, Kind: gremlin.KindString},
+				MapValue: &gremlin.FieldDescriptor{Name: "value", Number: 2, Kind: gremlin.KindMessage, TypeName: innerDesc.FullName, MessageType: innerDesc},
+			},
+			{Name: "ratio", Number: 6, Kind: gremlin.KindDouble, Label: gremlin.LabelOptional, Default: "1.5"},
+		},
+	}
+)
+
+func encodeOuter() []byte {
+	w := gremlin.NewWriter(256)
+	w.AppendString(1, "first")
+	w.AppendInt32(2, 2)
+	w.AppendBytes(3, []byte{8, 3}) // id: -2
+	w.AppendFixed32(4, 7)          // unpacked
+	w.AppendBytes(4, []byte{8, 0, 0, 0, 9, 0, 0, 0})
+	w.AppendBytes(5, []byte{10, 1, 'b', 18, 2, 8, 4})
+	w.AppendBytes(5, []byte{10, 1, 'a'})
+	w.AppendUint64(99, 5)     // unknown number
+	w.AppendString(6, "oops") // wrong wire type
+	w.AppendString(1, "last")
+	return w.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	data := encodeOuter()
+	m := NewMessage(outerDesc)
+	if err := m.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.Get("name"); v != "last" {
+		t.Errorf("the last occurrence should win, got %v", v)
+	}
+	if v := m.Get("color"); v != int32(2) {
+		t.Errorf("unexpected color %v", v)
+	}
+	if v := m.Get("inner").(*Message).Get("id"); v != int64(-2) {
+		t.Errorf("unexpected inner id %v", v)
+	}
+	if v := m.Get("values"); !reflect.DeepEqual(v, []any{uint32(7), uint32(8), uint32(9)}) {
+		t.Errorf("packed and unpacked values should be merged, got %v", v)
+	}
+	tags := m.Get("tags").(map[any]any)
+	if len(tags) != 2 || tags["b"].(*Message).Get("id") != int64(2) || tags["a"].(*Message).Has("id") {
+		t.Errorf("unexpected map %v", tags)
+	}
+	if v := m.Get("ratio"); v != 1.5 || m.Has("ratio") {
+		t.Errorf("a field with the wrong wire type should be unknown, got %v", v)
+	}
+	if m.Get("missing") != nil {
+		t.Errorf("unknown names should return nil")
+	}
+
+	unknown := gremlin.NewWriter(16)
+	unknown.AppendUint64(99, 5)
+	unknown.AppendString(6, "oops")
+	if !bytes.Equal(m.Unknown(), unknown.Bytes()) {
+		t.Errorf("unexpected unknown fields %v", m.Unknown())
+	}
+
+	var names []string
+	m.Range(func(fd *gremlin.FieldDescriptor, value any) bool {
+		names = append(names, fd.Name)
+		return true
+	})
+	if !reflect.DeepEqual(names, []string{"name", "color", "inner", "values", "tags"}) {
+		t.Errorf("unexpected present fields %v", names)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	m := NewMessage(outerDesc)
+	if m.Get("name") != "anon" || m.Get("color") != int32(0) || m.Get("ratio") != 1.5 {
+		t.Errorf("unexpected defaults %v", m)
+	}
+	if m.Get("inner") != nil || m.Get("values") != nil || m.Get("tags") != nil {
+		t.Errorf("absent messages, lists and maps should be nil")
+	}
+	if len(m.Marshal()) != 0 {
+		t.Errorf("an empty message should marshal to nothing")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data := encodeOuter()
+	m := NewMessage(outerDesc)
+	if err := m.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("name", 5); err == nil {
+		t.Errorf("setting a value of the wrong type should fail")
+	}
+	if err := m.Set("values", []any{uint32(1), uint32(2)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Get("inner").(*Message).Set("id", int64(10)); err != nil {
+		t.Fatal(err)
+	}
+	m.Clear("color")
+
+	out := NewMessage(outerDesc)
+	if err := out.Unmarshal(m.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if out.Get("name") != "last" || out.Has("color") || out.Get("inner").(*Message).Get("id") != int64(10) {
+		t.Errorf("unexpected message after round trip %v", out)
+	}
+	if v := out.Get("values"); !reflect.DeepEqual(v, []any{uint32(1), uint32(2)}) {
+		t.Errorf("unexpected values %v", v)
+	}
+	if len(out.Get("tags").(map[any]any)) != 2 || !bytes.Equal(out.Unknown(), m.Unknown()) {
+		t.Errorf("untouched and unknown fields should be kept")
+	}
+
+	if err := out.Unmarshal(data[:len(data)-2]); err == nil {
+		t.Errorf("truncated data should fail")
+	}
+	if err := out.Unmarshal([]byte{0, 1}); err == nil {
+		t.Errorf("field number 0 should fail")
+	}
+}
+
+func TestText(t *testing.T) {
+	m := NewMessage(outerDesc)
+	if err := m.Unmarshal(encodeOuter()); err != nil {
+		t.Fatal(err)
+	}
+	expected := `name:"last" color:BLUE inner:{id:-2} values:7 values:8 values:9 ` +
+		`tags:{key:"a" value:{}} tags:{key:"b" value:{id:2}}`
+	if m.String() != expected {
+		t.Errorf("unexpected text\n%v\nexpected\n%v", m.String(), expected)
+	}
+}
+
+var _ gremlin.ProtoMessage = (*Message)(nil)
+
+func TestWellKnownJSON(t *testing.T) {
+	desc := &gremlin.MessageDescriptor{
+		FullName: "dynamic_test.Event",
+		Fields: []*gremlin.FieldDescriptor{
+			{Name: "created_at", JSONName: "createdAt", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional,
+				TypeName: "google.protobuf.Timestamp", MessageType: gremlin.FindMessage("google.protobuf.Timestamp")},
+			{Name: "ttl", JSONName: "ttl", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+				TypeName: "google.protobuf.Duration", MessageType: gremlin.FindMessage("google.protobuf.Duration")},
+		},
+	}
+	m := NewMessage(desc)
+	in := `{"createdAt":"2024-02-29T12:30:00.500Z","ttl":["1.500s","-2s"]}`
+	if err := m.UnmarshalJSON([]byte(in)); err != nil {
+		t.Fatal(err)
+	}
+	if seconds := m.Get("ttl").([]any)[1].(*Message).Get("seconds"); seconds != int64(-2) {
+		t.Errorf("unexpected seconds %v", seconds)
+	}
+	if got := string(m.AppendJSON(nil)); got != in {
+		t.Errorf("unexpected JSON %v", got)
+	}
+	if err := m.UnmarshalJSON([]byte(`{"createdAt":{"seconds":1}}`)); err == nil {
+		t.Errorf("a Timestamp must be a string")
+	}
+}
@@ -0,0 +1,161 @@
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+func (m *Message) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *Message) String() string {
+	return gremlin.TextString(m.WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *Message) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.WriteText)
+}
+
+// WriteText writes the present fields in declaration order, unknown fields are skipped.
+func (m *Message) WriteText(w *gremlin.TextWriter) {
+	m.Range(func(fd *gremlin.FieldDescriptor, value any) bool {
+		switch {
+		case fd.IsMap():
+			entries := value.(map[any]any)
+			for _, k := range sortedKeys(entries) {
+				w.WriteName(fd.Name)
+				w.StartMessage()
+				w.WriteName("key")
+				writeTextValue(w, fd.MapKey, k)
+				w.WriteName("value")
+				writeTextValue(w, fd.MapValue, entries[k])
+				w.EndMessage()
+			}
+		case fd.Label == gremlin.LabelRepeated:
+			for _, v := range value.([]any) {
+				w.WriteName(fd.Name)
+				writeTextValue(w, fd, v)
+			}
+		default:
+			w.WriteName(fd.Name)
+			writeTextValue(w, fd, value)
+		}
+		return true
+	})
+}
+
+func writeTextValue(w *gremlin.TextWriter, fd *gremlin.FieldDescriptor, value any) {
+	switch v := value.(type) {
+	case int32:
+		if fd.Kind == gremlin.KindEnum {
+			w.WriteEnum(v, enumName(fd, v))
+			return
+		}
+		w.WriteInt(int64(v))
+	case int64:
+		w.WriteInt(v)
+	case uint32:
+		w.WriteUint(uint64(v))
+	case uint64:
+		w.WriteUint(v)
+	case float32:
+		w.WriteFloat(float64(v), 32)
+	case float64:
+		w.WriteFloat(v, 64)
+	case bool:
+		w.WriteBool(v)
+	case string:
+		w.WriteString(v)
+	case []byte:
+		w.WriteBytes(v)
+	case *Message:
+		w.StartMessage()
+		v.WriteText(w)
+		w.EndMessage()
+	}
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (m *Message) UnmarshalText(data []byte) error {
+	return m.DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// DecodeText replaces the message with the one read from d. Repeated fields and maps accumulate
+// all occurrences, for other fields the last occurrence wins.
+func (m *Message) DecodeText(d *gremlin.TextDecoder) error {
+	_ = m.UnmarshalWithOptions(nil, m.buf.Options())
+	return d.ReadMessage(func(name string) error {
+		fd := m.desc.FieldByName(name)
+		if fd == nil {
+			return d.UnknownField(name)
+		}
+		switch {
+		case fd.IsMap():
+			entries, _ := m.set[fd.Number].(map[any]any)
+			if entries == nil {
+				entries = map[any]any{}
+			}
+			if err := d.ReadRepeated(func() error {
+				mapKey, mapValue := defaultValue(fd.MapKey), defaultValue(fd.MapValue)
+				if err := d.ReadMessage(func(name string) error {
+					var err error
+					switch name {
+					case "key":
+						mapKey, err = m.readTextValue(d, fd.MapKey)
+					case "value":
+						mapValue, err = m.readTextValue(d, fd.MapValue)
+					default:
+						err = d.UnknownField(name)
+					}
+					return err
+				}); err != nil {
+					return err
+				}
+				if mapValue == nil {
+					mapValue = m.newNested(fd.MapValue, nil)
+				}
+				entries[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+			return m.setField(fd, entries)
+		case fd.Label == gremlin.LabelRepeated:
+			list, _ := m.set[fd.Number].([]any)
+			if err := d.ReadRepeated(func() error {
+				v, err := m.readTextValue(d, fd)
+				if err != nil {
+					return err
+				}
+				list = append(list, v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return m.setField(fd, list)
+		default:
+			v, err := m.readTextValue(d, fd)
+			if err != nil {
+				return err
+			}
+			return m.setField(fd, v)
+		}
+	})
+}
+
+func (m *Message) readTextValue(d *gremlin.TextDecoder, fd *gremlin.FieldDescriptor) (any, error) {
+	if fd.Kind == gremlin.KindMessage {
+		child := m.newNested(fd, nil)
+		if err := child.DecodeText(d); err != nil {
+			return nil, err
+		}
+		return child, nil
+	}
+	return readScalar(d, fd)
+}
@@ -0,0 +1,431 @@
+package dynamic
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+func scalarWire(kind gremlin.FieldKind) gremlin.ProtoWireType {
+	switch kind {
+	case gremlin.KindFixed32, gremlin.KindSfixed32, gremlin.KindFloat:
+		return gremlin.Fixed32Type
+	case gremlin.KindFixed64, gremlin.KindSfixed64, gremlin.KindDouble:
+		return gremlin.Fixed64Type
+	case gremlin.KindString, gremlin.KindBytes, gremlin.KindMessage:
+		return gremlin.BytesType
+	default:
+		return gremlin.VarIntType
+	}
+}
+
+func isPackable(kind gremlin.FieldKind) bool {
+	return scalarWire(kind) != gremlin.BytesType
+}
+
+func wireMatches(fd *gremlin.FieldDescriptor, wire gremlin.ProtoWireType) bool {
+	if fd.IsMap() {
+		return wire == gremlin.BytesType
+	}
+	if fd.Label == gremlin.LabelRepeated && isPackable(fd.Kind) && wire == gremlin.BytesType {
+		return true
+	}
+	return wire == scalarWire(fd.Kind)
+}
+
+// holdsMessages reports whether values of the field contain *Message values.
+func holdsMessages(fd *gremlin.FieldDescriptor) bool {
+	if fd.IsMap() {
+		return fd.MapValue.Kind == gremlin.KindMessage
+	}
+	return fd.Kind == gremlin.KindMessage
+}
+
+// decode reads the field from the source bytes, nil if it is not present.
+func (m *Message) decode(fd *gremlin.FieldDescriptor) any {
+	switch {
+	case fd.IsMap():
+		var res map[any]any
+		for _, o := range m.offsets {
+			if !o.known || o.number != fd.Number {
+				continue
+			}
+			if res == nil {
+				res = map[any]any{}
+			}
+			k, v := m.decodeMapEntry(fd, int(o.value))
+			res[k] = v
+		}
+		if res == nil {
+			return nil
+		}
+		return res
+	case fd.Label == gremlin.LabelRepeated:
+		var res []any
+		for _, o := range m.offsets {
+			if !o.known || o.number != fd.Number {
+				continue
+			}
+			if o.wire == gremlin.BytesType && isPackable(fd.Kind) {
+				packed := m.buf.ReadMessage(int(o.value))
+				start := int(o.end) - len(packed)
+				for offset := 0; offset < len(packed); {
+					if !m.validAt(fd.Kind, start+offset, int(o.end)) {
+						break
+					}
+					v, size := m.decodeValue(fd.Kind, fd, start+offset)
+					res = append(res, v)
+					offset += size
+				}
+			} else {
+				v, _ := m.decodeValue(fd.Kind, fd, int(o.value))
+				res = append(res, v)
+			}
+		}
+		if res == nil {
+			return nil
+		}
+		return res
+	default:
+		// the last occurrence wins, like in generated readers
+		for i := len(m.offsets) - 1; i >= 0; i-- {
+			if o := m.offsets[i]; o.known && o.number == fd.Number {
+				v, _ := m.decodeValue(fd.Kind, fd, int(o.value))
+				return v
+			}
+		}
+		return nil
+	}
+}
+
+func (m *Message) decodeMapEntry(fd *gremlin.FieldDescriptor, offset int) (any, any) {
+	entry := m.buf.ReadMessage(offset)
+	start := offset + gremlin.SizeVarInt(uint64(len(entry)))
+	var key, value any
+	limit := start + len(entry)
+	for pos := start; pos < limit; {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(pos)
+		if err != nil {
+			break
+		}
+		end, err := m.buf.SkipData(pos+tagSize, wire)
+		if err != nil || end > limit || end <= pos {
+			break
+		}
+		switch {
+		case tag == 1 && wire == scalarWire(fd.MapKey.Kind):
+			key, _ = m.decodeValue(fd.MapKey.Kind, fd.MapKey, pos+tagSize)
+		case tag == 2 && wire == scalarWire(fd.MapValue.Kind):
+			value, _ = m.decodeValue(fd.MapValue.Kind, fd.MapValue, pos+tagSize)
+		}
+		pos = end
+	}
+	if key == nil {
+		key = defaultValue(fd.MapKey)
+	}
+	if value == nil {
+		if value = defaultValue(fd.MapValue); value == nil {
+			value = m.newNested(fd.MapValue, nil)
+		}
+	}
+	return key, value
+}
+
+func (m *Message) newNested(fd *gremlin.FieldDescriptor, data []byte) *Message {
+	child := &Message{desc: fd.Message()}
+	if child.desc == nil {
+		child.desc = &gremlin.MessageDescriptor{FullName: fd.TypeName}
+	}
+	_ = child.UnmarshalWithOptions(data, m.buf.Options())
+	return child
+}
+
+// validAt reports whether a complete value of the kind starts at offset before end,
+// packed data is not checked when the message is unmarshalled.
+func (m *Message) validAt(kind gremlin.FieldKind, offset int, end int) bool {
+	next, err := m.buf.SkipData(offset, scalarWire(kind))
+	return err == nil && next <= end
+}
+
+// decodeValue reads a single value at offset and returns it with its size.
+func (m *Message) decodeValue(kind gremlin.FieldKind, fd *gremlin.FieldDescriptor, offset int) (any, int) {
+	switch kind {
+	case gremlin.KindInt32, gremlin.KindEnum:
+		return m.buf.SizedReadInt32(offset)
+	case gremlin.KindInt64:
+		return m.buf.SizedReadInt64(offset)
+	case gremlin.KindUint32:
+		return m.buf.SizedReadUint32(offset)
+	case gremlin.KindUint64:
+		return m.buf.SizedReadUint64(offset)
+	case gremlin.KindSint32:
+		return m.buf.SizedReadSInt32(offset)
+	case gremlin.KindSint64:
+		return m.buf.SizedReadSInt64(offset)
+	case gremlin.KindFixed32:
+		return m.buf.SizedReadFixed32(offset)
+	case gremlin.KindFixed64:
+		return m.buf.SizedReadFixed64(offset)
+	case gremlin.KindSfixed32:
+		return m.buf.SizedReadSFixed32(offset)
+	case gremlin.KindSfixed64:
+		return m.buf.SizedReadSFixed64(offset)
+	case gremlin.KindFloat:
+		return m.buf.SizedReadFloat32(offset)
+	case gremlin.KindDouble:
+		return m.buf.SizedReadFloat64(offset)
+	case gremlin.KindBool:
+		return m.buf.SizedReadBool(offset)
+	case gremlin.KindString:
+		return m.buf.SizedReadString(offset)
+	case gremlin.KindBytes:
+		return m.buf.SizedReadBytes(offset)
+	default:
+		data, size := m.buf.SizedReadMessage(offset)
+		return m.newNested(fd, data), size
+	}
+}
+
+// defaultValue returns the value of an absent field: the proto2 default, the first enum value
+// or the zero value. Messages, lists and maps have no default.
+func defaultValue(fd *gremlin.FieldDescriptor) any {
+	if fd.IsMap() || fd.Label == gremlin.LabelRepeated {
+		return nil
+	}
+	switch fd.Kind {
+	case gremlin.KindMessage:
+		return nil
+	case gremlin.KindEnum:
+		enum := fd.Enum()
+		if enum == nil || len(enum.Values) == 0 {
+			return int32(0)
+		}
+		if v, ok := enum.ValueByName(fd.Default); ok {
+			return v.Number
+		}
+		return enum.Values[0].Number
+	case gremlin.KindString:
+		return fd.Default
+	case gremlin.KindBytes:
+		if fd.Default == "" {
+			return []byte(nil)
+		}
+		return []byte(fd.Default)
+	case gremlin.KindBool:
+		return fd.Default == "true"
+	}
+	return parseNumber(fd.Kind, fd.Default)
+}
+
+func parseNumber(kind gremlin.FieldKind, lit string) any {
+	switch kind {
+	case gremlin.KindFloat, gremlin.KindDouble:
+		var v float64
+		switch lit {
+		case "inf":
+			v = math.Inf(1)
+		case "-inf":
+			v = math.Inf(-1)
+		case "nan":
+			v = math.NaN()
+		default:
+			v, _ = strconv.ParseFloat(lit, 64)
+		}
+		if kind == gremlin.KindFloat {
+			return float32(v)
+		}
+		return v
+	case gremlin.KindInt32, gremlin.KindSint32, gremlin.KindSfixed32:
+		v, _ := strconv.ParseInt(lit, 0, 32)
+		return int32(v)
+	case gremlin.KindInt64, gremlin.KindSint64, gremlin.KindSfixed64:
+		v, _ := strconv.ParseInt(lit, 0, 64)
+		return v
+	case gremlin.KindUint32, gremlin.KindFixed32:
+		v, _ := strconv.ParseUint(lit, 0, 32)
+		return uint32(v)
+	default:
+		v, _ := strconv.ParseUint(lit, 0, 64)
+		return v
+	}
+}
+
+func validValue(fd *gremlin.FieldDescriptor, value any) bool {
+	switch {
+	case fd.IsMap():
+		entries, ok := value.(map[any]any)
+		if !ok {
+			return false
+		}
+		for k, v := range entries {
+			if !validScalar(fd.MapKey, k) || !validScalar(fd.MapValue, v) {
+				return false
+			}
+		}
+		return true
+	case fd.Label == gremlin.LabelRepeated:
+		list, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		for _, v := range list {
+			if !validScalar(fd, v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return validScalar(fd, value)
+	}
+}
+
+func validScalar(fd *gremlin.FieldDescriptor, value any) bool {
+	var ok bool
+	switch fd.Kind {
+	case gremlin.KindInt32, gremlin.KindSint32, gremlin.KindSfixed32, gremlin.KindEnum:
+		_, ok = value.(int32)
+	case gremlin.KindInt64, gremlin.KindSint64, gremlin.KindSfixed64:
+		_, ok = value.(int64)
+	case gremlin.KindUint32, gremlin.KindFixed32:
+		_, ok = value.(uint32)
+	case gremlin.KindUint64, gremlin.KindFixed64:
+		_, ok = value.(uint64)
+	case gremlin.KindFloat:
+		_, ok = value.(float32)
+	case gremlin.KindDouble:
+		_, ok = value.(float64)
+	case gremlin.KindBool:
+		_, ok = value.(bool)
+	case gremlin.KindString:
+		_, ok = value.(string)
+	case gremlin.KindBytes:
+		_, ok = value.([]byte)
+	case gremlin.KindMessage:
+		var msg *Message
+		msg, ok = value.(*Message)
+		ok = ok && msg != nil && msg.desc.FullName == fd.TypeName
+	}
+	return ok
+}
+
+func appendTag(b []byte, number gremlin.ProtoWireNumber, wire gremlin.ProtoWireType) []byte {
+	return binary.AppendUvarint(b, uint64(number)<<3|uint64(wire))
+}
+
+func appendField(b []byte, fd *gremlin.FieldDescriptor, value any) []byte {
+	switch {
+	case fd.IsMap():
+		entries := value.(map[any]any)
+		for _, k := range sortedKeys(entries) {
+			entry := appendValue(nil, fd.MapKey, 1, k)
+			entry = appendValue(entry, fd.MapValue, 2, entries[k])
+			b = appendTag(b, fd.Number, gremlin.BytesType)
+			b = binary.AppendUvarint(b, uint64(len(entry)))
+			b = append(b, entry...)
+		}
+		return b
+	case fd.Label == gremlin.LabelRepeated:
+		list := value.([]any)
+		// scalars are always packed, like in generated code
+		if isPackable(fd.Kind) {
+			if len(list) == 0 {
+				return b
+			}
+			var packed []byte
+			for _, v := range list {
+				packed = appendScalar(packed, fd.Kind, v)
+			}
+			b = appendTag(b, fd.Number, gremlin.BytesType)
+			b = binary.AppendUvarint(b, uint64(len(packed)))
+			return append(b, packed...)
+		}
+		for _, v := range list {
+			b = appendValue(b, fd, fd.Number, v)
+		}
+		return b
+	default:
+		return appendValue(b, fd, fd.Number, value)
+	}
+}
+
+func appendValue(b []byte, fd *gremlin.FieldDescriptor, number gremlin.ProtoWireNumber, value any) []byte {
+	b = appendTag(b, number, scalarWire(fd.Kind))
+	return appendScalar(b, fd.Kind, value)
+}
+
+func appendScalar(b []byte, kind gremlin.FieldKind, value any) []byte {
+	switch kind {
+	case gremlin.KindInt32, gremlin.KindEnum:
+		return binary.AppendUvarint(b, uint64(int64(value.(int32))))
+	case gremlin.KindInt64:
+		return binary.AppendUvarint(b, uint64(value.(int64)))
+	case gremlin.KindUint32:
+		return binary.AppendUvarint(b, uint64(value.(uint32)))
+	case gremlin.KindUint64:
+		return binary.AppendUvarint(b, value.(uint64))
+	case gremlin.KindSint32:
+		v := value.(int32)
+		return binary.AppendUvarint(b, uint64(uint32(v<<1)^uint32(v>>31)))
+	case gremlin.KindSint64:
+		v := value.(int64)
+		return binary.AppendUvarint(b, uint64(v<<1)^uint64(v>>63))
+	case gremlin.KindFixed32:
+		return binary.LittleEndian.AppendUint32(b, value.(uint32))
+	case gremlin.KindSfixed32:
+		return binary.LittleEndian.AppendUint32(b, uint32(value.(int32)))
+	case gremlin.KindFloat:
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(value.(float32)))
+	case gremlin.KindFixed64:
+		return binary.LittleEndian.AppendUint64(b, value.(uint64))
+	case gremlin.KindSfixed64:
+		return binary.LittleEndian.AppendUint64(b, uint64(value.(int64)))
+	case gremlin.KindDouble:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(value.(float64)))
+	case gremlin.KindBool:
+		if value.(bool) {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case gremlin.KindString:
+		v := value.(string)
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		return append(b, v...)
+	case gremlin.KindBytes:
+		v := value.([]byte)
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		return append(b, v...)
+	default:
+		data := value.(*Message).Marshal()
+		b = binary.AppendUvarint(b, uint64(len(data)))
+		return append(b, data...)
+	}
+}
+
+// sortedKeys orders map keys like prototext, all keys of a map have the same type.
+func sortedKeys(entries map[any]any) []any {
+	keys := make([]any, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		switch a := keys[i].(type) {
+		case int32:
+			return a < keys[j].(int32)
+		case int64:
+			return a < keys[j].(int64)
+		case uint32:
+			return a < keys[j].(uint32)
+		case uint64:
+			return a < keys[j].(uint64)
+		case string:
+			return a < keys[j].(string)
+		case bool:
+			return !a && keys[j].(bool)
+		}
+		return false
+	})
+	return keys
+}
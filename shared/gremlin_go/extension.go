@@ -0,0 +1,169 @@
+package gremlin
+
+import "fmt"
+
+// ExtensionDescriptor describes a field declared in an extend block. Extensions are not fields of
+// the extended message: they are kept among its unknown fields and read with GetExtension.
+type ExtensionDescriptor struct {
+	FullName string           // named in the scope of the extend block, e.g. "pkg.Scope.name"
+	Extended string           // full name of the extended message
+	Field    *FieldDescriptor // Name is the short name
+}
+
+// Extension is a generated extension whose values have type T, the type a struct field would have.
+type Extension[T any] struct {
+	ExtensionDescriptor
+	// Decode reads the value from the wire data of the extended message, skipping the other fields.
+	// Missing values are the zero value or the declared default.
+	Decode func(data []byte) (T, error)
+	// Encode returns the field with its tag, nothing for empty lists and nil messages. Repeated scalars
+	// are packed as declared, by the packed option or the syntax default.
+	Encode func(value T) []byte
+}
+
+// Extendable is implemented by readers and structs of messages with extension ranges.
+type Extendable interface {
+	Descriptor() *MessageDescriptor
+	XXX_ExtensionData() []byte // wire data holding the extension fields among other fields
+}
+
+// ExtendableMessage is implemented by structs of messages with extension ranges.
+type ExtendableMessage interface {
+	ProtoMessage
+	XXX_ExtensionData() []byte
+	XXX_SetExtensionData(data []byte)
+}
+
+func (e *ExtensionDescriptor) checkExtended(msg *MessageDescriptor) error {
+	if msg.FullName != e.Extended {
+		return fmt.Errorf("extension %v extends %v, not %v", e.FullName, e.Extended, msg.FullName)
+	}
+	return nil
+}
+
+// GetExtension returns the value of ext in msg, a reader or a struct.
+func GetExtension[T any](msg Extendable, ext *Extension[T]) (T, error) {
+	if err := ext.checkExtended(msg.Descriptor()); err != nil {
+		var zero T
+		return zero, err
+	}
+	return ext.Decode(msg.XXX_ExtensionData())
+}
+
+// HasExtension reports whether msg holds a value of ext.
+func HasExtension[T any](msg Extendable, ext *Extension[T]) bool {
+	if ext.checkExtended(msg.Descriptor()) != nil {
+		return false
+	}
+	found := false
+	_ = XXX_RangeFields(msg.XXX_ExtensionData(), func(_ *Reader, tag ProtoWireNumber, _ ProtoWireType, _ int) error {
+		found = found || tag == ext.Field.Number
+		return nil
+	})
+	return found
+}
+
+// SetExtension replaces the value of ext in msg, zero scalars included. Empty lists and nil messages clear it.
+func SetExtension[T any](msg ExtendableMessage, ext *Extension[T], value T) error {
+	desc := msg.Descriptor()
+	if err := ext.checkExtended(desc); err != nil {
+		return err
+	}
+	if !desc.IsExtensionNumber(ext.Field.Number) {
+		return fmt.Errorf("extension %v: %v does not declare number %v for extensions", ext.FullName, desc.FullName, ext.Field.Number)
+	}
+	data, err := removeFields(msg.XXX_ExtensionData(), ext.Field.Number)
+	if err != nil {
+		return err
+	}
+	msg.XXX_SetExtensionData(append(data, ext.Encode(value)...))
+	return nil
+}
+
+// ClearExtension removes the value of ext from msg.
+func ClearExtension[T any](msg ExtendableMessage, ext *Extension[T]) error {
+	if err := ext.checkExtended(msg.Descriptor()); err != nil {
+		return err
+	}
+	data, err := removeFields(msg.XXX_ExtensionData(), ext.Field.Number)
+	if err != nil {
+		return err
+	}
+	msg.XXX_SetExtensionData(data)
+	return nil
+}
+
+// removeFields returns a copy of data without the occurrences of number.
+func removeFields(data []byte, number ProtoWireNumber) ([]byte, error) {
+	res := make([]byte, 0, len(data))
+	start := 0
+	err := rangeFieldBounds(data, func(tag ProtoWireNumber, end int) {
+		if tag != number {
+			res = append(res, data[start:end]...)
+		}
+		start = end
+	})
+	return res, err
+}
+
+// rangeFieldBounds calls fn with the number and the end offset of every field of data.
+func rangeFieldBounds(data []byte, fn func(tag ProtoWireNumber, end int)) error {
+	var buf Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+		offset, err = buf.SkipData(offset+tagSize, wire)
+		if err != nil {
+			return err
+		}
+		if offset > len(data) {
+			return fmt.Errorf("field %v is truncated", tag)
+		}
+		fn(tag, offset)
+	}
+	return nil
+}
+
+// XXX_RangeFields calls fn with the value offset of every field of data, used by generated extension decoders.
+// fn is called only for values that end inside data.
+func XXX_RangeFields(data []byte, fn func(buf *Reader, tag ProtoWireNumber, wire ProtoWireType, offset int) error) error {
+	var buf Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		if err := fn(&buf, tag, wire, offset); err != nil {
+			return err
+		}
+		offset = end
+	}
+	return nil
+}
+
+// XXX_AppendUnknownFields appends the fields of data that desc does not declare to dst, used by generated ToStruct.
+func XXX_AppendUnknownFields(dst []byte, data []byte, desc *MessageDescriptor) []byte {
+	start := 0
+	_ = rangeFieldBounds(data, func(tag ProtoWireNumber, end int) {
+		if desc.FieldByNumber(tag) == nil {
+			dst = append(dst, data[start:end]...)
+		}
+		start = end
+	})
+	return dst
+}
@@ -0,0 +1,74 @@
+package gremlin
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtensionRegistry(t *testing.T) {
+	isolateRegistry(t)
+	msg := &MessageDescriptor{
+		FullName:        "extension_test.Base",
+		Fields:          []*FieldDescriptor{{Name: "name", Number: 1, Kind: KindString, Label: LabelOptional}},
+		ExtensionRanges: []FieldRange{{From: 100, To: 199}},
+	}
+	ext := &ExtensionDescriptor{
+		FullName: "extension_test.Scope.weight",
+		Extended: "extension_test.Base",
+		Field:    &FieldDescriptor{Name: "weight", Number: 100, Kind: KindInt32, Label: LabelOptional},
+	}
+	RegisterExtension(ext)
+
+	if FindExtension("extension_test.Scope.weight") != ext || FindExtensionByNumber("extension_test.Base", 100) != ext {
+		t.Fatalf("registered extension not found")
+	}
+	if FindExtensionByNumber("extension_test.Base", 101) != nil || FindExtension("extension_test.weight") != nil {
+		t.Errorf("unexpected extension for an unknown name or number")
+	}
+	if !msg.IsExtensionNumber(100) || !msg.IsExtensionNumber(199) || msg.IsExtensionNumber(200) || msg.IsExtensionNumber(1) {
+		t.Errorf("unexpected extension ranges")
+	}
+	if err := ext.checkExtended(msg); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ext.checkExtended(&MessageDescriptor{FullName: "extension_test.Other"}); err == nil {
+		t.Errorf("extensions of other messages should be rejected")
+	}
+
+	RegisterExtension(&ExtensionDescriptor{FullName: "extension_test.other", Extended: "extension_test.Base", Field: &FieldDescriptor{Number: 100}})
+	if FindExtensionByNumber("extension_test.Base", 100) != ext || FindExtension("extension_test.other") != nil {
+		t.Errorf("a number registered twice should keep the first extension")
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	w := NewWriter(64)
+	w.AppendString(1, "name")
+	w.AppendInt32(100, 1)
+	w.AppendString(101, "x")
+	w.AppendInt32(100, 2)
+	data := w.Bytes()
+
+	removed, err := removeFields(data, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NewWriter(64)
+	want.AppendString(1, "name")
+	want.AppendString(101, "x")
+	if !bytes.Equal(removed, want.Bytes()) {
+		t.Errorf("unexpected data without field 100: %v", removed)
+	}
+
+	desc := &MessageDescriptor{Fields: []*FieldDescriptor{{Name: "name", Number: 1, Kind: KindString}}}
+	unknown := XXX_AppendUnknownFields(nil, removed, desc)
+	want = NewWriter(64)
+	want.AppendString(101, "x")
+	if !bytes.Equal(unknown, want.Bytes()) {
+		t.Errorf("unexpected unknown fields: %v", unknown)
+	}
+
+	if _, err := removeFields(data[:len(data)-1], 100); err == nil {
+		t.Errorf("truncated data should be rejected")
+	}
+}
@@ -0,0 +1,331 @@
+package gremlin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldMask is a set of field paths like "profile.address.city", it is implemented by
+// google.protobuf.FieldMask structs and readers, and by FieldPaths.
+type FieldMask interface {
+	GetPaths() []string
+}
+
+// FieldPaths is a FieldMask built from paths, e.g. FieldPaths{UserPaths.Name, UserPaths.Profile.Address.City}.
+type FieldPaths []string
+
+func (p FieldPaths) GetPaths() []string {
+	return p
+}
+
+// JoinPath appends the field name to path, used by generated path constants.
+func JoinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// valueMessages hold plain Go values in generated structs, so paths can't go into them.
+var valueMessages = map[string]bool{
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+	"google.protobuf.Struct":      true,
+	"google.protobuf.ListValue":   true,
+	"google.protobuf.Value":       true,
+}
+
+// ValidateMask reports whether every path of mask names a field of T. Paths use proto field names,
+// every part but the last must be a singular message field.
+func ValidateMask[T ProtoMessage](mask FieldMask) error {
+	var msg T
+	return validateMask(msg.Descriptor(), mask)
+}
+
+func validateMask(desc *MessageDescriptor, mask FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		if _, err := resolvePath(desc, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePath returns the fields along path.
+func resolvePath(desc *MessageDescriptor, path string) ([]*FieldDescriptor, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%v: empty field mask path", desc.FullName)
+	}
+	var res []*FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if len(res) > 0 {
+			parent := res[len(res)-1]
+			if parent.Label == LabelRepeated || valueMessages[parent.TypeName] || parent.Message() == nil {
+				return nil, fmt.Errorf("%v: path %q goes into %v, which is not a singular message field", desc.FullName, path, parent.Name)
+			}
+		}
+		field := resolvedMessage(desc, res).FieldByName(name)
+		if field == nil {
+			return nil, fmt.Errorf("%v: path %q: unknown field %q", desc.FullName, path, name)
+		}
+		res = append(res, field)
+	}
+	return res, nil
+}
+
+// resolvedMessage returns the message the last of fields points to, desc when there are none.
+func resolvedMessage(desc *MessageDescriptor, fields []*FieldDescriptor) *MessageDescriptor {
+	if len(fields) == 0 {
+		return desc
+	}
+	return fields[len(fields)-1].Message()
+}
+
+// CopyMasked sets the fields of dst selected by mask to the values in src, unset fields of src clear
+// them in dst. Messages on the way are created in dst as needed. Selected messages are copied with their
+// Copy method, other values are deep copies.
+func CopyMasked[T ProtoMessage](dst T, src T, mask FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		fields, err := resolvePath(dst.Descriptor(), path)
+		if err != nil {
+			return err
+		}
+		if err := copyPath(dst, src, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyPath(dst ProtoMessage, src ProtoMessage, fields []*FieldDescriptor) error {
+	field := fields[0]
+	value := src.GetField(field.Number)
+	if len(fields) == 1 {
+		return dst.SetField(field.Number, cloneValue(value))
+	}
+	srcChild := messageValue(value)
+	dstChild := messageValue(dst.GetField(field.Number))
+	if dstChild == nil {
+		if srcChild == nil {
+			return nil
+		}
+		var err error
+		if dstChild, err = setNewMessage(dst, field); err != nil {
+			return err
+		}
+	}
+	if srcChild == nil {
+		// an unset message in src clears the selected fields below it
+		desc := dstChild.Descriptor()
+		if desc.New == nil {
+			return fmt.Errorf("%v: messages of this type can't be created", desc.FullName)
+		}
+		srcChild = desc.New()
+	}
+	return copyPath(dstChild, srcChild, fields[1:])
+}
+
+// MergeMasked merges the fields of src selected by mask into dst: set values replace the ones in dst,
+// messages are merged field by field, repeated fields are appended to and map entries added.
+// Unset fields of src leave dst unchanged.
+func MergeMasked[T ProtoMessage](dst T, src T, mask FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		fields, err := resolvePath(dst.Descriptor(), path)
+		if err != nil {
+			return err
+		}
+		if err := mergePath(dst, src, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergePath(dst ProtoMessage, src ProtoMessage, fields []*FieldDescriptor) error {
+	field := fields[0]
+	value := src.GetField(field.Number)
+	if len(fields) == 1 {
+		return mergeField(dst, field, value)
+	}
+	srcChild := messageValue(value)
+	if srcChild == nil {
+		return nil
+	}
+	dstChild := messageValue(dst.GetField(field.Number))
+	if dstChild == nil {
+		var err error
+		if dstChild, err = setNewMessage(dst, field); err != nil {
+			return err
+		}
+	}
+	return mergePath(dstChild, srcChild, fields[1:])
+}
+
+func mergeField(dst ProtoMessage, field *FieldDescriptor, value any) error {
+	if isEmptyValue(field, value) {
+		return nil
+	}
+	current := dst.GetField(field.Number)
+	switch {
+	case field.IsMap():
+		if !isEmptyValue(field, current) {
+			res := reflect.ValueOf(cloneValue(current))
+			src := reflect.ValueOf(value)
+			for iter := src.MapRange(); iter.Next(); {
+				res.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+			return dst.SetField(field.Number, res.Interface())
+		}
+	case field.Label == LabelRepeated:
+		res := reflect.AppendSlice(reflect.ValueOf(current), reflect.ValueOf(cloneValue(value)))
+		return dst.SetField(field.Number, res.Interface())
+	default:
+		if dstChild, srcChild := messageValue(current), messageValue(value); dstChild != nil && srcChild != nil {
+			for _, f := range srcChild.Descriptor().Fields {
+				if err := mergeField(dstChild, f, srcChild.GetField(f.Number)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return dst.SetField(field.Number, cloneValue(value))
+}
+
+// MarshalMasked marshals only the fields of msg selected by mask.
+func MarshalMasked(msg ProtoMessage, mask FieldMask) ([]byte, error) {
+	desc := msg.Descriptor()
+	if desc.New == nil {
+		return nil, fmt.Errorf("%v: messages of this type can't be created", desc.FullName)
+	}
+	res := desc.New()
+	for _, path := range mask.GetPaths() {
+		fields, err := resolvePath(desc, path)
+		if err != nil {
+			return nil, err
+		}
+		if err := copyPath(res, msg, fields); err != nil {
+			return nil, err
+		}
+	}
+	return res.Marshal(), nil
+}
+
+// messageValue returns the message held by a field value, nil when it is unset or not a message.
+func messageValue(v any) ProtoMessage {
+	msg, ok := v.(ProtoMessage)
+	if !ok || reflect.ValueOf(msg).IsNil() {
+		return nil
+	}
+	return msg
+}
+
+func setNewMessage(parent ProtoMessage, field *FieldDescriptor) (ProtoMessage, error) {
+	desc := field.Message()
+	if desc == nil || desc.New == nil {
+		return nil, fmt.Errorf("%v: can't create a message for field %v", parent.Descriptor().FullName, field.Name)
+	}
+	res := desc.New()
+	if err := parent.SetField(field.Number, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// isEmptyValue reports whether a field value is unset. Singular messages are set unless nil,
+// google.protobuf.Value fields hold their value directly, so a set 0 or "" is not empty.
+func isEmptyValue(field *FieldDescriptor, v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case field.Kind == KindMessage && field.Label != LabelRepeated:
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			return rv.IsNil()
+		}
+		return false
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// cloneValue returns a copy of a field value, messages in it are copied with their Copy method.
+func cloneValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(v)).Interface()
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if copied, ok := copyMessage(v); ok {
+			return copied
+		}
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(deepCopy(v.Elem()))
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		for i := range v.NumField() {
+			if res.Field(i).CanSet() {
+				res.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			res.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			res.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return res
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(deepCopy(v.Elem()))
+		return res
+	}
+	return v
+}
+
+// copyMessage calls the generated Copy method of a message, which returns the same type.
+func copyMessage(v reflect.Value) (reflect.Value, bool) {
+	if _, ok := v.Interface().(ProtoMessage); !ok {
+		return reflect.Value{}, false
+	}
+	method := v.MethodByName("Copy")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+	return method.Call(nil)[0], true
+}
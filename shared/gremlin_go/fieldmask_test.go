@@ -0,0 +1,332 @@
+package gremlin
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	address := &MessageDescriptor{
+		FullName: "mask_test.Address",
+		Fields:   []*FieldDescriptor{{Name: "city", Number: 1, Kind: KindString, Label: LabelOptional}},
+	}
+	user := &MessageDescriptor{
+		FullName: "mask_test.User",
+		Fields: []*FieldDescriptor{
+			{Name: "name", Number: 1, Kind: KindString, Label: LabelOptional},
+			{Name: "address", Number: 2, Kind: KindMessage, Label: LabelOptional, TypeName: "mask_test.Address", MessageType: address},
+			{Name: "previous", Number: 3, Kind: KindMessage, Label: LabelRepeated, TypeName: "mask_test.Address", MessageType: address},
+			{Name: "nickname", Number: 4, Kind: KindMessage, Label: LabelOptional, TypeName: "google.protobuf.StringValue"},
+		},
+	}
+	fields, err := resolvePath(user, "address.city")
+	if err != nil || len(fields) != 2 || fields[0].Name != "address" || fields[1] != address.Fields[0] {
+		t.Errorf("unexpected fields %v, %v", fields, err)
+	}
+	if err := validateMask(user, FieldPaths{"name", "address", "previous", "nickname"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, path := range []string{"", "age", "address.country", "name.first", "previous.city", "nickname.value", "address."} {
+		if _, err := resolvePath(user, path); err == nil {
+			t.Errorf("path %q should be invalid", path)
+		}
+	}
+
+	if JoinPath("", "name") != "name" || JoinPath("address", "city") != "address.city" {
+		t.Errorf("unexpected joined paths")
+	}
+}
+
+// maskAddress and maskUser are hand-written messages, generated ones live outside of this module.
+type maskAddress struct {
+	City string
+	Tags []string
+}
+
+type maskUser struct {
+	Name     string
+	Address  *maskAddress
+	Previous []*maskAddress
+	Scores   []int32
+	Labels   map[string]string
+	Child    *maskUser
+}
+
+var maskAddressDesc = &MessageDescriptor{
+	FullName: "mask_test.Address",
+	Fields: []*FieldDescriptor{
+		{Name: "city", Number: 1, Kind: KindString, Label: LabelOptional},
+		{Name: "tags", Number: 2, Kind: KindString, Label: LabelRepeated},
+	},
+	New: func() ProtoMessage { return &maskAddress{} },
+}
+
+var maskUserDesc = &MessageDescriptor{
+	FullName: "mask_test.User",
+	Fields: []*FieldDescriptor{
+		{Name: "name", Number: 1, Kind: KindString, Label: LabelOptional},
+		{Name: "address", Number: 2, Kind: KindMessage, Label: LabelOptional, TypeName: "mask_test.Address", MessageType: maskAddressDesc},
+		{Name: "previous", Number: 3, Kind: KindMessage, Label: LabelRepeated, TypeName: "mask_test.Address", MessageType: maskAddressDesc},
+		{Name: "scores", Number: 4, Kind: KindInt32, Label: LabelRepeated},
+		{
+			Name: "labels", Number: 5, Kind: KindMessage, Label: LabelRepeated,
+			MapKey:   &FieldDescriptor{Name: "key", Number: 1, Kind: KindString},
+			MapValue: &FieldDescriptor{Name: "value", Number: 2, Kind: KindString},
+		},
+		{Name: "child", Number: 6, Kind: KindMessage, Label: LabelOptional, TypeName: "mask_test.User"},
+	},
+	New: func() ProtoMessage { return &maskUser{} },
+}
+
+func init() {
+	maskUserDesc.Fields[5].MessageType = maskUserDesc
+}
+
+// maskCopies counts Copy calls, masked copies use them for messages.
+var maskCopies int
+
+func (m *maskAddress) Descriptor() *MessageDescriptor { return maskAddressDesc }
+
+func (m *maskAddress) Unmarshal(data []byte) error { return fmt.Errorf("not supported") }
+
+func (m *maskAddress) Marshal() []byte {
+	w := NewWriter(32)
+	if m.City != "" {
+		w.AppendString(1, m.City)
+	}
+	for _, tag := range m.Tags {
+		w.AppendString(2, tag)
+	}
+	return w.Bytes()
+}
+
+func (m *maskAddress) Copy() *maskAddress {
+	maskCopies++
+	return &maskAddress{City: m.City, Tags: append([]string(nil), m.Tags...)}
+}
+
+func (m *maskAddress) GetField(number ProtoWireNumber) any {
+	switch number {
+	case 1:
+		return m.City
+	case 2:
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *maskAddress) SetField(number ProtoWireNumber, value any) error {
+	var ok bool
+	switch number {
+	case 1:
+		m.City, ok = value.(string)
+	case 2:
+		m.Tags, ok = value.([]string)
+	default:
+		return UnknownFieldError(maskAddressDesc, number)
+	}
+	if !ok {
+		return FieldTypeError(maskAddressDesc, number, value)
+	}
+	return nil
+}
+
+func (m *maskUser) Descriptor() *MessageDescriptor { return maskUserDesc }
+
+func (m *maskUser) Unmarshal(data []byte) error { return fmt.Errorf("not supported") }
+
+func (m *maskUser) Marshal() []byte {
+	w := NewWriter(64)
+	if m.Name != "" {
+		w.AppendString(1, m.Name)
+	}
+	if m.Address != nil {
+		w.AppendBytes(2, m.Address.Marshal())
+	}
+	for _, previous := range m.Previous {
+		w.AppendBytes(3, previous.Marshal())
+	}
+	for _, score := range m.Scores {
+		w.AppendInt32(4, score)
+	}
+	keys := make([]string, 0, len(m.Labels))
+	for key := range m.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entry := NewWriter(16)
+		entry.AppendString(1, key)
+		entry.AppendString(2, m.Labels[key])
+		w.AppendBytes(5, entry.Bytes())
+	}
+	if m.Child != nil {
+		w.AppendBytes(6, m.Child.Marshal())
+	}
+	return w.Bytes()
+}
+
+func (m *maskUser) Copy() *maskUser {
+	maskCopies++
+	res := &maskUser{Name: m.Name, Scores: append([]int32(nil), m.Scores...)}
+	if m.Address != nil {
+		res.Address = m.Address.Copy()
+	}
+	for _, previous := range m.Previous {
+		res.Previous = append(res.Previous, previous.Copy())
+	}
+	if m.Labels != nil {
+		res.Labels = map[string]string{}
+		for k, v := range m.Labels {
+			res.Labels[k] = v
+		}
+	}
+	if m.Child != nil {
+		res.Child = m.Child.Copy()
+	}
+	return res
+}
+
+func (m *maskUser) GetField(number ProtoWireNumber) any {
+	switch number {
+	case 1:
+		return m.Name
+	case 2:
+		return m.Address
+	case 3:
+		return m.Previous
+	case 4:
+		return m.Scores
+	case 5:
+		return m.Labels
+	case 6:
+		return m.Child
+	}
+	return nil
+}
+
+func (m *maskUser) SetField(number ProtoWireNumber, value any) error {
+	var ok bool
+	switch number {
+	case 1:
+		m.Name, ok = value.(string)
+	case 2:
+		m.Address, ok = value.(*maskAddress)
+	case 3:
+		m.Previous, ok = value.([]*maskAddress)
+	case 4:
+		m.Scores, ok = value.([]int32)
+	case 5:
+		m.Labels, ok = value.(map[string]string)
+	case 6:
+		m.Child, ok = value.(*maskUser)
+	default:
+		return UnknownFieldError(maskUserDesc, number)
+	}
+	if !ok {
+		return FieldTypeError(maskUserDesc, number, value)
+	}
+	return nil
+}
+
+func TestCopyMasked(t *testing.T) {
+	src := &maskUser{
+		Name:    "src",
+		Address: &maskAddress{City: "Oslo", Tags: []string{"home"}},
+		Scores:  []int32{3},
+		Labels:  map[string]string{"a": "1"},
+	}
+	dst := &maskUser{
+		Name:    "dst",
+		Address: &maskAddress{City: "Rome", Tags: []string{"work"}},
+		Scores:  []int32{1, 2},
+		Child:   &maskUser{Name: "child", Scores: []int32{7}},
+	}
+	mask := FieldPaths{"address.city", "scores", "labels", "child.name", "previous"}
+	if err := CopyMasked(dst, src, mask); err != nil {
+		t.Fatal(err)
+	}
+	// child is unset in src, so the selected field below it is cleared and the others stay
+	want := &maskUser{
+		Name:    "dst",
+		Address: &maskAddress{City: "Oslo", Tags: []string{"work"}},
+		Scores:  []int32{3},
+		Labels:  map[string]string{"a": "1"},
+		Child:   &maskUser{Scores: []int32{7}},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("unexpected result %+v", dst)
+	}
+	src.Scores[0], src.Labels["a"] = 9, "9"
+	if dst.Scores[0] != 3 || dst.Labels["a"] != "1" {
+		t.Errorf("selected lists and maps should not share memory with the source")
+	}
+
+	copies := maskCopies
+	if err := CopyMasked(dst, src, FieldPaths{"address"}); err != nil {
+		t.Fatal(err)
+	}
+	if maskCopies != copies+1 || dst.Address == src.Address || !reflect.DeepEqual(dst.Address, src.Address) {
+		t.Errorf("messages should be copied with Copy, got %+v", dst.Address)
+	}
+
+	if err := CopyMasked(dst, src, FieldPaths{"address.country"}); err == nil {
+		t.Errorf("unknown paths should be rejected")
+	}
+}
+
+func TestMergeMasked(t *testing.T) {
+	src := &maskUser{
+		Address:  &maskAddress{Tags: []string{"home"}},
+		Previous: []*maskAddress{{City: "Oslo"}},
+		Scores:   []int32{3},
+		Labels:   map[string]string{"b": "2"},
+	}
+	dst := &maskUser{
+		Name:    "dst",
+		Address: &maskAddress{City: "Rome", Tags: []string{"work"}},
+		Scores:  []int32{1, 2},
+		Labels:  map[string]string{"a": "1"},
+	}
+	copies := maskCopies
+	if err := MergeMasked(dst, src, FieldPaths{"name", "address", "previous", "scores", "labels", "child.name"}); err != nil {
+		t.Fatal(err)
+	}
+	// unset fields of src leave dst alone, messages are merged field by field
+	want := &maskUser{
+		Name:     "dst",
+		Address:  &maskAddress{City: "Rome", Tags: []string{"work", "home"}},
+		Previous: []*maskAddress{{City: "Oslo"}},
+		Scores:   []int32{1, 2, 3},
+		Labels:   map[string]string{"a": "1", "b": "2"},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("unexpected result %+v", dst)
+	}
+	if maskCopies != copies+1 || dst.Previous[0] == src.Previous[0] {
+		t.Errorf("appended messages should be copied with Copy")
+	}
+}
+
+func TestMarshalMasked(t *testing.T) {
+	msg := &maskUser{
+		Name:    "user",
+		Address: &maskAddress{City: "Rome", Tags: []string{"work"}},
+		Scores:  []int32{1},
+		Child:   &maskUser{Name: "child", Scores: []int32{7}},
+	}
+	data, err := MarshalMasked(msg, FieldPaths{"address.city", "child.scores"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &maskUser{Address: &maskAddress{City: "Rome"}, Child: &maskUser{Scores: []int32{7}}}
+	if !bytes.Equal(data, want.Marshal()) {
+		t.Errorf("unexpected data %x, expected %x", data, want.Marshal())
+	}
+	if _, err := MarshalMasked(msg, FieldPaths{"scores.x"}); err == nil {
+		t.Errorf("paths into repeated fields should be rejected")
+	}
+}
@@ -0,0 +1,53 @@
+package gremlin
+
+import "math/bits"
+
+// FieldIndex is the position of a field inside its generated message.
+// Generated code exports one constant per field, e.g. FieldUser_Email.
+type FieldIndex int32
+
+// FieldSet is a bitset of field indexes used by projection decoding
+// (UnmarshalFields). Build it once with NewFieldSet and reuse it.
+type FieldSet []uint64
+
+func NewFieldSet(fields ...FieldIndex) FieldSet {
+	var res FieldSet
+	for _, f := range fields {
+		word := int(f) >> 6
+		for len(res) <= word {
+			res = append(res, 0)
+		}
+		res[word] |= 1 << (uint(f) & 63)
+	}
+	return res
+}
+
+func (s FieldSet) Has(f FieldIndex) bool {
+	word := int(f) >> 6
+	if word >= len(s) {
+		return false
+	}
+	return s[word]&(1<<(uint(f)&63)) != 0
+}
+
+func (s FieldSet) Len() int {
+	var res = 0
+	for _, w := range s {
+		res += bits.OnesCount64(w)
+	}
+	return res
+}
+
+// IsSubsetOf reports whether every field of s is also present in other.
+func (s FieldSet) IsSubsetOf(other FieldSet) bool {
+	for i, w := range s {
+		var o uint64
+		if i < len(other) {
+			o = other[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package gremlin
+
+import "testing"
+
+func TestFieldSet(t *testing.T) {
+	set := NewFieldSet(1, 3, 70)
+	for _, f := range []FieldIndex{1, 3, 70} {
+		if !set.Has(f) {
+			t.Errorf("expected field %d in set", f)
+		}
+	}
+	for _, f := range []FieldIndex{0, 2, 64, 71, 200} {
+		if set.Has(f) {
+			t.Errorf("unexpected field %d in set", f)
+		}
+	}
+	if set.Len() != 3 {
+		t.Errorf("expected len 3, got %d", set.Len())
+	}
+}
+
+func TestFieldSetIsSubsetOf(t *testing.T) {
+	all := NewFieldSet(0, 1, 2, 3)
+	if !NewFieldSet(1, 3).IsSubsetOf(all) {
+		t.Errorf("expected subset")
+	}
+	if NewFieldSet(1, 70).IsSubsetOf(all) {
+		t.Errorf("expected not a subset")
+	}
+	if !NewFieldSet().IsSubsetOf(all) {
+		t.Errorf("empty set should be a subset")
+	}
+}
@@ -0,0 +1,50 @@
+// gen-wkt generates the gremlin_go/wkt runtime package from the well-known types bundled with gremlinc:
+//
+//	go run ./cmd/gen-wkt ../wkt
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gen-wkt <output dir>")
+		os.Exit(2)
+	}
+	if err := generate(os.Args[1]); err != nil {
+		fmt.Fprintf(os.Stderr, "gen-wkt: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(out string) error {
+	files, err := internal.WellKnownFiles()
+	if err != nil {
+		return err
+	}
+	if errs := internal.ParseStruct(files); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if errs := internal.ResolveImportsAndReferences(files); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	generated, errs := golang.GenerateFiles("", "", files)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// all files share the go_package of the runtime, so they go into a single directory
+	for _, file := range generated {
+		if err := os.WriteFile(filepath.Join(out, filepath.Base(file.Path)), []byte(file.Content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// protoc-gen-gremlin runs gremlinc as a protoc plugin:
+//
+//	protoc -I protos --gremlin_out=gen --gremlin_opt=module=github.com/user/repo/gen protos/*.proto
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/plugin"
+)
+
+func main() {
+	request, err := io.ReadAll(os.Stdin)
+	if err == nil {
+		var response []byte
+		if response, err = plugin.Run(request); err == nil {
+			_, err = os.Stdout.Write(response)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protoc-gen-gremlin: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/dynamic"
+)
+
+// runCodec implements the decode and encode subcommands, they translate between the wire format
+// on one side and text or JSON on the other, using the schema parsed from -src.
+func runCodec(mode string, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet(mode, flag.ContinueOnError)
+	schemaFlags := addSchemaFlags(fs, "source path where proto files are located")
+	typeName := fs.String("type", "", "full name of the message type (e.g. pkg.Msg)")
+	format := fs.String("format", "text", "format of the decoded side: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *typeName == "" {
+		return fmt.Errorf("missing required flag: -type (full name of the message type)")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q, expected text or json", *format)
+	}
+
+	schema, err := schemaFlags.parse()
+	if err != nil {
+		return err
+	}
+	msg := schema.NewMessage(*typeName)
+	if msg == nil {
+		return fmt.Errorf("message type %v not found in %v", *typeName, *schemaFlags.src)
+	}
+
+	in, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	if mode == "decode" {
+		out, err = decodeMessage(msg, in, *format)
+	} else {
+		out, err = encodeMessage(msg, in, *format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(out)
+	return err
+}
+
+func decodeMessage(msg *dynamic.Message, in []byte, format string) ([]byte, error) {
+	if err := msg.Unmarshal(in); err != nil {
+		return nil, err
+	}
+	if format == "json" {
+		return append(msg.AppendJSON(nil), '\n'), nil
+	}
+	return msg.MarshalText()
+}
+
+func encodeMessage(msg *dynamic.Message, in []byte, format string) ([]byte, error) {
+	var err error
+	if format == "json" {
+		err = msg.UnmarshalJSON(in)
+	} else {
+		err = msg.UnmarshalText(in)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg.Marshal(), nil
+}
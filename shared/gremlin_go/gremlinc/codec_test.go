@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/protobuf_unittest"
+)
+
+func runCodecString(t *testing.T, mode string, in []byte, args ...string) []byte {
+	var out bytes.Buffer
+	args = append([]string{"-src", "testproto", "-type", "protobuf_unittest.TestAllTypes"}, args...)
+	if err := runCodec(mode, args, bytes.NewReader(in), &out); err != nil {
+		t.Fatalf("%v %v: %v", mode, args, err)
+	}
+	return out.Bytes()
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	text := `optional_int32: -5
+optional_string: "hi"
+optional_nested_message: {
+  bb: 3
+}
+optional_nested_enum: BAZ
+repeated_int64: 1
+repeated_int64: 2
+`
+	wire := runCodecString(t, "encode", []byte(text))
+
+	// the generated code reads what the CLI wrote
+	reader := protobuf_unittest.NewTestAllTypesReader()
+	if err := reader.Unmarshal(wire); err != nil {
+		t.Fatal(err)
+	}
+	if reader.GetOptionalInt32() != -5 || reader.GetOptionalString() != "hi" || reader.GetOptionalNestedMessage().GetBb() != 3 ||
+		len(reader.GetRepeatedInt64()) != 2 || reader.GetOptionalNestedEnum() != protobuf_unittest.TestAllTypes_BAZ {
+		t.Errorf("unexpected message %+v", reader.ToStruct())
+	}
+
+	if decoded := runCodecString(t, "decode", wire); string(decoded) != text {
+		t.Errorf("unexpected text\n%s\nexpected\n%v", decoded, text)
+	}
+
+	json := runCodecString(t, "decode", wire, "-format", "json")
+	if again := runCodecString(t, "encode", json, "-format", "json"); !bytes.Equal(again, wire) {
+		t.Errorf("json round trip changed the message: %s", json)
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"-src", "testproto"}, "missing required flag: -type"},
+		{[]string{"-src", "testproto", "-type", "protobuf_unittest.TestAllTypes", "-format", "yaml"}, `unknown format "yaml"`},
+		{[]string{"-src", "testproto", "-type", "protobuf_unittest.Missing"}, "message type protobuf_unittest.Missing not found in testproto"},
+	}
+	for _, test := range tests {
+		err := runCodec("decode", test.args, bytes.NewReader(nil), &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: expected %q, got %v", test.args, test.err, err)
+		}
+	}
+}
@@ -7,4 +7,5 @@ require (
 	github.com/google/go-cmp v0.7.0
 	github.com/logrusorgru/aurora v2.0.3+incompatible
 	github.com/norma-core/norma-core/shared/gremlin_go v0.0.0-20260204105444-743edeb9ba8d
+	google.golang.org/protobuf v1.36.8
 )
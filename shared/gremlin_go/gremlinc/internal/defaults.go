@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// normalizeDefaultValue rewrites a default value the way protoc stores it in descriptors: strings and bytes
+// unescaped, integers in decimal and floats in their shortest form. Sources and descriptors then generate the same code.
+func normalizeDefaultValue(fieldType string, option *proto.Option) *proto.Option {
+	res := *option
+	source := option.Constant.Source
+	var err error
+	switch fieldType {
+	case "string", "bytes":
+		if option.Constant.IsString {
+			source, err = unescapeProtoString(source)
+		}
+	case "int32", "sint32", "sfixed32", "int64", "sint64", "sfixed64":
+		var v int64
+		if v, err = strconv.ParseInt(source, 0, 64); err == nil {
+			source = strconv.FormatInt(v, 10)
+		}
+	case "uint32", "fixed32", "uint64", "fixed64":
+		var v uint64
+		if v, err = strconv.ParseUint(source, 0, 64); err == nil {
+			source = strconv.FormatUint(v, 10)
+		}
+	case "float", "double":
+		source = formatFloatDefault(source, fieldType)
+	}
+	if err != nil {
+		return option
+	}
+	res.Constant.Source = source
+	return &res
+}
+
+// formatFloatDefault follows protoc's SimpleDtoa and SimpleFtoa, inf and nan keep their names.
+func formatFloatDefault(source string, fieldType string) string {
+	switch strings.ToLower(source) {
+	case "inf", "+inf":
+		return "inf"
+	case "-inf":
+		return "-inf"
+	case "nan", "+nan", "-nan":
+		return "nan"
+	}
+	bitSize, precision, fallback := 64, 15, 17
+	if fieldType == "float" {
+		bitSize, precision, fallback = 32, 6, 9
+	}
+	v, err := strconv.ParseFloat(source, bitSize)
+	if err != nil || math.IsInf(v, 0) {
+		return source
+	}
+	res := strconv.FormatFloat(v, 'g', precision, bitSize)
+	if parsed, _ := strconv.ParseFloat(res, bitSize); parsed != v {
+		res = strconv.FormatFloat(v, 'g', fallback, bitSize)
+	}
+	return res
+}
+
+// unescapeProtoString resolves the escapes of a proto string literal.
+func unescapeProtoString(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var res []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			res = append(res, s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("trailing backslash in %q", s)
+		}
+		switch c := s[i]; c {
+		case 'a':
+			res = append(res, '\a')
+		case 'b':
+			res = append(res, '\b')
+		case 'f':
+			res = append(res, '\f')
+		case 'n':
+			res = append(res, '\n')
+		case 'r':
+			res = append(res, '\r')
+		case 't':
+			res = append(res, '\t')
+		case 'v':
+			res = append(res, '\v')
+		case '\\', '\'', '"', '?':
+			res = append(res, c)
+		case 'x', 'X', 'u', 'U':
+			maxDigits := map[byte]int{'x': 2, 'X': 2, 'u': 4, 'U': 8}[c]
+			end := i + 1
+			for end < len(s) && end-i-1 < maxDigits && isHexDigit(s[end]) {
+				end++
+			}
+			if end == i+1 || (c == 'u' || c == 'U') && end-i-1 != maxDigits {
+				return "", fmt.Errorf("invalid escape \\%c in %q", c, s)
+			}
+			v, _ := strconv.ParseUint(s[i+1:end], 16, 32)
+			if c == 'x' || c == 'X' {
+				res = append(res, byte(v))
+			} else {
+				res = append(res, string(rune(v))...)
+			}
+			i = end - 1
+		default:
+			if c < '0' || c > '7' {
+				return "", fmt.Errorf("invalid escape \\%c in %q", c, s)
+			}
+			end := i + 1
+			for end < len(s) && end-i < 3 && s[end] >= '0' && s[end] <= '7' {
+				end++
+			}
+			v, _ := strconv.ParseUint(s[i:end], 8, 32)
+			res = append(res, byte(v))
+			i = end - 1
+		}
+	}
+	return string(res), nil
+}
+
+// escapeProtoString is the inverse of unescapeProtoString, it writes the escapes protoc uses for bytes defaults.
+func escapeProtoString(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\'':
+			sb.WriteString(`\'`)
+		case '\\':
+			sb.WriteString(`\\`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(&sb, `\%03o`, c)
+			} else {
+				sb.WriteByte(c)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
@@ -0,0 +1,787 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+
+	"github.com/emicklei/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// WriteDescriptorSet writes the files as a serialized FileDescriptorSet, like protoc's --descriptor_set_out
+// with --include_imports and --include_source_info.
+func WriteDescriptorSet(path string, files []*types.ProtoFile) error {
+	set, err := BuildDescriptorSet(files)
+	if err != nil {
+		return err
+	}
+	data, err := gproto.Marshal(set)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// BuildDescriptorSet describes resolved proto files as a FileDescriptorSet, every file comes after its imports.
+// Spans of the source info mark where declarations start, the parser does not track where they end.
+func BuildDescriptorSet(files []*types.ProtoFile) (*descriptorpb.FileDescriptorSet, error) {
+	fieldDefs := map[*proto.Field]*types.MessageFieldDefinition{}
+	for _, file := range files {
+		for _, msg := range file.Messages {
+			for _, field := range msg.Fields {
+				fieldDefs[field.ProtoDef] = field
+			}
+		}
+		for _, ext := range file.Extensions {
+			fieldDefs[ext.Field.ProtoDef] = ext.Field
+		}
+	}
+
+	res := &descriptorpb.FileDescriptorSet{}
+	var errs []error
+	added := map[*types.ProtoFile]bool{}
+	var add func(file *types.ProtoFile)
+	add = func(file *types.ProtoFile) {
+		if added[file] {
+			return
+		}
+		added[file] = true
+		for _, imp := range file.Imports {
+			if imp.TargetFile != nil {
+				add(imp.TargetFile)
+			}
+		}
+		b := &descriptorBuilder{file: file, fieldDefs: fieldDefs}
+		res.File = append(res.File, b.buildFile())
+		errs = append(errs, b.errors...)
+	}
+	for _, file := range files {
+		add(file)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to build descriptor set: %w", errors.Join(errs...))
+	}
+	return res, nil
+}
+
+// descriptorName is the name imports use for a file, its path from the import root.
+func descriptorName(file *types.ProtoFile) string {
+	if rel, err := filepath.Rel(file.BaseFolder, file.Path); err == nil && file.BaseFolder != "" {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file.RelativePath)
+}
+
+type descriptorBuilder struct {
+	file      *types.ProtoFile
+	fieldDefs map[*proto.Field]*types.MessageFieldDefinition
+	locations []*descriptorpb.SourceCodeInfo_Location
+	errors    []error
+}
+
+func (b *descriptorBuilder) errorf(format string, args ...any) {
+	b.errors = append(b.errors, fmt.Errorf("%v: "+format, append([]any{b.file.RelativePath}, args...)...))
+}
+
+func (b *descriptorBuilder) packageName() string {
+	if b.file.Package == nil || b.file.Package.ProtoDef == nil {
+		return ""
+	}
+	return b.file.Package.ProtoDef.Name
+}
+
+// addLocation records the source of a declaration, elements mapped from descriptors without source info have none.
+func (b *descriptorBuilder) addLocation(path []int32, pos any) {
+	var leading, trailing *proto.Comment
+	line, column := 0, 0
+	switch v := pos.(type) {
+	case *proto.Message:
+		line, column, leading = v.Position.Line, v.Position.Column, v.Comment
+	case *proto.Enum:
+		line, column, leading = v.Position.Line, v.Position.Column, v.Comment
+	case *proto.EnumField:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	case *proto.Field:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	case *proto.Oneof:
+		line, column, leading = v.Position.Line, v.Position.Column, v.Comment
+	case *proto.Service:
+		line, column, leading = v.Position.Line, v.Position.Column, v.Comment
+	case *proto.RPC:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	case *proto.Import:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	case *proto.Package:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	case *proto.Syntax:
+		line, column, leading, trailing = v.Position.Line, v.Position.Column, v.Comment, v.InlineComment
+	}
+	if line == 0 {
+		return
+	}
+	location := &descriptorpb.SourceCodeInfo_Location{
+		Path: childPath(path),
+		Span: []int32{int32(line - 1), int32(column - 1), int32(column - 1)},
+	}
+	if leading != nil && len(leading.Lines) > 0 {
+		location.LeadingComments = gproto.String(strings.Join(leading.Lines, "\n") + "\n")
+	}
+	if trailing != nil && len(trailing.Lines) > 0 {
+		location.TrailingComments = gproto.String(strings.Join(trailing.Lines, "\n") + "\n")
+	}
+	b.locations = append(b.locations, location)
+}
+
+func (b *descriptorBuilder) buildFile() *descriptorpb.FileDescriptorProto {
+	res := &descriptorpb.FileDescriptorProto{Name: gproto.String(descriptorName(b.file))}
+	scope := ""
+	if b.packageName() != "" {
+		scope = "." + b.packageName()
+	}
+
+	var options []*proto.Option
+	for _, element := range b.file.Parsed.Elements {
+		switch v := element.(type) {
+		case *proto.Syntax:
+			if v.Value != "proto2" {
+				res.Syntax = gproto.String(v.Value)
+			}
+			b.addLocation([]int32{fileSyntaxPath}, v)
+		case *proto.Package:
+			res.Package = gproto.String(v.Name)
+			b.addLocation([]int32{filePackagePath}, v)
+		case *proto.Import:
+			switch v.Kind {
+			case "public":
+				res.PublicDependency = append(res.PublicDependency, int32(len(res.Dependency)))
+			case "weak":
+				res.WeakDependency = append(res.WeakDependency, int32(len(res.Dependency)))
+			}
+			b.addLocation([]int32{fileDependencyPath, int32(len(res.Dependency))}, v)
+			res.Dependency = append(res.Dependency, v.Filename)
+		case *proto.Option:
+			options = append(options, v)
+		case *proto.Message:
+			if v.IsExtend {
+				res.Extension = append(res.Extension, b.buildExtensions(v, scope, []int32{fileExtensionPath}, len(res.Extension))...)
+				continue
+			}
+			res.MessageType = append(res.MessageType,
+				b.buildMessage(v, scope+"."+v.Name, []int32{fileMessagePath, int32(len(res.MessageType))}))
+		case *proto.Enum:
+			res.EnumType = append(res.EnumType, b.buildEnum(v, []int32{fileEnumPath, int32(len(res.EnumType))}))
+		case *proto.Service:
+			res.Service = append(res.Service, b.buildService(v, scope, []int32{fileServicePath, int32(len(res.Service))}))
+		}
+	}
+	if len(options) > 0 {
+		res.Options = &descriptorpb.FileOptions{}
+		b.setOptions(res.Options, options)
+	}
+	if len(b.locations) > 0 {
+		res.SourceCodeInfo = &descriptorpb.SourceCodeInfo{Location: b.locations}
+	}
+	return res
+}
+
+func (b *descriptorBuilder) buildMessage(msg *proto.Message, fullName string, path []int32) *descriptorpb.DescriptorProto {
+	res := &descriptorpb.DescriptorProto{Name: gproto.String(msg.Name)}
+	b.addLocation(path, msg)
+
+	var options []*proto.Option
+	var proto3Optional []*descriptorpb.FieldDescriptorProto
+	addField := func(field *descriptorpb.FieldDescriptorProto, def *proto.Field) {
+		b.addLocation(childPath(path, messageFieldPath, int32(len(res.Field))), def)
+		res.Field = append(res.Field, field)
+	}
+
+	for _, element := range msg.Elements {
+		switch v := element.(type) {
+		case *proto.NormalField:
+			field := b.buildField(v.Field, fullName)
+			switch {
+			case v.Repeated:
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			case v.Required:
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum()
+			case v.Optional && !b.file.Proto2:
+				field.Proto3Optional = gproto.Bool(true)
+				proto3Optional = append(proto3Optional, field)
+			}
+			addField(field, v.Field)
+		case *proto.MapField:
+			entryName := mapEntryName(v.Name)
+			entry := &descriptorpb.DescriptorProto{
+				Name: gproto.String(entryName),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					b.mapEntryField("key", 1, v.KeyType, nil),
+					b.mapEntryField("value", 2, v.Type, b.fieldDefs[v.Field]),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: gproto.Bool(true)},
+			}
+			res.NestedType = append(res.NestedType, entry)
+
+			field := b.buildField(v.Field, fullName)
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			field.TypeName = gproto.String(fullName + "." + entryName)
+			field.DefaultValue = nil
+			addField(field, v.Field)
+		case *proto.Oneof:
+			index := int32(len(res.OneofDecl))
+			oneof := &descriptorpb.OneofDescriptorProto{Name: gproto.String(v.Name)}
+			b.addLocation(childPath(path, messageOneofPath, index), v)
+			var oneofOptions []*proto.Option
+			for _, element := range v.Elements {
+				switch f := element.(type) {
+				case *proto.OneOfField:
+					field := b.buildField(f.Field, fullName)
+					field.OneofIndex = gproto.Int32(index)
+					addField(field, f.Field)
+				case *proto.Option:
+					oneofOptions = append(oneofOptions, f)
+				}
+			}
+			if len(oneofOptions) > 0 {
+				oneof.Options = &descriptorpb.OneofOptions{}
+				b.setOptions(oneof.Options, oneofOptions)
+			}
+			res.OneofDecl = append(res.OneofDecl, oneof)
+		case *proto.Group:
+			b.errorf("group %v in %v is not supported", v.Name, strings.TrimPrefix(fullName, "."))
+		case *proto.Message:
+			if v.IsExtend {
+				res.Extension = append(res.Extension, b.buildExtensions(v, fullName, childPath(path, messageExtensionPath), len(res.Extension))...)
+				continue
+			}
+			res.NestedType = append(res.NestedType,
+				b.buildMessage(v, fullName+"."+v.Name, childPath(path, messageNestedPath, int32(len(res.NestedType)))))
+		case *proto.Enum:
+			res.EnumType = append(res.EnumType, b.buildEnum(v, childPath(path, messageEnumPath, int32(len(res.EnumType)))))
+		case *proto.Option:
+			options = append(options, v)
+		case *proto.Extensions:
+			for _, r := range v.Ranges {
+				end := r.To
+				if r.Max {
+					end = types.MaxFieldNumber
+				}
+				res.ExtensionRange = append(res.ExtensionRange, &descriptorpb.DescriptorProto_ExtensionRange{
+					Start: gproto.Int32(int32(r.From)),
+					End:   gproto.Int32(int32(end) + 1),
+				})
+			}
+		case *proto.Reserved:
+			for _, r := range v.Ranges {
+				end := r.To
+				if r.Max {
+					end = types.MaxFieldNumber
+				}
+				res.ReservedRange = append(res.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+					Start: gproto.Int32(int32(r.From)),
+					End:   gproto.Int32(int32(end) + 1),
+				})
+			}
+			res.ReservedName = append(res.ReservedName, v.FieldNames...)
+		}
+	}
+
+	// protoc wraps every proto3 optional field in its own oneof, declared after the real ones
+	for _, field := range proto3Optional {
+		field.OneofIndex = gproto.Int32(int32(len(res.OneofDecl)))
+		res.OneofDecl = append(res.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: gproto.String("_" + field.GetName())})
+	}
+
+	if len(options) > 0 {
+		res.Options = &descriptorpb.MessageOptions{}
+		b.setOptions(res.Options, options)
+	}
+	return res
+}
+
+// mapEntryName follows protoc: the field name in CamelCase with an Entry suffix.
+func mapEntryName(fieldName string) string {
+	var sb strings.Builder
+	upper := true
+	for _, c := range fieldName {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		sb.WriteRune(c)
+	}
+	sb.WriteString("Entry")
+	return sb.String()
+}
+
+func (b *descriptorBuilder) mapEntryField(name string, number int32, typeName string, def *types.MessageFieldDefinition) *descriptorpb.FieldDescriptorProto {
+	res := &descriptorpb.FieldDescriptorProto{
+		Name:     gproto.String(name),
+		Number:   gproto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: gproto.String(name),
+	}
+	b.setFieldType(res, typeName, def)
+	return res
+}
+
+// buildExtensions returns the fields of an extend block, first is the number of extensions declared before it.
+func (b *descriptorBuilder) buildExtensions(msg *proto.Message, scope string, path []int32, first int) []*descriptorpb.FieldDescriptorProto {
+	extendee := b.resolveMessage(scope, msg.Name)
+	if extendee == "" {
+		if !strings.HasPrefix(msg.Name, "google.protobuf.") {
+			b.errorf("failed to resolve extend source %v", msg.Name)
+		}
+		extendee = "." + strings.TrimPrefix(msg.Name, ".")
+	}
+
+	var res []*descriptorpb.FieldDescriptorProto
+	for _, element := range msg.Elements {
+		v, ok := element.(*proto.NormalField)
+		if !ok {
+			continue
+		}
+		field := b.buildField(v.Field, scope)
+		field.Extendee = gproto.String(extendee)
+		switch {
+		case v.Repeated:
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		case v.Required:
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum()
+		}
+		b.addLocation(childPath(path, int32(first+len(res))), v.Field)
+		res = append(res, field)
+	}
+	return res
+}
+
+func (b *descriptorBuilder) buildField(field *proto.Field, scope string) *descriptorpb.FieldDescriptorProto {
+	def := b.fieldDefs[field]
+	res := &descriptorpb.FieldDescriptorProto{
+		Name:   gproto.String(field.Name),
+		Number: gproto.Int32(int32(field.Sequence)),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	b.setFieldType(res, field.Type, def)
+
+	var options []*proto.Option
+	for _, option := range field.Options {
+		if option.Name != "default" && option.Name != "json_name" {
+			options = append(options, option)
+		}
+	}
+	if len(options) > 0 {
+		res.Options = &descriptorpb.FieldOptions{}
+		b.setOptions(res.Options, options)
+	}
+
+	if def == nil {
+		b.errorf("field %v in %v was not resolved", field.Name, strings.TrimPrefix(scope, "."))
+		return res
+	}
+	res.JsonName = gproto.String(def.JSONName())
+	if def.DefaultValue != nil {
+		value := def.DefaultValue.Constant.Source
+		if res.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			value = escapeProtoString(value)
+		}
+		res.DefaultValue = gproto.String(value)
+	}
+	return res
+}
+
+// setFieldType sets the type of a scalar type name, or of the type the field definition was resolved to.
+func (b *descriptorBuilder) setFieldType(res *descriptorpb.FieldDescriptorProto, typeName string, def *types.MessageFieldDefinition) {
+	if _, isScalar := types.ProtobufScalarTypes[typeName]; isScalar {
+		t := descriptorpb.FieldDescriptorProto_Type(descriptorpb.FieldDescriptorProto_Type_value["TYPE_"+strings.ToUpper(typeName)])
+		res.Type = t.Enum()
+		return
+	}
+	switch {
+	case def == nil:
+	case def.LocalMsgType != nil:
+		res.Type, res.TypeName = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), gproto.String("."+def.LocalMsgType.Name.String())
+	case def.ExternalMsgType != nil:
+		res.Type, res.TypeName = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), gproto.String("."+def.ExternalMsgType.Name.String())
+	case def.LocalEnumType != nil:
+		res.Type, res.TypeName = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(), gproto.String("."+def.LocalEnumType.Name.String())
+	case def.ExternalEnumType != nil:
+		res.Type, res.TypeName = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(), gproto.String("."+def.ExternalEnumType.Name.String())
+	}
+}
+
+func (b *descriptorBuilder) buildEnum(enum *proto.Enum, path []int32) *descriptorpb.EnumDescriptorProto {
+	res := &descriptorpb.EnumDescriptorProto{Name: gproto.String(enum.Name)}
+	b.addLocation(path, enum)
+
+	var options []*proto.Option
+	for _, element := range enum.Elements {
+		switch v := element.(type) {
+		case *proto.EnumField:
+			value := &descriptorpb.EnumValueDescriptorProto{
+				Name:   gproto.String(v.Name),
+				Number: gproto.Int32(int32(v.Integer)),
+			}
+			var valueOptions []*proto.Option
+			for _, element := range v.Elements {
+				if option, ok := element.(*proto.Option); ok {
+					valueOptions = append(valueOptions, option)
+				}
+			}
+			if len(valueOptions) > 0 {
+				value.Options = &descriptorpb.EnumValueOptions{}
+				b.setOptions(value.Options, valueOptions)
+			}
+			b.addLocation(childPath(path, enumValuePath, int32(len(res.Value))), v)
+			res.Value = append(res.Value, value)
+		case *proto.Option:
+			options = append(options, v)
+		case *proto.Reserved:
+			for _, r := range v.Ranges {
+				end := r.To
+				if r.Max {
+					end = math.MaxInt32
+				}
+				res.ReservedRange = append(res.ReservedRange, &descriptorpb.EnumDescriptorProto_EnumReservedRange{
+					Start: gproto.Int32(int32(r.From)),
+					End:   gproto.Int32(int32(end)),
+				})
+			}
+			res.ReservedName = append(res.ReservedName, v.FieldNames...)
+		}
+	}
+	if len(options) > 0 {
+		res.Options = &descriptorpb.EnumOptions{}
+		b.setOptions(res.Options, options)
+	}
+	return res
+}
+
+func (b *descriptorBuilder) buildService(service *proto.Service, scope string, path []int32) *descriptorpb.ServiceDescriptorProto {
+	res := &descriptorpb.ServiceDescriptorProto{Name: gproto.String(service.Name)}
+	b.addLocation(path, service)
+
+	var options []*proto.Option
+	for _, element := range service.Elements {
+		switch v := element.(type) {
+		case *proto.RPC:
+			method := &descriptorpb.MethodDescriptorProto{
+				Name:       gproto.String(v.Name),
+				InputType:  gproto.String(b.resolveMessage(scope, v.RequestType)),
+				OutputType: gproto.String(b.resolveMessage(scope, v.ReturnsType)),
+			}
+			if method.GetInputType() == "" || method.GetOutputType() == "" {
+				b.errorf("failed to resolve types of rpc %v.%v", service.Name, v.Name)
+			}
+			if v.StreamsRequest {
+				method.ClientStreaming = gproto.Bool(true)
+			}
+			if v.StreamsReturns {
+				method.ServerStreaming = gproto.Bool(true)
+			}
+			var methodOptions []*proto.Option
+			for _, element := range v.Elements {
+				if option, ok := element.(*proto.Option); ok {
+					methodOptions = append(methodOptions, option)
+				}
+			}
+			if len(methodOptions) > 0 {
+				method.Options = &descriptorpb.MethodOptions{}
+				b.setOptions(method.Options, methodOptions)
+			}
+			b.addLocation(childPath(path, serviceMethodPath, int32(len(res.Method))), v)
+			res.Method = append(res.Method, method)
+		case *proto.Option:
+			options = append(options, v)
+		}
+	}
+	if len(options) > 0 {
+		res.Options = &descriptorpb.ServiceOptions{}
+		b.setOptions(res.Options, options)
+	}
+	return res
+}
+
+// resolveMessage finds the full name of a message referenced from scope, the way protoc does:
+// from the innermost scope outwards, in the file and in its imports. It returns "" if there is none.
+func (b *descriptorBuilder) resolveMessage(scope string, name string) string {
+	if strings.HasPrefix(name, ".") {
+		if b.isVisibleMessage(name) {
+			return name
+		}
+		return ""
+	}
+	for {
+		candidate := scope + "." + name
+		if b.isVisibleMessage(candidate) {
+			return candidate
+		}
+		if scope == "" {
+			return ""
+		}
+		scope = scope[:max(strings.LastIndex(scope, "."), 0)]
+	}
+}
+
+func (b *descriptorBuilder) isVisibleMessage(fullName string) bool {
+	if declaresMessage(b.file, fullName) {
+		return true
+	}
+	for _, imp := range b.file.Imports {
+		if imp.TargetFile != nil && declaresMessage(imp.TargetFile, fullName) {
+			return true
+		}
+	}
+	return false
+}
+
+// declaresMessage looks through the declarations of the file, extend blocks may have replaced definitions of the model.
+func declaresMessage(file *types.ProtoFile, fullName string) bool {
+	scope := ""
+	if file.Package != nil && file.Package.ProtoDef != nil {
+		scope = "." + file.Package.ProtoDef.Name
+	}
+	var find func(elements []proto.Visitee, scope string) bool
+	find = func(elements []proto.Visitee, scope string) bool {
+		for _, element := range elements {
+			msg, ok := element.(*proto.Message)
+			if !ok || msg.IsExtend {
+				continue
+			}
+			name := scope + "." + msg.Name
+			if name == fullName || strings.HasPrefix(fullName, name+".") && find(msg.Elements, name) {
+				return true
+			}
+		}
+		return false
+	}
+	return find(file.Parsed.Elements, scope)
+}
+
+// setOptions sets the options of a descriptor. Custom options are written as unknown fields of the options
+// message, their extensions must be declared with a scalar or enum type.
+func (b *descriptorBuilder) setOptions(options gproto.Message, list []*proto.Option) {
+	msg := options.ProtoReflect()
+	for _, option := range list {
+		if strings.HasPrefix(option.Name, "(") {
+			b.setCustomOption(msg, option)
+			continue
+		}
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(option.Name))
+		if fd == nil || fd.IsMap() || fd.Kind() == protoreflect.MessageKind {
+			b.errorf("unsupported option %v", option.Name)
+			continue
+		}
+		value, err := optionValue(fd.Kind(), option.Constant, fd.Enum())
+		if err != nil {
+			b.errorf("option %v: %v", option.Name, err)
+			continue
+		}
+		// repeated options are set once per value
+		if fd.IsList() {
+			msg.Mutable(fd).List().Append(value)
+			continue
+		}
+		msg.Set(fd, value)
+	}
+}
+
+func optionValue(kind protoreflect.Kind, literal proto.Literal, enum protoreflect.EnumDescriptor) (protoreflect.Value, error) {
+	source := literal.Source
+	switch kind {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(source)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.EnumKind:
+		value := enum.Values().ByName(protoreflect.Name(source))
+		if value == nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value %v of %v", source, enum.FullName())
+		}
+		return protoreflect.ValueOfEnum(value.Number()), nil
+	case protoreflect.StringKind:
+		v, err := unescapeProtoString(source)
+		return protoreflect.ValueOfString(v), err
+	case protoreflect.BytesKind:
+		v, err := unescapeProtoString(source)
+		return protoreflect.ValueOfBytes([]byte(v)), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(source, 0, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(source, 0, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(source, 0, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(source, 0, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := parseFloatOption(source, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := parseFloatOption(source, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported type %v", kind)
+}
+
+func parseFloatOption(source string, bitSize int) (float64, error) {
+	switch strings.ToLower(source) {
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(source, bitSize)
+}
+
+func (b *descriptorBuilder) setCustomOption(msg protoreflect.Message, option *proto.Option) {
+	name := strings.TrimSuffix(strings.TrimPrefix(option.Name, "("), ")")
+	extension, def := b.findOptionExtension(string(msg.Descriptor().FullName()), name)
+	if extension == nil {
+		b.errorf("unknown option %v", option.Name)
+		return
+	}
+
+	if def != nil && (def.LocalEnumType != nil || def.ExternalEnumType != nil) {
+		enumDef := def.LocalEnumType
+		if enumDef == nil {
+			enumDef = def.ExternalEnumType
+		}
+		var number int
+		found := false
+		for _, value := range enumDef.Values {
+			if value.Name.ProtoName() == option.Constant.Source {
+				number, found = value.Value, true
+			}
+		}
+		if !found {
+			b.errorf("option %v: unknown value %v of %v", option.Name, option.Constant.Source, enumDef.Name.String())
+			return
+		}
+		msg.SetUnknown(appendOptionValue(msg.GetUnknown(), extension.Sequence, protoreflect.Int32Kind, protoreflect.ValueOfInt32(int32(number))))
+		return
+	}
+	t, ok := descriptorpb.FieldDescriptorProto_Type_value["TYPE_"+strings.ToUpper(extension.Type)]
+	if !ok {
+		b.errorf("option %v: unsupported type %v", option.Name, extension.Type)
+		return
+	}
+	kind := protoreflect.Kind(t)
+	value, err := optionValue(kind, option.Constant, nil)
+	if err != nil {
+		b.errorf("option %v: %v", option.Name, err)
+		return
+	}
+	msg.SetUnknown(appendOptionValue(msg.GetUnknown(), extension.Sequence, kind, value))
+}
+
+// findOptionExtension finds the extension of an options message named by a custom option,
+// in the file or its imports. The name may leave out leading scopes.
+func (b *descriptorBuilder) findOptionExtension(optionsType string, name string) (*proto.Field, *types.MessageFieldDefinition) {
+	files := []*types.ProtoFile{b.file}
+	for _, imp := range b.file.Imports {
+		if imp.TargetFile != nil {
+			files = append(files, imp.TargetFile)
+		}
+	}
+	name = strings.TrimPrefix(name, ".")
+	for _, file := range files {
+		var res *proto.Field
+		proto.Walk(file.Parsed, func(v proto.Visitee) {
+			msg, ok := v.(*proto.Message)
+			if !ok || !msg.IsExtend || strings.TrimPrefix(msg.Name, ".") != optionsType &&
+				strings.TrimPrefix(msg.Name, ".") != strings.TrimPrefix(optionsType, "google.protobuf.") {
+				return
+			}
+			scope := extendScope(file, msg)
+			for _, element := range msg.Elements {
+				field, ok := element.(*proto.NormalField)
+				if !ok {
+					continue
+				}
+				fullName := strings.TrimPrefix(scope+"."+field.Name, ".")
+				if fullName == name || strings.HasSuffix(fullName, "."+name) {
+					res = field.Field
+				}
+			}
+		})
+		if res != nil {
+			return res, b.fieldDefs[res]
+		}
+	}
+	return nil, nil
+}
+
+// extendScope is the full name of the scope an extend block is declared in, its extensions are named in it.
+func extendScope(file *types.ProtoFile, msg *proto.Message) string {
+	var names []string
+	for parent, ok := msg.Parent.(*proto.Message); ok; parent, ok = parent.Parent.(*proto.Message) {
+		names = append([]string{parent.Name}, names...)
+	}
+	if file.Package != nil && file.Package.ProtoDef != nil {
+		names = append([]string{file.Package.ProtoDef.Name}, names...)
+	}
+	return strings.Join(names, ".")
+}
+
+// appendOptionValue appends a scalar option value the way an extension field of the options message is encoded.
+func appendOptionValue(b []byte, number int, kind protoreflect.Kind, value protoreflect.Value) []byte {
+	num := protowire.Number(number)
+	switch kind {
+	case protoreflect.BoolKind:
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(value.Bool()))
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(value.Int()))
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, value.Uint())
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(value.Int()))
+	case protoreflect.Fixed32Kind:
+		b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+		return protowire.AppendFixed32(b, uint32(value.Uint()))
+	case protoreflect.Sfixed32Kind:
+		b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+		return protowire.AppendFixed32(b, uint32(value.Int()))
+	case protoreflect.FloatKind:
+		b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+		return protowire.AppendFixed32(b, math.Float32bits(float32(value.Float())))
+	case protoreflect.Fixed64Kind:
+		b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, value.Uint())
+	case protoreflect.Sfixed64Kind:
+		b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, uint64(value.Int()))
+	case protoreflect.DoubleKind:
+		b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, math.Float64bits(value.Float()))
+	case protoreflect.StringKind:
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendString(b, value.String())
+	case protoreflect.BytesKind:
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, value.Bytes())
+	}
+	return b
+}
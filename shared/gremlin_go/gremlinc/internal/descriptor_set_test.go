@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+func resolveTestProtoFiles(t *testing.T) []*types.ProtoFile {
+	targets, err := FindAllProtobufFiles("../testproto", DefaultIgnorePatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseProtoFiles(targets); err != nil {
+		t.Fatal(err)
+	}
+	targets, err = AddWellKnownTypes(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ParseStruct(targets); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(targets); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return targets
+}
+
+// The descriptors built from sources must be the ones protoc compiled for the plugin request.
+func TestDescriptorSetMatchesProtoc(t *testing.T) {
+	set, err := BuildDescriptorSet(resolveTestProtoFiles(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := gproto.Unmarshal(getTestFileContent("plugin_request"), req); err != nil {
+		t.Fatal(err)
+	}
+	built := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, file := range set.File {
+		built[file.GetName()] = file
+	}
+	for _, expected := range req.ProtoFile {
+		file := built[expected.GetName()]
+		if file == nil {
+			t.Errorf("%v is missing", expected.GetName())
+			continue
+		}
+		// spans of the request were not written by protoc
+		if diff := cmp.Diff(expected, file, protocmp.Transform(),
+			protocmp.IgnoreFields(&descriptorpb.FileDescriptorProto{}, "source_code_info")); diff != "" {
+			t.Errorf("%v differs from protoc (-want +got):\n%v", expected.GetName(), diff)
+		}
+	}
+
+	// imports come first
+	seen := map[string]bool{}
+	for _, file := range set.File {
+		for _, dependency := range file.Dependency {
+			if !seen[dependency] {
+				t.Errorf("%v comes before its import %v", file.GetName(), dependency)
+			}
+		}
+		seen[file.GetName()] = true
+	}
+}
+
+// Written descriptor sets must read back into the same model.
+func TestDescriptorSetRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testproto.binpb")
+	if err := WriteDescriptorSet(path, resolveTestProtoFiles(t)); err != nil {
+		t.Fatal(err)
+	}
+	set, err := BuildDescriptorSet(resolveTestProtoFiles(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ReadDescriptorSets([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	roundTrip, err := BuildDescriptorSet(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(set, roundTrip, protocmp.Transform()); diff != "" {
+		t.Errorf("descriptor set changed in a round trip (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,707 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+
+	"github.com/emicklei/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DescriptorRoot is the virtual folder compiled descriptors are placed in, their imports are resolved by descriptor name.
+const DescriptorRoot = string(filepath.Separator)
+
+// field numbers of descriptor.proto, used for source code info paths
+const (
+	filePackagePath      = 2
+	fileDependencyPath   = 3
+	fileMessagePath      = 4
+	fileEnumPath         = 5
+	fileServicePath      = 6
+	fileExtensionPath    = 7
+	fileSyntaxPath       = 12
+	messageFieldPath     = 2
+	messageNestedPath    = 3
+	messageEnumPath      = 4
+	messageExtensionPath = 6
+	messageOneofPath     = 8
+	enumValuePath        = 2
+	serviceMethodPath    = 2
+)
+
+// ProtoFilesFromDescriptors maps compiled file descriptors to proto files, as if every one was parsed from its source.
+// The result goes through ParseStruct and ResolveImportsAndReferences like parsed files do.
+func ProtoFilesFromDescriptors(files []*descriptorpb.FileDescriptorProto) ([]*types.ProtoFile, error) {
+	schema := newDescriptorSchema(files)
+	var res []*types.ProtoFile
+	for _, file := range files {
+		if file.GetName() == "" {
+			return nil, fmt.Errorf("file descriptor without a name")
+		}
+		// protoc's copies of the well-known types would generate code of their own go_package
+		if IsWellKnown(file.GetName()) {
+			wellKnown, err := parseWellKnown(file.GetName())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, wellKnown)
+			continue
+		}
+		res = append(res, &types.ProtoFile{
+			Path:         filepath.Join(DescriptorRoot, file.GetName()),
+			RelativePath: file.GetName(),
+			Parsed:       newDescriptorMapper(file, schema).mapFile(),
+			BaseFolder:   DescriptorRoot,
+		})
+	}
+	return res, nil
+}
+
+// ReadDescriptorSets loads serialized FileDescriptorSets, like protoc's --descriptor_set_in.
+// A file may be in several sets as long as it is the same everywhere.
+func ReadDescriptorSets(paths []string) ([]*types.ProtoFile, error) {
+	var files []*descriptorpb.FileDescriptorProto
+	seen := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := gproto.Unmarshal(data, set); err != nil {
+			return nil, fmt.Errorf("failed to parse descriptor set %v: %w", path, err)
+		}
+		for _, file := range set.File {
+			if prev, found := seen[file.GetName()]; found {
+				if !gproto.Equal(prev, file) {
+					return nil, fmt.Errorf("%v in %v differs from a previous descriptor set", file.GetName(), path)
+				}
+				continue
+			}
+			seen[file.GetName()] = file
+			files = append(files, file)
+		}
+	}
+	return ProtoFilesFromDescriptors(files)
+}
+
+type descriptorMapper struct {
+	file      *descriptorpb.FileDescriptorProto
+	schema    *descriptorSchema
+	locations map[string]*descriptorpb.SourceCodeInfo_Location
+}
+
+func newDescriptorMapper(file *descriptorpb.FileDescriptorProto, schema *descriptorSchema) *descriptorMapper {
+	res := &descriptorMapper{file: file, schema: schema, locations: map[string]*descriptorpb.SourceCodeInfo_Location{}}
+	for _, location := range file.GetSourceCodeInfo().GetLocation() {
+		res.locations[locationKey(location.Path)] = location
+	}
+	return res
+}
+
+func locationKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+func childPath(path []int32, elements ...int32) []int32 {
+	res := make([]int32, 0, len(path)+len(elements))
+	return append(append(res, path...), elements...)
+}
+
+// position is the start of the element in its source, zero if the descriptor has no source info.
+func (d *descriptorMapper) position(path []int32) scanner.Position {
+	location := d.locations[locationKey(path)]
+	if location == nil || len(location.Span) < 2 {
+		return scanner.Position{}
+	}
+	return scanner.Position{Filename: d.file.GetName(), Line: int(location.Span[0]) + 1, Column: int(location.Span[1]) + 1}
+}
+
+func (d *descriptorMapper) comment(path []int32) *proto.Comment {
+	location := d.locations[locationKey(path)]
+	if location.GetLeadingComments() == "" {
+		return nil
+	}
+	text := strings.TrimSuffix(location.GetLeadingComments(), "\n")
+	return &proto.Comment{Position: d.position(path), Lines: strings.Split(text, "\n")}
+}
+
+func (d *descriptorMapper) inlineComment(path []int32) *proto.Comment {
+	location := d.locations[locationKey(path)]
+	if location.GetTrailingComments() == "" {
+		return nil
+	}
+	text := strings.TrimSuffix(location.GetTrailingComments(), "\n")
+	return &proto.Comment{Position: d.position(path), Lines: strings.Split(text, "\n")}
+}
+
+// sortBySource restores the source order of elements which descriptors keep in separate lists.
+func sortBySource(elements []proto.Visitee, positions []scanner.Position) {
+	for _, pos := range positions {
+		if pos.Line == 0 {
+			return
+		}
+	}
+	indexes := make([]int, len(elements))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := positions[indexes[i]], positions[indexes[j]]
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	sorted := make([]proto.Visitee, len(elements))
+	for i, index := range indexes {
+		sorted[i] = elements[index]
+	}
+	copy(elements, sorted)
+}
+
+func (d *descriptorMapper) mapFile() *proto.Proto {
+	file := d.file
+	res := &proto.Proto{Filename: file.GetName()}
+
+	syntax := file.GetSyntax()
+	if syntax == "" {
+		syntax = "proto2"
+	}
+	var header []proto.Visitee
+	var headerPositions []scanner.Position
+	syntaxPath := []int32{fileSyntaxPath}
+	header = append(header, &proto.Syntax{
+		Value:         syntax,
+		Position:      d.position(syntaxPath),
+		Comment:       d.comment(syntaxPath),
+		InlineComment: d.inlineComment(syntaxPath),
+		Parent:        res,
+	})
+	headerPositions = append(headerPositions, d.position(syntaxPath))
+
+	if file.Package != nil {
+		packagePath := []int32{filePackagePath}
+		header = append(header, &proto.Package{
+			Name:          file.GetPackage(),
+			Position:      d.position(packagePath),
+			Comment:       d.comment(packagePath),
+			InlineComment: d.inlineComment(packagePath),
+			Parent:        res,
+		})
+		headerPositions = append(headerPositions, d.position(packagePath))
+	}
+
+	kinds := map[int32]string{}
+	for _, i := range file.PublicDependency {
+		kinds[i] = "public"
+	}
+	for _, i := range file.WeakDependency {
+		kinds[i] = "weak"
+	}
+	for i, dependency := range file.Dependency {
+		importPath := []int32{fileDependencyPath, int32(i)}
+		header = append(header, &proto.Import{
+			Filename:      dependency,
+			Kind:          kinds[int32(i)],
+			Position:      d.position(importPath),
+			Comment:       d.comment(importPath),
+			InlineComment: d.inlineComment(importPath),
+			Parent:        res,
+		})
+		headerPositions = append(headerPositions, d.position(importPath))
+	}
+	sortBySource(header, headerPositions)
+	res.Elements = append(res.Elements, header...)
+
+	for _, option := range d.mapOptions(file.Options) {
+		option.Parent = res
+		res.Elements = append(res.Elements, option)
+	}
+
+	scope := ""
+	if file.GetPackage() != "" {
+		scope = "." + file.GetPackage()
+	}
+	var elements []proto.Visitee
+	var positions []scanner.Position
+	for i, message := range file.MessageType {
+		path := []int32{fileMessagePath, int32(i)}
+		elements = append(elements, d.mapMessage(message, scope+"."+message.GetName(), path, res))
+		positions = append(positions, d.position(path))
+	}
+	for i, enum := range file.EnumType {
+		path := []int32{fileEnumPath, int32(i)}
+		elements = append(elements, d.mapEnum(enum, path, res))
+		positions = append(positions, d.position(path))
+	}
+	extends, extendPositions := d.mapExtensions(file.Extension, []int32{fileExtensionPath}, res)
+	elements = append(elements, extends...)
+	positions = append(positions, extendPositions...)
+	for i, service := range file.Service {
+		path := []int32{fileServicePath, int32(i)}
+		elements = append(elements, d.mapService(service, path, res))
+		positions = append(positions, d.position(path))
+	}
+
+	sortBySource(elements, positions)
+	res.Elements = append(res.Elements, elements...)
+	return res
+}
+
+// mapMessage maps a message, fullName is its name with the package and a leading dot, as fields reference it.
+func (d *descriptorMapper) mapMessage(message *descriptorpb.DescriptorProto, fullName string, path []int32, parent proto.Visitee) proto.Visitee {
+	res := &proto.Message{
+		Name:     message.GetName(),
+		Position: d.position(path),
+		Comment:  d.comment(path),
+		Parent:   parent,
+	}
+
+	mapEntries := map[string]*descriptorpb.DescriptorProto{}
+	groups := map[string]bool{}
+	for _, field := range message.Field {
+		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			groups[field.GetTypeName()] = true
+		}
+	}
+
+	var elements []proto.Visitee
+	var positions []scanner.Position
+	for i, nested := range message.NestedType {
+		typeName := fullName + "." + nested.GetName()
+		if nested.GetOptions().GetMapEntry() {
+			mapEntries[typeName] = nested
+			continue
+		}
+		if groups[typeName] {
+			continue
+		}
+		nestedPath := childPath(path, messageNestedPath, int32(i))
+		elements = append(elements, d.mapMessage(nested, typeName, nestedPath, res))
+		positions = append(positions, d.position(nestedPath))
+	}
+	for i, enum := range message.EnumType {
+		enumPath := childPath(path, messageEnumPath, int32(i))
+		elements = append(elements, d.mapEnum(enum, enumPath, res))
+		positions = append(positions, d.position(enumPath))
+	}
+
+	oneofs := map[int32]*proto.Oneof{}
+	for i, field := range message.Field {
+		fieldPath := childPath(path, messageFieldPath, int32(i))
+		var element proto.Visitee
+		switch {
+		case field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+			element = &proto.Group{
+				Name:     field.GetTypeName()[strings.LastIndex(field.GetTypeName(), ".")+1:],
+				Sequence: int(field.GetNumber()),
+				Position: d.position(fieldPath),
+				Parent:   res,
+			}
+		case mapEntries[field.GetTypeName()] != nil:
+			entry := mapEntries[field.GetTypeName()]
+			element = &proto.MapField{
+				Field:   d.mapField(field, fieldPath, res, fieldTypeName(entry.Field[1])),
+				KeyType: fieldTypeName(entry.Field[0]),
+			}
+		case field.OneofIndex != nil && !field.GetProto3Optional():
+			oneof := oneofs[field.GetOneofIndex()]
+			if oneof == nil {
+				oneofPath := childPath(path, messageOneofPath, field.GetOneofIndex())
+				oneof = &proto.Oneof{
+					Name:     message.OneofDecl[field.GetOneofIndex()].GetName(),
+					Position: d.position(oneofPath),
+					Comment:  d.comment(oneofPath),
+					Parent:   res,
+				}
+				for _, option := range d.mapOptions(message.OneofDecl[field.GetOneofIndex()].Options) {
+					option.Parent = oneof
+					oneof.Elements = append(oneof.Elements, option)
+				}
+				oneofs[field.GetOneofIndex()] = oneof
+				element = oneof
+			}
+			oneof.Elements = append(oneof.Elements, &proto.OneOfField{
+				Field: d.mapField(field, fieldPath, oneof, fieldTypeName(field)),
+			})
+		default:
+			element = &proto.NormalField{
+				Field:    d.mapField(field, fieldPath, res, fieldTypeName(field)),
+				Repeated: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+				Optional: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL &&
+					(d.file.GetSyntax() != "proto3" || field.GetProto3Optional()),
+				Required: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED,
+			}
+		}
+		if element != nil {
+			elements = append(elements, element)
+			positions = append(positions, d.position(fieldPath))
+		}
+	}
+
+	extends, extendPositions := d.mapExtensions(message.Extension, childPath(path, messageExtensionPath), res)
+	elements = append(elements, extends...)
+	positions = append(positions, extendPositions...)
+
+	sortBySource(elements, positions)
+	for _, option := range d.mapOptions(message.Options) {
+		option.Parent = res
+		res.Elements = append(res.Elements, option)
+	}
+	if len(message.ExtensionRange) > 0 {
+		extensions := &proto.Extensions{Parent: res}
+		for _, r := range message.ExtensionRange {
+			extensions.Ranges = append(extensions.Ranges, fieldRange(r.GetStart(), r.GetEnd()))
+		}
+		res.Elements = append(res.Elements, extensions)
+	}
+	if len(message.ReservedRange) > 0 || len(message.ReservedName) > 0 {
+		reserved := &proto.Reserved{FieldNames: message.ReservedName, Parent: res}
+		for _, r := range message.ReservedRange {
+			reserved.Ranges = append(reserved.Ranges, fieldRange(r.GetStart(), r.GetEnd()))
+		}
+		res.Elements = append(res.Elements, reserved)
+	}
+	res.Elements = append(elements, res.Elements...)
+	return res
+}
+
+// fieldRange maps an exclusive range of field numbers to the inclusive ranges of sources.
+func fieldRange(start, end int32) proto.Range {
+	if end-1 == types.MaxFieldNumber {
+		return proto.Range{From: int(start), Max: true}
+	}
+	return proto.Range{From: int(start), To: int(end - 1)}
+}
+
+// mapExtensions groups consecutive extensions of the same message into extend blocks.
+func (d *descriptorMapper) mapExtensions(fields []*descriptorpb.FieldDescriptorProto, path []int32, parent proto.Visitee) ([]proto.Visitee, []scanner.Position) {
+	var res []proto.Visitee
+	var positions []scanner.Position
+	var current *proto.Message
+	for i, field := range fields {
+		fieldPath := childPath(path, int32(i))
+		if current == nil || current.Name != d.localTypeName(field.GetExtendee()) {
+			current = &proto.Message{
+				Name:     d.localTypeName(field.GetExtendee()),
+				IsExtend: true,
+				Position: d.position(fieldPath),
+				Parent:   parent,
+			}
+			res = append(res, current)
+			positions = append(positions, d.position(fieldPath))
+		}
+		current.Elements = append(current.Elements, &proto.NormalField{
+			Field:    d.mapField(field, fieldPath, current, fieldTypeName(field)),
+			Repeated: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+			Optional: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL,
+			Required: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED,
+		})
+	}
+	return res, positions
+}
+
+// localTypeName drops the package of the file from a type reference, the way it is usually written in sources.
+func (d *descriptorMapper) localTypeName(name string) string {
+	name = strings.TrimPrefix(name, ".")
+	if d.file.GetPackage() != "" {
+		name = strings.TrimPrefix(name, d.file.GetPackage()+".")
+	}
+	return name
+}
+
+func (d *descriptorMapper) mapField(field *descriptorpb.FieldDescriptorProto, path []int32, parent proto.Visitee, typeName string) *proto.Field {
+	res := &proto.Field{
+		Name:     field.GetName(),
+		Type:     typeName,
+		Sequence: int(field.GetNumber()),
+		Position: d.position(path),
+		Comment:  d.comment(path),
+		Parent:   parent,
+	}
+	res.InlineComment = d.inlineComment(path)
+	if field.DefaultValue != nil {
+		// descriptors keep string defaults as is and bytes defaults escaped, sources have both escaped
+		source := field.GetDefaultValue()
+		isString := field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING ||
+			field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES
+		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+			source = escapeProtoString(source)
+		}
+		res.Options = append(res.Options, &proto.Option{
+			Name:     "default",
+			Constant: proto.Literal{Source: source, IsString: isString},
+		})
+	}
+	if field.JsonName != nil {
+		res.Options = append(res.Options, &proto.Option{
+			Name:     "json_name",
+			Constant: proto.Literal{Source: field.GetJsonName(), IsString: true},
+		})
+	}
+	res.Options = append(res.Options, d.mapOptions(field.Options)...)
+	return res
+}
+
+// fieldTypeName is the type of a field as written in sources, scalar names or full names of messages and enums.
+func fieldTypeName(field *descriptorpb.FieldDescriptorProto) string {
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return strings.TrimPrefix(field.GetTypeName(), ".")
+	default:
+		return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+	}
+}
+
+func (d *descriptorMapper) mapEnum(enum *descriptorpb.EnumDescriptorProto, path []int32, parent proto.Visitee) proto.Visitee {
+	res := &proto.Enum{
+		Name:     enum.GetName(),
+		Position: d.position(path),
+		Comment:  d.comment(path),
+		Parent:   parent,
+	}
+	for i, value := range enum.Value {
+		valuePath := childPath(path, enumValuePath, int32(i))
+		enumField := &proto.EnumField{
+			Name:          value.GetName(),
+			Integer:       int(value.GetNumber()),
+			Position:      d.position(valuePath),
+			Comment:       d.comment(valuePath),
+			InlineComment: d.inlineComment(valuePath),
+			Parent:        res,
+		}
+		for _, option := range d.mapOptions(value.Options) {
+			option.Parent = enumField
+			enumField.Elements = append(enumField.Elements, option)
+		}
+		res.Elements = append(res.Elements, enumField)
+	}
+	for _, option := range d.mapOptions(enum.Options) {
+		option.Parent = res
+		res.Elements = append(res.Elements, option)
+	}
+	if len(enum.ReservedRange) > 0 || len(enum.ReservedName) > 0 {
+		reserved := &proto.Reserved{FieldNames: enum.ReservedName, Parent: res}
+		for _, r := range enum.ReservedRange {
+			// enum ranges are inclusive already
+			if r.GetEnd() == math.MaxInt32 {
+				reserved.Ranges = append(reserved.Ranges, proto.Range{From: int(r.GetStart()), Max: true})
+			} else {
+				reserved.Ranges = append(reserved.Ranges, proto.Range{From: int(r.GetStart()), To: int(r.GetEnd())})
+			}
+		}
+		res.Elements = append(res.Elements, reserved)
+	}
+	return res
+}
+
+func (d *descriptorMapper) mapService(service *descriptorpb.ServiceDescriptorProto, path []int32, parent proto.Visitee) proto.Visitee {
+	res := &proto.Service{
+		Name:     service.GetName(),
+		Position: d.position(path),
+		Comment:  d.comment(path),
+		Parent:   parent,
+	}
+	for _, option := range d.mapOptions(service.Options) {
+		option.Parent = res
+		res.Elements = append(res.Elements, option)
+	}
+	for i, method := range service.Method {
+		methodPath := childPath(path, serviceMethodPath, int32(i))
+		rpc := &proto.RPC{
+			Name:           method.GetName(),
+			RequestType:    d.localTypeName(method.GetInputType()),
+			StreamsRequest: method.GetClientStreaming(),
+			ReturnsType:    d.localTypeName(method.GetOutputType()),
+			StreamsReturns: method.GetServerStreaming(),
+			Position:       d.position(methodPath),
+			Comment:        d.comment(methodPath),
+			InlineComment:  d.inlineComment(methodPath),
+			Parent:         res,
+		}
+		for _, option := range d.mapOptions(method.Options) {
+			option.Parent = rpc
+			rpc.Elements = append(rpc.Elements, option)
+		}
+		res.Elements = append(res.Elements, rpc)
+	}
+	return res
+}
+
+// descriptorSchema is what mappers know about all files, custom options are named by the extensions they set.
+type descriptorSchema struct {
+	extensions map[string]map[int32]*schemaExtension // by full name of the extended options message
+	enums      map[string]*descriptorpb.EnumDescriptorProto
+}
+
+type schemaExtension struct {
+	fullName string
+	field    *descriptorpb.FieldDescriptorProto
+}
+
+func newDescriptorSchema(files []*descriptorpb.FileDescriptorProto) *descriptorSchema {
+	res := &descriptorSchema{
+		extensions: map[string]map[int32]*schemaExtension{},
+		enums:      map[string]*descriptorpb.EnumDescriptorProto{},
+	}
+	addExtensions := func(scope string, fields []*descriptorpb.FieldDescriptorProto) {
+		for _, field := range fields {
+			extendee := strings.TrimPrefix(field.GetExtendee(), ".")
+			if res.extensions[extendee] == nil {
+				res.extensions[extendee] = map[int32]*schemaExtension{}
+			}
+			res.extensions[extendee][field.GetNumber()] = &schemaExtension{fullName: scope + "." + field.GetName(), field: field}
+		}
+	}
+	var addMessages func(scope string, messages []*descriptorpb.DescriptorProto)
+	addMessages = func(scope string, messages []*descriptorpb.DescriptorProto) {
+		for _, message := range messages {
+			name := scope + "." + message.GetName()
+			for _, enum := range message.EnumType {
+				res.enums[name+"."+enum.GetName()] = enum
+			}
+			addExtensions(name, message.Extension)
+			addMessages(name, message.NestedType)
+		}
+	}
+	for _, file := range files {
+		scope := ""
+		if file.GetPackage() != "" {
+			scope = "." + file.GetPackage()
+		}
+		for _, enum := range file.EnumType {
+			res.enums[scope+"."+enum.GetName()] = enum
+		}
+		addExtensions(scope, file.Extension)
+		addMessages(scope, file.MessageType)
+	}
+	return res
+}
+
+// mapOptions maps set options back to option declarations. Custom options are kept if their extension
+// is known and has a scalar or enum type, the model has no use for others.
+func (d *descriptorMapper) mapOptions(options gproto.Message) []*proto.Option {
+	if options == nil || !options.ProtoReflect().IsValid() {
+		return nil
+	}
+	msg := options.ProtoReflect()
+
+	var fields []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number() < fields[j].Number() })
+
+	var res []*proto.Option
+	for _, fd := range fields {
+		if fd.IsMap() || fd.Kind() == protoreflect.MessageKind || fd.IsExtension() {
+			continue
+		}
+		if !fd.IsList() {
+			res = append(res, &proto.Option{Name: string(fd.Name()), Constant: optionLiteral(fd, msg.Get(fd))})
+			continue
+		}
+		list := msg.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			res = append(res, &proto.Option{Name: string(fd.Name()), Constant: optionLiteral(fd, list.Get(i))})
+		}
+	}
+
+	extensions := d.schema.extensions[string(msg.Descriptor().FullName())]
+	unknown := msg.GetUnknown()
+	for len(unknown) > 0 {
+		number, wireType, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			break
+		}
+		unknown = unknown[n:]
+		var raw uint64
+		var data []byte
+		switch wireType {
+		case protowire.VarintType:
+			raw, n = protowire.ConsumeVarint(unknown)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(unknown)
+			raw = uint64(v)
+		case protowire.Fixed64Type:
+			raw, n = protowire.ConsumeFixed64(unknown)
+		case protowire.BytesType:
+			data, n = protowire.ConsumeBytes(unknown)
+		default:
+			n = protowire.ConsumeFieldValue(number, wireType, unknown)
+		}
+		if n < 0 {
+			break
+		}
+		unknown = unknown[n:]
+
+		extension := extensions[int32(number)]
+		if extension == nil {
+			continue
+		}
+		if literal, ok := d.customOptionLiteral(extension.field, raw, data); ok {
+			res = append(res, &proto.Option{Name: "(" + d.localTypeName(extension.fullName) + ")", Constant: literal})
+		}
+	}
+	return res
+}
+
+func optionLiteral(fd protoreflect.FieldDescriptor, value protoreflect.Value) proto.Literal {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if v := fd.Enum().Values().ByNumber(value.Enum()); v != nil {
+			return proto.Literal{Source: string(v.Name())}
+		}
+	case protoreflect.StringKind:
+		return proto.Literal{Source: escapeProtoString(value.String()), IsString: true}
+	case protoreflect.BytesKind:
+		return proto.Literal{Source: escapeProtoString(string(value.Bytes())), IsString: true}
+	}
+	return proto.Literal{Source: value.String()}
+}
+
+func (d *descriptorMapper) customOptionLiteral(field *descriptorpb.FieldDescriptorProto, raw uint64, data []byte) (proto.Literal, bool) {
+	var source string
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		source = strconv.FormatBool(raw != 0)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		source = strconv.FormatInt(int64(int32(raw)), 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		source = strconv.FormatInt(int64(raw), 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT64:
+		source = strconv.FormatInt(protowire.DecodeZigZag(raw), 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		source = strconv.FormatUint(raw, 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		source = strconv.FormatFloat(float64(math.Float32frombits(uint32(raw))), 'g', -1, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		source = strconv.FormatFloat(math.Float64frombits(raw), 'g', -1, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return proto.Literal{Source: escapeProtoString(string(data)), IsString: true}, true
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		enum := d.schema.enums[field.GetTypeName()]
+		for _, value := range enum.GetValue() {
+			if value.GetNumber() == int32(raw) {
+				return proto.Literal{Source: value.GetName()}, true
+			}
+		}
+		return proto.Literal{}, false
+	default:
+		return proto.Literal{}, false
+	}
+	return proto.Literal{Source: source}, true
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+)
+
+// The unittest descriptors of the plugin request, saved as a descriptor set, must generate testpb.
+func TestDescriptorSetMatchesSources(t *testing.T) {
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := gproto.Unmarshal(getTestFileContent("plugin_request"), req); err != nil {
+		t.Fatal(err)
+	}
+	data, err := gproto.Marshal(&descriptorpb.FileDescriptorSet{File: req.ProtoFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "unittest.binpb")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same set twice is fine, files are deduplicated
+	targets, err := ReadDescriptorSets([]string{path, path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ParseStruct(targets); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(targets); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	files, errs := golang.GenerateFiles("", "github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb", targets)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %v", len(files))
+	}
+	for _, file := range files {
+		expected, err := os.ReadFile(filepath.Join("../testpb", file.Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(expected), file.Content); diff != "" {
+			t.Errorf("%v differs from the generated sources (-want +got):\n%v", file.Path, diff)
+		}
+	}
+}
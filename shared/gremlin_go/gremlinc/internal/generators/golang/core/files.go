@@ -12,20 +12,34 @@ type GoGeneratedFile struct {
 	imports            []*GoImport
 	samePackageImports []*GoGeneratedFile
 
-	enums   []GoType
-	structs []GoType
+	enums      []GoType
+	structs    []GoType
+	extensions []GoExtension
 
 	ProtoFile        *types.ProtoFile
 	Path             string
 	FullPackageName  string
 	ShortPackageName string
 	FullOutputPath   string
+
+	// InlinedReaders lists message fields whose readers are embedded into the parent reader.
+	InlinedReaders map[*types.MessageFieldDefinition]bool
+	// RecursiveFields lists singular message fields whose message can reach the parent message again.
+	RecursiveFields map[*types.MessageFieldDefinition]bool
 }
 
 func (g *GoGeneratedFile) IsProto2() bool {
 	return g.ProtoFile.Proto2
 }
 
+func (g *GoGeneratedFile) IsReaderInlined(field *types.MessageFieldDefinition) bool {
+	return g.InlinedReaders[field]
+}
+
+func (g *GoGeneratedFile) IsRecursive(field *types.MessageFieldDefinition) bool {
+	return g.RecursiveFields[field]
+}
+
 func (g *GoGeneratedFile) AddImport(path string, alias string) {
 	for _, goImport := range g.imports {
 		if goImport.Path == path {
@@ -95,6 +109,10 @@ func (g *GoGeneratedFile) AddStruct(structDef GoType) {
 	g.structs = append(g.structs, structDef)
 }
 
+func (g *GoGeneratedFile) AddExtension(extension GoExtension) {
+	g.extensions = append(g.extensions, extension)
+}
+
 func (g *GoGeneratedFile) FindEnum(enumType *types.EnumDefinition) GoType {
 	for _, enum := range g.enums {
 		if enum.IsEnum(enumType) {
@@ -175,6 +193,11 @@ func (g *GoImport) FindStruct(msgType *types.MessageDefinition) GoType {
 func (g *GoGeneratedFile) GenerateCode() string {
 	if len(g.structs) > 0 {
 		g.AddImport("github.com/norma-core/norma-core/shared/gremlin_go", "gremlin")
+		g.AddImport("io", "io")
+		g.AddImport("fmt", "fmt")
+	}
+	if len(g.extensions) > 0 {
+		g.AddImport("github.com/norma-core/norma-core/shared/gremlin_go", "gremlin")
 	}
 	sb := &strings.Builder{}
 
@@ -210,5 +233,9 @@ package %v
 		structDef.GenerateCode(sb)
 	}
 
+	for _, extension := range g.extensions {
+		extension.GenerateCode(sb)
+	}
+
 	return sb.String()
 }
@@ -10,16 +10,23 @@ type GoFieldType interface {
 	ReaderTypeName() string
 	WriterTypeName() string
 
-	OffsetsType() string
-	WireTypeType() string
 	CanBePacked() bool
 
-	EntrySizedReader(tabs string, localVarName string) string       // should produce entry and entrySize variables
-	EntryReader(tabs string, localVarName string) string            // should produce entry
-	EntryUnmarshalSaveOffsets(tabs string, fieldName string) string // should set m.offset%v, m.wireType%v, etc if needed
+	EntrySizedReader(tabs string, localVarName string) string // should produce entry and entrySize variables
+	// should produce entry, singular fields get wOffset, repeated and map fields get wField to look up in m.repeated
+	EntryReader(tabs string, localVarName string) string
+	// should set m.offset%v or append to m.repeated
+	EntryUnmarshalSaveOffsets(tabs string, fieldName string, fieldIndex string) string
 	DefaultReturn() string
 
+	// should decode the value at offsetVar of buf straight into targetVar, used by struct Unmarshal
+	EntryDecode(tabs string, targetVar string, offsetVar string) string
+	EntryDefault(tabs string, targetVar string) string // should set the declared default, empty if there is none
+
 	ToStruct(tabs string, targetVar string, readerField string) string
+	Freeze(tabs string, readerField string) string // should freeze nested readers, empty if there are none
+	// should move parsed values from the from buffer to its copy to, empty if they never reference the buffer
+	Rebase(tabs string, varName string) string
 	EntryCopy(tabs string, targetVar string, srcVar string) string
 	JsonStructCanBeUsedDirectly() bool
 
@@ -29,6 +36,53 @@ type GoFieldType interface {
 
 	EntryWriter(tabs string, targetBuffer string, tag string, varName string) string
 	PackedEntryWriter(tabs string, targetBuffer string, varName string) string
+
+	JSONEncode(tabs string, targetBuffer string, varName string) string // should append varName to the targetBuffer slice
+	JSONDecode(tabs string, targetVar string) string                    // should read targetVar from d, returning the error
+	// should append the value at offsetVar of buf to s.B, repeated and map fields also write the following occurrences of tag
+	JSONTranscode(tabs string, offsetVar string) string
+
+	// should write the field name and the value of varName to w, repeated and map fields write the name for every entry
+	TextEncode(tabs string, fieldName string, varName string) string
+	TextDecode(tabs string, targetVar string) string // should read one occurrence of the field from d into targetVar, returning the error
+}
+
+// GoInlineReaderType is implemented by message fields whose reader can be stored
+// inside the parent reader instead of being allocated separately.
+type GoInlineReaderType interface {
+	InlineReaderTypeName() string // empty if the reader is not inlined
+	SetInlineStorage(varName string)
+}
+
+// GoWellKnownType is implemented by message fields, which tells apart the well-known types bundled with
+// gremlinc, whose code in the wkt package has conversions to Go types.
+type GoWellKnownType interface {
+	WellKnownName() string            // full proto name of the bundled type, empty for other messages
+	QualifiedName(name string) string // name declared in the package of the message type
+}
+
+// GoMessageType is implemented by singular message fields, generated field mask paths descend into them.
+type GoMessageType interface {
+	GoWellKnownType
+	MessageName() string // name of the generated struct, without the package
+	IsRecursive() bool   // the message can reach the message holding the field again
+}
+
+// GoWrapperType is implemented by fields of the well-known wrapper types such as google.protobuf.Int32Value,
+// structs keep a pointer to the value and reader getters return the value and whether the field is set.
+type GoWrapperType interface {
+	ValueTypeName() string // Go type of the wrapped value
+	ZeroValue() string
+}
+
+const (
+	WellKnownTimestamp = "google.protobuf.Timestamp"
+	WellKnownDuration  = "google.protobuf.Duration"
+)
+
+// GoExtension is a field of an extend block, generated after the messages of its file.
+type GoExtension interface {
+	GenerateCode(sb *strings.Builder)
 }
 
 type GoType interface {
@@ -29,23 +29,12 @@ func (e *goEnumValueType) resolveDefaultValue(field *types.MessageFieldDefinitio
 	e.DefaultValue = enumPackage + enumType.GetEnumForDefault(field.DefaultValue.Constant.Source)
 }
 
-func (e *goEnumValueType) OffsetsType() string {
-	return "int"
-}
-
-func (e *goEnumValueType) WireTypeType() string {
-	return ""
-}
-
 func (e *goEnumValueType) CanBePacked() bool {
 	return true // protobufjs uses packed enums
 }
 
-func (e *goEnumValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	return formatting.AddTabs(
-		fmt.Sprintf(`m.offset%v = offset`, fieldName),
-		tabs,
-	)
+func (e *goEnumValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string, _ string) string {
+	return singularSaveOffset(tabs, fieldName)
 }
 
 func (e *goEnumValueType) EntryReader(tabs string, localVarName string) string {
@@ -102,10 +91,34 @@ if wOffset > 0 {
 	return formatting.AddTabs(res, tabs)
 }
 
+func (e *goEnumValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(buf.ReadInt32(%v))`, targetVar, e.EnumName, offsetVar), tabs)
+}
+
+func (e *goEnumValueType) packedDecode(tabs string, targetVar string, sizeVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`rawEntry, %v := buf.SizedReadInt32(%v)
+%v := %v(rawEntry)`, sizeVar, offsetVar, targetVar, e.EnumName), tabs)
+}
+
+func (e *goEnumValueType) EntryDefault(tabs string, targetVar string) string {
+	if e.DefaultValue == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v`, targetVar, e.DefaultValue), tabs)
+}
+
 func (e *goEnumValueType) ToStruct(tabs string, targetVar string, readerField string) string {
 	return formatting.AddTabs(fmt.Sprintf(`%v = %v`, targetVar, readerField), tabs)
 }
 
+func (e *goEnumValueType) Freeze(string, string) string {
+	return ""
+}
+
+func (e *goEnumValueType) Rebase(string, string) string {
+	return ""
+}
+
 func (e *goEnumValueType) EntryIsNotEmpty(localVarName string) string {
 	if e.Required {
 		return "true"
@@ -148,3 +161,29 @@ func (e *goEnumValueType) DefaultReturn() string {
 func (e *goEnumValueType) JsonStructCanBeUsedDirectly() bool {
 	return true
 }
+
+func (e *goEnumValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = gremlin.AppendJSONEnum(%v, int32(%v), %v.String())`, targetBuffer, targetBuffer, varName, varName), tabs)
+}
+
+func (e *goEnumValueType) JSONDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := d.ReadEnum(%v_value)
+if err != nil {
+	return err
+}
+%v = %v(value)`, e.EnumName, targetVar, e.EnumName), tabs)
+}
+
+func (e *goEnumValueType) JSONTranscode(tabs string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`enumValue := buf.ReadInt32(%v)
+s.B = gremlin.AppendJSONEnum(s.B, enumValue, %v(enumValue).String())`, offsetVar, e.EnumName), tabs)
+}
+
+func (e *goEnumValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`w.WriteName(%q)
+w.WriteEnum(int32(%v), %v.String())`, fieldName, varName, varName), tabs)
+}
+
+func (e *goEnumValueType) TextDecode(tabs string, targetVar string) string {
+	return e.JSONDecode(tabs, targetVar)
+}
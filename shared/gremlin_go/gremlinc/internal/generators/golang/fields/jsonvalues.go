@@ -0,0 +1,114 @@
+package fields
+
+import (
+	"fmt"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
+)
+
+// jsonValueKinds maps google.protobuf.Struct, ListValue and Value to the Go type structs keep them as,
+// to the suffix of the wkt helpers converting them and to the wkt function validating them.
+var jsonValueKinds = map[string]struct{ GoType, Helper, Check string }{
+	"google.protobuf.Struct":    {"map[string]any", "Map", "CheckStruct"},
+	"google.protobuf.ListValue": {"[]any", "Slice", "CheckList"},
+	"google.protobuf.Value":     {"any", "Interface", "CheckValue"},
+}
+
+// goJSONValueType is a singular field of the JSON-like types google.protobuf.Struct, ListValue and Value.
+// Readers keep the generated readers, which offer AsMap, AsSlice and AsInterface, while structs hold plain
+// Go values converted by the wkt package straight from and to the wire format.
+type goJSONValueType struct {
+	*goStructValueType
+	GoType string
+	Helper string
+	Check  string
+}
+
+func newJSONValueType(structType *goStructValueType) *goJSONValueType {
+	kind := jsonValueKinds[structType.WellKnown]
+	return &goJSONValueType{
+		goStructValueType: structType,
+		GoType:            kind.GoType,
+		Helper:            kind.Helper,
+		Check:             kind.Check,
+	}
+}
+
+// helper returns the qualified name of the wkt function with the given prefix and suffix around the kind.
+func (t *goJSONValueType) helper(prefix string, suffix string) string {
+	return t.QualifiedName(prefix + t.Helper + suffix)
+}
+
+func (t *goJSONValueType) WriterTypeName() string {
+	return t.GoType
+}
+
+func (t *goJSONValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := %v(buf.ReadMessage(%v), buf.Options())
+if err != nil {
+	return err
+}
+%v = value`, t.helper("XXX_Read", ""), offsetVar, targetVar), tabs)
+}
+
+func (t *goJSONValueType) ToStruct(tabs string, targetVar string, readerField string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(%v)`, targetVar, t.helper("XXX_", "FromReader"), readerField), tabs)
+}
+
+func (t *goJSONValueType) EntryIsNotEmpty(localVarName string) string {
+	return fmt.Sprintf(`%v != nil`, localVarName)
+}
+
+func (t *goJSONValueType) EntryFullSizeWithTag(tabs string, sizeVarName string, fieldName string, fieldTag string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(%v)
+%v += gremlin.SizeUint64(uint64(%v)) + gremlin.SizeTag(%v)
+`, sizeVarName, t.helper("XXX_", "Size"), fieldName, sizeVarName, sizeVarName, fieldTag), tabs)
+}
+
+func (t *goJSONValueType) EntryFullSizeWithoutTag(tabs string, sizeVarName string, fieldName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(%v)
+%v += gremlin.SizeUint64(uint64(%v))
+`, sizeVarName, t.helper("XXX_", "Size"), fieldName, sizeVarName, sizeVarName), tabs)
+}
+
+func (t *goJSONValueType) EntryWriter(tabs string, targetBuffer string, tag string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v(%v, %v, %v)`, t.helper("XXX_Append", ""), targetBuffer, tag, varName), tabs)
+}
+
+func (t *goJSONValueType) EntryCopy(tabs string, targetVar string, srcVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(%v)`, targetVar, t.helper("XXX_Copy", ""), srcVar), tabs)
+}
+
+// JSONEncode writes the canonical mapping, a JSON object, array or any value, for readers and Go values alike.
+func (t *goJSONValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v(%v, %v)`, targetBuffer, t.QualifiedName("AppendValueJSON"), targetBuffer, varName), tabs)
+}
+
+func (t *goJSONValueType) JSONDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := %v(d)
+if err != nil {
+	return err
+}
+%v = value`, t.helper("XXX_Decode", "JSON"), targetVar), tabs)
+}
+
+func (t *goJSONValueType) JSONTranscode(tabs string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if err := %v(s, buf.ReadMessage(%v)); err != nil {
+	return err
+}`, t.helper("XXX_Transcode", "JSON"), offsetVar), tabs)
+}
+
+func (t *goJSONValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`w.WriteName(%q)
+w.StartMessage()
+%v(w, %v)
+w.EndMessage()`, fieldName, t.helper("XXX_Write", "Text"), varName), tabs)
+}
+
+func (t *goJSONValueType) TextDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := %v(d)
+if err != nil {
+	return err
+}
+%v = value`, t.helper("XXX_Decode", "Text"), targetVar), tabs)
+}
@@ -3,6 +3,7 @@ package fields
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
@@ -21,18 +22,6 @@ func (t *goRepeatedValueType) WriterTypeName() string {
 	return fmt.Sprintf("[]%v", t.RepeatedType.WriterTypeName())
 }
 
-func (t *goRepeatedValueType) OffsetsType() string {
-	return "[]int"
-}
-
-func (t *goRepeatedValueType) WireTypeType() string {
-	if t.RepeatedType.CanBePacked() {
-		return "[]gremlin.ProtoWireType"
-	} else {
-		return ""
-	}
-}
-
 func (t *goRepeatedValueType) CanBePacked() bool {
 	return false
 }
@@ -41,38 +30,107 @@ func (t *goRepeatedValueType) EntrySizedReader(string, string) string {
 	return ""
 }
 
-func (t *goRepeatedValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	return formatting.AddTabs(fmt.Sprintf(`m.offset%v = append(m.offset%v, offset)`, fieldName, fieldName), tabs)
+func (t *goRepeatedValueType) EntryUnmarshalSaveOffsets(tabs string, _ string, fieldIndex string) string {
+	return repeatedSaveOffset(tabs, fieldIndex)
 }
 
 func (t *goRepeatedValueType) EntryReader(tabs string, localVarName string) string {
-	var res = fmt.Sprintf(`
+	var res string
+	if structType, isStruct := t.RepeatedType.(*goStructValueType); isStruct {
+		// all readers of the list are allocated as a single block
+		structType.storage = fmt.Sprintf("listReaders[len(%v)]", localVarName)
+		res = fmt.Sprintf(`
 var %v %v
-for i := 0; i < len(wOffset); i++ {
-	wOffset := wOffset[i]
+if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+	%v = gremlin.MakeSlice[%v](m.buf.Arena(), 0, count)
+	listReaders := gremlin.MakeSlice[%v](m.buf.Arena(), count, count)
+	for _, fieldOffset := range m.repeated {
+		if fieldOffset.Field != wField {
+			continue
+		}
+		wOffset := int(fieldOffset.Offset)
 %v
-	%v = append(%v, listEntry)
+		%v = append(%v, listEntry)
+	}
+}
+`, localVarName, t.ReaderTypeName(),
+			localVarName, t.RepeatedType.ReaderTypeName(),
+			strings.TrimPrefix(structType.ReaderTypeName(), "*"),
+			t.RepeatedType.EntryReader("\t\t", "listEntry"),
+			localVarName, localVarName)
+	} else {
+		res = fmt.Sprintf(`
+var %v %v
+if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+	%v = gremlin.MakeSlice[%v](m.buf.Arena(), 0, count)
+	for _, fieldOffset := range m.repeated {
+		if fieldOffset.Field != wField {
+			continue
+		}
+		wOffset := int(fieldOffset.Offset)
+%v
+		%v = append(%v, listEntry)
+	}
 }
 `, localVarName, t.ReaderTypeName(),
-		t.RepeatedType.EntryReader("\t", "listEntry"),
-		localVarName, localVarName)
+			localVarName, t.RepeatedType.ReaderTypeName(),
+			t.RepeatedType.EntryReader("\t\t", "listEntry"),
+			localVarName, localVarName)
+	}
 
 	return formatting.AddTabs(res, tabs)
 }
 
+// EntryDecode presizes the list on the first occurrence.
+func (t *goRepeatedValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if %v == nil {
+	%v = make(%v, 0, buf.CountField(%v, wire, tag, 0))
+}
+{
+	var listEntry %v
+%v
+	%v = append(%v, listEntry)
+}`, targetVar, targetVar, t.WriterTypeName(), offsetVar,
+		t.RepeatedType.WriterTypeName(), t.RepeatedType.EntryDecode("\t", "listEntry", offsetVar), targetVar, targetVar), tabs)
+}
+
+func (t *goRepeatedValueType) EntryDefault(string, string) string {
+	return ""
+}
+
 func (t *goRepeatedValueType) ToStruct(tabs string, targetVar string, readerField string) string {
 	res := fmt.Sprintf(`if len(%v) > 0 {
-	%v = make(%v, len(%v))
+	%v = gremlin.MakeSlice[%v](m.buf.Arena(), len(%v), len(%v))
 	for i := range %v {
 %v
 	}
-}`, readerField, targetVar, t.WriterTypeName(), readerField, readerField,
+}`, readerField, targetVar, t.RepeatedType.WriterTypeName(), readerField, readerField, readerField,
 		t.RepeatedType.ToStruct("\t\t", targetVar+"[i]", readerField+"[i]"),
 	)
 
 	return formatting.AddTabs(res, tabs)
 }
 
+func (t *goRepeatedValueType) Freeze(tabs string, readerField string) string {
+	entryFreeze := t.RepeatedType.Freeze("\t", "entry")
+	if entryFreeze == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`for _, entry := range %v {
+%v
+}`, readerField, entryFreeze), tabs)
+}
+
+func (t *goRepeatedValueType) Rebase(tabs string, varName string) string {
+	entryRebase := t.RepeatedType.Rebase("\t", varName+"[i]")
+	if entryRebase == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`for i := range %v {
+%v
+}`, varName, entryRebase), tabs)
+}
+
 func (t *goRepeatedValueType) EntryIsNotEmpty(localVarName string) string {
 	if t.Required {
 		return "true"
@@ -124,3 +182,70 @@ for i := range %v {
 }`, targetVar, t.WriterTypeName(), srcVar, srcVar, t.RepeatedType.EntryCopy("\t", targetVar+"[i]", srcVar+"[i]")), tabs)
 	}
 }
+
+func (t *goRepeatedValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return jsonListEncode(tabs, t.RepeatedType, targetBuffer, varName)
+}
+
+func (t *goRepeatedValueType) JSONDecode(tabs string, targetVar string) string {
+	return jsonListDecode(tabs, t.RepeatedType, targetVar)
+}
+
+// jsonListEncode is shared by repeated and packed lists, they look the same in JSON.
+func jsonListEncode(tabs string, entryType core.GoFieldType, targetBuffer string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%[1]v = append(%[1]v, '[')
+for i, entry := range %[2]v {
+	if i > 0 {
+		%[1]v = append(%[1]v, ',')
+	}
+%[3]v
+}
+%[1]v = append(%[1]v, ']')`, targetBuffer, varName, entryType.JSONEncode("\t", targetBuffer, "entry")), tabs)
+}
+
+func jsonListDecode(tabs string, entryType core.GoFieldType, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if err := d.ReadArray(func() error {
+	var listEntry %v
+%v
+	%v = append(%v, listEntry)
+	return nil
+}); err != nil {
+	return err
+}`, entryType.WriterTypeName(), entryType.JSONDecode("\t", "listEntry"), targetVar, targetVar), tabs)
+}
+
+func (t *goRepeatedValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return textListEncode(tabs, t.RepeatedType, fieldName, varName)
+}
+
+func (t *goRepeatedValueType) TextDecode(tabs string, targetVar string) string {
+	return textListDecode(tabs, t.RepeatedType, targetVar)
+}
+
+// textListEncode is shared by repeated and packed lists, every entry is written as a separate field.
+func textListEncode(tabs string, entryType core.GoFieldType, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`for _, entry := range %v {
+%v
+}`, varName, entryType.TextEncode("\t", fieldName, "entry")), tabs)
+}
+
+func textListDecode(tabs string, entryType core.GoFieldType, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if err := d.ReadRepeated(func() error {
+	var listEntry %v
+%v
+	%v = append(%v, listEntry)
+	return nil
+}); err != nil {
+	return err
+}`, entryType.WriterTypeName(), entryType.TextDecode("\t", "listEntry"), targetVar, targetVar), tabs)
+}
+
+func (t *goRepeatedValueType) JSONTranscode(tabs string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`entrySep := byte('[')
+for entryOffset, entryWire, found := %v, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+	s.B = append(s.B, entrySep)
+	entrySep = ','
+%v
+}
+s.B = append(s.B, ']')`, offsetVar, t.RepeatedType.JSONTranscode("\t", "entryOffset")), tabs)
+}
@@ -26,58 +26,54 @@ func (t *goMapValueType) WriterTypeName() string {
 	return fmt.Sprintf("map[%v]%v", t.KeyType.WriterTypeName(), t.ValueType.WriterTypeName())
 }
 
-func (t *goMapValueType) OffsetsType() string {
-	return "[]int"
-}
-
-func (t *goMapValueType) WireTypeType() string {
-	return ""
-}
-
 func (t *goMapValueType) CanBePacked() bool {
 	return false
 }
 
-func (t *goMapValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	return formatting.AddTabs(
-		fmt.Sprintf(`m.offset%v = append(m.offset%v, offset)`, fieldName, fieldName),
-		tabs,
-	)
+func (t *goMapValueType) EntryUnmarshalSaveOffsets(tabs string, _ string, fieldIndex string) string {
+	return repeatedSaveOffset(tabs, fieldIndex)
 }
 
 func (t *goMapValueType) EntryReader(tabs string, localVarName string) string {
 	res := fmt.Sprintf(`
-var %v = map[%v]%v{}
-for i := range wOffset {
-	wOffset := wOffset[i]
-
-	entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-	endOffset := wOffset + entrySizeSize + int(entrySize)
-	wOffset += entrySizeSize
-	
-	var keyData %v
-	var valueData %v
-	for wOffset < endOffset {
-		tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-		wOffset += tagSize
-		if tag == 1 {
-%v
-			wOffset += keyEntrySize
-			keyData = keyEntry
-		} else if tag == 2 {
-%v
-			wOffset += valueEntrySize
-			valueData = valueEntry
-		} else {
-			wOffset, _ = m.buf.SkipData(wOffset, wireType)
+var %v %v
+if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+	%v = gremlin.MakeMap[%v, %v](m.buf.Arena(), count)
+	for _, fieldOffset := range m.repeated {
+		if fieldOffset.Field != wField {
+			continue
+		}
+		wOffset := int(fieldOffset.Offset)
+
+		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+		endOffset := wOffset + entrySizeSize + int(entrySize)
+		wOffset += entrySizeSize
+
+		var keyData %v
+		var valueData %v
+		for wOffset < endOffset {
+			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+			wOffset += tagSize
+			if tag == 1 {
+%v
+				wOffset += keyEntrySize
+				keyData = keyEntry
+			} else if tag == 2 {
+%v
+				wOffset += valueEntrySize
+				valueData = valueEntry
+			} else {
+				wOffset, _ = m.buf.SkipData(wOffset, wireType)
+			}
 		}
+		%v[keyData] = valueData
 	}
-	%v[keyData] = valueData
 }
-`, localVarName, t.KeyType.ReaderTypeName(), t.ValueType.ReaderTypeName(),
+`, localVarName, t.ReaderTypeName(),
+		localVarName, t.KeyType.ReaderTypeName(), t.ValueType.ReaderTypeName(),
 		t.KeyType.ReaderTypeName(), t.ValueType.ReaderTypeName(),
-		t.KeyType.EntrySizedReader("\t\t\t", "keyEntry"),
-		t.ValueType.EntrySizedReader("\t\t\t", "valueEntry"),
+		t.KeyType.EntrySizedReader("\t\t\t\t", "keyEntry"),
+		t.ValueType.EntrySizedReader("\t\t\t\t", "valueEntry"),
 		localVarName)
 
 	return formatting.AddTabs(res, tabs)
@@ -87,20 +83,103 @@ func (t *goMapValueType) EntrySizedReader(string, string) string {
 	return ""
 }
 
+// EntryDecode presizes the map on the first occurrence, fields of the entry must end inside it.
+func (t *goMapValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`{
+	if %v == nil {
+		%v = make(%v, buf.CountField(%v, wire, tag, 0))
+	}
+	entrySize, entrySizeSize := buf.SizedReadVarInt(%v)
+	entryOffset := %v + entrySizeSize
+	entryEnd := entryOffset + int(entrySize)
+
+	var mapKey %v
+	var mapValue %v
+	for entryOffset < entryEnd {
+		entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+		if err != nil {
+			return err
+		}
+		entryOffset += entryTagSize
+		entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+		if err != nil {
+			return err
+		}
+		if entryNext > entryEnd {
+			return gremlin.ErrTruncated
+		}
+		switch entryTag {
+		case %d:
+%v
+%v
+		case %d:
+%v
+%v
+		}
+		entryOffset = entryNext
+	}
+	%v[mapKey] = mapValue
+}`, targetVar, targetVar, t.WriterTypeName(), offsetVar, offsetVar, offsetVar,
+		t.KeyType.WriterTypeName(), t.ValueType.WriterTypeName(),
+		mapEntryKeyTag, WireTypeCheck("\t\t\t", t.KeyType, "entryTag", "entryWire"), t.KeyType.EntryDecode("\t\t\t", "mapKey", "entryOffset"),
+		mapEntryValueTag, WireTypeCheck("\t\t\t", t.ValueType, "entryTag", "entryWire"), t.ValueType.EntryDecode("\t\t\t", "mapValue", "entryOffset"),
+		targetVar), tabs)
+}
+
+func (t *goMapValueType) EntryDefault(string, string) string {
+	return ""
+}
+
 func (t *goMapValueType) ToStruct(tabs string, targetVar string, readerField string) string {
 	res := fmt.Sprintf(`if len(%v) > 0 {
-	%v = make(%v, len(%v))
+	%v = gremlin.MakeMap[%v, %v](m.buf.Arena(), len(%v))
 	for k,v := range %v {
 %v
 	}
 }`,
-		readerField, targetVar, t.WriterTypeName(), readerField,
+		readerField, targetVar, t.KeyType.WriterTypeName(), t.ValueType.WriterTypeName(), readerField,
 		readerField,
 		t.ValueType.ToStruct("\t\t", targetVar+"[k]", "v"),
 	)
 	return formatting.AddTabs(res, tabs)
 }
 
+func (t *goMapValueType) Freeze(tabs string, readerField string) string {
+	valueFreeze := t.ValueType.Freeze("\t", "v")
+	if valueFreeze == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`for _, v := range %v {
+%v
+}`, readerField, valueFreeze), tabs)
+}
+
+func (t *goMapValueType) Rebase(tabs string, varName string) string {
+	keyRebase := t.KeyType.Rebase("\t\t", "k")
+	valueRebase := t.ValueType.Rebase("\t\t", "v")
+	if keyRebase != "" {
+		// keys can't be changed in place, so the map is rebuilt
+		if valueRebase != "" {
+			keyRebase += "\n" + valueRebase
+		}
+		return formatting.AddTabs(fmt.Sprintf(`if %v != nil {
+	rebased := make(%v, len(%v))
+	for k, v := range %v {
+%v
+		rebased[k] = v
+	}
+	%v = rebased
+}`, varName, t.ReaderTypeName(), varName, varName, keyRebase, varName), tabs)
+	}
+	if valueRebase == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`for k, v := range %v {
+%v
+	%v[k] = v
+}`, varName, t.ValueType.Rebase("\t", "v"), varName), tabs)
+}
+
 func (t *goMapValueType) EntryIsNotEmpty(localVarName string) string {
 	return fmt.Sprintf(`len(%v) > 0`, localVarName)
 }
@@ -165,3 +244,143 @@ for k, v := range %v {
 %v
 }`, targetVar, t.KeyType.WriterTypeName(), t.ValueType.WriterTypeName(), srcVar, srcVar, t.ValueType.EntryCopy("\t", targetVar+"[k]", "v")), tabs)
 }
+
+func (t *goMapValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	keyType := t.KeyType.(*goBasicValueType)
+	return formatting.AddTabs(fmt.Sprintf(`%[1]v = append(%[1]v, '{')
+for i, k := range %[2]v {
+	if i > 0 {
+		%[1]v = append(%[1]v, ',')
+	}
+%[3]v
+	%[1]v = append(%[1]v, ':')
+%[4]v
+}
+%[1]v = append(%[1]v, '}')`, targetBuffer, keyType.jsonSortedKeys(varName),
+		keyType.jsonMapKeyEncode("\t", targetBuffer, "k"),
+		t.ValueType.JSONEncode("\t", targetBuffer, varName+"[k]")), tabs)
+}
+
+func (t *goMapValueType) JSONDecode(tabs string, targetVar string) string {
+	keyType := t.KeyType.(*goBasicValueType)
+	return formatting.AddTabs(fmt.Sprintf(`if %v == nil {
+	%v = make(%v)
+}
+if err := d.ReadObject(func(key string) error {
+%v
+	var mapValue %v
+%v
+	%v[mapKey] = mapValue
+	return nil
+}); err != nil {
+	return err
+}`, targetVar, targetVar, t.WriterTypeName(),
+		keyType.jsonMapKeyDecode("\t", "mapKey", "key"),
+		t.ValueType.WriterTypeName(),
+		t.ValueType.JSONDecode("\t", "mapValue"),
+		targetVar), tabs)
+}
+
+func (t *goMapValueType) JSONTranscode(tabs string, offsetVar string) string {
+	keyType := t.KeyType.(*goBasicValueType)
+	return formatting.AddTabs(fmt.Sprintf(`entrySep := byte('{')
+for entryOffset, entryWire, found := %v, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+	s.B = append(s.B, entrySep)
+	entrySep = ','
+
+	entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+	mapOffset := entryOffset + entrySizeSize
+	mapEnd := mapOffset + int(entrySize)
+	keyOffset, valueOffset := 0, 0
+	for mapOffset < mapEnd {
+		mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+		if err != nil {
+			return err
+		}
+		mapOffset += mapTagSize
+		mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+		if err != nil {
+			return err
+		}
+		if mapNext > mapEnd {
+			return gremlin.ErrTruncated
+		}
+		switch mapTag {
+		case %d:
+%v
+			keyOffset = mapOffset
+		case %d:
+%v
+			valueOffset = mapOffset
+		}
+		mapOffset = mapNext
+	}
+
+	var mapKey %v
+	if keyOffset > 0 {
+%v
+	}
+%v
+	s.B = append(s.B, ':')
+	if valueOffset > 0 {
+%v
+	} else {
+		var mapValue %v
+%v
+	}
+}
+s.B = append(s.B, '}')`, offsetVar,
+		mapEntryKeyTag, WireTypeCheck("\t\t\t", t.KeyType, "mapTag", "mapWire"),
+		mapEntryValueTag, WireTypeCheck("\t\t\t", t.ValueType, "mapTag", "mapWire"),
+		t.KeyType.WriterTypeName(),
+		t.KeyType.EntryDecode("\t\t", "mapKey", "keyOffset"),
+		keyType.jsonMapKeyEncode("\t", "s.B", "mapKey"),
+		t.ValueType.JSONTranscode("\t\t", "valueOffset"),
+		t.ValueType.WriterTypeName(),
+		t.ValueType.JSONEncode("\t\t", "s.B", "mapValue")), tabs)
+}
+
+// TextEncode writes every entry as a message with key and value fields, sorted by key like prototext.
+func (t *goMapValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	keyType := t.KeyType.(*goBasicValueType)
+	return formatting.AddTabs(fmt.Sprintf(`for _, k := range %v {
+	w.WriteName(%q)
+	w.StartMessage()
+%v
+%v
+	w.EndMessage()
+}`, keyType.jsonSortedKeys(varName), fieldName,
+		t.KeyType.TextEncode("\t", "key", "k"),
+		t.ValueType.TextEncode("\t", "value", varName+"[k]")), tabs)
+}
+
+func (t *goMapValueType) TextDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if %v == nil {
+	%v = make(%v)
+}
+if err := d.ReadRepeated(func() error {
+	var mapKey %v
+	var mapValue %v
+	if err := d.ReadMessage(func(name string) error {
+		switch name {
+		case "key":
+%v
+		case "value":
+%v
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	%v[mapKey] = mapValue
+	return nil
+}); err != nil {
+	return err
+}`, targetVar, targetVar, t.WriterTypeName(),
+		t.KeyType.WriterTypeName(), t.ValueType.WriterTypeName(),
+		t.KeyType.TextDecode("\t\t\t", "mapKey"),
+		t.ValueType.TextDecode("\t\t\t", "mapValue"),
+		targetVar), tabs)
+}
@@ -21,18 +21,6 @@ func (t *goRepeatedPackedValueType) WriterTypeName() string {
 	return fmt.Sprintf("[]%v", t.RepeatedType.WriterTypeName())
 }
 
-func (t *goRepeatedPackedValueType) OffsetsType() string {
-	return "[]int"
-}
-
-func (t *goRepeatedPackedValueType) WireTypeType() string {
-	if t.RepeatedType.CanBePacked() {
-		return "[]gremlin.ProtoWireType"
-	} else {
-		return ""
-	}
-}
-
 func (t *goRepeatedPackedValueType) CanBePacked() bool {
 	return false
 }
@@ -41,55 +29,117 @@ func (t *goRepeatedPackedValueType) EntrySizedReader(string, string) string {
 	return ""
 }
 
-func (t *goRepeatedPackedValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	var res = fmt.Sprintf(`m.offset%v = append(m.offset%v, offset)
-m.wireType%v = append(m.wireType%v, wire)`, fieldName, fieldName, fieldName, fieldName)
-
-	return formatting.AddTabs(res, tabs)
+func (t *goRepeatedPackedValueType) EntryUnmarshalSaveOffsets(tabs string, _ string, fieldIndex string) string {
+	return repeatedSaveOffset(tabs, fieldIndex)
 }
 
 func (t *goRepeatedPackedValueType) EntryReader(tabs string, localVarName string) string {
 	var res = fmt.Sprintf(`
 var %v %v
-for i := 0; i < len(wOffset); i++ {
-	wOffset := wOffset[i]
-	wType := wType[i]
-	if wType == gremlin.BytesType {
-		size, sizeSize := m.buf.SizedReadVarInt(wOffset)
-		offset := 0
-		for offset < int(size) {
-			wOffset := wOffset + sizeSize + offset
+if count := m.buf.CountPacked(m.repeated, wField, %v); count > 0 {
+	%v = gremlin.MakeSlice[%v](m.buf.Arena(), 0, count)
+	for _, fieldOffset := range m.repeated {
+		if fieldOffset.Field != wField {
+			continue
+		}
+		wOffset := int(fieldOffset.Offset)
+		if fieldOffset.Wire == gremlin.BytesType {
+			size, sizeSize := m.buf.SizedReadVarInt(wOffset)
+			offset := 0
+			for offset < int(size) {
+				wOffset := wOffset + sizeSize + offset
+%v
+				if listEntrySize <= 0 {
+					break
+				}
+				%v = append(%v, listEntry)
+				offset += listEntrySize
+			}
+		} else {
 %v
 			%v = append(%v, listEntry)
-			offset += listEntrySize
 		}
-	} else {
-%v
-		%v = append(%v, listEntry)
 	}
 }
-`, localVarName, t.ReaderTypeName(),
-		t.RepeatedType.EntrySizedReader("\t\t\t", "listEntry"),
+`, localVarName, t.ReaderTypeName(), t.packedEntryMinSize(),
+		localVarName, t.RepeatedType.ReaderTypeName(),
+		t.RepeatedType.EntrySizedReader("\t\t\t\t", "listEntry"),
 		localVarName, localVarName,
-		t.RepeatedType.EntryReader("\t\t", "listEntry"),
+		t.RepeatedType.EntryReader("\t\t\t", "listEntry"),
 		localVarName, localVarName)
 
 	return formatting.AddTabs(res, tabs)
 }
 
+// smallest wire size of a single packed entry, used to estimate list capacity
+func (t *goRepeatedPackedValueType) packedEntryMinSize() int {
+	if basic, isBasic := t.RepeatedType.(*goBasicValueType); isBasic {
+		switch basic.ProtoType {
+		case "fixed32", "sfixed32", "float":
+			return 4
+		case "fixed64", "sfixed64", "double":
+			return 8
+		}
+	}
+	return 1
+}
+
+// EntryDecode presizes the list on the first occurrence, packed entries must end inside their chunk.
+func (t *goRepeatedPackedValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	packed := t.RepeatedType.(packedDecoder)
+	return formatting.AddTabs(fmt.Sprintf(`if %v == nil {
+	%v = make(%v, 0, buf.CountField(%v, wire, tag, %v))
+}
+if wire == gremlin.BytesType {
+	size, sizeSize := buf.SizedReadVarInt(%v)
+	packedOffset := %v + sizeSize
+	packedEnd := packedOffset + int(size)
+	for packedOffset < packedEnd {
+%v
+		if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+			return gremlin.ErrTruncated
+		}
+		%v = append(%v, listEntry)
+		packedOffset += listEntrySize
+	}
+} else {
+	var listEntry %v
+%v
+	%v = append(%v, listEntry)
+}`, targetVar, targetVar, t.WriterTypeName(), offsetVar, t.packedEntryMinSize(),
+		offsetVar, offsetVar,
+		packed.packedDecode("\t\t", "listEntry", "listEntrySize", "packedOffset"),
+		targetVar, targetVar,
+		t.RepeatedType.WriterTypeName(),
+		t.RepeatedType.EntryDecode("\t", "listEntry", offsetVar),
+		targetVar, targetVar), tabs)
+}
+
+func (t *goRepeatedPackedValueType) EntryDefault(string, string) string {
+	return ""
+}
+
 func (t *goRepeatedPackedValueType) ToStruct(tabs string, targetVar string, readerField string) string {
 	res := fmt.Sprintf(`if len(%v) > 0 {
-	%v = make(%v, len(%v))
+	%v = gremlin.MakeSlice[%v](m.buf.Arena(), len(%v), len(%v))
 	for i := range %v {
 %v
 	}
-}`, readerField, targetVar, t.WriterTypeName(), readerField, readerField,
+}`, readerField, targetVar, t.RepeatedType.WriterTypeName(), readerField, readerField, readerField,
 		t.RepeatedType.ToStruct("\t\t", targetVar+"[i]", readerField+"[i]"),
 	)
 
 	return formatting.AddTabs(res, tabs)
 }
 
+func (t *goRepeatedPackedValueType) Freeze(string, string) string {
+	return "" // packed values are always scalars
+}
+
+func (t *goRepeatedPackedValueType) Rebase(string, string) string {
+	return ""
+}
+
 func (t *goRepeatedPackedValueType) EntryIsNotEmpty(localVarName string) string {
 	if t.Required {
 		return "true"
@@ -169,3 +219,52 @@ for i := range %v {
 }`, targetVar, t.WriterTypeName(), srcVar, srcVar, t.RepeatedType.EntryCopy("\t", targetVar+"[i]", srcVar+"[i]")), tabs)
 	}
 }
+
+func (t *goRepeatedPackedValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return jsonListEncode(tabs, t.RepeatedType, targetBuffer, varName)
+}
+
+func (t *goRepeatedPackedValueType) JSONDecode(tabs string, targetVar string) string {
+	return jsonListDecode(tabs, t.RepeatedType, targetVar)
+}
+
+func (t *goRepeatedPackedValueType) JSONTranscode(tabs string, offsetVar string) string {
+	packed := t.RepeatedType.(packedDecoder)
+	return formatting.AddTabs(fmt.Sprintf(`entrySep := byte('[')
+for entryOffset, entryWire, found := %v, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+	if entryWire == gremlin.BytesType {
+		size, sizeSize := buf.SizedReadVarInt(entryOffset)
+		packedOffset := entryOffset + sizeSize
+		packedEnd := packedOffset + int(size)
+		for packedOffset < packedEnd {
+%v
+			if listEntrySize <= 0 || packedOffset+listEntrySize > packedEnd {
+				return gremlin.ErrTruncated
+			}
+			s.B = append(s.B, entrySep)
+			entrySep = ','
+%v
+			packedOffset += listEntrySize
+		}
+	} else {
+		s.B = append(s.B, entrySep)
+		entrySep = ','
+%v
+	}
+}
+if entrySep == '[' {
+	s.B = append(s.B, entrySep)
+}
+s.B = append(s.B, ']')`, offsetVar,
+		packed.packedDecode("\t\t\t", "listEntry", "listEntrySize", "packedOffset"),
+		t.RepeatedType.JSONEncode("\t\t\t", "s.B", "listEntry"),
+		t.RepeatedType.JSONTranscode("\t\t", "entryOffset")), tabs)
+}
+
+func (t *goRepeatedPackedValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return textListEncode(tabs, t.RepeatedType, fieldName, varName)
+}
+
+func (t *goRepeatedPackedValueType) TextDecode(tabs string, targetVar string) string {
+	return textListDecode(tabs, t.RepeatedType, targetVar)
+}
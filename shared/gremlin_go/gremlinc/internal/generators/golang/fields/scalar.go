@@ -102,14 +102,6 @@ func (t *goBasicValueType) setNanDefault(file GoEntitiesProvider) {
 	}
 }
 
-func (t *goBasicValueType) OffsetsType() string {
-	return "int"
-}
-
-func (t *goBasicValueType) WireTypeType() string {
-	return ""
-}
-
 var canBePacked = map[string]bool{
 	"bool":     true,
 	"double":   true,
@@ -148,11 +140,8 @@ var bufBasicTypesReaders = map[string]string{
 	"sfixed64": "ReadSFixed64",
 }
 
-func (t *goBasicValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	return formatting.AddTabs(
-		fmt.Sprintf(`m.offset%v = offset`, fieldName),
-		tabs,
-	)
+func (t *goBasicValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string, _ string) string {
+	return singularSaveOffset(tabs, fieldName)
 }
 
 func (t *goBasicValueType) EntryReader(tabs string, localVarName string) string {
@@ -203,10 +192,39 @@ if wOffset > 0 {
 	return formatting.AddTabs(res, tabs)
 }
 
+func (t *goBasicValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = buf.%v(%v)`, targetVar, bufBasicTypesReaders[t.ProtoType], offsetVar), tabs)
+}
+
+func (t *goBasicValueType) packedDecode(tabs string, targetVar string, sizeVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v, %v := buf.Sized%v(%v)`, targetVar, sizeVar, bufBasicTypesReaders[t.ProtoType], offsetVar), tabs)
+}
+
+func (t *goBasicValueType) EntryDefault(tabs string, targetVar string) string {
+	if t.DefaultValue == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v`, targetVar, t.DefaultValue), tabs)
+}
+
 func (t *goBasicValueType) ToStruct(tabs string, targetVar string, readerField string) string {
 	return formatting.AddTabs(fmt.Sprintf(`%v = %v`, targetVar, readerField), tabs)
 }
 
+func (t *goBasicValueType) Freeze(string, string) string {
+	return ""
+}
+
+func (t *goBasicValueType) Rebase(tabs string, varName string) string {
+	switch t.ProtoType {
+	case "string":
+		return formatting.AddTabs(fmt.Sprintf(`%v = d.RebaseString(%v)`, varName, varName), tabs)
+	case "bytes":
+		return formatting.AddTabs(fmt.Sprintf(`%v = d.RebaseBytes(%v)`, varName, varName), tabs)
+	}
+	return ""
+}
+
 func (t *goBasicValueType) EntryIsNotEmpty(localVarName string) string {
 	if t.Required {
 		return "true"
@@ -328,3 +346,134 @@ func (t *goBasicValueType) EntryFullSizeWithoutTag(tabs string, sizeVarName stri
 			tabs)
 	}
 }
+
+var jsonBasicTypesWriters = map[string]string{
+	"string":   "%[1]v = gremlin.AppendJSONString(%[1]v, %[2]v)",
+	"bytes":    "%[1]v = gremlin.AppendJSONBytes(%[1]v, %[2]v)",
+	"bool":     "%[1]v = gremlin.AppendJSONBool(%[1]v, %[2]v)",
+	"double":   "%[1]v = gremlin.AppendJSONFloat(%[1]v, %[2]v, 64)",
+	"float":    "%[1]v = gremlin.AppendJSONFloat(%[1]v, float64(%[2]v), 32)",
+	"int32":    "%[1]v = gremlin.AppendJSONInt(%[1]v, int64(%[2]v))",
+	"int64":    "%[1]v = gremlin.AppendJSONInt64(%[1]v, %[2]v)",
+	"uint32":   "%[1]v = gremlin.AppendJSONUint(%[1]v, uint64(%[2]v))",
+	"uint64":   "%[1]v = gremlin.AppendJSONUint64(%[1]v, %[2]v)",
+	"sint32":   "%[1]v = gremlin.AppendJSONInt(%[1]v, int64(%[2]v))",
+	"sint64":   "%[1]v = gremlin.AppendJSONInt64(%[1]v, %[2]v)",
+	"fixed32":  "%[1]v = gremlin.AppendJSONUint(%[1]v, uint64(%[2]v))",
+	"fixed64":  "%[1]v = gremlin.AppendJSONUint64(%[1]v, %[2]v)",
+	"sfixed32": "%[1]v = gremlin.AppendJSONInt(%[1]v, int64(%[2]v))",
+	"sfixed64": "%[1]v = gremlin.AppendJSONInt64(%[1]v, %[2]v)",
+}
+
+func (t *goBasicValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(jsonBasicTypesWriters[t.ProtoType], targetBuffer, varName), tabs)
+}
+
+var jsonBasicTypesReaders = map[string]string{
+	"string":   "ReadString",
+	"bytes":    "ReadBytes",
+	"bool":     "ReadBool",
+	"double":   "ReadFloat64",
+	"float":    "ReadFloat32",
+	"int32":    "ReadInt32",
+	"int64":    "ReadInt64",
+	"uint32":   "ReadUint32",
+	"uint64":   "ReadUint64",
+	"sint32":   "ReadInt32",
+	"sint64":   "ReadInt64",
+	"fixed32":  "ReadUint32",
+	"fixed64":  "ReadUint64",
+	"sfixed32": "ReadInt32",
+	"sfixed64": "ReadInt64",
+}
+
+func (t *goBasicValueType) JSONDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := d.%v()
+if err != nil {
+	return err
+}
+%v = value`, jsonBasicTypesReaders[t.ProtoType], targetVar), tabs)
+}
+
+// jsonMapKeyEncode writes a map key, JSON object keys are always strings.
+func (t *goBasicValueType) jsonMapKeyEncode(tabs string, targetBuffer string, varName string) string {
+	var writer string
+	switch t.ProtoType {
+	case "string":
+		writer = `%[1]v = gremlin.AppendJSONString(%[1]v, %[2]v)`
+	case "bool":
+		writer = `%[1]v = gremlin.AppendJSONMapKeyBool(%[1]v, %[2]v)`
+	case "int32", "int64", "sint32", "sint64", "sfixed32", "sfixed64":
+		writer = `%[1]v = gremlin.AppendJSONMapKeyInt(%[1]v, int64(%[2]v))`
+	default:
+		writer = `%[1]v = gremlin.AppendJSONMapKeyUint(%[1]v, uint64(%[2]v))`
+	}
+	return formatting.AddTabs(fmt.Sprintf(writer, targetBuffer, varName), tabs)
+}
+
+// jsonMapKeyDecode parses the object key keyVar into targetVar.
+func (t *goBasicValueType) jsonMapKeyDecode(tabs string, targetVar string, keyVar string) string {
+	var parse string
+	switch t.ProtoType {
+	case "string":
+		return formatting.AddTabs(fmt.Sprintf(`%v := %v`, targetVar, keyVar), tabs)
+	case "bool":
+		return formatting.AddTabs(fmt.Sprintf(`%v, err := gremlin.ParseJSONBool(%v)
+if err != nil {
+	return err
+}`, targetVar, keyVar), tabs)
+	case "int32", "sint32", "sfixed32":
+		parse = fmt.Sprintf(`gremlin.ParseJSONInt(%v, 32)`, keyVar)
+	case "int64", "sint64", "sfixed64":
+		parse = fmt.Sprintf(`gremlin.ParseJSONInt(%v, 64)`, keyVar)
+	case "uint32", "fixed32":
+		parse = fmt.Sprintf(`gremlin.ParseJSONUint(%v, 32)`, keyVar)
+	default:
+		parse = fmt.Sprintf(`gremlin.ParseJSONUint(%v, 64)`, keyVar)
+	}
+	return formatting.AddTabs(fmt.Sprintf(`parsedKey, err := %v
+if err != nil {
+	return err
+}
+%v := %v(parsedKey)`, parse, targetVar, t.Name), tabs)
+}
+
+// jsonSortedKeys returns the expression listing the keys of the map varName in protojson order.
+func (t *goBasicValueType) jsonSortedKeys(varName string) string {
+	if t.ProtoType == "bool" {
+		return fmt.Sprintf(`gremlin.SortedBoolKeys(%v)`, varName)
+	}
+	return fmt.Sprintf(`gremlin.SortedKeys(%v)`, varName)
+}
+
+var textBasicTypesWriters = map[string]string{
+	"string":   "w.WriteString(%v)",
+	"bytes":    "w.WriteBytes(%v)",
+	"bool":     "w.WriteBool(%v)",
+	"double":   "w.WriteFloat(%v, 64)",
+	"float":    "w.WriteFloat(float64(%v), 32)",
+	"int32":    "w.WriteInt(int64(%v))",
+	"int64":    "w.WriteInt(%v)",
+	"uint32":   "w.WriteUint(uint64(%v))",
+	"uint64":   "w.WriteUint(%v)",
+	"sint32":   "w.WriteInt(int64(%v))",
+	"sint64":   "w.WriteInt(%v)",
+	"fixed32":  "w.WriteUint(uint64(%v))",
+	"fixed64":  "w.WriteUint(%v)",
+	"sfixed32": "w.WriteInt(int64(%v))",
+	"sfixed64": "w.WriteInt(%v)",
+}
+
+func (t *goBasicValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`w.WriteName(%q)
+%v`, fieldName, fmt.Sprintf(textBasicTypesWriters[t.ProtoType], varName)), tabs)
+}
+
+// TextDecode reuses the JSON readers, TextDecoder has the same scalar methods.
+func (t *goBasicValueType) TextDecode(tabs string, targetVar string) string {
+	return t.JSONDecode(tabs, targetVar)
+}
+
+func (t *goBasicValueType) JSONTranscode(tabs string, offsetVar string) string {
+	return t.JSONEncode(tabs, "s.B", fmt.Sprintf("buf.%v(%v)", bufBasicTypesReaders[t.ProtoType], offsetVar))
+}
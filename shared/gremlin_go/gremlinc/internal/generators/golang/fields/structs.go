@@ -3,6 +3,7 @@ package fields
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
 )
@@ -11,6 +12,49 @@ type goStructValueType struct {
 	StructPackage string
 	StructName    string
 	Required      bool
+	Inline        bool
+	Recursive     bool   // the message can reach the message holding the field again
+	Element       bool   // element of a repeated or map field, read as nil when empty like nil elements are written
+	WellKnown     string // full name of the bundled well-known type, empty for other messages
+
+	storage string // where the reader is placed instead of a separate allocation
+}
+
+func (t *goStructValueType) InlineReaderTypeName() string {
+	if !t.Inline {
+		return ""
+	}
+	return strings.TrimPrefix(t.ReaderTypeName(), "*")
+}
+
+func (t *goStructValueType) SetInlineStorage(varName string) {
+	t.storage = varName
+}
+
+func (t *goStructValueType) WellKnownName() string {
+	return t.WellKnown
+}
+
+func (t *goStructValueType) QualifiedName(name string) string {
+	if t.StructPackage != "" {
+		return t.StructPackage + "." + name
+	}
+	return name
+}
+
+func (t *goStructValueType) MessageName() string {
+	return t.StructName
+}
+
+func (t *goStructValueType) IsRecursive() bool {
+	return t.Recursive
+}
+
+func (t *goStructValueType) newReader() string {
+	if t.storage != "" {
+		return "&" + t.storage
+	}
+	return fmt.Sprintf("gremlin.New[%v](m.buf.Arena())", strings.TrimPrefix(t.ReaderTypeName(), "*"))
 }
 
 func (t *goStructValueType) ReaderTypeName() string {
@@ -28,84 +72,79 @@ func (t *goStructValueType) WriterTypeName() string {
 	}
 }
 
-func (t *goStructValueType) OffsetsType() string {
-	return "int"
-}
-
-func (t *goStructValueType) WireTypeType() string {
-	return ""
-}
-
 func (t *goStructValueType) CanBePacked() bool {
 	return false
 }
 
-func (t *goStructValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string) string {
-	return formatting.AddTabs(
-		fmt.Sprintf(`m.offset%v = offset`, fieldName),
-		tabs,
-	)
+func (t *goStructValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string, _ string) string {
+	return singularSaveOffset(tabs, fieldName)
 }
 
+// EntryReader sets singular fields whenever they are on the wire, even empty like in other protobuf
+// implementations, while empty elements of repeated and map fields read as nil.
 func (t *goStructValueType) EntryReader(tabs string, localVarName string) string {
 	var res string
-	if t.StructPackage == "" {
+	if t.Element {
 		res = fmt.Sprintf(`
-var %v *%vReader
+var %v %v
 if wOffset > 0 {
-	var %vData = m.buf.ReadBytes(wOffset)
+	var %vData = m.buf.ReadMessage(wOffset)
 	if len(%vData) > 0 {
-		%v = New%vReader()
-		%v.Unmarshal(%vData)
+		%v = %v
+		%v.UnmarshalWithOptions(%vData, m.buf.Options())
 	}
 }
-`, localVarName, t.StructName, localVarName, localVarName, localVarName, t.StructName, localVarName, localVarName)
+`, localVarName, t.ReaderTypeName(), localVarName, localVarName, localVarName, t.newReader(), localVarName, localVarName)
 	} else {
 		res = fmt.Sprintf(`
-var %v *%v.%vReader
+var %v %v
 if wOffset > 0 {
-	var %vData = m.buf.ReadBytes(wOffset)
-	if len(%vData) > 0 {
-		%v = %v.New%vReader()
-		%v.Unmarshal(%vData)
-	}
+	%v = %v
+	%v.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 }
-`, localVarName, t.StructPackage, t.StructName, localVarName, localVarName, localVarName, t.StructPackage, t.StructName, localVarName, localVarName)
+`, localVarName, t.ReaderTypeName(), localVarName, t.newReader(), localVarName)
 	}
 
 	return formatting.AddTabs(res, tabs)
 }
 
 func (t *goStructValueType) EntrySizedReader(tabs string, localVarName string) string {
-	var res string
-	if t.StructPackage == "" {
-		res = fmt.Sprintf(`
-var %v *%vReader
+	res := fmt.Sprintf(`
+var %v %v
 var %vSize int
 if wOffset > 0 {
-	var %vData, %vDataSize = m.buf.SizedReadBytes(wOffset)
+	var %vData, %vDataSize = m.buf.SizedReadMessage(wOffset)
 	if len(%vData) > 0 {
-		%v = New%vReader()
-		%v.Unmarshal(%vData)
+		%v = %v
+		%v.UnmarshalWithOptions(%vData, m.buf.Options())
 	}
 	%vSize = %vDataSize
 }
-`, localVarName, t.StructName, localVarName, localVarName, localVarName, localVarName, localVarName, t.StructName, localVarName, localVarName, localVarName, localVarName)
-	} else {
-		res = fmt.Sprintf(`
-var %v *%v.%vReader
-var %vSize int
-if wOffset > 0 {
-	var %vData, %vDataSize = m.buf.SizedReadBytes(wOffset)
-	if len(%vData) > 0 {
-		%v = %v.New%vReader()
-		%v.Unmarshal(%vData)
-	}
-	%vSize = %vDataSize
+`, localVarName, t.ReaderTypeName(), localVarName, localVarName, localVarName, localVarName, localVarName, t.newReader(), localVarName, localVarName, localVarName, localVarName)
+	return formatting.AddTabs(res, tabs)
 }
-`, localVarName, t.StructPackage, t.StructName, localVarName, localVarName, localVarName, localVarName, localVarName, t.StructPackage, t.StructName, localVarName, localVarName, localVarName, localVarName)
+
+func (t *goStructValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	if t.Element {
+		return formatting.AddTabs(fmt.Sprintf(`if entryData := buf.ReadMessage(%v); len(entryData) > 0 {
+	var entry = &%v{}
+	if err := entry.Unmarshal(entryData); err != nil {
+		return err
 	}
-	return formatting.AddTabs(res, tabs)
+	%v = entry
+} else {
+	%v = nil
+}`, offsetVar, strings.TrimPrefix(t.WriterTypeName(), "*"), targetVar, targetVar), tabs)
+	}
+	return formatting.AddTabs(fmt.Sprintf(`var entry = &%v{}
+if err := entry.Unmarshal(buf.ReadMessage(%v)); err != nil {
+	return err
+}
+%v = entry`, strings.TrimPrefix(t.WriterTypeName(), "*"), offsetVar, targetVar), tabs)
+}
+
+func (t *goStructValueType) EntryDefault(string, string) string {
+	return ""
 }
 
 func (t *goStructValueType) ToStruct(tabs string, targetVar string, readerField string) string {
@@ -115,6 +154,14 @@ func (t *goStructValueType) ToStruct(tabs string, targetVar string, readerField
 	return formatting.AddTabs(res, tabs)
 }
 
+func (t *goStructValueType) Freeze(tabs string, readerField string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v.Freeze()`, readerField), tabs)
+}
+
+func (t *goStructValueType) Rebase(tabs string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v.XXX_Rebase(d)`, varName), tabs)
+}
+
 func (t *goStructValueType) EntryIsNotEmpty(localVarName string) string {
 	if t.Required {
 		return "true"
@@ -158,3 +205,40 @@ func (t *goStructValueType) EntryCopy(tabs string, targetVar string, srcVar stri
 	%v = %v.Copy()
 }`, srcVar, targetVar, srcVar), tabs)
 }
+
+func (t *goStructValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = %v.AppendJSON(%v)`, targetBuffer, varName, targetBuffer), tabs)
+}
+
+func (t *goStructValueType) JSONDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value := &%v{}
+if err := value.XXX_DecodeJSON(d); err != nil {
+	return err
+}
+%v = value`, strings.TrimPrefix(t.WriterTypeName(), "*"), targetVar), tabs)
+}
+
+func (t *goStructValueType) JSONTranscode(tabs string, offsetVar string) string {
+	transcoder := "XXX_Transcode" + t.StructName + "JSON"
+	if t.StructPackage != "" {
+		transcoder = t.StructPackage + "." + transcoder
+	}
+	return formatting.AddTabs(fmt.Sprintf(`if err := %v(s, buf.ReadMessage(%v)); err != nil {
+	return err
+}`, transcoder, offsetVar), tabs)
+}
+
+func (t *goStructValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`w.WriteName(%q)
+w.StartMessage()
+%v.XXX_WriteText(w)
+w.EndMessage()`, fieldName, varName), tabs)
+}
+
+func (t *goStructValueType) TextDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value := &%v{}
+if err := value.XXX_DecodeText(d); err != nil {
+	return err
+}
+%v = value`, strings.TrimPrefix(t.WriterTypeName(), "*"), targetVar), tabs)
+}
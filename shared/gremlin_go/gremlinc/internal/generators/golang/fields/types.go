@@ -3,6 +3,7 @@ package fields
 import (
 	"fmt"
 
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 )
@@ -14,6 +15,99 @@ type GoEntitiesProvider interface {
 	FindEnumInImports(file *types.ProtoFile, enumType *types.EnumDefinition) (string, core.GoType)
 	FindStruct(msgType *types.MessageDefinition) core.GoType
 	FindStructInImports(file *types.ProtoFile, msgType *types.MessageDefinition) (string, core.GoType)
+	IsReaderInlined(field *types.MessageFieldDefinition) bool
+	IsRecursive(field *types.MessageFieldDefinition) bool
+}
+
+// packedDecoder is implemented by types that can be stored in packed lists.
+type packedDecoder interface {
+	// should produce targetVar and sizeVar from the packed entry at offsetVar
+	packedDecode(tabs string, targetVar string, sizeVar string, offsetVar string) string
+}
+
+var scalarWireTypes = map[string]string{
+	"string":   "gremlin.BytesType",
+	"bytes":    "gremlin.BytesType",
+	"bool":     "gremlin.VarIntType",
+	"double":   "gremlin.Fixed64Type",
+	"float":    "gremlin.Fixed32Type",
+	"int32":    "gremlin.VarIntType",
+	"int64":    "gremlin.VarIntType",
+	"uint32":   "gremlin.VarIntType",
+	"uint64":   "gremlin.VarIntType",
+	"sint32":   "gremlin.VarIntType",
+	"sint64":   "gremlin.VarIntType",
+	"fixed32":  "gremlin.Fixed32Type",
+	"fixed64":  "gremlin.Fixed64Type",
+	"sfixed32": "gremlin.Fixed32Type",
+	"sfixed64": "gremlin.Fixed64Type",
+}
+
+// wireType returns the wire type constant a single value of t is encoded with, for lists the one of an element.
+func wireType(t core.GoFieldType) string {
+	switch t := t.(type) {
+	case *goBasicValueType:
+		return scalarWireTypes[t.ProtoType]
+	case *goEnumValueType:
+		return "gremlin.VarIntType"
+	case *goRepeatedValueType:
+		return wireType(t.RepeatedType)
+	case *goRepeatedPackedValueType:
+		return wireType(t.RepeatedType)
+	default: // messages and maps
+		return "gremlin.BytesType"
+	}
+}
+
+// WireTypeCheck returns code failing with gremlin.WireTypeError unless wireVar is a wire type t can be encoded
+// with, packable lists accept both encodings. Decoders reading values right away run it first.
+func WireTypeCheck(tabs string, t core.GoFieldType, tagVar string, wireVar string) string {
+	condition := fmt.Sprintf("%v != %v", wireVar, wireType(t))
+	if _, packed := t.(*goRepeatedPackedValueType); packed {
+		condition += fmt.Sprintf(" && %v != gremlin.BytesType", wireVar)
+	}
+	return formatting.AddTabs(fmt.Sprintf(`if %v {
+	return gremlin.WireTypeError(%v, %v)
+}`, condition, tagVar, wireVar), tabs)
+}
+
+// IsMessage reports whether t is a singular message field, its occurrences merge instead of replacing each other.
+func IsMessage(t core.GoFieldType) bool {
+	switch t.(type) {
+	case *goStructValueType, *goJSONValueType, *goWrapperValueType:
+		return true
+	}
+	return false
+}
+
+// JSONValueSetter returns the Go type, the setter suffix and the qualified wkt function validating values
+// of a singular Struct, ListValue or Value field, and ok false for other types.
+func JSONValueSetter(t core.GoFieldType) (goType string, suffix string, check string, ok bool) {
+	jsonValue, ok := t.(*goJSONValueType)
+	if !ok {
+		return "", "", "", false
+	}
+	return jsonValue.GoType, jsonValue.Helper, jsonValue.QualifiedName(jsonValue.Check), true
+}
+
+// Unpacked returns t written with a tag for every element if it is a packable list, other types as they are.
+// Decoders of both accept either encoding.
+func Unpacked(t core.GoFieldType) core.GoFieldType {
+	if packed, ok := t.(*goRepeatedPackedValueType); ok {
+		return &goRepeatedValueType{RepeatedType: packed.RepeatedType, Required: packed.Required}
+	}
+	return t
+}
+
+func singularSaveOffset(tabs string, fieldName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`m.offset%v = int32(offset)`, fieldName), tabs)
+}
+
+func repeatedSaveOffset(tabs string, fieldIndex string) string {
+	return formatting.AddTabs(
+		fmt.Sprintf(`m.repeated = append(m.repeated, gremlin.FieldOffset{Field: %v, Offset: int32(offset), Wire: wire})`, fieldIndex),
+		tabs,
+	)
 }
 
 func ResolveType(targetFile GoEntitiesProvider, field *types.MessageFieldDefinition) (core.GoFieldType, error) {
@@ -144,6 +238,13 @@ func resolveMsgType(targetFile GoEntitiesProvider, field *types.MessageFieldDefi
 		StructPackage: msgPackage,
 		StructName:    msgType.GetName(),
 		Required:      field.Required,
+		Inline:        targetFile.IsReaderInlined(field),
+		Recursive:     targetFile.IsRecursive(field),
+		Element:       field.Repeated || field.Map,
+	}
+	// copies of the well-known types among the sources are generated without the wkt conversions
+	if field.ExternalTypeFile != nil && field.ExternalTypeFile.WellKnown {
+		valueType.WellKnown = field.ExternalMsgType.Name.String()
 	}
 
 	if field.Repeated {
@@ -161,6 +262,15 @@ func resolveMsgType(targetFile GoEntitiesProvider, field *types.MessageFieldDefi
 			ValueType: valueType,
 		}, nil
 	} else {
+		if _, ok := wrapperValueTypes[valueType.WellKnown]; ok {
+			return newWrapperValueType(valueType), nil
+		}
+		if _, ok := jsonValueKinds[valueType.WellKnown]; ok {
+			return newJSONValueType(valueType), nil
+		}
+		if valueType.WellKnown == core.WellKnownTimestamp || valueType.WellKnown == core.WellKnownDuration {
+			targetFile.AddImport("time", "time")
+		}
 		return valueType, nil
 	}
 }
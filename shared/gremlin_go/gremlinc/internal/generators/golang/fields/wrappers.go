@@ -0,0 +1,214 @@
+package fields
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
+)
+
+// wrapperValueTypes maps the well-known wrapper messages to the proto type of their value field.
+var wrapperValueTypes = map[string]string{
+	"google.protobuf.DoubleValue": "double",
+	"google.protobuf.FloatValue":  "float",
+	"google.protobuf.Int64Value":  "int64",
+	"google.protobuf.UInt64Value": "uint64",
+	"google.protobuf.Int32Value":  "int32",
+	"google.protobuf.UInt32Value": "uint32",
+	"google.protobuf.BoolValue":   "bool",
+	"google.protobuf.StringValue": "string",
+	"google.protobuf.BytesValue":  "bytes",
+}
+
+// goWrapperValueType is a singular field of a wrapper type such as google.protobuf.StringValue. Structs keep a
+// pointer to the value, nil when the field is unset, and reader getters return the value and whether it is set.
+// On the wire it stays a message with the value in field 1.
+type goWrapperValueType struct {
+	Value  *goBasicValueType
+	Struct *goStructValueType // the wrapper message, used to parse the text format
+}
+
+func newWrapperValueType(structType *goStructValueType) *goWrapperValueType {
+	protoType := wrapperValueTypes[structType.WellKnown]
+	return &goWrapperValueType{
+		Value:  &goBasicValueType{Name: goBasicTypesMap[protoType], ProtoType: protoType},
+		Struct: structType,
+	}
+}
+
+func (t *goWrapperValueType) ValueTypeName() string {
+	return t.Value.Name
+}
+
+func (t *goWrapperValueType) ZeroValue() string {
+	return t.Value.DefaultReturn()
+}
+
+// readWrapper returns the expression decoding the wrapper message at offsetVar of buf into value and err.
+func (t *goWrapperValueType) readWrapper(buf string, offsetVar string) string {
+	return fmt.Sprintf(`gremlin.ReadWrapper(%[1]v.ReadMessage(%[2]v), %[1]v.Options(), %[3]v, (*gremlin.Reader).%[4]v)`,
+		buf, offsetVar, wireType(t.Value), bufBasicTypesReaders[t.Value.ProtoType])
+}
+
+func (t *goWrapperValueType) ReaderTypeName() string {
+	return "*" + t.Value.Name
+}
+
+func (t *goWrapperValueType) WriterTypeName() string {
+	return "*" + t.Value.Name
+}
+
+func (t *goWrapperValueType) CanBePacked() bool {
+	return false
+}
+
+func (t *goWrapperValueType) EntryUnmarshalSaveOffsets(tabs string, fieldName string, _ string) string {
+	return singularSaveOffset(tabs, fieldName)
+}
+
+func (t *goWrapperValueType) EntryReader(tabs string, localVarName string) string {
+	res := fmt.Sprintf(`
+var %[1]v %[2]v
+if wOffset > 0 {
+	if value, err := %[3]v; err == nil {
+		%[1]v = &value
+	}
+}
+`, localVarName, t.ReaderTypeName(), t.readWrapper("m.buf", "wOffset"))
+	return formatting.AddTabs(res, tabs)
+}
+
+func (t *goWrapperValueType) EntrySizedReader(tabs string, localVarName string) string {
+	res := fmt.Sprintf(`
+var %[1]v %[2]v
+var %[1]vSize int
+if wOffset > 0 {
+	if value, err := %[3]v; err == nil {
+		%[1]v = &value
+	}
+	_, %[1]vSize = m.buf.SizedReadMessage(wOffset)
+}
+`, localVarName, t.ReaderTypeName(), t.readWrapper("m.buf", "wOffset"))
+	return formatting.AddTabs(res, tabs)
+}
+
+func (t *goWrapperValueType) EntryDecode(tabs string, targetVar string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := %v
+if err != nil {
+	return err
+}
+%v = &value`, t.readWrapper("buf", offsetVar), targetVar), tabs)
+}
+
+func (t *goWrapperValueType) EntryDefault(string, string) string {
+	return ""
+}
+
+func (t *goWrapperValueType) ToStruct(tabs string, targetVar string, readerField string) string {
+	return formatting.AddTabs(fmt.Sprintf(`if %[2]v != nil {
+	value := *%[2]v
+	%[1]v = &value
+}`, targetVar, readerField), tabs)
+}
+
+func (t *goWrapperValueType) Freeze(string, string) string {
+	return ""
+}
+
+func (t *goWrapperValueType) Rebase(tabs string, varName string) string {
+	rebase := t.Value.Rebase("\t", "*"+varName)
+	if rebase == "" {
+		return ""
+	}
+	return formatting.AddTabs(fmt.Sprintf(`if %v != nil {
+%v
+}`, varName, rebase), tabs)
+}
+
+func (t *goWrapperValueType) EntryIsNotEmpty(localVarName string) string {
+	return fmt.Sprintf(`%v != nil`, localVarName)
+}
+
+// valueSize adds the size of the wrapper message to sizeVarName, the value is left out when it is the default.
+func (t *goWrapperValueType) valueSize(sizeVarName string, fieldName string) string {
+	return fmt.Sprintf(`if %v {
+%v
+}`, t.Value.EntryIsNotEmpty("*"+fieldName), t.Value.EntryFullSizeWithTag("\t", sizeVarName, "*"+fieldName, "1"))
+}
+
+func (t *goWrapperValueType) EntryFullSizeWithTag(tabs string, sizeVarName string, fieldName string, fieldTag string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = 0
+%v
+%v += gremlin.SizeUint64(uint64(%v)) + gremlin.SizeTag(%v)`, sizeVarName, t.valueSize(sizeVarName, fieldName), sizeVarName, sizeVarName, fieldTag), tabs)
+}
+
+func (t *goWrapperValueType) EntryFullSizeWithoutTag(tabs string, sizeVarName string, fieldName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`%v = 0
+%v
+%v += gremlin.SizeUint64(uint64(%v))`, sizeVarName, t.valueSize(sizeVarName, fieldName), sizeVarName, sizeVarName), tabs)
+}
+
+func (t *goWrapperValueType) EntryWriter(tabs string, targetBuffer string, tag string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`wrapperSize := 0
+%v
+%v.AppendBytesTag(%v, wrapperSize)
+if %v {
+%v
+}`, t.valueSize("wrapperSize", varName), targetBuffer, tag, t.Value.EntryIsNotEmpty("*"+varName), t.Value.EntryWriter("\t", targetBuffer, "1", "*"+varName)), tabs)
+}
+
+func (t *goWrapperValueType) PackedEntryWriter(string, string, string) string {
+	log.Panicf("PackedEntryWriter should not be called on a wrapper value type")
+	return ""
+}
+
+func (t *goWrapperValueType) DefaultReturn() string {
+	return "nil"
+}
+
+func (t *goWrapperValueType) JsonStructCanBeUsedDirectly() bool {
+	return false
+}
+
+func (t *goWrapperValueType) EntryCopy(tabs string, targetVar string, srcVar string) string {
+	return t.ToStruct(tabs, targetVar, srcVar)
+}
+
+// JSONEncode writes the bare value, the JSON mapping of wrappers.
+func (t *goWrapperValueType) JSONEncode(tabs string, targetBuffer string, varName string) string {
+	return t.Value.JSONEncode(tabs, targetBuffer, "*"+varName)
+}
+
+func (t *goWrapperValueType) JSONDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := d.%v()
+if err != nil {
+	return err
+}
+%v = &value`, jsonBasicTypesReaders[t.Value.ProtoType], targetVar), tabs)
+}
+
+func (t *goWrapperValueType) JSONTranscode(tabs string, offsetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value, err := %v
+if err != nil {
+	return err
+}
+%v`, t.readWrapper("buf", offsetVar), t.Value.JSONEncode("", "s.B", "value")), tabs)
+}
+
+// TextEncode writes the wrapper message, the text format has no special mapping for wrappers.
+func (t *goWrapperValueType) TextEncode(tabs string, fieldName string, varName string) string {
+	return formatting.AddTabs(fmt.Sprintf(`w.WriteName(%q)
+w.StartMessage()
+if %v {
+%v
+}
+w.EndMessage()`, fieldName, t.Value.EntryIsNotEmpty("*"+varName), t.Value.TextEncode("\t", "value", "*"+varName)), tabs)
+}
+
+func (t *goWrapperValueType) TextDecode(tabs string, targetVar string) string {
+	return formatting.AddTabs(fmt.Sprintf(`value := &%v{}
+if err := value.XXX_DecodeText(d); err != nil {
+	return err
+}
+%v = &value.Value`, t.Struct.QualifiedName(t.Struct.StructName), targetVar), tabs)
+}
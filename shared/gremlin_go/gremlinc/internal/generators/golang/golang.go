@@ -8,19 +8,47 @@ import (
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 )
 
+// GeneratedFile is the code generated for one proto file.
+type GeneratedFile struct {
+	ProtoFile *types.ProtoFile
+	Path      string
+	Content   string
+}
+
 func Generate(root string, modulePath string, targets []*types.ProtoFile) []error {
-	mapped, errors := mapper.MapProtoFiles(root, modulePath, targets)
+	files, errors := GenerateFiles(root, modulePath, targets)
 	if len(errors) > 0 {
 		return errors
 	}
 
-	for _, target := range mapped {
-		content := target.GenerateCode()
-		_ = os.MkdirAll(filepath.Dir(target.FullOutputPath), 0755)
-		if err := os.WriteFile(target.FullOutputPath, []byte(content), 0644); err != nil {
+	for _, file := range files {
+		// well-known types are generated into the runtime, imports from include paths on their own
+		if file.ProtoFile.WellKnown || file.ProtoFile.Dependency {
+			continue
+		}
+		_ = os.MkdirAll(filepath.Dir(file.Path), 0755)
+		if err := os.WriteFile(file.Path, []byte(file.Content), 0644); err != nil {
 			errors = append(errors, err)
 		}
 	}
 
 	return errors
 }
+
+// GenerateFiles generates the code of all targets without writing it, output paths start with root.
+func GenerateFiles(root string, modulePath string, targets []*types.ProtoFile) ([]*GeneratedFile, []error) {
+	mapped, errors := mapper.MapProtoFiles(root, modulePath, targets)
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	var res []*GeneratedFile
+	for _, target := range mapped {
+		res = append(res, &GeneratedFile{
+			ProtoFile: target.ProtoFile,
+			Path:      target.FullOutputPath,
+			Content:   target.GenerateCode(),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package mapper
+
+import (
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+// maxInlineReaderSize is a rough limit (in bytes) for a child reader embedded into
+// its parent reader. Bigger children are still allocated on first access.
+const maxInlineReaderSize = 1024
+
+// readerInliner decides which singular message fields keep their reader inside the parent.
+// A child is never embedded when it can reach the parent again (recursive messages),
+// otherwise the reader type would contain itself.
+type readerInliner struct {
+	reachable map[*types.MessageDefinition]map[*types.MessageDefinition]bool
+	sizes     map[*types.MessageDefinition]int
+	inlined   map[*types.MessageFieldDefinition]bool
+	recursive map[*types.MessageFieldDefinition]bool
+}
+
+// findInlinedReaders returns the inlined fields and the recursive ones, whose message can reach the parent again.
+func findInlinedReaders(files []*types.ProtoFile) (map[*types.MessageFieldDefinition]bool, map[*types.MessageFieldDefinition]bool) {
+	inliner := &readerInliner{
+		reachable: map[*types.MessageDefinition]map[*types.MessageDefinition]bool{},
+		sizes:     map[*types.MessageDefinition]int{},
+		inlined:   map[*types.MessageFieldDefinition]bool{},
+		recursive: map[*types.MessageFieldDefinition]bool{},
+	}
+	for _, file := range files {
+		for _, msg := range file.Messages {
+			inliner.readerSize(msg)
+		}
+	}
+	return inliner.inlined, inliner.recursive
+}
+
+func singularMessageType(field *types.MessageFieldDefinition) *types.MessageDefinition {
+	if field.Repeated || field.Map {
+		return nil
+	}
+	if field.LocalMsgType != nil {
+		return field.LocalMsgType
+	}
+	return field.ExternalMsgType
+}
+
+func (r *readerInliner) reaches(from *types.MessageDefinition, to *types.MessageDefinition) bool {
+	if set, ok := r.reachable[from]; ok {
+		return set[to]
+	}
+	set := map[*types.MessageDefinition]bool{}
+	var queue = []*types.MessageDefinition{from}
+	for len(queue) > 0 {
+		msg := queue[0]
+		queue = queue[1:]
+		for _, field := range msg.Fields {
+			child := singularMessageType(field)
+			if child == nil || set[child] {
+				continue
+			}
+			set[child] = true
+			queue = append(queue, child)
+		}
+	}
+	r.reachable[from] = set
+	return set[to]
+}
+
+// readerSize estimates the size of the generated reader, including inlined children.
+func (r *readerInliner) readerSize(msg *types.MessageDefinition) int {
+	if size, ok := r.sizes[msg]; ok {
+		return size
+	}
+	// buf, parsed bitset and offsets of repeated fields
+	var size = 64 + 16*len(msg.Fields)
+	for _, field := range msg.Fields {
+		size += 8
+		child := singularMessageType(field)
+		if child == nil {
+			continue
+		}
+		if r.reaches(child, msg) {
+			r.recursive[field] = true
+			continue
+		}
+		if childSize := r.readerSize(child); size+childSize <= maxInlineReaderSize {
+			size += childSize
+			r.inlined[field] = true
+		}
+	}
+	r.sizes[msg] = size
+	return size
+}
@@ -35,8 +35,11 @@ func MapProtoFiles(root string, modulePath string, files []*types.ProtoFile) ([]
 		return nil, errors
 	}
 
+	inlinedReaders, recursiveFields := findInlinedReaders(files)
 	for i, target := range result {
 		result[i].FullOutputPath = buildOutputPath(root, target)
+		result[i].InlinedReaders = inlinedReaders
+		result[i].RecursiveFields = recursiveFields
 	}
 
 	// now we have package names, imports and aliases for imports
@@ -44,16 +47,23 @@ func MapProtoFiles(root string, modulePath string, files []*types.ProtoFile) ([]
 	for i := range result {
 		goFile := result[i]
 		var fileStructs []*gotypes.GoStructType
+		var fileEnums []*gotypes.GoEnumType
 		for j := range goFile.ProtoFile.Enums {
 			enumDef := goFile.ProtoFile.Enums[j]
-			goFile.AddEnum(gotypes.NewEnumType(enumDef))
+			goEnum := gotypes.NewEnumType(enumDef)
+			goEnum.File = goFile.ProtoFile.RelativePath
+			goFile.AddEnum(goEnum)
+			fileEnums = append(fileEnums, goEnum)
 		}
 		for j := range goFile.ProtoFile.Messages {
 			messageDef := goFile.ProtoFile.Messages[j]
 			goStruct := gotypes.NewStructType(messageDef)
+			goStruct.File = goFile.ProtoFile.RelativePath
+			goStruct.WellKnown = goFile.ProtoFile.WellKnown
 			goFile.AddStruct(goStruct)
 			fileStructs = append(fileStructs, goStruct)
 		}
+		mapNestedTypes(fileStructs, fileEnums)
 		structs = append(structs, fileStructs)
 	}
 
@@ -73,11 +83,35 @@ func MapProtoFiles(root string, modulePath string, files []*types.ProtoFile) ([]
 				goMessageDef.AddField(fieldDef, fieldType)
 			}
 		}
+		for _, extDef := range goFile.ProtoFile.Extensions {
+			fieldType, err := fields.ResolveType(goFile, extDef.Field)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("file: %v, %w", goFile.ProtoFile.RelativePath, err))
+				continue
+			}
+			goFile.AddExtension(gotypes.NewExtensionType(extDef, fieldType, extDef.Field.Packed(goFile.IsProto2())))
+		}
 	}
 
 	return result, errors
 }
 
+// mapNestedTypes links every message to the messages and enums declared inside it, for descriptors.
+func mapNestedTypes(structs []*gotypes.GoStructType, enums []*gotypes.GoEnumType) {
+	for _, parent := range structs {
+		for _, nested := range structs {
+			if nested.IsNestedIn(parent) {
+				parent.NestedMessages = append(parent.NestedMessages, nested)
+			}
+		}
+		for _, nested := range enums {
+			if nested.IsNestedIn(parent) {
+				parent.NestedEnums = append(parent.NestedEnums, nested)
+			}
+		}
+	}
+}
+
 func getGoPackage(outBase string, modulePath string, target *types.ProtoFile) (string, string) {
 	var pkgName = filepath.Dir(target.RelativePath)
 	var protoName = ""
@@ -9,6 +9,7 @@ import (
 
 type GoEnumType struct {
 	EnumName string
+	File     string // path of the proto file, used in the descriptor
 
 	enumValuesPrefix string
 	Values           []*goEnumVariant
@@ -83,6 +84,8 @@ func (g *GoEnumType) GenerateCode(sb *strings.Builder) {
 	g.writeEnumHeader(sb)
 	g.writeEnumConstants(sb)
 	g.writeEnumToString(sb)
+	g.writeEnumValues(sb)
+	g.writeDescriptor(sb)
 }
 
 func (g *GoEnumType) writeEnumHeader(sb *strings.Builder) {
@@ -117,3 +120,40 @@ func (e %v) String() string {
 }
 `)
 }
+
+// writeEnumValues writes the lookup by value name, used to decode enums from JSON.
+func (g *GoEnumType) writeEnumValues(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf("\nvar %v_value = map[string]int32{\n", g.EnumName))
+	for _, v := range g.Values {
+		sb.WriteString(fmt.Sprintf("\t%q: %v,\n", v.Proto.Name.ProtoName(), v.Value))
+	}
+	sb.WriteString("}\n")
+}
+
+func (g *GoEnumType) descriptorVarName() string {
+	return "descriptor" + g.EnumName
+}
+
+// IsNestedIn reports whether the enum is declared inside parent.
+func (g *GoEnumType) IsNestedIn(parent *GoStructType) bool {
+	return len(g.Proto.Name.LocalPath()) > 0 && g.Proto.Name.ToParent().String() == parent.Proto.Name.String()
+}
+
+func (g *GoEnumType) writeDescriptor(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+var %v = &gremlin.EnumDescriptor{
+	FullName: %q,
+	File:     %q,
+	Values: []gremlin.EnumValueDescriptor{
+`, g.descriptorVarName(), g.Proto.Name.String(), g.File))
+	for _, v := range g.Values {
+		sb.WriteString(fmt.Sprintf("\t\t{Name: %q, Number: %v},\n", v.Proto.Name.ProtoName(), v.Value))
+	}
+	sb.WriteString(fmt.Sprintf(`	},
+}
+
+func init() {
+	gremlin.RegisterEnum(%v)
+}
+`, g.descriptorVarName()))
+}
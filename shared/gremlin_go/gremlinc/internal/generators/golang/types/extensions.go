@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/formatting"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/fields"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+// GoExtensionType is a field of an extend block, generated as a typed gremlin.Extension. Its value is
+// decoded from and encoded to the unknown fields of the extended message with the code of a struct field.
+type GoExtensionType struct {
+	VarName  string // E_ followed by the Go names of the scope and the field, as protoc-gen-go does
+	Extended string // full name of the extended message
+	Proto    *types.ExtensionDefinition
+	Field    *GoStructField
+	Encoded  core.GoFieldType // Field.Type, or its unpacked form for repeated extensions that aren't packed
+}
+
+func NewExtensionType(def *types.ExtensionDefinition, fieldType core.GoFieldType, packed bool) *GoExtensionType {
+	field := &GoStructField{
+		Proto: def.Field,
+		Type:  fieldType,
+	}
+	field.parseName(def.Field)
+	res := &GoExtensionType{
+		VarName:  "E_" + field.Name,
+		Extended: def.ExtendedType().Name.String(),
+		Proto:    def,
+		Field:    field,
+		Encoded:  fieldType,
+	}
+	if !packed {
+		res.Encoded = fields.Unpacked(fieldType)
+	}
+	if def.Scope != nil {
+		res.VarName = "E_" + NewStructType(def.Scope).StructName + "_" + field.Name
+	}
+	return res
+}
+
+func (g *GoExtensionType) wireTypeConstName() string {
+	return "wire" + g.VarName
+}
+
+// encodeCondition returns the condition of writing the value: set scalars are written even if they are
+// zero, so HasExtension reports them, only empty lists and nil messages are left out.
+func (g *GoExtensionType) encodeCondition() string {
+	if g.Field.isSingular() && !fields.IsMessage(g.Field.Type) {
+		return "true"
+	}
+	return g.Encoded.EntryIsNotEmpty("s.Value")
+}
+
+func (g *GoExtensionType) GenerateCode(sb *strings.Builder) {
+	valueType := g.Field.Type.WriterTypeName()
+	wire := g.wireTypeConstName()
+	var defaults string
+	if def := g.Field.Type.EntryDefault("\t\t", "s.Value"); def != "" {
+		defaults = "\n" + def
+	}
+	encodeSize := g.Encoded.EntryFullSizeWithTag("\t\t", "size", "s.Value", wire)
+	encode := g.Encoded.EntryWriter("\t\t", "res", wire, "s.Value")
+	if condition := g.encodeCondition(); condition != "true" {
+		encodeSize = fmt.Sprintf("\t\tif %v {\n%v\n\t\t}", condition, formatting.AddTabs(encodeSize, "\t"))
+		encode = fmt.Sprintf("\t\tif %v {\n%v\n\t\t}", condition, formatting.AddTabs(encode, "\t"))
+	}
+	sb.WriteString(fmt.Sprintf(`
+const %v gremlin.ProtoWireNumber = %v
+
+// %v is the extension %v of %v.
+var %v = &gremlin.Extension[%v]{
+	ExtensionDescriptor: gremlin.ExtensionDescriptor{
+		FullName: %q,
+		Extended: %q,
+		Field:    &gremlin.FieldDescriptor{%v},
+	},
+	Decode: func(data []byte) (%v, error) {
+		var s struct{ Value %v }%v
+		err := gremlin.XXX_RangeFields(data, func(buf *gremlin.Reader, tag gremlin.ProtoWireNumber, wire gremlin.ProtoWireType, offset int) error {
+			if tag == %v {
+%v
+%v
+			}
+			return nil
+		})
+		return s.Value, err
+	},
+	Encode: func(value %v) []byte {
+		s := struct{ Value %v }{value}
+		var size = 0
+%v
+		res := gremlin.NewWriter(size)
+%v
+		return res.Bytes()
+	},
+}
+
+func init() {
+	gremlin.RegisterExtension(&%v.ExtensionDescriptor)
+}
+`,
+		wire, g.Proto.Field.ProtoDef.Sequence,
+		g.VarName, g.Proto.Field.Name.String(), g.Extended,
+		g.VarName, valueType,
+		g.Proto.Field.Name.String(), g.Extended, g.Field.descriptorContents(),
+		valueType, valueType, defaults,
+		wire, fields.WireTypeCheck("\t\t\t\t", g.Field.Type, "tag", "wire"), g.Field.Type.EntryDecode("\t\t\t\t", "s.Value", "offset"),
+		valueType, valueType,
+		encodeSize, encode,
+		g.VarName))
+}
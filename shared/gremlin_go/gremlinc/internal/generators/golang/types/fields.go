@@ -5,16 +5,37 @@ import (
 	"strings"
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/fields"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 )
 
 type GoStructField struct {
 	Struct *GoStructType
 	Name   string
+	Index  int
 	Type   core.GoFieldType
 	Proto  *types.MessageFieldDefinition
 }
 
+// reservedFieldNames are methods of the generated struct, fields with these names get
+// a trailing underscore like protoc-gen-go does.
+var reservedFieldNames = map[string]bool{
+	"Unmarshal":     true,
+	"Marshal":       true,
+	"MarshalTo":     true,
+	"Copy":          true,
+	"AppendJSON":    true,
+	"MarshalJSON":   true,
+	"UnmarshalJSON": true,
+	"MarshalText":   true,
+	"UnmarshalText": true,
+	"String":        true,
+	"Format":        true,
+	"Descriptor":    true,
+	"GetField":      true,
+	"SetField":      true,
+}
+
 func (g *GoStructField) parseName(field *types.MessageFieldDefinition) {
 	var name = field.Name.ProtoName()
 	targetNameShouldUpper := true
@@ -32,6 +53,9 @@ func (g *GoStructField) parseName(field *types.MessageFieldDefinition) {
 			}
 		}
 	}
+	if reservedFieldNames[resName] {
+		resName += "_"
+	}
 	g.Name = resName
 }
 
@@ -43,32 +67,162 @@ func (g *GoStructField) writeWireTypeConst(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf("\t%v gremlin.ProtoWireNumber = %v\n", g.wireTypeConstName(), g.Proto.ProtoDef.Sequence))
 }
 
+func (g *GoStructField) fieldIndexConstName() string {
+	return fmt.Sprintf(`Field%v_%v`, g.Struct.StructName, g.Name)
+}
+
+func (g *GoStructField) writeFieldIndexConst(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf("\t%v gremlin.FieldIndex = %v\n", g.fieldIndexConstName(), g.Index))
+}
+
+func (g *GoStructField) isSingular() bool {
+	return !g.Proto.Repeated && !g.Proto.Map
+}
+
 func (g *GoStructField) writeProtoStructData(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf("\tdata%v     %v\n", g.Name, g.Type.ReaderTypeName()))
 }
 
 func (g *GoStructField) writeProtoStructOffset(sb *strings.Builder) {
-	wType := g.Type.WireTypeType()
-	oType := g.Type.OffsetsType()
+	if g.isSingular() {
+		sb.WriteString(fmt.Sprintf("\toffset%v   int32\n", g.Name))
+	}
+}
 
-	var wTypeCode = ""
-	if wType != "" {
-		wTypeCode = fmt.Sprintf("\n\twireType%v %v", g.Name, wType)
+func (g *GoStructField) inlineReaderTypeName() string {
+	if inline, ok := g.Type.(core.GoInlineReaderType); ok {
+		return inline.InlineReaderTypeName()
 	}
+	return ""
+}
 
-	sb.WriteString(fmt.Sprintf("\toffset%v   %v%v\n", g.Name, oType, wTypeCode))
+func (g *GoStructField) writeProtoStructInline(sb *strings.Builder) {
+	if typeName := g.inlineReaderTypeName(); typeName != "" {
+		sb.WriteString(fmt.Sprintf("\tinline%v   %v\n", g.Name, typeName))
+	}
 }
 
-func (g *GoStructField) writeProtoStructParsedFlag(sb *strings.Builder) {
-	sb.WriteString(fmt.Sprintf("\tparsed%v   bool\n", g.Name))
+// parsedFlag returns the word and the mask of the field inside the reader parsed bitset.
+func (g *GoStructField) parsedFlag() (int, string) {
+	return g.Index / 64, fmt.Sprintf("1 << %v", g.Index%64)
 }
 
 func (g *GoStructField) writeAccessors(sb *strings.Builder) {
 	g.writeGetter(sb)
 	g.writeReader(sb)
+	g.writeTimeGetter(sb)
+}
+
+// timeConversion returns the accessor suffix and the Go type of Timestamp and Duration fields.
+func (g *GoStructField) timeConversion() (string, string, core.GoWellKnownType) {
+	wellKnown, ok := g.Type.(core.GoWellKnownType)
+	if !ok {
+		return "", "", nil
+	}
+	var suffix, goType string
+	switch wellKnown.WellKnownName() {
+	case core.WellKnownTimestamp:
+		suffix, goType = "Time", "time.Time"
+	case core.WellKnownDuration:
+		suffix, goType = "Duration", "time.Duration"
+	default:
+		return "", "", nil
+	}
+	// a field named like the accessor keeps its getter
+	if g.Struct.hasField(g.Name + suffix) {
+		return "", "", nil
+	}
+	return suffix, goType, wellKnown
+}
+
+func (g *GoStructField) writeTimeGetter(sb *strings.Builder) {
+	suffix, goType, wellKnown := g.timeConversion()
+	if wellKnown == nil {
+		return
+	}
+	convert, zero := "AsTime", "time.Time{}"
+	if goType == "time.Duration" {
+		convert, zero = "AsDuration", "0"
+	}
+	sb.WriteString(fmt.Sprintf(`
+// Get%[2]v%[3]v returns %[4]v as a %[5]v, %[7]v if it is unset or out of range.
+func (m *%[1]vReader) Get%[2]v%[3]v() %[5]v {
+	value, err := m.Get%[2]v().%[6]v()
+	if err != nil {
+		return %[7]v
+	}
+	return value
+}
+`, g.Struct.StructName, g.Name, suffix, g.Proto.Name.ProtoName(), goType, convert, zero))
+}
+
+func (g *GoStructField) writeTimeSetter(sb *strings.Builder) {
+	suffix, goType, wellKnown := g.timeConversion()
+	if wellKnown == nil {
+		return
+	}
+	if goType == "time.Duration" {
+		sb.WriteString(fmt.Sprintf(`
+// Set%[2]v%[3]v sets %[4]v to d.
+func (s *%[1]v) Set%[2]v%[3]v(d time.Duration) {
+	s.%[2]v = %[5]v(d)
+}
+`, g.Struct.StructName, g.Name, suffix, g.Proto.Name.ProtoName(), wellKnown.QualifiedName("NewDuration")))
+		return
+	}
+	sb.WriteString(fmt.Sprintf(`
+// Set%[2]v%[3]v sets %[4]v to t, the zero time.Time clears it. Times before year 1 or after year 9999 are an error.
+func (s *%[1]v) Set%[2]v%[3]v(t time.Time) error {
+	if t.IsZero() {
+		s.%[2]v = nil
+		return nil
+	}
+	value, err := %[5]v(t)
+	if err != nil {
+		return err
+	}
+	s.%[2]v = value
+	return nil
+}
+`, g.Struct.StructName, g.Name, suffix, g.Proto.Name.ProtoName(), wellKnown.QualifiedName("NewTimestamp")))
+}
+
+// writeJSONValueSetter writes Set<Field><Kind> for Struct, ListValue and Value fields, which validates the
+// value like the wkt Check functions before storing it.
+func (g *GoStructField) writeJSONValueSetter(sb *strings.Builder) {
+	goType, suffix, check, ok := fields.JSONValueSetter(g.Type)
+	if !ok || g.Struct.hasField(g.Name+suffix) {
+		return
+	}
+	sb.WriteString(fmt.Sprintf(`
+// Set%[2]v%[3]v sets %[4]v to v, nil clears it.
+// Values %[6]v rejects are an error and leave the field unchanged.
+func (s *%[1]v) Set%[2]v%[3]v(v %[5]v) error {
+	if err := %[6]v(v); err != nil {
+		return err
+	}
+	s.%[2]v = v
+	return nil
+}
+`, g.Struct.StructName, g.Name, suffix, g.Proto.Name.ProtoName(), goType, check))
 }
 
 func (g *GoStructField) writeGetter(sb *strings.Builder) {
+	if wrapper, ok := g.Type.(core.GoWrapperType); ok {
+		sb.WriteString(fmt.Sprintf(`
+// Get%[2]v returns the value of %[3]v and whether it is set.
+func (m *%[1]vReader) Get%[2]v() (%[4]v, bool) {
+	if m == nil {
+		return %[5]v, false
+	}
+	if value := m.read%[2]v(); value != nil {
+		return *value, true
+	}
+	return %[5]v, false
+}
+`, g.Struct.StructName, g.Name, g.Proto.Name.ProtoName(), wrapper.ValueTypeName(), wrapper.ZeroValue()))
+		return
+	}
 	sb.WriteString(fmt.Sprintf(`
 func (m *%vReader) Get%v() %v {
 	if m == nil {
@@ -80,28 +234,66 @@ func (m *%vReader) Get%v() %v {
 }
 
 func (g *GoStructField) writeReader(sb *strings.Builder) {
-	var wireTypeCode = ""
-	if g.Type.WireTypeType() != "" {
-		wireTypeCode = fmt.Sprintf("\tvar wType = m.wireType%v\n", g.Name)
+	var location string
+	if g.isSingular() {
+		location = fmt.Sprintf("wOffset := int(m.offset%v)", g.Name)
+	} else {
+		location = fmt.Sprintf("wField := %v", g.fieldIndexConstName())
 	}
+	word, mask := g.parsedFlag()
 	sb.WriteString(fmt.Sprintf(`
 func (m *%vReader) read%v() %v {
-	if m.parsed%v {
+	if m.parsed[%v]&(%v) != 0 {
 		return m.data%v
 	}
-	wOffset := m.offset%v
-%v%v
+	%v
+%v
 	m.data%v = entry
-	m.parsed%v = true
+	m.parsed[%v] |= %v
 	return entry
 }
-`, g.Struct.StructName, g.Name, g.Type.ReaderTypeName(), g.Name, g.Name, g.Name, wireTypeCode, g.Type.EntryReader("\t", "entry"), g.Name, g.Name))
+`, g.Struct.StructName, g.Name, g.Type.ReaderTypeName(), word, mask, g.Name, location, g.Type.EntryReader("\t", "entry"), g.Name, word, mask))
 }
 
 func (g *GoStructField) writeUnmarshal(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 		case %v:
-%v`, g.wireTypeConstName(), g.Type.EntryUnmarshalSaveOffsets("\t\t\t", g.Name)))
+%v`, g.wireTypeConstName(), g.Type.EntryUnmarshalSaveOffsets("\t\t\t", g.Name, g.fieldIndexConstName())))
+}
+
+func (g *GoStructField) writeUnmarshalFields(sb *strings.Builder) {
+	if g.isSingular() {
+		sb.WriteString(fmt.Sprintf(`
+		case %v:
+			if fields.Has(%v) {
+				if m.offset%v == 0 {
+					found++
+				}
+%v
+				if found == stopAfter {
+					return nil
+				}
+			}`, g.wireTypeConstName(), g.fieldIndexConstName(), g.Name, g.Type.EntryUnmarshalSaveOffsets("\t\t\t\t", g.Name, g.fieldIndexConstName())))
+	} else {
+		sb.WriteString(fmt.Sprintf(`
+		case %v:
+			if fields.Has(%v) {
+%v
+			}`, g.wireTypeConstName(), g.fieldIndexConstName(), g.Type.EntryUnmarshalSaveOffsets("\t\t\t\t", g.Name, g.fieldIndexConstName())))
+	}
+}
+
+func (g *GoStructField) writeStructDefault(sb *strings.Builder) {
+	if def := g.Type.EntryDefault("\t", "s."+g.Name); def != "" {
+		sb.WriteString(def + "\n")
+	}
+}
+
+func (g *GoStructField) writeStructDecode(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+		case %v:
+%v
+%v`, g.wireTypeConstName(), fields.WireTypeCheck("\t\t\t", g.Type, "tag", "wire"), g.Type.EntryDecode("\t\t\t", "s."+g.Name, "offset")))
 }
 
 func (g *GoStructField) writeStructField(sb *strings.Builder) {
@@ -109,19 +301,48 @@ func (g *GoStructField) writeStructField(sb *strings.Builder) {
 `, g.Name, g.Type.WriterTypeName(), g.Proto.Name.ProtoName()))
 }
 
+// readerValue returns the expression reading the field from the reader m, getters of wrappers return a pair instead.
+func (g *GoStructField) readerValue() string {
+	if _, ok := g.Type.(core.GoWrapperType); ok {
+		return fmt.Sprintf("m.read%v()", g.Name)
+	}
+	return fmt.Sprintf("m.Get%v()", g.Name)
+}
+
 func (g *GoStructField) writeToStruct(sb *strings.Builder) {
 	if g.Type.JsonStructCanBeUsedDirectly() {
 		sb.WriteString(fmt.Sprintf("\tres.%v = m.Get%v()\n", g.Name, g.Name))
 	} else {
 		sb.WriteString(fmt.Sprintf(`
 	{
-		var data = m.Get%v()
+		var data = %v
 		var structData %v
 %v
 		res.%v = structData
 	}
-`, g.Name, g.Type.WriterTypeName(), g.Type.ToStruct("\t\t", "structData", "data"), g.Name))
+`, g.readerValue(), g.Type.WriterTypeName(), g.Type.ToStruct("\t\t", "structData", "data"), g.Name))
+	}
+}
+
+func (g *GoStructField) writeFreeze(sb *strings.Builder) {
+	freeze := g.Type.Freeze("\t", "m.read"+g.Name+"()")
+	if freeze == "" {
+		sb.WriteString(fmt.Sprintf("\tm.read%v()\n", g.Name))
+	} else {
+		sb.WriteString(freeze + "\n")
+	}
+}
+
+func (g *GoStructField) writeRebase(sb *strings.Builder) {
+	rebase := g.Type.Rebase("\t\t", "m.data"+g.Name)
+	if rebase == "" {
+		return
+	}
+	word, mask := g.parsedFlag()
+	sb.WriteString(fmt.Sprintf(`	if m.parsed[%v]&(%v) != 0 {
+%v
 	}
+`, word, mask, rebase))
 }
 
 func (g *GoStructField) writeSizeCalc(sb *strings.Builder) {
@@ -146,3 +367,215 @@ func (g *GoStructField) writeMarshal(sb *strings.Builder) {
 func (g *GoStructField) writeCopy(sb *strings.Builder) {
 	sb.WriteString(g.Type.EntryCopy("\t", "res."+g.Name, "s."+g.Name) + "\n")
 }
+
+func (g *GoStructField) jsonName() string {
+	return g.Proto.JSONName()
+}
+
+func (g *GoStructField) writeJSONField(sb *strings.Builder, condition string, value string) {
+	sb.WriteString(fmt.Sprintf(`
+	if %v {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, %q...)
+%v
+	}`, condition, fmt.Sprintf(`"%v":`, g.jsonName()), g.Type.JSONEncode("\t\t", "b", value)))
+}
+
+func (g *GoStructField) writeStructAppendJSON(sb *strings.Builder) {
+	g.writeJSONField(sb, g.Type.EntryIsNotEmpty("s."+g.Name), "s."+g.Name)
+}
+
+func (g *GoStructField) writeReaderAppendJSON(sb *strings.Builder) {
+	g.writeJSONField(sb, fmt.Sprintf("value := %v; %v", g.readerValue(), g.Type.EntryIsNotEmpty("value")), "value")
+}
+
+func (g *GoStructField) writeStructDecodeJSON(sb *strings.Builder) {
+	names := fmt.Sprintf("%q", g.jsonName())
+	if protoName := g.Proto.Name.ProtoName(); protoName != g.jsonName() {
+		names += fmt.Sprintf(", %q", protoName)
+	}
+	sb.WriteString(fmt.Sprintf(`
+		case %v:
+%v`, names, g.Type.JSONDecode("\t\t\t", "s."+g.Name)))
+}
+
+// writeTranscodeJSONCheck writes the case of the first transcoder pass, which checks the wire type and
+// remembers where a singular field occurs.
+func (g *GoStructField) writeTranscodeJSONCheck(sb *strings.Builder) {
+	var occurrence string
+	if g.isSingular() {
+		if fields.IsMessage(g.Type) {
+			occurrence += fmt.Sprintf(`
+			if first[%v] == 0 {
+				first[%v] = offset
+			}`, g.Index, g.Index)
+		}
+		occurrence += fmt.Sprintf(`
+			last[%v] = offset`, g.Index)
+	}
+	sb.WriteString(fmt.Sprintf(`
+		case %v:
+%v%v`, g.wireTypeConstName(), fields.WireTypeCheck("\t\t\t", g.Type, "tag", "wire"), occurrence))
+}
+
+func (g *GoStructField) writeTranscodeJSON(sb *strings.Builder) {
+	var once, merge string
+	if g.isSingular() {
+		// earlier occurrences are replaced by the last one, or merged into it
+		once = fmt.Sprintf(`
+			if offset != last[%v] {
+				break
+			}`, g.Index)
+		if fields.IsMessage(g.Type) {
+			merge = fmt.Sprintf(`
+			buf, offset := buf.MergeMessage(first[%v], offset, tag)`, g.Index)
+		}
+	} else {
+		// all occurrences are written together with the first one
+		word, mask := g.parsedFlag()
+		once = fmt.Sprintf(`
+			if written[%v]&(%v) != 0 {
+				break
+			}
+			written[%v] |= %v`, word, mask, word, mask)
+	}
+	sb.WriteString(fmt.Sprintf(`
+		case %v:%v
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, %q...)%v
+%v`, g.wireTypeConstName(), once, fmt.Sprintf(`"%v":`, g.jsonName()), merge, g.Type.JSONTranscode("\t\t\t", "offset")))
+}
+
+func (g *GoStructField) writeTextField(sb *strings.Builder, condition string, value string) {
+	sb.WriteString(fmt.Sprintf(`
+	if %v {
+%v
+	}`, condition, g.Type.TextEncode("\t\t", g.Proto.Name.ProtoName(), value)))
+}
+
+func (g *GoStructField) writeStructWriteText(sb *strings.Builder) {
+	g.writeTextField(sb, g.Type.EntryIsNotEmpty("s."+g.Name), "s."+g.Name)
+}
+
+func (g *GoStructField) writeReaderWriteText(sb *strings.Builder) {
+	g.writeTextField(sb, fmt.Sprintf("value := %v; %v", g.readerValue(), g.Type.EntryIsNotEmpty("value")), "value")
+}
+
+func (g *GoStructField) writeStructDecodeText(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+		case %q:
+%v`, g.Proto.Name.ProtoName(), g.Type.TextDecode("\t\t\t", "s."+g.Name)))
+}
+
+var descriptorKinds = map[string]string{
+	"string":   "gremlin.KindString",
+	"bytes":    "gremlin.KindBytes",
+	"bool":     "gremlin.KindBool",
+	"double":   "gremlin.KindDouble",
+	"float":    "gremlin.KindFloat",
+	"int32":    "gremlin.KindInt32",
+	"int64":    "gremlin.KindInt64",
+	"uint32":   "gremlin.KindUint32",
+	"uint64":   "gremlin.KindUint64",
+	"sint32":   "gremlin.KindSint32",
+	"sint64":   "gremlin.KindSint64",
+	"fixed32":  "gremlin.KindFixed32",
+	"fixed64":  "gremlin.KindFixed64",
+	"sfixed32": "gremlin.KindSfixed32",
+	"sfixed64": "gremlin.KindSfixed64",
+}
+
+// valueKind returns the kind and the full type name of the field value, for maps the map value.
+func (g *GoStructField) valueKind() (string, string) {
+	switch {
+	case g.Proto.ScalarValueType != "":
+		return descriptorKinds[g.Proto.ScalarValueType], ""
+	case g.Proto.LocalEnumType != nil:
+		return "gremlin.KindEnum", g.Proto.LocalEnumType.Name.String()
+	case g.Proto.ExternalEnumType != nil:
+		return "gremlin.KindEnum", g.Proto.ExternalEnumType.Name.String()
+	case g.Proto.LocalMsgType != nil:
+		return "gremlin.KindMessage", g.Proto.LocalMsgType.Name.String()
+	default:
+		return "gremlin.KindMessage", g.Proto.ExternalMsgType.Name.String()
+	}
+}
+
+// writeDescriptor writes the field descriptor, map fields are messages like in descriptor.proto
+// with the entry described by MapKey and MapValue.
+func (g *GoStructField) writeDescriptor(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf("\t\t{%v},\n", g.descriptorContents()))
+}
+
+// descriptorContents returns the fields of the FieldDescriptor literal.
+func (g *GoStructField) descriptorContents() string {
+	kind, typeName := g.valueKind()
+	label := "gremlin.LabelOptional"
+	switch {
+	case g.Proto.Repeated || g.Proto.Map:
+		label = "gremlin.LabelRepeated"
+	case g.Proto.Required:
+		label = "gremlin.LabelRequired"
+	}
+	desc := fmt.Sprintf("Name: %q, JSONName: %q, Number: %v, ", g.Proto.Name.ProtoName(), g.jsonName(), g.Proto.ProtoDef.Sequence)
+	if g.Proto.Map {
+		valueDesc := fmt.Sprintf("Name: \"value\", JSONName: \"value\", Number: 2, Kind: %v, Label: gremlin.LabelOptional", kind)
+		if typeName != "" {
+			valueDesc += fmt.Sprintf(", TypeName: %q", typeName)
+		}
+		desc += fmt.Sprintf("Kind: gremlin.KindMessage, Label: %v,\n\t\t\tMapKey: &gremlin.FieldDescriptor{Name: \"key\", JSONName: \"key\", Number: 1, Kind: %v, Label: gremlin.LabelOptional},\n\t\t\tMapValue: &gremlin.FieldDescriptor{%v},\n\t\t",
+			label, descriptorKinds[g.Proto.MapKeyType], valueDesc)
+		return desc
+	}
+	desc += fmt.Sprintf("Kind: %v, Label: %v", kind, label)
+	if typeName != "" {
+		desc += fmt.Sprintf(", TypeName: %q", typeName)
+	}
+	if g.Proto.OneOfGroup != "" {
+		desc += fmt.Sprintf(", OneOf: %q", g.Proto.OneOfGroup)
+	}
+	if g.Proto.DefaultValue != nil {
+		desc += fmt.Sprintf(", Default: %q", g.Proto.DefaultValue.Constant.Source)
+	}
+	return desc
+}
+
+func (g *GoStructField) writeGetField(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+	case %v:
+		return s.%v`, g.wireTypeConstName(), g.Name))
+}
+
+func (g *GoStructField) writeSetField(sb *strings.Builder) {
+	_, _, check, jsonValue := fields.JSONValueSetter(g.Type)
+	if g.Type.WriterTypeName() == "any" {
+		// every value, nil included, has the type
+		sb.WriteString(fmt.Sprintf(`
+	case %v:`, g.wireTypeConstName()))
+		if jsonValue {
+			sb.WriteString(fmt.Sprintf(`
+		if err := %v(value); err != nil {
+			return err
+		}`, check))
+		}
+		sb.WriteString(fmt.Sprintf(`
+		s.%v = value`, g.Name))
+		return
+	}
+	sb.WriteString(fmt.Sprintf(`
+	case %v:
+		v, ok := value.(%v)
+		if !ok {
+			return gremlin.FieldTypeError(%v, number, value)
+		}`, g.wireTypeConstName(), g.Type.WriterTypeName(), g.Struct.descriptorVarName()))
+	if jsonValue {
+		sb.WriteString(fmt.Sprintf(`
+		if err := %v(v); err != nil {
+			return err
+		}`, check))
+	}
+	sb.WriteString(fmt.Sprintf(`
+		s.%v = v`, g.Name))
+}
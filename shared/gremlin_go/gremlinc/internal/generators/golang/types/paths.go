@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
+)
+
+// pathMessage returns the type of a field whose paths go on into its message. Repeated fields, maps
+// and the well-known types, which structs mostly keep as plain Go values, end the path.
+func (g *GoStructField) pathMessage() core.GoMessageType {
+	if !g.isSingular() {
+		return nil
+	}
+	msg, ok := g.Type.(core.GoMessageType)
+	if !ok || msg.WellKnownName() != "" {
+		return nil
+	}
+	return msg
+}
+
+func pathsTypeName(structName string) string {
+	return structName + "PathNames"
+}
+
+// writePaths writes the field mask path constants, e.g. UserPaths.Profile.Address.City is
+// "profile.address.city". Fields of recursive messages are methods, a struct can't contain itself.
+func (g *GoStructType) writePaths(sb *strings.Builder) {
+	typeName := pathsTypeName(g.StructName)
+	sb.WriteString(fmt.Sprintf(`
+// %vPaths are the field mask paths of %v.
+var %vPaths = XXX_New%v("")
+
+type %v struct {
+	path string
+`, g.StructName, g.Proto.Name.String(), g.StructName, typeName, typeName))
+	hasPathField := false
+	for _, field := range g.Fields {
+		hasPathField = hasPathField || field.Name == "Path"
+		msg := field.pathMessage()
+		switch {
+		case msg == nil:
+			sb.WriteString(fmt.Sprintf("\t%v string\n", field.Name))
+		case !msg.IsRecursive():
+			sb.WriteString(fmt.Sprintf("\t%v %v\n", field.Name, msg.QualifiedName(pathsTypeName(msg.MessageName()))))
+		}
+	}
+	sb.WriteString("}\n")
+
+	sb.WriteString(fmt.Sprintf(`
+func XXX_New%v(path string) %v {
+	return %v{
+		path: path,
+`, typeName, typeName, typeName))
+	for _, field := range g.Fields {
+		msg := field.pathMessage()
+		switch {
+		case msg == nil:
+			sb.WriteString(fmt.Sprintf("\t\t%v: gremlin.JoinPath(path, %q),\n", field.Name, field.Proto.Name.ProtoName()))
+		case !msg.IsRecursive():
+			sb.WriteString(fmt.Sprintf("\t\t%v: %v(gremlin.JoinPath(path, %q)),\n", field.Name, msg.QualifiedName("XXX_New"+pathsTypeName(msg.MessageName())), field.Proto.Name.ProtoName()))
+		}
+	}
+	sb.WriteString("\t}\n}\n")
+
+	for _, field := range g.Fields {
+		if msg := field.pathMessage(); msg != nil && msg.IsRecursive() {
+			pathsType := msg.QualifiedName(pathsTypeName(msg.MessageName()))
+			sb.WriteString(fmt.Sprintf(`
+func (p %v) %v() %v {
+	return %v(gremlin.JoinPath(p.path, %q))
+}
+`, typeName, field.Name, msg.QualifiedName(pathsType), msg.QualifiedName("XXX_New"+pathsType), field.Proto.Name.ProtoName()))
+		}
+	}
+	if !hasPathField {
+		sb.WriteString(fmt.Sprintf(`
+// Path returns the path of the message itself, empty for the root.
+func (p %v) Path() string {
+	return p.path
+}
+`, typeName))
+	}
+}
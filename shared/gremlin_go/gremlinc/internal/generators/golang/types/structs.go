@@ -5,14 +5,20 @@ import (
 	"strings"
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/core"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang/fields"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 )
 
 type GoStructType struct {
 	StructName string
 	Proto      *types.MessageDefinition
+	File       string // path of the proto file, used in the descriptor
+	WellKnown  bool   // generated into the wkt runtime package
 
 	Fields []*GoStructField
+
+	NestedMessages []*GoStructType
+	NestedEnums    []*GoEnumType
 }
 
 func (g *GoStructType) GetName() string {
@@ -54,27 +60,45 @@ func (g *GoStructType) AddField(fieldDef *types.MessageFieldDefinition, fieldTyp
 		Proto:  fieldDef,
 		Type:   fieldType,
 		Struct: g,
+		Index:  len(g.Fields),
 	}
 	field.parseName(fieldDef)
+	if inline, ok := fieldType.(core.GoInlineReaderType); ok && inline.InlineReaderTypeName() != "" {
+		inline.SetInlineStorage("m.inline" + field.Name)
+	}
 
 	g.Fields = append(g.Fields, field)
 }
 
 func (g *GoStructType) GenerateCode(sb *strings.Builder) {
 	g.writeWireTypes(sb)
+	g.writeDescriptor(sb)
 	// reader
 	g.writeReaderStruct(sb)
 	g.writeReaderConstructor(sb)
 	g.writeFieldsAccessors(sb)
 	g.writeUnmarshal(sb)
+	g.writeUnmarshalFields(sb)
 	g.writeToStruct(sb)
+	g.writeFreeze(sb)
+	g.writeDetach(sb)
 	g.writeGetBytes(sb)
+	g.writeReaderJSON(sb)
+	g.writeTranscodeJSON(sb)
+	g.writeReaderText(sb)
 
 	// writer
 	g.writeStruct(sb)
+	g.writeStructAccessors(sb)
+	g.writeStructUnmarshal(sb)
 	g.writeMarshal(sb)
 	g.writeCopy(sb)
 	g.writeSize(sb)
+	g.writeStructJSON(sb)
+	g.writeStructText(sb)
+	g.writeReflection(sb)
+	g.writeExtensionData(sb)
+	g.writePaths(sb)
 }
 
 func (g *GoStructType) writeWireTypes(sb *strings.Builder) {
@@ -83,6 +107,26 @@ func (g *GoStructType) writeWireTypes(sb *strings.Builder) {
 		field.writeWireTypeConst(sb)
 	}
 	sb.WriteString(")\n")
+
+	sb.WriteString("\nconst (\n")
+	for _, field := range g.Fields {
+		field.writeFieldIndexConst(sb)
+	}
+	sb.WriteString(")\n")
+
+	if g.hasSingularFields() {
+		var singular []string
+		for _, field := range g.Fields {
+			if field.isSingular() {
+				singular = append(singular, field.fieldIndexConstName())
+			}
+		}
+		sb.WriteString(fmt.Sprintf("\nvar %v = gremlin.NewFieldSet(%v)\n", g.singularFieldsVarName(), strings.Join(singular, ", ")))
+	}
+}
+
+func (g *GoStructType) singularFieldsVarName() string {
+	return fmt.Sprintf("singularFields%v", g.StructName)
 }
 
 func (g *GoStructType) writeReaderConstructor(sb *strings.Builder) {
@@ -93,13 +137,33 @@ func New%vReader() *%vReader {
 `, g.StructName, g.StructName, g.StructName))
 }
 
+func (g *GoStructType) hasRepeatedFields() bool {
+	for _, field := range g.Fields {
+		if !field.isSingular() {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *GoStructType) writeReaderStruct(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 type %vReader struct {
-	buf *gremlin.Reader
-
+	buf gremlin.Reader
 `, g.StructName))
 
+	if len(g.Fields) > 0 {
+		sb.WriteString(fmt.Sprintf("\tparsed [%v]uint64\n", (len(g.Fields)+63)/64))
+	}
+	if g.hasRepeatedFields() {
+		sb.WriteString(`	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
+`)
+	}
+
+	sb.WriteByte('\n')
+
 	for _, field := range g.Fields {
 		field.writeProtoStructData(sb)
 	}
@@ -110,15 +174,26 @@ type %vReader struct {
 		field.writeProtoStructOffset(sb)
 	}
 
-	sb.WriteByte('\n')
-
+	var inline = &strings.Builder{}
 	for _, field := range g.Fields {
-		field.writeProtoStructParsedFlag(sb)
+		field.writeProtoStructInline(inline)
+	}
+	if inline.Len() > 0 {
+		sb.WriteByte('\n')
+		sb.WriteString(inline.String())
 	}
 
 	sb.WriteString("}\n")
 }
 
+func (g *GoStructType) writeRepeatedInit() string {
+	if g.hasRepeatedFields() {
+		return `
+	m.repeated = m.repeatedInline[:0]`
+	}
+	return ""
+}
+
 func (g *GoStructType) writeStruct(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 type %v struct {
@@ -126,9 +201,28 @@ type %v struct {
 	for _, field := range g.Fields {
 		field.writeStructField(sb)
 	}
+	if g.isExtendable() {
+		sb.WriteString("\tXXX_Unknown\t[]byte\t`json:\"-\"` // fields the message does not declare, extensions among them\n")
+	}
 	sb.WriteString("}\n")
 }
 
+func (g *GoStructType) writeStructAccessors(sb *strings.Builder) {
+	for _, field := range g.Fields {
+		field.writeTimeSetter(sb)
+		field.writeJSONValueSetter(sb)
+	}
+}
+
+func (g *GoStructType) hasField(name string) bool {
+	for _, field := range g.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *GoStructType) writeFieldsAccessors(sb *strings.Builder) {
 	for _, field := range g.Fields {
 		field.writeAccessors(sb)
@@ -138,7 +232,14 @@ func (g *GoStructType) writeFieldsAccessors(sb *strings.Builder) {
 func (g *GoStructType) writeUnmarshal(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 func (m *%vReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *%vReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}%v
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -147,7 +248,7 @@ func (m *%vReader) Unmarshal(data []byte) error {
 		}
 
 		offset += tagSize
-		switch tag {`, g.StructName))
+		switch tag {`, g.StructName, g.StructName, g.writeRepeatedInit()))
 	for _, field := range g.Fields {
 		field.writeUnmarshal(sb)
 	}
@@ -164,23 +265,188 @@ func (m *%vReader) Unmarshal(data []byte) error {
 `)
 }
 
+func (g *GoStructType) hasSingularFields() bool {
+	for _, field := range g.Fields {
+		if field.isSingular() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GoStructType) writeUnmarshalFields(sb *strings.Builder) {
+	var earlyStop = ""
+	if g.hasSingularFields() {
+		earlyStop = fmt.Sprintf(`
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(%v) {
+		stopAfter = fields.Len()
+	}
+	found := 0`, g.singularFieldsVarName())
+	}
+	sb.WriteString(fmt.Sprintf(`
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *%vReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *%vReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = %vReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}%v%v
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {`, g.StructName, g.StructName, g.StructName, g.writeRepeatedInit(), earlyStop))
+	for _, field := range g.Fields {
+		field.writeUnmarshalFields(sb)
+	}
+	sb.WriteString(`
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`)
+}
+
 func (g *GoStructType) writeToStruct(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 func (m *%vReader) ToStruct() *%v {
 	if m == nil {
 		return nil
 	}
-	res := &%v{}
+	res := gremlin.New[%v](m.buf.Arena())
 `, g.StructName, g.StructName, g.StructName))
 	for _, field := range g.Fields {
 		field.writeToStruct(sb)
 	}
+	if g.isExtendable() {
+		sb.WriteString(fmt.Sprintf(`
+	res.XXX_Unknown = gremlin.XXX_AppendUnknownFields(nil, m.buf.Bytes(), %v)`, g.descriptorVarName()))
+	}
 	sb.WriteString(`
 	return res
 }
 `)
 }
 
+func (g *GoStructType) writeFreeze(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *%vReader) Freeze() {
+	if m == nil {
+		return
+	}
+`, g.StructName))
+	for _, field := range g.Fields {
+		field.writeFreeze(sb)
+	}
+	sb.WriteString("}\n")
+}
+
+func (g *GoStructType) writeStructUnmarshal(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *%v) Unmarshal(data []byte) error {
+	*s = %v{}
+`, g.StructName, g.StructName))
+	for _, field := range g.Fields {
+		field.writeStructDefault(sb)
+	}
+	sb.WriteString(`
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {`)
+	if g.isExtendable() {
+		sb.WriteString(`
+		start := offset`)
+	}
+	sb.WriteString(`
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {`)
+	for _, field := range g.Fields {
+		field.writeStructDecode(sb)
+	}
+	if g.isExtendable() {
+		sb.WriteString(`
+		default:
+			s.XXX_Unknown = append(s.XXX_Unknown, data[start:end]...)`)
+	}
+	sb.WriteString(`
+		}
+		offset = end
+	}
+	return nil
+}
+`)
+}
+
+func (g *GoStructType) writeDetach(sb *strings.Builder) {
+	keep := "m.buf.Release(d)"
+	if g.isExtendable() {
+		// unknown fields and extensions are read from the message bytes
+		keep = "m.buf.Rebase(d)"
+	}
+	sb.WriteString(fmt.Sprintf(`
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *%vReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *%vReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	%v
+`, g.StructName, g.StructName, keep))
+	for _, field := range g.Fields {
+		field.writeRebase(sb)
+	}
+	sb.WriteString("}\n")
+}
+
 func (g *GoStructType) writeMarshal(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
 func (s *%v) Marshal() []byte {
@@ -205,6 +471,10 @@ func (s *%v) MarshalTo(res *gremlin.Writer) {
 	for _, field := range g.Fields {
 		field.writeMarshal(sb)
 	}
+	if g.isExtendable() {
+		sb.WriteString(`
+	res.AppendRaw(s.XXX_Unknown)`)
+	}
 	sb.WriteString(`
 }
 `)
@@ -222,6 +492,10 @@ func (s *%v) XXX_PbContentSize() int {
 	for _, field := range g.Fields {
 		field.writeSizeCalc(sb)
 	}
+	if g.isExtendable() {
+		sb.WriteString(`
+	size += len(s.XXX_Unknown)`)
+	}
 	sb.WriteString(`
 	return size
 }
@@ -230,6 +504,8 @@ func (s *%v) XXX_PbContentSize() int {
 
 func (g *GoStructType) writeGetBytes(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf(`
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *%vReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -250,8 +526,496 @@ func (s *%v) Copy() *%v {
 	for _, field := range g.Fields {
 		field.writeCopy(sb)
 	}
+	if g.isExtendable() {
+		sb.WriteString(`
+	res.XXX_Unknown = append([]byte(nil), s.XXX_Unknown...)`)
+	}
 	sb.WriteString(`
 	return res
 }
 `)
 }
+
+// customJSONTypes are the well-known types with a JSON mapping of their own, such as a string for
+// google.protobuf.Timestamp. The wkt package implements it in append<Name>JSON and decode<Name>JSON.
+var customJSONTypes = map[string]bool{
+	"google.protobuf.Any":         true,
+	"google.protobuf.Duration":    true,
+	"google.protobuf.Empty":       true,
+	"google.protobuf.FieldMask":   true,
+	"google.protobuf.ListValue":   true,
+	"google.protobuf.Struct":      true,
+	"google.protobuf.Timestamp":   true,
+	"google.protobuf.Value":       true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+func (g *GoStructType) hasCustomJSON() bool {
+	return g.WellKnown && customJSONTypes[g.Proto.Name.String()]
+}
+
+func (g *GoStructType) writeReaderJSON(sb *strings.Builder) {
+	if g.hasCustomJSON() {
+		sb.WriteString(fmt.Sprintf(`
+// AppendJSON appends the message in the JSON mapping of %v, the output
+// is the same as ToStruct().AppendJSON(b).
+func (m *%vReader) AppendJSON(b []byte) []byte {
+	return m.ToStruct().AppendJSON(b)
+}
+`, g.Proto.Name.String(), g.StructName))
+	} else {
+		sb.WriteString(fmt.Sprintf(`
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *%vReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')`, g.StructName))
+		for _, field := range g.Fields {
+			field.writeReaderAppendJSON(sb)
+		}
+		sb.WriteString(`
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+`)
+	}
+	sb.WriteString(fmt.Sprintf(`
+func (m *%vReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+`, g.StructName))
+}
+
+func (g *GoStructType) writeStructJSON(sb *strings.Builder) {
+	if g.hasCustomJSON() {
+		sb.WriteString(fmt.Sprintf(`
+// AppendJSON appends the message in the JSON mapping of %v, the same bytes
+// protojson produces.
+func (s *%v) AppendJSON(b []byte) []byte {
+	return append%vJSON(b, s)
+}
+`, g.Proto.Name.String(), g.StructName, g.StructName))
+	} else {
+		sb.WriteString(fmt.Sprintf(`
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *%v) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')`, g.StructName))
+		for _, field := range g.Fields {
+			field.writeStructAppendJSON(sb)
+		}
+		sb.WriteString(`
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+`)
+	}
+	sb.WriteString(fmt.Sprintf(`
+func (s *%v) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *%v) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *%v) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = %v{}
+`, g.StructName, g.StructName, g.StructName, g.StructName))
+	if g.hasCustomJSON() {
+		sb.WriteString(fmt.Sprintf(`	return decode%vJSON(d, s)
+}
+`, g.StructName))
+		return
+	}
+	for _, field := range g.Fields {
+		field.writeStructDefault(sb)
+	}
+	if len(g.Fields) == 0 {
+		sb.WriteString(`	return d.ReadObject(d.UnknownField)
+}
+`)
+		return
+	}
+	sb.WriteString(`	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {`)
+	for _, field := range g.Fields {
+		field.writeStructDecodeJSON(sb)
+	}
+	sb.WriteString(`
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+`)
+}
+
+func (g *GoStructType) writeTranscodeJSON(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// Transcode%vJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func Transcode%vJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_Transcode%vJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+`, g.StructName, g.StructName, g.StructName))
+	if g.hasCustomJSON() {
+		// the custom mappings need the whole message, which is small for all of them but Any and Struct
+		sb.WriteString(fmt.Sprintf(`
+// XXX_Transcode%vJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_Transcode%vJSON(s *gremlin.JSONStream, data []byte) error {
+	var msg %v
+	if err := msg.Unmarshal(data); err != nil {
+		return err
+	}
+	s.B = msg.AppendJSON(s.B)
+	return s.Check()
+}
+`, g.StructName, g.StructName, g.StructName))
+		return
+	}
+
+	var occurrences string
+	hasSingular, hasMessages := false, false
+	for _, field := range g.Fields {
+		if field.isSingular() {
+			hasSingular = true
+			hasMessages = hasMessages || fields.IsMessage(field.Type)
+		}
+	}
+	if hasMessages {
+		occurrences = fmt.Sprintf("\n\tvar first, last [%v]int", len(g.Fields))
+	} else if hasSingular {
+		occurrences = fmt.Sprintf("\n\tvar last [%v]int", len(g.Fields))
+	}
+	var written string
+	if g.hasRepeatedFields() {
+		written = fmt.Sprintf("\n\tvar written [%v]uint64", (len(g.Fields)+63)/64)
+	}
+	sb.WriteString(fmt.Sprintf(`
+// XXX_Transcode%vJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_Transcode%vJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}%v
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {`, g.StructName, g.StructName, occurrences))
+	for _, field := range g.Fields {
+		field.writeTranscodeJSONCheck(sb)
+	}
+	sb.WriteString(fmt.Sprintf(`
+		}
+		offset = end
+	}
+%v
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {`, written))
+	for _, field := range g.Fields {
+		field.writeTranscodeJSON(sb)
+	}
+	sb.WriteString(`
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+`)
+}
+
+// writeTextMethods writes the text methods shared by readers and structs around XXX_WriteText.
+func writeTextMethods(sb *strings.Builder, recv string, typeName string) {
+	sb.WriteString(fmt.Sprintf(`
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (%[1]v %[2]v) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	%[1]v.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (%[1]v %[2]v) String() string {
+	return gremlin.TextString(%[1]v.XXX_WriteText)
+}
+
+// Format prints the single line text format for %%v and the multiline one for %%+v.
+func (%[1]v %[2]v) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, %[1]v.XXX_WriteText)
+}
+`, recv, typeName))
+}
+
+func (g *GoStructType) writeReaderText(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *%vReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}`, g.StructName))
+	for _, field := range g.Fields {
+		field.writeReaderWriteText(sb)
+	}
+	sb.WriteString(`
+}
+`)
+	writeTextMethods(sb, "m", fmt.Sprintf("*%vReader", g.StructName))
+}
+
+func (g *GoStructType) writeStructText(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *%v) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}`, g.StructName))
+	for _, field := range g.Fields {
+		field.writeStructWriteText(sb)
+	}
+	sb.WriteString(`
+}
+`)
+	writeTextMethods(sb, "s", "*"+g.StructName)
+	sb.WriteString(fmt.Sprintf(`
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *%v) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *%v) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = %v{}
+`, g.StructName, g.StructName, g.StructName))
+	for _, field := range g.Fields {
+		field.writeStructDefault(sb)
+	}
+	if len(g.Fields) == 0 {
+		sb.WriteString(`	return d.ReadMessage(d.UnknownField)
+}
+`)
+		return
+	}
+	sb.WriteString(`	return d.ReadMessage(func(name string) error {
+		switch name {`)
+	for _, field := range g.Fields {
+		field.writeStructDecodeText(sb)
+	}
+	sb.WriteString(`
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+`)
+}
+
+func (g *GoStructType) descriptorVarName() string {
+	return "descriptor" + g.StructName
+}
+
+// IsNestedIn reports whether the message is declared inside parent.
+func (g *GoStructType) IsNestedIn(parent *GoStructType) bool {
+	return len(g.Proto.Name.LocalPath()) > 0 && g.Proto.Name.ToParent().String() == parent.Proto.Name.String()
+}
+
+func (g *GoStructType) writeDescriptor(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+var %v = &gremlin.MessageDescriptor{
+	FullName: %q,
+	File:     %q,
+	Fields: []*gremlin.FieldDescriptor{
+`, g.descriptorVarName(), g.Proto.Name.String(), g.File))
+	for _, field := range g.Fields {
+		field.writeDescriptor(sb)
+	}
+	sb.WriteString("\t},\n")
+	if len(g.NestedMessages) > 0 {
+		sb.WriteString("\tMessages: []*gremlin.MessageDescriptor{")
+		for i, nested := range g.NestedMessages {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(nested.descriptorVarName())
+		}
+		sb.WriteString("},\n")
+	}
+	if len(g.NestedEnums) > 0 {
+		sb.WriteString("\tEnums: []*gremlin.EnumDescriptor{")
+		for i, nested := range g.NestedEnums {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(nested.descriptorVarName())
+		}
+		sb.WriteString("},\n")
+	}
+	if ranges := g.Proto.ExtensionRanges(); len(ranges) > 0 {
+		sb.WriteString("\tExtensionRanges: []gremlin.FieldRange{")
+		for i, r := range ranges {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("{From: %v, To: %v}", r.From, r.To))
+		}
+		sb.WriteString("},\n")
+	}
+	sb.WriteString(fmt.Sprintf(`	New: func() gremlin.ProtoMessage {
+		return &%v{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return New%vReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(%v)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *%vReader) Descriptor() *gremlin.MessageDescriptor {
+	return %v
+}
+`, g.StructName, g.StructName, g.descriptorVarName(), g.StructName, g.descriptorVarName()))
+}
+
+func (g *GoStructType) writeReflection(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf(`
+// Descriptor returns the descriptor of the message type.
+func (s *%v) Descriptor() *gremlin.MessageDescriptor {
+	return %v
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *%v) GetField(number gremlin.ProtoWireNumber) any {
+`, g.StructName, g.descriptorVarName(), g.StructName))
+	if len(g.Fields) == 0 {
+		sb.WriteString(fmt.Sprintf(`	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *%v) SetField(number gremlin.ProtoWireNumber, value any) error {
+	return gremlin.UnknownFieldError(%v, number)
+}
+`, g.StructName, g.descriptorVarName()))
+		return
+	}
+	sb.WriteString("\tswitch number {")
+	for _, field := range g.Fields {
+		field.writeGetField(sb)
+	}
+	sb.WriteString(fmt.Sprintf(`
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *%v) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {`, g.StructName))
+	for _, field := range g.Fields {
+		field.writeSetField(sb)
+	}
+	sb.WriteString(fmt.Sprintf(`
+	default:
+		return gremlin.UnknownFieldError(%v, number)
+	}
+	return nil
+}
+`, g.descriptorVarName()))
+}
+
+// isExtendable reports whether the message declares extension ranges. Its struct keeps unknown fields,
+// where extensions are stored, and both the reader and the struct implement gremlin.Extendable.
+func (g *GoStructType) isExtendable() bool {
+	return len(g.Proto.ExtensionRanges()) > 0
+}
+
+func (g *GoStructType) writeExtensionData(sb *strings.Builder) {
+	if !g.isExtendable() {
+		return
+	}
+	sb.WriteString(fmt.Sprintf(`
+// XXX_ExtensionData returns the message, extensions are read from it with gremlin.GetExtension.
+func (m *%vReader) XXX_ExtensionData() []byte {
+	if m == nil {
+		return nil
+	}
+	return m.buf.Bytes()
+}
+
+// XXX_ExtensionData returns the unknown fields, extensions are read from them with gremlin.GetExtension.
+func (s *%v) XXX_ExtensionData() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.XXX_Unknown
+}
+
+// XXX_SetExtensionData replaces the unknown fields, used by gremlin.SetExtension.
+func (s *%v) XXX_SetExtensionData(data []byte) {
+	s.XXX_Unknown = data
+}
+`, g.StructName, g.StructName, g.StructName))
+}
@@ -2,15 +2,36 @@ package internal
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testdata"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/map_test"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/protobuf_unittest"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/protobuf_unittest_import"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/test"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/wellknown"
+	"github.com/norma-core/norma-core/shared/gremlin_go/wkt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestGoldenMessage(t *testing.T) {
@@ -870,3 +891,1484 @@ func TestBasicValues(t *testing.T) {
 		t.Fatalf("Expected %v, got %v", msg.Field15, parsed.GetField15())
 	}
 }
+
+func TestUnmarshalFields(t *testing.T) {
+	fields := gremlin.NewFieldSet(
+		protobuf_unittest.FieldTestAllTypes_OptionalInt32,
+		protobuf_unittest.FieldTestAllTypes_RepeatedString,
+		protobuf_unittest.FieldTestAllTypes_OptionalNestedMessage,
+	)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.UnmarshalFields(getTestFileContent("golden_message"), fields); err != nil {
+		t.Fatalf("failed to unmarshal golden message: %v", err)
+	}
+
+	if parsed.GetOptionalInt32() != 101 {
+		t.Errorf("optional_int32: got %v, want %v", parsed.GetOptionalInt32(), 101)
+	}
+	if !cmp.Equal(parsed.GetRepeatedString(), []string{"215", "315"}) {
+		t.Errorf("repeated_string: got %v, want %v", parsed.GetRepeatedString(), []string{"215", "315"})
+	}
+	if parsed.GetOptionalNestedMessage().GetBb() != 118 {
+		t.Errorf("optional_nested_message.bb: got %v, want %v", parsed.GetOptionalNestedMessage().GetBb(), 118)
+	}
+
+	// fields outside of the projection are left empty
+	if parsed.GetOptionalInt64() != 0 {
+		t.Errorf("optional_int64: got %v, want %v", parsed.GetOptionalInt64(), 0)
+	}
+	if len(parsed.GetRepeatedInt32()) != 0 {
+		t.Errorf("repeated_int32: got %v, want empty", parsed.GetRepeatedInt32())
+	}
+}
+
+func TestUnmarshalFieldsStopsEarly(t *testing.T) {
+	msg := &protobuf_unittest.TestAllTypes{
+		OptionalInt32:  1,
+		OptionalString: "two",
+	}
+	content := msg.Marshal()
+	// garbage after the requested fields is never reached
+	content = append(content, 0xff, 0xff, 0xff)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	stopEarly := gremlin.ReaderOptions{StopEarly: true}
+	if err := parsed.UnmarshalFieldsWithOptions(content, gremlin.NewFieldSet(protobuf_unittest.FieldTestAllTypes_OptionalInt32), stopEarly); err != nil {
+		t.Fatalf("expected early stop, got error: %v", err)
+	}
+	if parsed.GetOptionalInt32() != 1 {
+		t.Errorf("optional_int32: got %v, want %v", parsed.GetOptionalInt32(), 1)
+	}
+
+	if err := parsed.UnmarshalFieldsWithOptions(content, gremlin.NewFieldSet(protobuf_unittest.FieldTestAllTypes_RepeatedInt32), stopEarly); err == nil {
+		t.Errorf("expected error for repeated projection scanning whole message")
+	}
+	if err := parsed.UnmarshalFields(content, gremlin.NewFieldSet(protobuf_unittest.FieldTestAllTypes_OptionalInt32)); err == nil {
+		t.Errorf("expected error without StopEarly scanning whole message")
+	}
+}
+
+func TestUnmarshalFieldsStopEarlyDuplicates(t *testing.T) {
+	w := gremlin.NewWriter(32)
+	w.AppendInt32(1, 1)
+	w.AppendInt32(1, 2)
+	w.AppendString(14, "stop")
+	w.AppendInt32(1, 3)
+	fields := gremlin.NewFieldSet(protobuf_unittest.FieldTestAllTypes_OptionalInt32, protobuf_unittest.FieldTestAllTypes_OptionalString)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.UnmarshalFieldsWithOptions(w.Bytes(), fields, gremlin.ReaderOptions{StopEarly: true}); err != nil {
+		t.Fatal(err)
+	}
+	// the last occurrence before the stop wins, the one after it is never read
+	if parsed.GetOptionalInt32() != 2 || parsed.GetOptionalString() != "stop" {
+		t.Errorf("unexpected early stop result %v %q", parsed.GetOptionalInt32(), parsed.GetOptionalString())
+	}
+	if err := parsed.UnmarshalFields(w.Bytes(), fields); err != nil || parsed.GetOptionalInt32() != 3 {
+		t.Errorf("without StopEarly the last occurrence should win, got %v, %v", parsed.GetOptionalInt32(), err)
+	}
+}
+
+func TestUnmarshalFieldsLastOccurrenceWins(t *testing.T) {
+	first := (&protobuf_unittest.TestAllTypes{OptionalInt32: 1, OptionalString: "first"}).Marshal()
+	second := (&protobuf_unittest.TestAllTypes{OptionalInt32: 2}).Marshal()
+	content := append(first, second...)
+	fields := gremlin.NewFieldSet(protobuf_unittest.FieldTestAllTypes_OptionalInt32, protobuf_unittest.FieldTestAllTypes_OptionalString)
+
+	full := protobuf_unittest.NewTestAllTypesReader()
+	if err := full.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	projected := protobuf_unittest.NewTestAllTypesReader()
+	if err := projected.UnmarshalFields(content, fields); err != nil {
+		t.Fatal(err)
+	}
+	if projected.GetOptionalInt32() != full.GetOptionalInt32() || projected.GetOptionalString() != full.GetOptionalString() {
+		t.Errorf("projection got %v %q, full decode %v %q", projected.GetOptionalInt32(), projected.GetOptionalString(), full.GetOptionalInt32(), full.GetOptionalString())
+	}
+
+	arena := gremlin.NewArena()
+	if err := projected.UnmarshalFieldsWithOptions(content, fields, gremlin.ReaderOptions{Buffer: gremlin.BufferDetached, Arena: arena}); err != nil {
+		t.Fatal(err)
+	}
+	if projected.GetOptionalInt32() != 2 || projected.ToStruct().OptionalString != "first" {
+		t.Errorf("unexpected projection with options %v %q", projected.GetOptionalInt32(), projected.GetOptionalString())
+	}
+}
+
+// run with -race to verify that frozen readers are never written to
+func TestFrozenReaderConcurrentAccess(t *testing.T) {
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.Unmarshal(getTestFileContent("golden_message")); err != nil {
+		t.Fatalf("failed to unmarshal golden message: %v", err)
+	}
+	parsed.Freeze()
+
+	maps := map_test.NewTestMapReader()
+	if err := maps.Unmarshal(getTestFileContent("map_test")); err != nil {
+		t.Fatalf("failed to unmarshal map test: %v", err)
+	}
+	maps.Freeze()
+
+	wg := sync.WaitGroup{}
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkParsedGoldenMessage(t, parsed)
+			_ = parsed.ToStruct()
+
+			if maps.GetInt32ToMessageField()[104].GetValue() != 104 {
+				t.Errorf("int32_to_message_field: got %v", maps.GetInt32ToMessageField())
+			}
+			_ = maps.ToStruct()
+		}()
+	}
+	wg.Wait()
+}
+
+// unmarshalBoth decodes content into a struct directly and through reader.ToStruct.
+func unmarshalBoth[S any, PS interface {
+	*S
+	Unmarshal([]byte) error
+}, R interface {
+	Unmarshal([]byte) error
+	ToStruct() *S
+}](t *testing.T, reader R, content []byte) (fromReader *S, direct *S) {
+	t.Helper()
+	if err := reader.Unmarshal(content); err != nil {
+		t.Fatalf("failed to unmarshal reader: %v", err)
+	}
+	direct = new(S)
+	if err := PS(direct).Unmarshal(content); err != nil {
+		t.Fatalf("failed to unmarshal struct: %v", err)
+	}
+	return reader.ToStruct(), direct
+}
+
+func TestStructUnmarshalMatchesToStruct(t *testing.T) {
+	for _, name := range []string{"golden_message", "golden_message_oneof_implemented"} {
+		t.Run(name, func(t *testing.T) {
+			fromReader, direct := unmarshalBoth[protobuf_unittest.TestAllTypes](t, protobuf_unittest.NewTestAllTypesReader(), getTestFileContent(name))
+			if diff := cmp.Diff(fromReader, direct); diff != "" {
+				t.Errorf("struct mismatch (-reader +struct):\n%v", diff)
+			}
+		})
+	}
+
+	t.Run("golden_packed_fields_message", func(t *testing.T) {
+		fromReader, direct := unmarshalBoth[protobuf_unittest.TestPackedTypes](t, protobuf_unittest.NewTestPackedTypesReader(), getTestFileContent("golden_packed_fields_message"))
+		if diff := cmp.Diff(fromReader, direct); diff != "" {
+			t.Errorf("struct mismatch (-reader +struct):\n%v", diff)
+		}
+	})
+
+	t.Run("map_test", func(t *testing.T) {
+		fromReader, direct := unmarshalBoth[map_test.TestMap](t, map_test.NewTestMapReader(), getTestFileContent("map_test"))
+		if diff := cmp.Diff(fromReader, direct); diff != "" {
+			t.Errorf("struct mismatch (-reader +struct):\n%v", diff)
+		}
+	})
+
+	t.Run("empty_messages", func(t *testing.T) {
+		// present but empty singular messages are set, empty elements of repeated and map fields are nil
+		var content []byte
+		for _, number := range []protowire.Number{2, 3, 4, 5, 6, 8} {
+			content = protowire.AppendTag(content, number, protowire.BytesType)
+			content = protowire.AppendBytes(content, nil)
+		}
+		reader := wellknown.NewEventReader()
+		fromReader, direct := unmarshalBoth[wellknown.Event](t, reader, content)
+		if diff := cmp.Diff(fromReader, direct); diff != "" {
+			t.Errorf("struct mismatch (-reader +struct):\n%v", diff)
+		}
+		want := &wellknown.Event{
+			CreatedAt:  &wkt.Timestamp{},
+			Ttl:        &wkt.Duration{},
+			History:    []*wkt.Timestamp{nil},
+			Payload:    &wkt.Any{},
+			Attributes: map[string]any{},
+			UpdateMask: &wkt.FieldMask{},
+		}
+		if diff := cmp.Diff(want, direct); diff != "" {
+			t.Errorf("unexpected struct (-want +got):\n%v", diff)
+		}
+		if got := direct.Marshal(); !bytes.Equal(got, content) {
+			t.Errorf("empty messages were not written back: %x", got)
+		}
+
+		structJSON, err := direct.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var transcoded bytes.Buffer
+		if err := wellknown.TranscodeEventJSON(&transcoded, content); err != nil {
+			t.Fatal(err)
+		}
+		if readerJSON := reader.AppendJSON(nil); string(readerJSON) != string(structJSON) || transcoded.String() != string(structJSON) {
+			t.Errorf("JSON mismatch, reader %s, struct %s, transcoded %s", readerJSON, structJSON, transcoded.String())
+		}
+	})
+}
+
+func TestStructUnmarshalDefaults(t *testing.T) {
+	var msg protobuf_unittest.TestExtremeDefaultValues
+	if err := msg.Unmarshal(nil); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	reader := protobuf_unittest.NewTestExtremeDefaultValuesReader()
+	if err := reader.Unmarshal(nil); err != nil {
+		t.Fatalf("failed to unmarshal reader: %v", err)
+	}
+	if diff := cmp.Diff(reader.ToStruct(), &msg, cmpopts.EquateNaNs()); diff != "" {
+		t.Errorf("defaults mismatch (-reader +struct):\n%v", diff)
+	}
+}
+
+func TestStructUnmarshalMalformed(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{"invalid wire type", "4f"},
+		{"truncated string", "08057205"},
+		{"truncated packed field", "0805720568656c6c6f9201020803fa0103"},
+		{"packed entry past its chunk", "fa010180"},
+		{"string as varint", "7205"},
+		{"int32 as bytes", "0a0161"},
+		{"packed varint running into the next field", "fa0101800801"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var msg protobuf_unittest.TestAllTypes
+			if err := msg.Unmarshal(data); err == nil {
+				t.Errorf("expected an error, got %v", &msg)
+			}
+		})
+	}
+
+	var msg map_test.TestMap
+	if err := msg.Unmarshal([]byte{0x0a, 0x04, 0x08, 0x01, 0x12, 0x05}); err == nil {
+		t.Errorf("expected an error for a map entry value past the entry, got %v", &msg)
+	}
+}
+
+func TestStructUnmarshalTruncated(t *testing.T) {
+	content := getTestFileContent("golden_message")
+	for size := range content {
+		var msg protobuf_unittest.TestAllTypes
+		// cuts at field boundaries are valid messages, all others must fail without panicking
+		_ = msg.Unmarshal(content[:size])
+	}
+}
+
+func FuzzStructUnmarshal(f *testing.F) {
+	for _, name := range []string{"golden_message", "golden_packed_fields_message", "map_test"} {
+		f.Add(getTestFileContent(name))
+	}
+	f.Add([]byte{0x4f})
+	f.Add([]byte{0x08, 0x05, 0x72, 0x05})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var all protobuf_unittest.TestAllTypes
+		if err := all.Unmarshal(data); err == nil {
+			// decoded messages are written back as valid data
+			if err := new(protobuf_unittest.TestAllTypes).Unmarshal(all.Marshal()); err != nil {
+				t.Errorf("failed to decode the marshalled message: %v", err)
+			}
+		}
+		var packed protobuf_unittest.TestPackedTypes
+		_ = packed.Unmarshal(data)
+		var maps map_test.TestMap
+		_ = maps.Unmarshal(data)
+	})
+}
+
+func TestDetachAliasedReader(t *testing.T) {
+	content := append([]byte(nil), getTestFileContent("golden_message")...)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.UnmarshalWithOptions(content, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		t.Fatalf("failed to unmarshal golden message: %v", err)
+	}
+	// part of the values is read before detaching, the rest after
+	if parsed.GetOptionalString() != "115" {
+		t.Errorf("optional_string: got %v, want %v", parsed.GetOptionalString(), "115")
+	}
+	if parsed.GetOptionalNestedMessage().GetBb() != 118 {
+		t.Errorf("optional_nested_message.bb: got %v, want %v", parsed.GetOptionalNestedMessage().GetBb(), 118)
+	}
+	_ = parsed.GetRepeatedString()
+
+	parsed.Detach()
+	for i := range content {
+		content[i] = 0
+	}
+
+	checkParsedGoldenMessage(t, parsed)
+}
+
+func TestDetachCopiesReferencedBytes(t *testing.T) {
+	var content []byte
+	content = protowire.AppendTag(content, 14, protowire.BytesType)
+	content = protowire.AppendString(content, "kept")
+	// fields the message doesn't know are not referenced after Detach
+	content = protowire.AppendTag(content, 999, protowire.BytesType)
+	content = protowire.AppendBytes(content, make([]byte, 1<<20))
+	nested := protowire.AppendTag(nil, 1, protowire.VarintType)
+	nested = protowire.AppendVarint(nested, 7)
+	content = protowire.AppendTag(content, 18, protowire.BytesType)
+	content = protowire.AppendBytes(content, nested)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.UnmarshalWithOptions(content, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	parsed.Detach()
+	runtime.ReadMemStats(&after)
+	for i := range content {
+		content[i] = 0
+	}
+
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated >= 1<<20 {
+		t.Errorf("Detach allocated %v bytes, unknown fields should not be copied", allocated)
+	}
+	if parsed.GetOptionalString() != "kept" {
+		t.Errorf("optional_string: got %q, want %q", parsed.GetOptionalString(), "kept")
+	}
+	if parsed.GetOptionalNestedMessage().GetBb() != 7 {
+		t.Errorf("optional_nested_message.bb: got %v, want %v", parsed.GetOptionalNestedMessage().GetBb(), 7)
+	}
+}
+
+func TestDetachedBufferMode(t *testing.T) {
+	content := append([]byte(nil), getTestFileContent("golden_message")...)
+
+	parsed := protobuf_unittest.NewTestAllTypesReader()
+	if err := parsed.UnmarshalWithOptions(content, gremlin.ReaderOptions{Buffer: gremlin.BufferDetached}); err != nil {
+		t.Fatalf("failed to unmarshal golden message: %v", err)
+	}
+	optionalBytes := parsed.GetOptionalBytes()
+	repeatedBytes := parsed.GetRepeatedBytes()
+	for i := range content {
+		content[i] = 0
+	}
+
+	if string(optionalBytes) != "116" {
+		t.Errorf("optional_bytes: got %q, want %q", optionalBytes, "116")
+	}
+	if !cmp.Equal(repeatedBytes, [][]byte{[]byte("216"), []byte("316")}) {
+		t.Errorf("repeated_bytes: got %q", repeatedBytes)
+	}
+}
+
+func TestArenaReaders(t *testing.T) {
+	content := getTestFileContent("golden_message")
+	expected := protobuf_unittest.NewTestAllTypesReader()
+	if err := expected.Unmarshal(content); err != nil {
+		t.Fatalf("failed to unmarshal golden message: %v", err)
+	}
+
+	arena := gremlin.NewArena()
+	for range 3 {
+		arena.Reset()
+		parsed := gremlin.New[protobuf_unittest.TestAllTypesReader](arena)
+		if err := parsed.UnmarshalWithOptions(content, gremlin.ReaderOptions{Arena: arena}); err != nil {
+			t.Fatalf("failed to unmarshal golden message: %v", err)
+		}
+		checkParsedGoldenMessage(t, parsed)
+		if diff := cmp.Diff(expected.ToStruct(), parsed.ToStruct()); diff != "" {
+			t.Errorf("struct mismatch (-plain +arena):\n%v", diff)
+		}
+	}
+
+	maps := gremlin.New[map_test.TestMapReader](arena)
+	if err := maps.UnmarshalWithOptions(getTestFileContent("map_test"), gremlin.ReaderOptions{Arena: arena}); err != nil {
+		t.Fatalf("failed to unmarshal map test: %v", err)
+	}
+	if maps.GetInt32ToMessageField()[204].GetValue() != 204 {
+		t.Errorf("int32_to_message_field: got %v", maps.GetInt32ToMessageField())
+	}
+}
+
+func TestJSONNames(t *testing.T) {
+	msg := &protobuf_unittest.TestJsonName{
+		FieldName1: 1, FieldName2: 2, FieldName3: 3, FieldName4: 4, FIELDNAME5: 5, FieldName6: 6, Fieldname7: 7,
+	}
+	data, err := msg.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	const expected = `{"fieldName1":1,"fieldName2":2,"FieldName3":3,"FieldName4":4,"FIELDNAME5":5,"@type":6,"fieldname7":7}`
+	if string(data) != expected {
+		t.Errorf("unexpected JSON:\n got: %s\nwant: %s", data, expected)
+	}
+
+	var decoded protobuf_unittest.TestJsonName
+	if err := decoded.UnmarshalJSON([]byte(`{"field_name1": 1, "fieldName2": 2, "FieldName3": 3, "_field_name4": 4, "FIELD_NAME5": 5, "@type": 6, "fieldname7": null}`)); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(&protobuf_unittest.TestJsonName{FieldName1: 1, FieldName2: 2, FieldName3: 3, FieldName4: 4, FIELDNAME5: 5, FieldName6: 6}, &decoded); diff != "" {
+		t.Errorf("decoded mismatch (-want +got):\n%v", diff)
+	}
+	if err := decoded.UnmarshalJSON([]byte(`{"unknown": 1}`)); err == nil {
+		t.Errorf("expected an error for an unknown field")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Run("golden_packed_fields_message", func(t *testing.T) {
+		reader := protobuf_unittest.NewTestPackedTypesReader()
+		if err := reader.Unmarshal(getTestFileContent("golden_packed_fields_message")); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		data := reader.AppendJSON(nil)
+		if structData, _ := reader.ToStruct().MarshalJSON(); !bytes.Equal(data, structData) {
+			t.Fatalf("reader and struct JSON differ:\n%s\n%s", data, structData)
+		}
+		var msg protobuf_unittest.TestPackedTypes
+		if err := msg.UnmarshalJSON(data); err != nil {
+			t.Fatalf("failed to unmarshal JSON: %v", err)
+		}
+		if diff := cmp.Diff(reader.ToStruct(), &msg); diff != "" {
+			t.Errorf("struct mismatch (-reader +json):\n%v", diff)
+		}
+	})
+
+	t.Run("map_test", func(t *testing.T) {
+		reader := map_test.NewTestMapReader()
+		if err := reader.Unmarshal(getTestFileContent("map_test")); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		var out bytes.Buffer
+		if err := reader.WriteJSON(&out); err != nil {
+			t.Fatalf("failed to write JSON: %v", err)
+		}
+		var msg map_test.TestMap
+		if err := msg.UnmarshalJSON(out.Bytes()); err != nil {
+			t.Fatalf("failed to unmarshal JSON: %v", err)
+		}
+		if diff := cmp.Diff(reader.ToStruct(), &msg); diff != "" {
+			t.Errorf("struct mismatch (-reader +json):\n%v", diff)
+		}
+	})
+}
+
+func TestTranscodeJSON(t *testing.T) {
+	t.Run("golden_message", func(t *testing.T) {
+		reader := protobuf_unittest.NewTestAllTypesReader()
+		if err := reader.Unmarshal(getTestFileContent("golden_message")); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		// re-encode to get fields in declaration order and a single oneof member
+		content := reader.ToStruct().Marshal()
+		var out bytes.Buffer
+		if err := protobuf_unittest.TranscodeTestAllTypesJSON(&out, content); err != nil {
+			t.Fatalf("failed to transcode: %v", err)
+		}
+		expected, _ := reader.ToStruct().MarshalJSON()
+		if !bytes.Equal(out.Bytes(), expected) {
+			t.Errorf("transcoded JSON differs:\n got: %s\nwant: %s", out.Bytes(), expected)
+		}
+	})
+
+	t.Run("golden_packed_fields_message", func(t *testing.T) {
+		content := getTestFileContent("golden_packed_fields_message")
+		var out bytes.Buffer
+		if err := protobuf_unittest.TranscodeTestPackedTypesJSON(&out, content); err != nil {
+			t.Fatalf("failed to transcode: %v", err)
+		}
+		reader := protobuf_unittest.NewTestPackedTypesReader()
+		if err := reader.Unmarshal(content); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		if expected := reader.AppendJSON(nil); !bytes.Equal(out.Bytes(), expected) {
+			t.Errorf("transcoded JSON differs:\n got: %s\nwant: %s", out.Bytes(), expected)
+		}
+	})
+
+	t.Run("map_test", func(t *testing.T) {
+		content := getTestFileContent("map_test")
+		var out bytes.Buffer
+		if err := map_test.TranscodeTestMapJSON(&out, content); err != nil {
+			t.Fatalf("failed to transcode: %v", err)
+		}
+		// map entries keep the wire order, so compare decoded values
+		var msg map_test.TestMap
+		if err := msg.UnmarshalJSON(out.Bytes()); err != nil {
+			t.Fatalf("failed to unmarshal JSON: %v", err)
+		}
+		reader := map_test.NewTestMapReader()
+		if err := reader.Unmarshal(content); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		if diff := cmp.Diff(reader.ToStruct(), &msg); diff != "" {
+			t.Errorf("struct mismatch (-reader +json):\n%v", diff)
+		}
+	})
+
+	t.Run("interleaved_repeated", func(t *testing.T) {
+		w := gremlin.NewWriter(64)
+		w.AppendInt32(31, 1)
+		w.AppendInt32(1, 5)
+		w.AppendInt32(31, 2)
+		var out bytes.Buffer
+		if err := protobuf_unittest.TranscodeTestAllTypesJSON(&out, w.Bytes()); err != nil {
+			t.Fatalf("failed to transcode: %v", err)
+		}
+		if expected := `{"repeatedInt32":[1,2],"optionalInt32":5}`; out.String() != expected {
+			t.Errorf("unexpected JSON:\n got: %s\nwant: %s", out.Bytes(), expected)
+		}
+	})
+
+	t.Run("repeated_singular", func(t *testing.T) {
+		w := gremlin.NewWriter(64)
+		w.AppendInt32(1, 5)
+		w.AppendBytes(18, []byte{0x08, 0x03})
+		w.AppendInt32(1, 7)
+		w.AppendBytes(18, nil)
+		var out bytes.Buffer
+		if err := protobuf_unittest.TranscodeTestAllTypesJSON(&out, w.Bytes()); err != nil {
+			t.Fatalf("failed to transcode: %v", err)
+		}
+		// the last scalar wins, messages are merged
+		if expected := `{"optionalInt32":7,"optionalNestedMessage":{"bb":3}}`; out.String() != expected {
+			t.Errorf("unexpected JSON:\n got: %s\nwant: %s", out.Bytes(), expected)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, data := range []string{
+			"4f",               // unknown wire type
+			"7205",             // string running past the end
+			"0805720568656c6c", // string cut after a valid field
+			"fa010180",         // packed int32 cut inside a value
+			"0a0161",           // int64 encoded as bytes
+			"920102088001",     // varint running past the end of a nested message
+		} {
+			content, _ := hex.DecodeString(data)
+			if err := protobuf_unittest.TranscodeTestAllTypesJSON(io.Discard, content); err == nil {
+				t.Errorf("%v: expected an error", data)
+			}
+		}
+		mapEntry, _ := hex.DecodeString("0a0408011005")
+		mapEntry[1] = 3 // the value runs past the entry
+		if err := map_test.TranscodeTestMapJSON(io.Discard, mapEntry); err == nil {
+			t.Errorf("map entry: expected an error")
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, name := range []string{"golden_message", "golden_packed_fields_message", "map_test"} {
+			content := getTestFileContent(name)
+			for size := range content {
+				// cuts at field boundaries are valid messages, all others must fail without panicking
+				_ = protobuf_unittest.TranscodeTestAllTypesJSON(io.Discard, content[:size])
+				_ = protobuf_unittest.TranscodeTestPackedTypesJSON(io.Discard, content[:size])
+				_ = map_test.TranscodeTestMapJSON(io.Discard, content[:size])
+			}
+		}
+	})
+}
+
+func FuzzTranscodeJSON(f *testing.F) {
+	for _, name := range []string{"golden_message", "golden_packed_fields_message", "map_test"} {
+		f.Add(getTestFileContent(name))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := protobuf_unittest.TranscodeTestAllTypesJSON(io.Discard, data); err == nil {
+			// data the transcoder accepts is valid for the struct decoder too
+			if err := new(protobuf_unittest.TestAllTypes).Unmarshal(data); err != nil {
+				t.Errorf("transcoded data the struct decoder rejects: %v", err)
+			}
+		}
+		_ = protobuf_unittest.TranscodeTestPackedTypesJSON(io.Discard, data)
+		_ = map_test.TranscodeTestMapJSON(io.Discard, data)
+	})
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	t.Run("golden_message", func(t *testing.T) {
+		reader := protobuf_unittest.NewTestAllTypesReader()
+		if err := reader.Unmarshal(getTestFileContent("golden_message")); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		data, _ := reader.MarshalText()
+		if structData, _ := reader.ToStruct().MarshalText(); !bytes.Equal(data, structData) {
+			t.Fatalf("reader and struct text differ:\n%s\n%s", data, structData)
+		}
+		var msg protobuf_unittest.TestAllTypes
+		if err := msg.UnmarshalText(data); err != nil {
+			t.Fatalf("failed to unmarshal text: %v", err)
+		}
+		if diff := cmp.Diff(reader.ToStruct(), &msg); diff != "" {
+			t.Errorf("struct mismatch (-reader +text):\n%v", diff)
+		}
+	})
+
+	t.Run("map_test", func(t *testing.T) {
+		reader := map_test.NewTestMapReader()
+		if err := reader.Unmarshal(getTestFileContent("map_test")); err != nil {
+			t.Fatalf("failed to unmarshal reader: %v", err)
+		}
+		var msg map_test.TestMap
+		if err := msg.UnmarshalText([]byte(reader.String())); err != nil {
+			t.Fatalf("failed to unmarshal text: %v", err)
+		}
+		if diff := cmp.Diff(reader.ToStruct(), &msg); diff != "" {
+			t.Errorf("struct mismatch (-reader +text):\n%v", diff)
+		}
+	})
+}
+
+func TestTextFormat(t *testing.T) {
+	// start from the proto2 defaults so fields with default values are omitted
+	msg := &protobuf_unittest.TestAllTypes{}
+	if err := msg.Unmarshal(nil); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	msg.OptionalInt32 = 5
+	msg.OptionalString = "a\"b\n\x01é"
+	msg.OptionalNestedMessage = &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 7}
+	msg.RepeatedInt32 = []int32{1, 2}
+	msg.OptionalNestedEnum = protobuf_unittest.TestAllTypes_BAZ
+	single := `optional_int32:5 optional_string:"a\"b\n\x01é" optional_nested_message:{bb:7} optional_nested_enum:BAZ repeated_int32:1 repeated_int32:2`
+	if got := msg.String(); got != single {
+		t.Errorf("unexpected String:\n got: %s\nwant: %s", got, single)
+	}
+	if got := fmt.Sprintf("%v", msg); got != single {
+		t.Errorf("unexpected %%v:\n got: %s\nwant: %s", got, single)
+	}
+	multi := `optional_int32: 5
+optional_string: "a\"b\n\x01é"
+optional_nested_message: {
+  bb: 7
+}
+optional_nested_enum: BAZ
+repeated_int32: 1
+repeated_int32: 2
+`
+	if got := fmt.Sprintf("%+v", msg); got != multi {
+		t.Errorf("unexpected %%+v:\n got: %s\nwant: %s", got, multi)
+	}
+	if got := (&protobuf_unittest.TestAllTypes_NestedMessage{}).String(); got != "" {
+		t.Errorf("empty message should print nothing, got %q", got)
+	}
+}
+
+func TestTextParsing(t *testing.T) {
+	input := `# comment
+optional_int32: 0x10, optional_int64: -7;
+optional_float: 1.5f optional_double: -inf
+optional_bool: t
+optional_string: 'single' "\x41\101\u00e9"
+optional_bytes: "\377\000"
+optional_nested_message < bb: 3 >
+optional_nested_enum: 3
+repeated_int32: [1, 2] repeated_int32: 3
+repeated_nested_message [{bb: 1}, {bb: 2}]
+`
+	var msg protobuf_unittest.TestAllTypes
+	if err := msg.UnmarshalText([]byte(input)); err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	expected := &protobuf_unittest.TestAllTypes{}
+	if err := expected.Unmarshal(nil); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	expected.OptionalInt32 = 16
+	expected.OptionalInt64 = -7
+	expected.OptionalFloat = 1.5
+	expected.OptionalDouble = math.Inf(-1)
+	expected.OptionalBool = true
+	expected.OptionalString = "singleAAé"
+	expected.OptionalBytes = []byte{0xff, 0}
+	expected.OptionalNestedMessage = &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 3}
+	expected.OptionalNestedEnum = protobuf_unittest.TestAllTypes_BAZ
+	expected.RepeatedInt32 = []int32{1, 2, 3}
+	expected.RepeatedNestedMessage = []*protobuf_unittest.TestAllTypes_NestedMessage{{Bb: 1}, {Bb: 2}}
+	if diff := cmp.Diff(expected, &msg); diff != "" {
+		t.Errorf("struct mismatch (-want +got):\n%v", diff)
+	}
+
+	var maps map_test.TestMap
+	if err := maps.UnmarshalText([]byte(`int32_to_string_field {key: 1 value: "a"} int32_to_enum_field: [{key: 2 value: QUX}]`)); err != nil {
+		t.Fatalf("failed to parse maps: %v", err)
+	}
+	if maps.Int32ToStringField[1] != "a" || maps.Int32ToEnumField[2] != map_test.TestMap_QUX {
+		t.Errorf("unexpected maps: %v", &maps)
+	}
+
+	for _, input := range []string{
+		`unknown_field: 1`,
+		`optional_int32: "1"`,
+		`optional_nested_message { bb: 1`,
+		`optional_string: "\xff"`,
+		`[ext.field]: 1`,
+	} {
+		if err := msg.UnmarshalText([]byte(input)); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestDescriptors(t *testing.T) {
+	desc := gremlin.FindMessage("protobuf_unittest.TestAllTypes")
+	if desc == nil {
+		t.Fatalf("TestAllTypes is not registered")
+	}
+	if desc != (&protobuf_unittest.TestAllTypes{}).Descriptor() || desc != protobuf_unittest.NewTestAllTypesReader().Descriptor() {
+		t.Fatalf("registered descriptor differs from the generated one")
+	}
+	if desc.Name() != "TestAllTypes" || desc.File != "unittest.proto" {
+		t.Errorf("unexpected name %v in %v", desc.Name(), desc.File)
+	}
+
+	field := desc.FieldByName("optional_nested_enum")
+	if field == nil || field.Number != 21 || field.Kind != gremlin.KindEnum || field.Label != gremlin.LabelOptional {
+		t.Fatalf("unexpected field descriptor %+v", field)
+	}
+	if enum := field.Enum(); enum == nil || enum.FullName != "protobuf_unittest.TestAllTypes.NestedEnum" {
+		t.Fatalf("unexpected enum descriptor %+v", enum)
+	}
+	if v, ok := field.Enum().ValueByNumber(3); !ok || v.Name != "BAZ" {
+		t.Errorf("unexpected enum value %+v", v)
+	}
+
+	field = desc.FieldByNumber(48)
+	if field == nil || field.Name != "repeated_nested_message" || field.Label != gremlin.LabelRepeated {
+		t.Fatalf("unexpected field descriptor %+v", field)
+	}
+	if nested := field.Message(); nested == nil || nested != desc.Messages[0] || nested.FieldByName("bb") == nil {
+		t.Fatalf("unexpected nested message descriptor %+v", nested)
+	}
+	if len(desc.Enums) != 1 || desc.Enums[0].Name() != "NestedEnum" {
+		t.Errorf("unexpected nested enums %+v", desc.Enums)
+	}
+	if field := desc.FieldByName("default_int32"); field.Default != "41" {
+		t.Errorf("unexpected default %q", field.Default)
+	}
+	if field := desc.FieldByName("oneof_uint32"); field.OneOf != "oneof_field" {
+		t.Errorf("unexpected oneof %q", field.OneOf)
+	}
+
+	mapField := gremlin.FindMessage("map_test.TestMap").FieldByName("int32_to_message_field")
+	if !mapField.IsMap() || mapField.MapKey.Kind != gremlin.KindInt32 || mapField.MapValue.Message() == nil {
+		t.Errorf("unexpected map field %+v", mapField)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	var msg gremlin.ProtoMessage = gremlin.FindMessage("protobuf_unittest.TestAllTypes").New()
+	if err := msg.SetField(1, int32(5)); err != nil {
+		t.Fatalf("failed to set field: %v", err)
+	}
+	if err := msg.SetField(18, &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 7}); err != nil {
+		t.Fatalf("failed to set field: %v", err)
+	}
+	if err := msg.SetField(31, []int32{1, 2}); err != nil {
+		t.Fatalf("failed to set field: %v", err)
+	}
+	if err := msg.SetField(1, int64(5)); err == nil {
+		t.Errorf("expected an error for a value of the wrong type")
+	}
+	if err := msg.SetField(9999, int32(5)); err == nil {
+		t.Errorf("expected an error for an unknown field")
+	}
+
+	if v := msg.GetField(1); v != int32(5) {
+		t.Errorf("unexpected value %v", v)
+	}
+	if v := msg.GetField(9999); v != nil {
+		t.Errorf("unexpected value %v for an unknown field", v)
+	}
+
+	reader := protobuf_unittest.NewTestAllTypesReader()
+	if err := reader.Unmarshal(msg.Marshal()); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if reader.GetOptionalInt32() != 5 || reader.GetOptionalNestedMessage().GetBb() != 7 || len(reader.GetRepeatedInt32()) != 2 {
+		t.Errorf("unexpected message %v", reader)
+	}
+}
+
+func TestTimeAccessors(t *testing.T) {
+	created := time.Date(2024, 2, 29, 12, 30, 15, 500, time.UTC)
+	event := &wellknown.Event{}
+	if err := event.SetCreatedAtTime(created); err != nil {
+		t.Fatal(err)
+	}
+	event.SetTtlDuration(-90 * time.Second)
+	if event.CreatedAt.Seconds != created.Unix() || event.CreatedAt.Nanos != 500 || event.Ttl.Seconds != -90 {
+		t.Errorf("unexpected wire values %v %v", event.CreatedAt, event.Ttl)
+	}
+
+	reader := wellknown.NewEventReader()
+	if err := reader.Unmarshal(event.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if got := reader.GetCreatedAtTime(); !got.Equal(created) {
+		t.Errorf("unexpected time %v", got)
+	}
+	if got := reader.GetTtlDuration(); got != -90*time.Second {
+		t.Errorf("unexpected duration %v", got)
+	}
+
+	if err := event.SetCreatedAtTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("times after year 9999 should be rejected")
+	}
+	if event.CreatedAt.Seconds != created.Unix() {
+		t.Errorf("a rejected time should leave the field unchanged")
+	}
+	if err := event.SetCreatedAtTime(time.Time{}); err != nil || event.CreatedAt != nil {
+		t.Errorf("the zero time should clear the field")
+	}
+
+	// out of range values on the wire read as unset
+	event.CreatedAt = &wkt.Timestamp{Nanos: -1}
+	event.Ttl = &wkt.Duration{Seconds: 1, Nanos: -1}
+	reader = wellknown.NewEventReader()
+	if err := reader.Unmarshal(event.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if !reader.GetCreatedAtTime().IsZero() || reader.GetTtlDuration() != 0 {
+		t.Errorf("invalid values should read as zero, got %v %v", reader.GetCreatedAtTime(), reader.GetTtlDuration())
+	}
+	if reader.GetCreatedAt().CheckValid() == nil {
+		t.Errorf("CheckValid should report the invalid timestamp")
+	}
+}
+
+func TestWrapperFields(t *testing.T) {
+	note, archived := "", false
+	event := &wellknown.Event{Note: &note, Archived: &archived}
+	revision := int64(42)
+	event.Revision = &revision
+
+	data := event.Marshal()
+	reader := wellknown.NewEventReader()
+	if err := reader.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := reader.GetNote(); !ok || value != "" {
+		t.Errorf("a set empty note should read as set, got %q %v", value, ok)
+	}
+	if value, ok := reader.GetRevision(); !ok || value != 42 {
+		t.Errorf("unexpected revision %v %v", value, ok)
+	}
+	if value, ok := reader.GetArchived(); !ok || value {
+		t.Errorf("unexpected archived %v %v", value, ok)
+	}
+
+	// on the wire the wrappers stay messages with the value in field 1
+	wrapped := &wkt.Int64Value{Value: 42}
+	if !bytes.Contains(data, append([]byte{0x52, byte(len(wrapped.Marshal()))}, wrapped.Marshal()...)) {
+		t.Errorf("revision is not encoded as an Int64Value message: %x", data)
+	}
+
+	got := reader.ToStruct()
+	if got.Note == nil || *got.Note != "" || got.Archived == nil || *got.Archived || *got.Revision != 42 {
+		t.Errorf("unexpected struct %v", got)
+	}
+	if got.Revision == event.Revision {
+		t.Errorf("ToStruct should not share pointers with the source")
+	}
+
+	unset := wellknown.NewEventReader()
+	if err := unset.Unmarshal((&wellknown.Event{Id: "x"}).Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := unset.GetNote(); ok {
+		t.Errorf("an unset note should not read as set")
+	}
+	if unset.ToStruct().Revision != nil {
+		t.Errorf("unset wrappers should stay nil")
+	}
+
+	// the JSON mapping of wrappers is the bare value
+	wantJSON := `{"note":"","revision":"42","archived":false}`
+	if got, err := event.MarshalJSON(); err != nil || string(got) != wantJSON {
+		t.Errorf("unexpected JSON %s, %v", got, err)
+	}
+	if got := string(reader.AppendJSON(nil)); got != wantJSON {
+		t.Errorf("unexpected reader JSON %s", got)
+	}
+	decoded := &wellknown.Event{}
+	if err := decoded.UnmarshalJSON([]byte(wantJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Archived == nil || *decoded.Archived || *decoded.Revision != 42 {
+		t.Errorf("unexpected decoded %v", decoded)
+	}
+
+	text, err := event.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromText := &wellknown.Event{}
+	if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if fromText.Note == nil || fromText.Archived == nil || *fromText.Revision != 42 {
+		t.Errorf("unexpected text round trip %v from %s", fromText, text)
+	}
+}
+
+func TestJSONValueFields(t *testing.T) {
+	event := &wellknown.Event{
+		Attributes: map[string]any{
+			"name":  "gremlin",
+			"count": 0,
+			"tags":  []any{"a", nil, false},
+		},
+		Extra: wkt.NullValue_NULL_VALUE,
+	}
+	data := event.Marshal()
+
+	// the wire format is the one of google.protobuf.Struct
+	found := false
+	for b := data; len(b) > 0; {
+		number, wireType, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		if number == 6 {
+			found = true
+			value, _ := protowire.ConsumeBytes(b)
+			st := &structpb.Struct{}
+			if err := proto.Unmarshal(value, st); err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]any{"name": "gremlin", "count": 0.0, "tags": []any{"a", nil, false}}
+			if diff := cmp.Diff(want, st.AsMap()); diff != "" {
+				t.Errorf("unexpected Struct (-want +got):\n%v", diff)
+			}
+		}
+		b = b[protowire.ConsumeFieldValue(number, wireType, b):]
+	}
+	if !found {
+		t.Errorf("attributes are missing from %x", data)
+	}
+
+	reader := wellknown.NewEventReader()
+	if err := reader.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	attributes, err := reader.GetAttributes().AsMap()
+	if err != nil || attributes["count"] != 0.0 || attributes["tags"].([]any)[2] != false {
+		t.Errorf("unexpected attributes %v, %v", attributes, err)
+	}
+	if extra, err := reader.GetExtra().AsInterface(); extra != nil || err != nil {
+		t.Errorf("unexpected extra %v, %v", extra, err)
+	}
+
+	got := reader.ToStruct()
+	if got.Extra != wkt.NullValue_NULL_VALUE || got.Attributes["count"] != 0.0 {
+		t.Errorf("unexpected struct %#v", got)
+	}
+	var decoded wellknown.Event
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, &decoded); diff != "" {
+		t.Errorf("Unmarshal and ToStruct differ (-reader +struct):\n%v", diff)
+	}
+
+	// canonical JSON, a null Value field is written as null
+	wantJSON := `{"attributes":{"count":0,"name":"gremlin","tags":["a",null,false]},"extra":null}`
+	if got, err := event.MarshalJSON(); err != nil || string(got) != wantJSON {
+		t.Errorf("unexpected JSON %s, %v", got, err)
+	}
+	if got := string(reader.AppendJSON(nil)); got != wantJSON {
+		t.Errorf("unexpected reader JSON %s", got)
+	}
+	var transcoded bytes.Buffer
+	if err := wellknown.TranscodeEventJSON(&transcoded, data); err != nil || transcoded.String() != wantJSON {
+		t.Errorf("unexpected transcoded JSON %s, %v", transcoded.String(), err)
+	}
+	fromJSON := &wellknown.Event{}
+	if err := fromJSON.UnmarshalJSON([]byte(`{"attributes":{"a":[1,{"b":null}]},"extra":"x"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]any{"a": []any{1.0, map[string]any{"b": nil}}}, fromJSON.Attributes); diff != "" || fromJSON.Extra != "x" {
+		t.Errorf("unexpected decoded JSON %v %v", fromJSON.Attributes, fromJSON.Extra)
+	}
+	if err := fromJSON.UnmarshalJSON([]byte(`{"attributes":[1]}`)); err == nil {
+		t.Errorf("a Struct must be a JSON object")
+	}
+
+	text, err := event.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromText := &wellknown.Event{}
+	if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, fromText); diff != "" {
+		t.Errorf("unexpected text round trip (-want +got):\n%v\n%s", diff, text)
+	}
+
+	copied := event.Copy()
+	copied.Attributes["tags"].([]any)[0] = "b"
+	if event.Attributes["tags"].([]any)[0] != "a" {
+		t.Errorf("Copy should copy nested values")
+	}
+}
+
+// The well-known types are written in their own JSON mappings, byte for byte what protojson produces for
+// the same wire bytes, and read back from it.
+func TestWellKnownJSONMatchesProtojson(t *testing.T) {
+	set, err := BuildDescriptorSet(resolveTestProtoFiles(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := new(protoregistry.Types)
+	// empty.proto is not imported by the test protos
+	if err := resolver.RegisterMessage((&emptypb.Empty{}).ProtoReflect().Type()); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []protoreflect.FullName{"wellknown.Event", "google.protobuf.Duration", "google.protobuf.StringValue"} {
+		desc, err := files.FindDescriptorByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := resolver.RegisterMessage(dynamicpb.NewMessageType(desc.(protoreflect.MessageDescriptor))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventType, _ := resolver.FindMessageByName("wellknown.Event")
+
+	note, revision, archived := "n\"ote", int64(-1)<<40, false
+	inner := &wellknown.Event{
+		Id:  "inner",
+		Ttl: &wkt.Duration{Seconds: 3},
+	}
+	events := map[string]*wellknown.Event{
+		"all fields": {
+			Id:        "event",
+			CreatedAt: &wkt.Timestamp{Seconds: 1709209800, Nanos: 500_000_000},
+			Ttl:       &wkt.Duration{Seconds: -1, Nanos: -500_000_000},
+			History: []*wkt.Timestamp{
+				{Seconds: -62135596800},
+				{Seconds: 253402300799, Nanos: 1000},
+				{Nanos: 1},
+			},
+			Payload:    wkt.PackAny(inner),
+			Attributes: map[string]any{"name": "gremlin", "tags": []any{1.5, nil}},
+			Extra:      "extra",
+			UpdateMask: &wkt.FieldMask{Paths: []string{"created_at", "history.foo_bar", "id"}},
+			Note:       &note,
+			Revision:   &revision,
+			Archived:   &archived,
+			// one entry, the transcoder writes map entries in wire order
+			Deadlines: map[string]*wkt.Timestamp{"a": {Seconds: 1, Nanos: 20_000_000}},
+		},
+		"duration payload":    {Payload: wkt.PackAny(&wkt.Duration{Seconds: 1, Nanos: 5})},
+		"empty payload":       {Payload: wkt.PackAny(&wkt.Empty{})},
+		"wrapper payload":     {Payload: wkt.PackAny(&wkt.StringValue{Value: "wrapped"})},
+		"empty inner payload": {Payload: wkt.PackAny(&wellknown.Event{})},
+		"empty mask":          {UpdateMask: &wkt.FieldMask{}, Ttl: &wkt.Duration{}},
+	}
+	for name, event := range events {
+		t.Run(name, func(t *testing.T) {
+			data := event.Marshal()
+			msg := eventType.New().Interface()
+			if err := proto.Unmarshal(data, msg); err != nil {
+				t.Fatal(err)
+			}
+			want, err := protojson.MarshalOptions{Resolver: resolver}.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := event.MarshalJSON()
+			if err != nil || string(got) != compact.String() {
+				t.Errorf("JSON differs from protojson, %v\n got: %s\nwant: %v", err, got, compact.String())
+			}
+			reader := wellknown.NewEventReader()
+			if err := reader.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			if got := reader.AppendJSON(nil); string(got) != compact.String() {
+				t.Errorf("reader JSON differs from protojson\n got: %s\nwant: %v", got, compact.String())
+			}
+			var transcoded bytes.Buffer
+			if err := wellknown.TranscodeEventJSON(&transcoded, data); err != nil || transcoded.String() != compact.String() {
+				t.Errorf("transcoded JSON differs from protojson, %v\n got: %v\nwant: %v", err, transcoded.String(), compact.String())
+			}
+
+			decoded := &wellknown.Event{}
+			if err := decoded.UnmarshalJSON(want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(event, decoded); diff != "" {
+				t.Errorf("protojson output decoded into a different message (-want +got):\n%v", diff)
+			}
+		})
+	}
+
+	// "@type" can come after the fields, the proto field names are accepted as well
+	decoded := &wellknown.Event{}
+	err = decoded.UnmarshalJSON([]byte(`{"payload":{"ttl":"3s","id":"inner","@type":"type.googleapis.com/wellknown.Event"},
+		"created_at":"2024-02-29T13:30:00.5+01:00","update_mask":"a.fooBar,b","ttl":"-0.000000001s"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &wellknown.Event{
+		Payload:    wkt.PackAny(inner),
+		CreatedAt:  &wkt.Timestamp{Seconds: 1709209800, Nanos: 500_000_000},
+		UpdateMask: &wkt.FieldMask{Paths: []string{"a.foo_bar", "b"}},
+		Ttl:        &wkt.Duration{Nanos: -1},
+	}
+	if diff := cmp.Diff(want, decoded); diff != "" {
+		t.Errorf("unexpected decoded JSON (-want +got):\n%v", diff)
+	}
+
+	// protojson refuses types missing from the registry, their bytes are kept in "value"
+	unknown := &wellknown.Event{Payload: &wkt.Any{TypeUrl: "type.googleapis.com/unknown.Type", Value: []byte{8, 1}}}
+	wantJSON := `{"payload":{"@type":"type.googleapis.com/unknown.Type","value":"CAE="}}`
+	if got, err := unknown.MarshalJSON(); err != nil || string(got) != wantJSON {
+		t.Errorf("unexpected JSON %s, %v", got, err)
+	}
+	decoded = &wellknown.Event{}
+	if err := decoded.UnmarshalJSON([]byte(wantJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(unknown, decoded); diff != "" {
+		t.Errorf("unexpected decoded JSON (-want +got):\n%v", diff)
+	}
+
+	for _, invalid := range []string{
+		`{"createdAt":"2024-02-29"}`,
+		`{"createdAt":"10000-01-01T00:00:00Z"}`,
+		`{"createdAt":1}`,
+		`{"ttl":"1"}`,
+		`{"ttl":"1.s"}`,
+		`{"ttl":"1.0000000001s"}`,
+		`{"ttl":"+1s"}`,
+		`{"ttl":"315576000001s"}`,
+		`{"updateMask":"foo_bar"}`,
+		`{"payload":{"id":"x"}}`,
+		`{"payload":{"@type":""}}`,
+		`{"payload":{"@type":"type.googleapis.com/unknown.Type","id":"x"}}`,
+		`{"payload":{"@type":"type.googleapis.com/wellknown.Event","unknown":1}}`,
+		`{"payload":{"@type":"type.googleapis.com/google.protobuf.Duration","value":"1s","ttl":"1s"}}`,
+	} {
+		if err := (&wellknown.Event{}).UnmarshalJSON([]byte(invalid)); err == nil {
+			t.Errorf("%v should be rejected", invalid)
+		}
+	}
+}
+
+func TestJSONValueSetters(t *testing.T) {
+	event := &wellknown.Event{}
+	if err := event.SetAttributesMap(map[string]any{"n": int64(1), "tags": []any{"a", nil}}); err != nil || event.Attributes["n"] != int64(1) {
+		t.Errorf("unexpected result %v, %v", event.Attributes, err)
+	}
+	for _, value := range []any{struct{}{}, math.NaN(), "\xff", []any{make(chan int)}} {
+		if err := event.SetExtraInterface(value); err == nil || event.Extra != nil {
+			t.Errorf("%#v should be rejected, got %v", value, event.Extra)
+		}
+		if err := event.SetField(7, value); err == nil || event.Extra != nil {
+			t.Errorf("SetField should reject %#v", value)
+		}
+	}
+	if err := event.SetAttributesMap(map[string]any{"time": time.Now()}); err == nil || len(event.Attributes) != 2 {
+		t.Errorf("unsupported values should leave the field unchanged")
+	}
+	if err := event.SetField(6, map[string]any{"nested": map[string]any{"x": math.Inf(1)}}); err == nil {
+		t.Errorf("SetField should validate nested values")
+	}
+	if err := event.SetExtraInterface(nil); err != nil || event.Extra != nil {
+		t.Errorf("nil should clear the field, got %v", err)
+	}
+}
+
+func TestFieldMasks(t *testing.T) {
+	paths := protobuf_unittest.NestedTestAllTypesPaths
+	if got := paths.Child().Child().Payload.OptionalNestedMessage.Bb; got != "child.child.payload.optional_nested_message.bb" {
+		t.Errorf("unexpected path %q", got)
+	}
+	if got := paths.Payload.OptionalImportMessage.D; got != "payload.optional_import_message.d" {
+		t.Errorf("unexpected path across packages %q", got)
+	}
+	if got := paths.Child().Payload.Path(); got != "child.payload" || protobuf_unittest.NestedTestAllTypesPaths.Path() != "" {
+		t.Errorf("unexpected message path %q", got)
+	}
+
+	valid := gremlin.FieldPaths{paths.Child().Payload.OptionalInt32, paths.RepeatedChild, wellknown.EventPaths.Extra}
+	if err := gremlin.ValidateMask[*protobuf_unittest.NestedTestAllTypes](valid[:2]); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, path := range []string{"payload.missing", "repeated_child.payload", "payload.optional_int32.x"} {
+		if err := gremlin.ValidateMask[*protobuf_unittest.NestedTestAllTypes](gremlin.FieldPaths{path}); err == nil {
+			t.Errorf("path %q should be invalid", path)
+		}
+	}
+	if err := gremlin.ValidateMask[*wellknown.Event](&wkt.FieldMask{Paths: []string{"note.value"}}); err == nil {
+		t.Errorf("paths should not go into wrapper fields")
+	}
+
+	src := &protobuf_unittest.NestedTestAllTypes{
+		Payload: &protobuf_unittest.TestAllTypes{
+			OptionalInt32:         1,
+			OptionalString:        "src",
+			RepeatedInt32:         []int32{3},
+			OptionalNestedMessage: &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 5},
+		},
+	}
+	dst := &protobuf_unittest.NestedTestAllTypes{
+		Payload: &protobuf_unittest.TestAllTypes{
+			OptionalInt32:  2,
+			OptionalString: "dst",
+			RepeatedInt32:  []int32{1, 2},
+			OptionalBool:   true,
+		},
+		Child: &protobuf_unittest.NestedTestAllTypes{Payload: &protobuf_unittest.TestAllTypes{OptionalInt32: 7, OptionalBool: true}},
+	}
+
+	copied := dst.Copy()
+	mask := gremlin.FieldPaths{
+		paths.Payload.OptionalString,
+		paths.Payload.RepeatedInt32,
+		paths.Payload.OptionalNestedMessage.Bb,
+		paths.Child().Payload.OptionalInt32,
+		paths.EagerChild.OptionalInt32,
+	}
+	if err := gremlin.CopyMasked(copied, src, mask); err != nil {
+		t.Fatal(err)
+	}
+	want := &protobuf_unittest.NestedTestAllTypes{
+		Payload: &protobuf_unittest.TestAllTypes{
+			OptionalInt32:         2,
+			OptionalString:        "src",
+			RepeatedInt32:         []int32{3},
+			OptionalBool:          true,
+			OptionalNestedMessage: &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 5},
+		},
+		// unset in src, so the selected field is cleared, the others stay
+		Child: &protobuf_unittest.NestedTestAllTypes{Payload: &protobuf_unittest.TestAllTypes{OptionalBool: true}},
+	}
+	if diff := cmp.Diff(want, copied, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected CopyMasked result (-want +got):\n%v", diff)
+	}
+	src.Payload.RepeatedInt32[0] = 9
+	if copied.Payload.RepeatedInt32[0] != 3 {
+		t.Errorf("CopyMasked should copy values")
+	}
+	src.Payload.RepeatedInt32[0] = 3
+
+	merged := dst.Copy()
+	if err := gremlin.MergeMasked(merged, src, gremlin.FieldPaths{paths.Payload.Path(), paths.Child().Payload.OptionalInt32}); err != nil {
+		t.Fatal(err)
+	}
+	want = &protobuf_unittest.NestedTestAllTypes{
+		Payload: &protobuf_unittest.TestAllTypes{
+			OptionalInt32:         1,
+			OptionalString:        "src",
+			RepeatedInt32:         []int32{1, 2, 3},
+			OptionalBool:          true,
+			OptionalNestedMessage: &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 5},
+		},
+		Child: &protobuf_unittest.NestedTestAllTypes{Payload: &protobuf_unittest.TestAllTypes{OptionalInt32: 7, OptionalBool: true}},
+	}
+	if diff := cmp.Diff(want, merged, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected MergeMasked result (-want +got):\n%v", diff)
+	}
+	if err := gremlin.MergeMasked(merged, src, gremlin.FieldPaths{"payload.unknown"}); err == nil {
+		t.Errorf("MergeMasked should reject unknown paths")
+	}
+
+	data, err := gremlin.MarshalMasked(dst, gremlin.FieldPaths{paths.Payload.OptionalString, paths.Child().Payload.OptionalBool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &protobuf_unittest.NestedTestAllTypes{}
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	want = &protobuf_unittest.NestedTestAllTypes{
+		Payload: &protobuf_unittest.TestAllTypes{OptionalString: "dst"},
+		Child:   &protobuf_unittest.NestedTestAllTypes{Payload: &protobuf_unittest.TestAllTypes{OptionalBool: true}},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected MarshalMasked result (-want +got):\n%v", diff)
+	}
+
+	// a Value holds its value directly: a set 0 is merged, an unset one clears the field when copied
+	event := &wellknown.Event{Extra: "x"}
+	if err := gremlin.MergeMasked(event, &wellknown.Event{Extra: 0.0}, gremlin.FieldPaths{wellknown.EventPaths.Extra}); err != nil || event.Extra != 0.0 {
+		t.Errorf("unexpected merged value %v, %v", event.Extra, err)
+	}
+	if err := gremlin.CopyMasked(event, &wellknown.Event{}, valid[2:]); err != nil || event.Extra != nil {
+		t.Errorf("unexpected copied value %v, %v", event.Extra, err)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	msg := &protobuf_unittest.TestAllExtensions{}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_OptionalInt32Extension, 101); err != nil {
+		t.Fatal(err)
+	}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_OptionalNestedMessageExtension, &protobuf_unittest.TestAllTypes_NestedMessage{Bb: 118}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_RepeatedStringExtension, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_TestNestedExtension_Test, "nested"); err != nil {
+		t.Fatal(err)
+	}
+	// setting a value again replaces it
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_OptionalInt32Extension, 102); err != nil {
+		t.Fatal(err)
+	}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_PackedInt32Extension, []int32{1}); err == nil {
+		t.Errorf("extensions of other messages should be rejected")
+	}
+
+	data := msg.Marshal()
+	var fields []protowire.Number
+	for b := data; len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		fields = append(fields, num)
+		b = b[n+protowire.ConsumeFieldValue(num, typ, b[n:]):]
+	}
+	if diff := cmp.Diff([]protowire.Number{18, 44, 44, 1002, 1}, fields); diff != "" {
+		t.Errorf("unexpected encoded fields (-want +got):\n%v", diff)
+	}
+
+	reader := protobuf_unittest.NewTestAllExtensionsReader()
+	if err := reader.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := gremlin.GetExtension(reader, protobuf_unittest.E_OptionalInt32Extension); err != nil || v != 102 {
+		t.Errorf("unexpected int32 extension %v, %v", v, err)
+	}
+	if v, err := gremlin.GetExtension(reader, protobuf_unittest.E_OptionalNestedMessageExtension); err != nil || v == nil || v.Bb != 118 {
+		t.Errorf("unexpected message extension %v, %v", v, err)
+	}
+	if v, err := gremlin.GetExtension(reader, protobuf_unittest.E_RepeatedStringExtension); err != nil || !cmp.Equal(v, []string{"a", "b"}) {
+		t.Errorf("unexpected repeated extension %v, %v", v, err)
+	}
+	if v, err := gremlin.GetExtension(reader, protobuf_unittest.E_TestNestedExtension_Test); err != nil || v != "nested" {
+		t.Errorf("unexpected nested extension %v, %v", v, err)
+	}
+	if v, err := gremlin.GetExtension(reader, protobuf_unittest.E_DefaultInt32Extension); err != nil || v != 41 {
+		t.Errorf("missing extensions should have their default, got %v, %v", v, err)
+	}
+	if gremlin.HasExtension(reader, protobuf_unittest.E_DefaultInt32Extension) || !gremlin.HasExtension(reader, protobuf_unittest.E_OptionalInt32Extension) {
+		t.Errorf("unexpected HasExtension results")
+	}
+
+	// unknown fields survive decoding into a struct and copying
+	decoded := &protobuf_unittest.TestAllExtensions{}
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*protobuf_unittest.TestAllExtensions{decoded, reader.ToStruct(), decoded.Copy()} {
+		if !bytes.Equal(got.Marshal(), data) {
+			t.Errorf("extensions were lost: %v", got.XXX_Unknown)
+		}
+	}
+
+	if err := gremlin.ClearExtension(decoded, protobuf_unittest.E_RepeatedStringExtension); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := gremlin.GetExtension(decoded, protobuf_unittest.E_RepeatedStringExtension); err != nil || v != nil {
+		t.Errorf("unexpected cleared extension %v, %v", v, err)
+	}
+	if v, err := gremlin.GetExtension(decoded, protobuf_unittest.E_OptionalInt32Extension); err != nil || v != 102 {
+		t.Errorf("clearing should keep other extensions, got %v, %v", v, err)
+	}
+
+	packed := &protobuf_unittest.TestPackedExtensions{}
+	if err := gremlin.SetExtension(packed, protobuf_unittest.E_PackedInt32Extension, []int32{1, 2, 300}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := gremlin.GetExtension(packed, protobuf_unittest.E_PackedInt32Extension); err != nil || !cmp.Equal(v, []int32{1, 2, 300}) {
+		t.Errorf("unexpected packed extension %v, %v", v, err)
+	}
+	if ext := gremlin.FindExtensionByNumber("protobuf_unittest.TestAllExtensions", 1); ext != &protobuf_unittest.E_OptionalInt32Extension.ExtensionDescriptor {
+		t.Errorf("extension not registered, got %v", ext)
+	}
+}
+
+func TestExtensionEncoding(t *testing.T) {
+	msg := &protobuf_unittest.TestAllExtensions{}
+	// zero values that were set are kept
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_OptionalInt32Extension, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !gremlin.HasExtension(msg, protobuf_unittest.E_OptionalInt32Extension) {
+		t.Errorf("zero extension should be present")
+	}
+	if err := gremlin.SetExtension(msg, protobuf_unittest.E_RepeatedStringExtension, nil); err != nil {
+		t.Fatal(err)
+	}
+	if gremlin.HasExtension(msg, protobuf_unittest.E_RepeatedStringExtension) {
+		t.Errorf("empty repeated extension should not be present")
+	}
+
+	var unpacked []byte
+	for _, v := range []uint64{1, 2} {
+		unpacked = protowire.AppendTag(unpacked, 31, protowire.VarintType)
+		unpacked = protowire.AppendVarint(unpacked, v)
+	}
+	packed := protowire.AppendTag(nil, 90, protowire.BytesType)
+	packed = protowire.AppendBytes(packed, []byte{1, 2})
+	for _, tc := range []struct {
+		name    string
+		encoded []byte
+		want    []byte
+	}{
+		// proto2 repeated scalars are unpacked unless declared packed
+		{"proto2 default", protobuf_unittest.E_RepeatedInt32Extension.Encode([]int32{1, 2}), unpacked},
+		{"packed", protobuf_unittest.E_PackedInt32Extension.Encode([]int32{1, 2}), packed},
+		{"packed false", protobuf_unittest.E_UnpackedInt32Extension.Encode([]int32{1, 2}), bytes.ReplaceAll(unpacked, []byte{0xf8, 0x01}, []byte{0xd0, 0x05})},
+	} {
+		if !bytes.Equal(tc.encoded, tc.want) {
+			t.Errorf("%v: got %x, want %x", tc.name, tc.encoded, tc.want)
+		}
+	}
+	// decoders accept both encodings
+	if v, err := protobuf_unittest.E_RepeatedInt32Extension.Decode(bytes.ReplaceAll(packed, []byte{0xd2, 0x05}, []byte{0xfa, 0x01})); err != nil || !cmp.Equal(v, []int32{1, 2}) {
+		t.Errorf("unexpected packed value of an unpacked extension %v, %v", v, err)
+	}
+
+	decodeString := func(data []byte) error {
+		_, err := protobuf_unittest.E_OptionalStringExtension.Decode(data)
+		return err
+	}
+	decodeRepeated := func(data []byte) error {
+		_, err := protobuf_unittest.E_RepeatedInt32Extension.Decode(data)
+		return err
+	}
+	for _, tc := range []struct {
+		data   string
+		decode func([]byte) error
+	}{
+		{"727a616263", decodeString}, // string longer than the data
+		{"7003", decodeString},       // string encoded as varint
+		{"f801", decodeRepeated},     // varint cut after the tag
+		{"fa010180", decodeRepeated}, // packed value cut inside an entry
+	} {
+		content, _ := hex.DecodeString(tc.data)
+		if err := tc.decode(content); err == nil {
+			t.Errorf("%v: expected an error", tc.data)
+		}
+	}
+}
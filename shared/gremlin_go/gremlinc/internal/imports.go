@@ -20,30 +20,45 @@ package internal
 import (
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 
+	"github.com/emicklei/proto"
 	"github.com/logrusorgru/aurora"
 )
 
 func ResolveImportsAndReferences(parsed []*types.ProtoFile) []error {
-	if err := resolveBaseFolders(parsed); err != nil {
-		return []error{err}
+	// files mapped from descriptors, loaded with include paths or bundled come with their base folder
+	var withoutBase []*types.ProtoFile
+	for _, p := range parsed {
+		if p.BaseFolder == "" {
+			withoutBase = append(withoutBase, p)
+		}
+	}
+	if len(withoutBase) > 0 {
+		if err := resolveBaseFolders(withoutBase); err != nil {
+			return []error{err}
+		}
 	}
 
 	parsedMap := map[string]*types.ProtoFile{}
+	wellKnown := map[string]*types.ProtoFile{}
 	for _, p := range parsed {
 		p := p
 		parsedMap[p.Path] = p
+		if p.WellKnown {
+			wellKnown[filepath.ToSlash(p.RelativePath)] = p
+		}
 	}
 
 	var errors []error
 
 	for i := range parsed {
 		file := parsed[i]
-		errors = append(errors, resolveImports(file, parsedMap)...)
+		errors = append(errors, resolveImports(file, parsedMap, wellKnown)...)
 	}
 
 	for i := range parsed {
@@ -81,7 +96,7 @@ func addPublicImports(pFile *types.ProtoFile) {
 	}
 }
 
-func resolveImports(pFile *types.ProtoFile, parsed map[string]*types.ProtoFile) []error {
+func resolveImports(pFile *types.ProtoFile, parsed map[string]*types.ProtoFile, wellKnown map[string]*types.ProtoFile) []error {
 	if len(pFile.Imports) == 0 {
 		return nil
 	}
@@ -90,10 +105,15 @@ func resolveImports(pFile *types.ProtoFile, parsed map[string]*types.ProtoFile)
 
 	for _, imp := range pFile.Imports {
 		path := filepath.Join(pFile.BaseFolder, imp.FSPath)
+		if resolved, found := pFile.ImportPaths[imp.FSPath]; found {
+			path = resolved
+		}
 		imp := imp
 
 		if _, found := parsed[path]; found {
 			imp.TargetFile = parsed[path]
+		} else if wellKnown[imp.FSPath] != nil {
+			imp.TargetFile = wellKnown[imp.FSPath]
 		} else {
 			errors = append(errors, fmt.Errorf("failed to resolve `%v`\nSource: %v\nRoot: %v\nPath: %v",
 				aurora.Red("import "+imp.FSPath),
@@ -106,6 +126,101 @@ func resolveImports(pFile *types.ProtoFile, parsed map[string]*types.ProtoFile)
 	return errors
 }
 
+// LoadImports resolves imports against include paths, like protoc's -I: each import is looked up in the
+// include paths in order and the first match wins. Files are named by the first include path they are in. Imported files which are not among files are parsed and added to the result as dependencies,
+// which are resolved against but not generated.
+func LoadImports(files []*types.ProtoFile, includePaths []string) ([]*types.ProtoFile, []error) {
+	var roots []string
+	for _, includePath := range includePaths {
+		root, err := filepath.Abs(includePath)
+		if err != nil {
+			return nil, []error{err}
+		}
+		roots = append(roots, root)
+	}
+
+	var errors []error
+	loaded := map[string]*types.ProtoFile{}
+	for _, file := range files {
+		root := includeRoot(roots, file.Path)
+		if root == "" {
+			errors = append(errors, fmt.Errorf("%v is not in any include path (-I)", aurora.Red(file.Path)))
+			continue
+		}
+		file.BaseFolder = root
+		file.RelativePath, _ = filepath.Rel(root, file.Path)
+		loaded[file.Path] = file
+	}
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	res := append([]*types.ProtoFile{}, files...)
+	// res grows while imported files are loaded
+	for i := 0; i < len(res); i++ {
+		file := res[i]
+		file.ImportPaths = map[string]string{}
+		for _, element := range file.Parsed.Elements {
+			imp, ok := element.(*proto.Import)
+			if !ok {
+				continue
+			}
+			path, root, err := findImport(roots, imp.Filename)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("%v: %w", file.RelativePath, err))
+				continue
+			}
+			if path == "" {
+				continue
+			}
+			file.ImportPaths[imp.Filename] = path
+			if loaded[path] != nil {
+				continue
+			}
+
+			imported := &types.ProtoFile{
+				Path:         path,
+				RelativePath: filepath.FromSlash(imp.Filename),
+				BaseFolder:   root,
+				Dependency:   true,
+			}
+			if err := ParseProtoFiles([]*types.ProtoFile{imported}); err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			loaded[path] = imported
+			res = append(res, imported)
+		}
+	}
+	return res, errors
+}
+
+func includeRoot(roots []string, path string) string {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return root
+		}
+	}
+	return ""
+}
+
+// findImport returns the file an import names and the first include path it is in,
+// or no file for well-known types which are not in any include path.
+func findImport(roots []string, name string) (string, string, error) {
+	for _, root := range roots {
+		candidate := filepath.Join(root, filepath.FromSlash(name))
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, root, nil
+		}
+	}
+	if IsWellKnown(name) {
+		return "", "", nil
+	}
+	return "", "", fmt.Errorf("failed to resolve `%v`, searched %v",
+		aurora.Red("import "+name), aurora.Cyan(strings.Join(roots, ", ")))
+}
+
 type fsNode struct {
 	Path     string
 	Children []*fsNode
@@ -161,7 +276,7 @@ func resolveBaseFolders(parsed []*types.ProtoFile) error {
 
 	if !hasChildren && !hasFiles {
 		// No files and no subdirectories - empty structure
-		log.Fatalf(`
+		return fmt.Errorf(`
 No proto files or subdirectories found at build path.
 Here is what we see at the very system root of OS we're building on: %v
 `, rootNode.Path)
@@ -169,7 +284,7 @@ Here is what we see at the very system root of OS we're building on: %v
 
 	if hasFiles && hasChildren {
 		// Files at root level AND subdirectories - ambiguous structure
-		log.Fatalf(`
+		return fmt.Errorf(`
 Found both proto files and subdirectories at the same level.
 This creates ambiguous import paths. Please organize proto files either:
   - All in subdirectories (multi-package)
@@ -178,8 +293,6 @@ Path: %v
 `, rootNode.Path)
 	}
 
-	fmt.Printf("Found root for all protobufs: %v, total proto files: %v\n", aurora.Cyan(rootNode.Path), aurora.Cyan(len(parsed)))
-
 	assignBaseFolder(rootNode)
 
 	return nil
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+func writeProtoFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// api has files next to subdirectories and imports from a second root, which no single base folder can describe.
+func writeIncludeRoots(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	api, shared := filepath.Join(dir, "api"), filepath.Join(dir, "shared")
+	writeProtoFiles(t, api, map[string]string{
+		"order.proto": `syntax = "proto3";
+package api;
+import "common/money.proto";
+import "api/v1/item.proto";
+message Order {
+  common.Money total = 1;
+  api.v1.Item item = 2;
+}
+`,
+		"api/v1/item.proto": `syntax = "proto3";
+package api.v1;
+import "common/money.proto";
+message Item {
+  common.Money price = 1;
+}
+`,
+	})
+	writeProtoFiles(t, shared, map[string]string{
+		"common/money.proto": `syntax = "proto3";
+package common;
+message Money {
+  int64 units = 1;
+}
+`,
+	})
+	return api, shared
+}
+
+func loadWithIncludes(t *testing.T, src string, includePaths ...string) ([]*types.ProtoFile, []error) {
+	files, err := FindAllProtobufFiles(src, DefaultIgnorePatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseProtoFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	return LoadImports(files, includePaths)
+}
+
+func TestLoadImports(t *testing.T) {
+	api, shared := writeIncludeRoots(t)
+	files, errs := loadWithIncludes(t, api, api, shared)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	names := map[string]*types.ProtoFile{}
+	for _, file := range files {
+		names[filepath.ToSlash(file.RelativePath)] = file
+	}
+	for _, name := range []string{"order.proto", "api/v1/item.proto", "common/money.proto"} {
+		if names[name] == nil {
+			t.Fatalf("%v was not loaded, got %v", name, names)
+		}
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %v", len(files))
+	}
+	if names["common/money.proto"].BaseFolder != shared {
+		t.Errorf("common/money.proto is in %v", names["common/money.proto"].BaseFolder)
+	}
+	if !names["common/money.proto"].Dependency || names["order.proto"].Dependency || names["api/v1/item.proto"].Dependency {
+		t.Errorf("only imports from outside of the source should be dependencies")
+	}
+
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	for _, msg := range names["order.proto"].Messages {
+		for _, field := range msg.Fields {
+			if field.ExternalMsgType == nil {
+				t.Errorf("%v was not resolved", field.Name)
+			}
+		}
+	}
+
+	out := t.TempDir()
+	if errs := golang.Generate(out, "example.com/gen", files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	var generated []string
+	err := filepath.WalkDir(out, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(out, path)
+			generated = append(generated, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// dependencies are generated on their own
+	if len(generated) != 2 || slices.ContainsFunc(generated, func(name string) bool { return strings.Contains(name, "money") }) {
+		t.Errorf("unexpected generated files %v", generated)
+	}
+}
+
+func TestLoadImportsFirstMatch(t *testing.T) {
+	api, shared := writeIncludeRoots(t)
+	// an earlier include path shadows later ones, like with protoc
+	other := filepath.Join(filepath.Dir(api), "other")
+	writeProtoFiles(t, other, map[string]string{"common/money.proto": `syntax = "proto3";
+package common;
+message Money {
+  int64 units = 1;
+}
+`})
+	for _, roots := range [][]string{{api, other, shared}, {api, shared, other}} {
+		files, errs := loadWithIncludes(t, api, roots...)
+		if len(errs) > 0 {
+			t.Fatal(errs)
+		}
+		var money []string
+		for _, file := range files {
+			if file.Dependency {
+				money = append(money, file.Path)
+			}
+		}
+		if want := filepath.Join(roots[1], "common", "money.proto"); len(money) != 1 || money[0] != want {
+			t.Errorf("-I %v: expected %v, got %v", roots, want, money)
+		}
+	}
+}
+
+func TestLoadImportsErrors(t *testing.T) {
+	api, shared := writeIncludeRoots(t)
+
+	_, errs := loadWithIncludes(t, api, api)
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), "failed to resolve") {
+		t.Errorf("expected missing imports, got %v", errs)
+	}
+
+	_, errs = loadWithIncludes(t, api, shared)
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), "not in any include path") {
+		t.Errorf("expected files outside of include paths, got %v", errs)
+	}
+}
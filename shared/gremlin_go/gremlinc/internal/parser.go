@@ -33,9 +33,6 @@ func extractProtoStruct(file *types.ProtoFile, errors *[]error, lock *sync.Mutex
 func walkHandler(pFile *types.ProtoFile, errors *[]error, lock *sync.Mutex) proto.Handler {
 	return func(v proto.Visitee) {
 		if i, ok := v.(*proto.Import); ok {
-			if i.Filename == "google/protobuf/descriptor.proto" {
-				return
-			}
 			pFile.Imports = append(pFile.Imports, &types.ProtoImport{
 				FSPath:   i.Filename,
 				ProtoDef: i,
@@ -74,6 +71,11 @@ func walkHandler(pFile *types.ProtoFile, errors *[]error, lock *sync.Mutex) prot
 			return
 		}
 
+		if m, ok := v.(*proto.Message); ok && m.IsExtend {
+			pFile.Extensions = append(pFile.Extensions, extractExtensions(pFile, m, errors, lock)...)
+			return
+		}
+
 		if m, ok := v.(*proto.Message); ok {
 			msg := extractMessage(pFile, m, errors, lock)
 			pFile.Messages = append(pFile.Messages, msg)
@@ -149,6 +151,34 @@ func extractMessage(pFile *types.ProtoFile, e *proto.Message, errors *[]error, l
 	return res
 }
 
+// extractExtensions returns the fields of an extend block, named in the scope the block is declared in.
+func extractExtensions(pFile *types.ProtoFile, e *proto.Message, errors *[]error, lock *sync.Mutex) []*types.ExtensionDefinition {
+	var scope types.ScopedName
+	if parent, ok := e.Parent.(*proto.Message); ok {
+		scope = buildScopedName(pFile, parent, parent.Name)
+	} else if pFile.Package != nil {
+		scope = pFile.Package.Name
+	}
+
+	var res []*types.ExtensionDefinition
+	for _, element := range e.Elements {
+		if g, ok := element.(*proto.Group); ok {
+			lock.Lock()
+			*errors = append(*errors, fmt.Errorf("groups are deprecated and not supported by this parser (file: %s, group: %v)", pFile.Path, g.Name))
+			lock.Unlock()
+			continue
+		}
+
+		for _, field := range parseStructElement(scope, element) {
+			res = append(res, &types.ExtensionDefinition{
+				Field:    field,
+				ProtoDef: e,
+			})
+		}
+	}
+	return res
+}
+
 func parseStructElement(name types.ScopedName, element proto.Visitee) []*types.MessageFieldDefinition {
 	var target any = element
 	if v, ok := element.(*proto.OneOfField); ok {
@@ -232,7 +262,7 @@ func extractOneOf(name types.ScopedName, oneof *proto.Oneof) []*types.MessageFie
 func extractDefaultValue(field *proto.Field) *proto.Option {
 	for _, option := range field.Options {
 		if option.Name == "default" {
-			return option
+			return normalizeDefaultValue(field.Type, option)
 		}
 	}
 	return nil
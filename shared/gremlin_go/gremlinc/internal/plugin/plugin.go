@@ -0,0 +1,98 @@
+// Package plugin runs gremlinc as a protoc plugin, the schema comes compiled from protoc or buf instead of .proto sources.
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Params are the options passed with --gremlin_opt, a comma-separated list of key=value pairs.
+type Params struct {
+	Module string // go module path for generated imports, same as the -module flag
+}
+
+func ParseParams(parameter string) (Params, error) {
+	var res Params
+	for _, param := range strings.Split(parameter, ",") {
+		if param == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(param, "=")
+		switch strings.TrimSpace(key) {
+		case "module":
+			res.Module = strings.TrimSpace(value)
+		default:
+			return res, fmt.Errorf("unknown parameter %q", param)
+		}
+	}
+	return res, nil
+}
+
+// Run decodes a serialized CodeGeneratorRequest and returns the serialized response.
+// Schema and generation errors are reported to protoc in the response, not as an error.
+func Run(request []byte) ([]byte, error) {
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(request, req); err != nil {
+		return nil, fmt.Errorf("failed to decode CodeGeneratorRequest: %w", err)
+	}
+	return proto.Marshal(Generate(req))
+}
+
+// Generate generates the files to generate of the request, their dependencies are only used to resolve references.
+func Generate(req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	res := &pluginpb.CodeGeneratorResponse{
+		SupportedFeatures: proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)),
+	}
+	files, errs := generate(req)
+	if len(errs) > 0 {
+		res.Error = proto.String(errors.Join(errs...).Error())
+		return res
+	}
+	res.File = files
+	return res
+}
+
+func generate(req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, []error) {
+	params, err := ParseParams(req.GetParameter())
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	targets, err := internal.ProtoFilesFromDescriptors(req.ProtoFile)
+	if err != nil {
+		return nil, []error{err}
+	}
+	if errs := internal.ParseStruct(targets); len(errs) > 0 {
+		return nil, errs
+	}
+	if errs := internal.ResolveImportsAndReferences(targets); len(errs) > 0 {
+		return nil, errs
+	}
+
+	generated, errs := golang.GenerateFiles("", params.Module, targets)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	toGenerate := map[string]bool{}
+	for _, name := range req.FileToGenerate {
+		toGenerate[name] = true
+	}
+	var res []*pluginpb.CodeGeneratorResponse_File
+	for _, file := range generated {
+		if toGenerate[file.ProtoFile.RelativePath] {
+			res = append(res, &pluginpb.CodeGeneratorResponse_File{
+				Name:    proto.String(file.Path),
+				Content: proto.String(file.Content),
+			})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testdata"
+)
+
+// plugin_request is what protoc sends for the unittest protos of testproto, the response must be testpb.
+func TestPluginMatchesStandalone(t *testing.T) {
+	request, err := testdata.TestData.ReadFile("plugin_request")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Run(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := &pluginpb.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(data, res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Error != nil {
+		t.Fatalf("plugin error: %v", res.GetError())
+	}
+	if len(res.File) != 3 {
+		t.Fatalf("expected 3 files, got %v", len(res.File))
+	}
+	for _, file := range res.File {
+		expected, err := os.ReadFile(filepath.Join("../../testpb", file.GetName()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(expected), file.GetContent()); diff != "" {
+			t.Errorf("%v differs from the standalone output (-want +got):\n%v", file.GetName(), diff)
+		}
+	}
+}
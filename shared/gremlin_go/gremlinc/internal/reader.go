@@ -40,7 +40,7 @@ func ParseProtoFiles(files []*types.ProtoFile) error {
 
 	for i, err := range errors {
 		if err != nil {
-			return fmt.Errorf("failed to parse %v: %v", files[i], err)
+			return fmt.Errorf("failed to parse %v: %v", files[i].Path, err)
 		}
 	}
 
@@ -9,66 +9,65 @@ import (
 	"github.com/emicklei/proto"
 )
 
+// resolveExtensions finds the messages extended by extend blocks and the messages the blocks are declared in.
+// Extension numbers must be in the extension ranges of the extended message.
 func resolveExtensions(pFile *types.ProtoFile) []error {
 	var errors []error
 
-	var removed []*types.MessageDefinition
-	for i := range pFile.Messages {
-		message := pFile.Messages[i]
-		if message.ProtoDef.IsExtend {
-			localSrc := resolveLocalExtendSource(pFile, message)
-			if localSrc != nil {
-				if localSrc.Name.Equal(message.Name) {
-					removed = append(removed, localSrc)
-				}
-				for _, field := range localSrc.Fields {
-					field := field.Copy()
-					field.ExtraScopes = append(field.ExtraScopes, localSrc.Name)
-					message.Fields = append(message.Fields, field)
-				}
-			} else {
-				externalSrc := resolveImportedExtendSource(pFile, message)
-				if externalSrc != nil {
-					for _, field := range externalSrc.Fields {
-						field := field.Copy()
-						field.ExtraScopes = append(field.ExtraScopes, externalSrc.Name)
-						message.Fields = append(message.Fields, field)
-					}
-				} else if !strings.HasPrefix(message.ProtoDef.Name, "google.protobuf.") {
-					errors = append(errors,
-						fmt.Errorf("failed to resolve extend source %v in %v (%v)",
-							message.ProtoDef.Name,
-							message.Name.String(),
-							pFile.RelativePath,
-						))
-				}
-			}
-		}
-	}
-
-	// remove extended messages which are overridden by extensions
-	if len(removed) > 0 {
-		var filteredMessages []*types.MessageDefinition
+	var resolved []*types.ExtensionDefinition
+	for _, ext := range pFile.Extensions {
 		for _, msg := range pFile.Messages {
-			isRemoved := false
-			for _, removedMsg := range removed {
-				if msg == removedMsg {
-					isRemoved = true
-					break
-				}
+			if msg.ProtoDef == ext.ProtoDef.Parent {
+				ext.Scope = msg
 			}
+		}
 
-			if !isRemoved {
-				filteredMessages = append(filteredMessages, msg)
+		name := strings.TrimPrefix(ext.ProtoDef.Name, ".")
+		scope := ext.Field.Name.ToParent()
+		if msg := resolveLocalExtendSource(pFile, name, scope); msg != nil {
+			ext.LocalExtendedType = msg
+		} else if file, msg := resolveImportedExtendSource(pFile, name); msg != nil {
+			ext.ExternalExtendedType = msg
+			ext.ExternalExtendedFile = file
+		} else {
+			if !strings.HasPrefix(name, "google.protobuf.") {
+				errors = append(errors,
+					fmt.Errorf("failed to resolve extend source %v of %v (%v)",
+						ext.ProtoDef.Name,
+						ext.Field.Name.String(),
+						pFile.RelativePath,
+					))
 			}
+			continue
 		}
 
-		pFile.Messages = filteredMessages
+		number := ext.Field.ProtoDef.Sequence
+		if !inRanges(ext.ExtendedType().ExtensionRanges(), number) {
+			errors = append(errors,
+				fmt.Errorf("extension %v uses number %v, which %v does not declare for extensions (%v)",
+					ext.Field.Name.String(),
+					number,
+					ext.ExtendedType().Name.String(),
+					pFile.RelativePath,
+				))
+			continue
+		}
+		resolved = append(resolved, ext)
 	}
+	pFile.Extensions = resolved
 
 	return errors
 }
 
+func inRanges(ranges []types.FieldRange, number int) bool {
+	for _, r := range ranges {
+		if number >= r.From && number <= r.To {
+			return true
+		}
+	}
+	return false
+}
+
 func resolveReferences(pFile *types.ProtoFile) []error {
 	var errors []error
 
@@ -80,7 +79,7 @@ func resolveReferences(pFile *types.ProtoFile) []error {
 				continue
 			}
 
-			if resolveLocalReference(pFile, message, field) {
+			if resolveLocalReference(pFile, message.Name, field) {
 				continue
 			}
 
@@ -97,19 +96,35 @@ func resolveReferences(pFile *types.ProtoFile) []error {
 		}
 	}
 
+	for _, ext := range pFile.Extensions {
+		field := ext.Field
+		if field.ScalarValueType != "" {
+			continue
+		}
+		if resolveLocalReference(pFile, field.Name.ToParent(), field) || resolveImportedReference(pFile, field) {
+			continue
+		}
+		errors = append(errors,
+			fmt.Errorf("failed to resolve reference %v in %v (%v)",
+				field.ProtoDef.Type,
+				field.Name.String(),
+				pFile.RelativePath,
+			))
+	}
+
 	return errors
 }
 
-func resolveLocalExtendSource(pFile *types.ProtoFile, m *types.MessageDefinition) *types.MessageDefinition {
-	scopedName := types.ParseName(m.ProtoDef.Name)
-	searchPath := m.Name
+func resolveLocalExtendSource(pFile *types.ProtoFile, extended string, scope types.ScopedName) *types.MessageDefinition {
+	scopedName := types.ParseName(extended)
+	searchPath := scope
 	search := true
 	for search {
 		name := scopedName.ToScope(searchPath)
 
 		for i := range pFile.Messages {
 			msg := pFile.Messages[i]
-			if msg.Name.Equal(name) && !msg.ProtoDef.IsExtend {
+			if msg.Name.Equal(name) {
 				return msg
 			}
 		}
@@ -120,8 +135,8 @@ func resolveLocalExtendSource(pFile *types.ProtoFile, m *types.MessageDefinition
 	return nil
 }
 
-func resolveImportedExtendSource(file *types.ProtoFile, m *types.MessageDefinition) *types.MessageDefinition {
-	scopedName := types.ParseName(m.ProtoDef.Name)
+func resolveImportedExtendSource(file *types.ProtoFile, extended string) (*types.ProtoFile, *types.MessageDefinition) {
+	scopedName := types.ParseName(extended)
 
 	for _, protoImport := range file.Imports {
 		var searchPath types.ScopedName
@@ -139,43 +154,40 @@ func resolveImportedExtendSource(file *types.ProtoFile, m *types.MessageDefiniti
 
 			for i := range protoImport.TargetFile.Messages {
 				msg := protoImport.TargetFile.Messages[i]
-				if msg.Name.Equal(name) && !msg.ProtoDef.IsExtend {
-					return msg
+				if msg.Name.Equal(name) {
+					return protoImport.TargetFile, msg
 				}
 			}
 			search = searchPath.CanResolveParent()
 			searchPath = searchPath.ToParent()
 		}
 	}
-	return nil
+	return nil, nil
 }
 
-func resolveLocalReference(file *types.ProtoFile, m *types.MessageDefinition, field *types.MessageFieldDefinition) bool {
-	typeName := field.ProtoDef.Type
-	scopesToSearch := []types.ScopedName{types.ParseName(typeName)}
-	scopesToSearch = append(scopesToSearch, field.ExtraScopes...)
+// resolveLocalReference resolves the type of field in the file, looking from scope outwards.
+func resolveLocalReference(file *types.ProtoFile, scope types.ScopedName, field *types.MessageFieldDefinition) bool {
+	scopedName := types.ParseName(field.ProtoDef.Type)
 
-	for _, scopedName := range scopesToSearch {
-		searchPath := m.Name
-		search := true
-		for search {
-			name := scopedName.ToScope(searchPath)
+	searchPath := scope
+	search := true
+	for search {
+		name := scopedName.ToScope(searchPath)
 
-			for _, enum := range file.Enums {
-				if enum.Name.Equal(name) {
-					field.LocalEnumType = enum
-					return true
-				}
+		for _, enum := range file.Enums {
+			if enum.Name.Equal(name) {
+				field.LocalEnumType = enum
+				return true
 			}
-			for _, msg := range file.Messages {
-				if msg.Name.Equal(name) {
-					field.LocalMsgType = msg
-					return true
-				}
+		}
+		for _, msg := range file.Messages {
+			if msg.Name.Equal(name) {
+				field.LocalMsgType = msg
+				return true
 			}
-			search = searchPath.CanResolveParent()
-			searchPath = searchPath.ToParent()
 		}
+		search = searchPath.CanResolveParent()
+		searchPath = searchPath.ToParent()
 	}
 
 	return false
@@ -183,21 +195,21 @@ func resolveLocalReference(file *types.ProtoFile, m *types.MessageDefinition, fi
 
 func resolveImportedReference(file *types.ProtoFile, field *types.MessageFieldDefinition) bool {
 	// imported can be used only by part of package name, or by full package name
-	typeName := field.ProtoDef.Type
-	scopesToSearch := []types.ScopedName{types.ParseName(typeName)}
-	scopesToSearch = append(scopesToSearch, field.ExtraScopes...)
-
-	for _, scopedName := range scopesToSearch {
-		for _, protoImport := range file.Imports {
-			var searchPath types.ScopedName
-			if protoImport.TargetFile == nil {
-				continue
-			}
+	scopedName := types.ParseName(field.ProtoDef.Type)
 
-			if protoImport.TargetFile.Package != nil {
-				searchPath = protoImport.TargetFile.Package.Name
-			}
+	for _, protoImport := range file.Imports {
+		if protoImport.TargetFile == nil {
+			continue
+		}
 
+		var scopesToSearch []types.ScopedName
+		if protoImport.TargetFile.Package != nil {
+			scopesToSearch = append(scopesToSearch, protoImport.TargetFile.Package.Name)
+		} else {
+			scopesToSearch = append(scopesToSearch, types.ScopedName{})
+		}
+
+		for _, searchPath := range scopesToSearch {
 			var search = true
 			for search {
 				name := scopedName.ToScope(searchPath)
@@ -228,12 +240,9 @@ func resolveOptions(file *types.ProtoFile) []error {
 	var extraOptions = map[string]*types.MessageFieldDefinition{}
 	var errors []error
 
-	for _, message := range file.Messages {
-		if message.Name.ProtoName() == "google.protobuf.EnumValueOptions" {
-			for _, field := range message.Fields {
-				field := field
-				extraOptions[field.Name.ProtoName()] = field
-			}
+	for _, ext := range file.Extensions {
+		if ext.ExtendedType().Name.String() == "google.protobuf.EnumValueOptions" {
+			extraOptions[ext.Field.Name.ProtoName()] = ext.Field
 		}
 	}
 
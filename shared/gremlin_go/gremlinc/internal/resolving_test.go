@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveExtensions(t *testing.T) {
+	dir := t.TempDir()
+	writeProtoFiles(t, dir, map[string]string{
+		"base.proto": `syntax = "proto2";
+package base;
+message Config {
+  optional string name = 1;
+  extensions 100 to 199, 1000 to max;
+}
+`,
+		"ext.proto": `syntax = "proto2";
+package ext;
+import "base.proto";
+message Motor {
+  optional int32 rpm = 1;
+  extend base.Config {
+    optional Motor motor = 100;
+  }
+}
+extend base.Config {
+  repeated Motor motors = 1000;
+}
+`,
+	})
+	files, errs := loadWithIncludes(t, dir, dir)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	for _, file := range files {
+		if file.RelativePath == "base.proto" {
+			if fields := file.Messages[0].Fields; len(fields) != 1 {
+				t.Errorf("extensions should not be added to the extended message, got %v fields", len(fields))
+			}
+			continue
+		}
+		if len(file.Messages) != 1 || len(file.Extensions) != 2 {
+			t.Fatalf("expected one message and two extensions, got %v and %v", len(file.Messages), len(file.Extensions))
+		}
+		nested, top := file.Extensions[0], file.Extensions[1]
+		if nested.Field.Name.String() != "ext.Motor.motor" || nested.Scope != file.Messages[0] {
+			t.Errorf("unexpected nested extension %v in %v", nested.Field.Name, nested.Scope)
+		}
+		if top.Field.Name.String() != "ext.motors" || top.Scope != nil {
+			t.Errorf("unexpected extension %v", top.Field.Name)
+		}
+		for _, ext := range file.Extensions {
+			if ext.ExternalExtendedType == nil || ext.ExternalExtendedType.Name.String() != "base.Config" {
+				t.Errorf("the extended message of %v was not resolved", ext.Field.Name)
+			}
+			if ext.Field.LocalMsgType != file.Messages[0] {
+				t.Errorf("the type of %v was not resolved", ext.Field.Name)
+			}
+		}
+	}
+
+	writeProtoFiles(t, dir, map[string]string{
+		"ext.proto": `syntax = "proto2";
+package ext;
+import "base.proto";
+extend base.Config {
+  optional int32 outside = 200;
+}
+`,
+	})
+	files, errs = loadWithIncludes(t, dir, dir)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	errs = ResolveImportsAndReferences(files)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "does not declare for extensions") {
+		t.Errorf("expected a number outside of the extension ranges, got %v", errs)
+	}
+}
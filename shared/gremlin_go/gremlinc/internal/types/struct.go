@@ -67,6 +67,55 @@ type MessageDefinition struct {
 	ProtoDef *proto.Message
 }
 
+// MaxFieldNumber is the largest field number, the end of "extensions 1000 to max".
+const MaxFieldNumber = 536870911
+
+// FieldRange is a range of field numbers, both ends included.
+type FieldRange struct {
+	From int
+	To   int
+}
+
+// ExtensionRanges returns the field numbers declared with extensions.
+func (m *MessageDefinition) ExtensionRanges() []FieldRange {
+	var res []FieldRange
+	for _, element := range m.ProtoDef.Elements {
+		extensions, ok := element.(*proto.Extensions)
+		if !ok {
+			continue
+		}
+		for _, r := range extensions.Ranges {
+			to := r.To
+			if r.Max {
+				to = MaxFieldNumber
+			}
+			res = append(res, FieldRange{From: r.From, To: to})
+		}
+	}
+	return res
+}
+
+// ExtensionDefinition is a field declared in an extend block. It is not a field of the extended
+// message, its values are kept among the unknown fields of the extended message.
+type ExtensionDefinition struct {
+	Field *MessageFieldDefinition // named in the scope of the extend block
+	Scope *MessageDefinition      // message the extend block is declared in, nil at file level
+
+	LocalExtendedType    *MessageDefinition
+	ExternalExtendedType *MessageDefinition
+	ExternalExtendedFile *ProtoFile
+
+	ProtoDef *proto.Message // the extend block
+}
+
+// ExtendedType returns the extended message, nil until it is resolved.
+func (e *ExtensionDefinition) ExtendedType() *MessageDefinition {
+	if e.LocalExtendedType != nil {
+		return e.LocalExtendedType
+	}
+	return e.ExternalExtendedType
+}
+
 type MessageFieldDefinition struct {
 	Name       ScopedName
 	Repeated   bool
@@ -85,34 +134,38 @@ type MessageFieldDefinition struct {
 	LocalMsgType  *MessageDefinition
 
 	ProtoDef *proto.Field
-	// because of extensions - we should look first from out type and after - of parent
-	ExtraScopes []ScopedName
 
 	DefaultValue *proto.Option
 }
 
-func (m *MessageFieldDefinition) Copy() *MessageFieldDefinition {
-	res := &MessageFieldDefinition{
-		Name:             m.Name,
-		Repeated:         m.Repeated,
-		Map:              m.Map,
-		OneOfGroup:       m.OneOfGroup,
-		MapKeyType:       m.MapKeyType,
-		ScalarValueType:  m.ScalarValueType,
-		ExternalTypeFile: m.ExternalTypeFile,
-		ExternalEnumType: m.ExternalEnumType,
-		ExternalMsgType:  m.ExternalMsgType,
-		LocalEnumType:    m.LocalEnumType,
-		LocalMsgType:     m.LocalMsgType,
-		ProtoDef:         m.ProtoDef,
-		DefaultValue:     m.DefaultValue,
-		Required:         m.Required,
+// Packed reports whether a repeated scalar field is written packed: the packed option if set, otherwise
+// the default of the syntax, packed in proto3 only.
+func (m *MessageFieldDefinition) Packed(proto2 bool) bool {
+	for _, option := range m.ProtoDef.Options {
+		if option.Name == "packed" {
+			return option.Constant.Source == "true"
+		}
 	}
+	return !proto2
+}
 
-	if m.ExtraScopes != nil {
-		res.ExtraScopes = make([]ScopedName, len(m.ExtraScopes))
-		copy(res.ExtraScopes, m.ExtraScopes)
+// JSONName follows protoc: the json_name option if set, otherwise the proto name in lowerCamelCase.
+func (m *MessageFieldDefinition) JSONName() string {
+	for _, option := range m.ProtoDef.Options {
+		if option.Name == "json_name" {
+			return option.Constant.Source
+		}
 	}
-
-	return res
+	var res []byte
+	var wasUnderscore bool
+	for _, c := range []byte(m.Name.ProtoName()) {
+		if c != '_' {
+			if wasUnderscore && c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			res = append(res, c)
+		}
+		wasUnderscore = c == '_'
+	}
+	return string(res)
 }
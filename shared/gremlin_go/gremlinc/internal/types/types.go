@@ -17,7 +17,13 @@ type ProtoFile struct {
 	Enums    []*EnumDefinition
 	Messages []*MessageDefinition
 
-	BaseFolder string // used for search for imports
+	Extensions []*ExtensionDefinition // fields of extend blocks, at any level
+
+	BaseFolder  string            // used for search for imports
+	ImportPaths map[string]string // files of imports resolved against include paths, by import name
+
+	WellKnown  bool // bundled with gremlinc, its code lives in the gremlin_go/wkt runtime package
+	Dependency bool // loaded from an include path for an import only, its code is generated separately
 }
 
 type ProtoImport struct {
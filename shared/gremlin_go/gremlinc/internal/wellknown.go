@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+
+	"github.com/emicklei/proto"
+)
+
+// the well-known types of google/protobuf, their code is generated into the gremlin_go/wkt runtime package
+//
+//go:embed wellknown/google/protobuf/*.proto
+var wellKnownFS embed.FS
+
+// WellKnownRoot is the virtual include path of the bundled well-known types.
+var WellKnownRoot = filepath.Join(DescriptorRoot, "gremlinc", "wellknown")
+
+// IsWellKnown reports whether an import names a bundled well-known type file.
+func IsWellKnown(name string) bool {
+	info, err := fs.Stat(wellKnownFS, path.Join("wellknown", name))
+	return err == nil && !info.IsDir()
+}
+
+// WellKnownFiles parses all bundled well-known type files.
+func WellKnownFiles() ([]*types.ProtoFile, error) {
+	names, err := fs.Glob(wellKnownFS, "wellknown/google/protobuf/*.proto")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var res []*types.ProtoFile
+	for _, name := range names {
+		file, err := parseWellKnown(strings.TrimPrefix(name, "wellknown/"))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, file)
+	}
+	return res, nil
+}
+
+func parseWellKnown(name string) (*types.ProtoFile, error) {
+	file, err := wellKnownFS.Open(path.Join("wellknown", name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	parsed, err := proto.NewParser(file).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundled %v: %v", name, err)
+	}
+	return &types.ProtoFile{
+		Path:         filepath.Join(WellKnownRoot, filepath.FromSlash(name)),
+		RelativePath: filepath.FromSlash(name),
+		Parsed:       parsed,
+		BaseFolder:   WellKnownRoot,
+		WellKnown:    true,
+	}, nil
+}
+
+// AddWellKnownTypes adds the bundled files the parsed files import, directly or through other bundled files.
+// Copies of them among files are replaced by the bundled files: their code already lives in the wkt package,
+// and generating it again would register the same message names twice.
+func AddWellKnownTypes(files []*types.ProtoFile) ([]*types.ProtoFile, error) {
+	// copies are recognised by the name imports use, which needs base folders
+	var withoutBase []*types.ProtoFile
+	for _, file := range files {
+		if file.BaseFolder == "" {
+			withoutBase = append(withoutBase, file)
+		}
+	}
+	if len(withoutBase) > 0 {
+		if err := resolveBaseFolders(withoutBase); err != nil {
+			return nil, err
+		}
+	}
+
+	provided := map[string]bool{}
+	var res []*types.ProtoFile
+	for _, file := range files {
+		if !file.WellKnown && isWellKnownCopy(file) {
+			continue
+		}
+		if file.WellKnown {
+			provided[filepath.ToSlash(file.RelativePath)] = true
+		}
+		res = append(res, file)
+	}
+
+	// res grows while bundled files are added
+	for i := 0; i < len(res); i++ {
+		for _, element := range res[i].Parsed.Elements {
+			imp, ok := element.(*proto.Import)
+			if !ok || provided[imp.Filename] || !IsWellKnown(imp.Filename) {
+				continue
+			}
+			file, err := parseWellKnown(imp.Filename)
+			if err != nil {
+				return nil, err
+			}
+			provided[imp.Filename] = true
+			res = append(res, file)
+		}
+	}
+	return res, nil
+}
+
+// isWellKnownCopy reports whether a file is named like a bundled file, relative to its base folder or to -src.
+func isWellKnownCopy(file *types.ProtoFile) bool {
+	if IsWellKnown(filepath.ToSlash(file.RelativePath)) {
+		return true
+	}
+	name, err := filepath.Rel(file.BaseFolder, file.Path)
+	return err == nil && IsWellKnown(filepath.ToSlash(name))
+}
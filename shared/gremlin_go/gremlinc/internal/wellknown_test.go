@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+func TestAddWellKnownTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeProtoFiles(t, dir, map[string]string{
+		"svc/svc.proto": `syntax = "proto3";
+package svc;
+import "google/protobuf/api.proto";
+import "google/protobuf/empty.proto";
+message Listing {
+  google.protobuf.Api api = 1;
+}
+`,
+		// a copy shipped with the sources is replaced by the bundled one
+		"svc/google/protobuf/empty.proto": `syntax = "proto3";
+package google.protobuf;
+message Empty {}
+`,
+		"other/other.proto": `syntax = "proto3";
+package other;
+`,
+	})
+	files, err := FindAllProtobufFiles(dir, DefaultIgnorePatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseProtoFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	files, err = AddWellKnownTypes(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]*types.ProtoFile{}
+	for _, file := range files {
+		names[filepath.ToSlash(file.RelativePath)] = file
+	}
+	// api.proto pulls in source_context and type, type pulls in any
+	for _, name := range []string{"google/protobuf/api.proto", "google/protobuf/source_context.proto", "google/protobuf/type.proto", "google/protobuf/any.proto"} {
+		if names[name] == nil || !names[name].WellKnown {
+			t.Errorf("%v should be added as a well-known file", name)
+		}
+	}
+	if names["google/protobuf/timestamp.proto"] != nil {
+		t.Errorf("files nobody imports should not be added")
+	}
+
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	svc := names["svc/svc.proto"]
+	field := svc.Messages[0].Fields[0]
+	if field.ExternalMsgType == nil || field.ExternalTypeFile != names["google/protobuf/api.proto"] {
+		t.Errorf("google.protobuf.Api should resolve to the bundled file, got %+v", field.ExternalTypeFile)
+	}
+	if names["svc/google/protobuf/empty.proto"] != nil {
+		t.Errorf("the local copy of empty.proto should be dropped")
+	}
+	for _, imp := range svc.Imports {
+		if imp.FSPath == "google/protobuf/empty.proto" && imp.TargetFile != names["google/protobuf/empty.proto"] {
+			t.Errorf("empty.proto should resolve to the bundled file, got %v", imp.TargetFile.Path)
+		}
+	}
+}
+
+func TestAddWellKnownTypesSkipsVendoredCopies(t *testing.T) {
+	dir := t.TempDir()
+	writeProtoFiles(t, dir, map[string]string{
+		"svc/svc.proto": `syntax = "proto3";
+package svc;
+import "google/protobuf/timestamp.proto";
+message Event {
+  google.protobuf.Timestamp at = 1;
+}
+`,
+		// vendored next to the sources, generating it would register google.protobuf.Timestamp twice
+		"google/protobuf/timestamp.proto": `syntax = "proto3";
+package google.protobuf;
+message Timestamp {
+  int64 seconds = 1;
+  int32 nanos = 2;
+}
+`,
+	})
+	files, err := FindAllProtobufFiles(dir, DefaultIgnorePatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseProtoFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	files, err = AddWellKnownTypes(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ParseStruct(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := ResolveImportsAndReferences(files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	for _, file := range files {
+		if strings.HasPrefix(file.Path, dir) && strings.Contains(file.Path, "timestamp") {
+			t.Errorf("the vendored copy %v should be dropped", file.RelativePath)
+		}
+	}
+
+	out := t.TempDir()
+	if errs := golang.Generate(out, "example.com/gen", files); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	var generated []string
+	err = filepath.WalkDir(out, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(out, path)
+			generated = append(generated, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(generated) != 1 || strings.Contains(generated[0], "timestamp") {
+		t.Errorf("unexpected generated files %v", generated)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,20 +13,63 @@ import (
 
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal"
 	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/generators/golang"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
 )
 
 var srcPath = flag.String("src", "", "source path where proto files are located")
 var outPath = flag.String("out", "", "output path for generated files")
 var modulePath = flag.String("module", "", "go module path for generated imports (e.g. github.com/user/repo/generated)")
-var ignorePatterns = flag.String("ignore", "", "comma-separated list of directory names to ignore (defaults: node_modules,vendor,test_data,.git)")
+var descriptorSetIn = flag.String("descriptor_set_in", "", "generate from serialized FileDescriptorSets instead of -src, several paths are separated by "+string(filepath.ListSeparator))
+var descriptorSetOut = flag.String("descriptor_set_out", "", "also write the parsed proto files as a serialized FileDescriptorSet, with imports and source info")
+var includePaths stringList
+var ignorePatterns = flag.String("ignore", "", ignoreUsage)
+
+const (
+	ignoreUsage  = "comma-separated list of directory names to ignore (defaults: node_modules,vendor,test_data,.git)"
+	includeUsage = "include path imports are resolved against, may be repeated; the import root is guessed from -src without it"
+)
+
+func init() {
+	flag.Var(&includePaths, "I", includeUsage)
+}
+
+// stringList is a flag which may be given several times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// subcommands run instead of code generation when named by the first argument.
+var subcommands = map[string]func(args []string) error{
+	"decode": func(args []string) error { return runCodec("decode", args, os.Stdin, os.Stdout) },
+	"encode": func(args []string) error { return runCodec("encode", args, os.Stdin, os.Stdout) },
+	"raw":    runRaw,
+	"size":   runSize,
+}
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v: %v\n", aurora.Red("ERR"), err)
+				os.Exit(-1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
 	t := time.Now()
 
 	var protoDir = *srcPath
-	if protoDir == "" {
+	if protoDir == "" && *descriptorSetIn == "" {
 		log.Fatal("Missing required flag: -src (source path where proto files are located)")
 	}
 
@@ -38,28 +82,29 @@ func main() {
 		panic(err.Error())
 	}
 
-	var ignore []string
-	if *ignorePatterns != "" {
-		ignore = strings.Split(*ignorePatterns, ",")
-		for i := range ignore {
-			ignore[i] = strings.TrimSpace(ignore[i])
+	var targets []*types.ProtoFile
+	if *descriptorSetIn != "" {
+		var err error
+		targets, err = internal.ReadDescriptorSets(filepath.SplitList(*descriptorSetIn))
+		if err != nil {
+			fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
+			os.Exit(-1)
 		}
+		fmt.Printf("Loaded %v proto files from %v\n", aurora.Cyan(len(targets)), aurora.Cyan(*descriptorSetIn))
 	} else {
-		ignore = internal.DefaultIgnorePatterns
-	}
-
-	targets, err := internal.FindAllProtobufFiles(protoDir, ignore)
-	if err != nil {
-		panic(err.Error())
-	}
-
-	if err := internal.ParseProtoFiles(targets); err != nil {
-		panic(err.Error())
+		var errors []error
+		targets, errors = findAndParseProtoFiles(protoDir)
+		if len(errors) > 0 {
+			for _, err := range errors {
+				fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
+			}
+			os.Exit(-1)
+		}
 	}
 
 	errors := internal.ParseStruct(targets)
 	if len(errors) > 0 {
-		for _, err = range errors {
+		for _, err := range errors {
 			fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
 		}
 		os.Exit(-1)
@@ -67,18 +112,26 @@ func main() {
 
 	errors = internal.ResolveImportsAndReferences(targets)
 	if len(errors) > 0 {
-		for _, err = range errors {
+		for _, err := range errors {
 			fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
 		}
 		os.Exit(-1)
 	}
 
 	fmt.Printf("All files parsed and analyzed in %v\n", aurora.Yellow(time.Since(t).Truncate(time.Millisecond)))
+
+	if *descriptorSetOut != "" {
+		if err := internal.WriteDescriptorSet(*descriptorSetOut, targets); err != nil {
+			fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
+			os.Exit(-1)
+		}
+		fmt.Printf("Descriptor set written to %v\n", aurora.Cyan(*descriptorSetOut))
+	}
 	fmt.Printf("Generating golang files...\n")
 
 	errors = golang.Generate(targetDir, *modulePath, targets)
 	if len(errors) > 0 {
-		for _, err = range errors {
+		for _, err := range errors {
 			fmt.Printf("%v: %v\n", aurora.Red("ERR"), err.Error())
 		}
 		os.Exit(-1)
@@ -86,3 +139,38 @@ func main() {
 
 	fmt.Printf("Done in %v\n", aurora.Yellow(time.Since(t).Truncate(time.Millisecond).Truncate(time.Millisecond)))
 }
+
+func findAndParseProtoFiles(protoDir string) ([]*types.ProtoFile, []error) {
+	ignore := splitIgnorePatterns(*ignorePatterns)
+	if ignore == nil {
+		ignore = internal.DefaultIgnorePatterns
+	}
+
+	targets, err := internal.FindAllProtobufFiles(protoDir, ignore)
+	if err != nil {
+		return nil, []error{err}
+	}
+	fmt.Printf("Found %v proto files in %v\n", aurora.Cyan(len(targets)), aurora.Cyan(protoDir))
+
+	if err := internal.ParseProtoFiles(targets); err != nil {
+		return nil, []error{err}
+	}
+
+	if len(includePaths) > 0 {
+		found := len(targets)
+		var errors []error
+		targets, errors = internal.LoadImports(targets, includePaths)
+		if len(errors) > 0 {
+			return nil, errors
+		}
+		if len(targets) > found {
+			fmt.Printf("Loaded %v imported proto files from %v, they are not generated\n", aurora.Cyan(len(targets)-found), aurora.Cyan(includePaths.String()))
+		}
+	}
+
+	targets, err = internal.AddWellKnownTypes(targets)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return targets, nil
+}
@@ -0,0 +1,227 @@
+// Package protoparse parses .proto files at runtime with the gremlinc front end and describes them
+// with gremlin descriptors, so that messages can be read and written without generated code.
+package protoparse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+	"github.com/norma-core/norma-core/shared/gremlin_go/dynamic"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/internal/types"
+)
+
+// DefaultIgnorePatterns are the directory names skipped by ParseDir when ignore is nil.
+var DefaultIgnorePatterns = internal.DefaultIgnorePatterns
+
+// Schema holds the descriptors of a set of parsed files. Its types are not added to the global
+// registry, they may clash with generated code linked into the same binary.
+type Schema struct {
+	Files []string // paths of the parsed files relative to the root, or to their include path
+
+	messages map[string]*gremlin.MessageDescriptor
+	enums    map[string]*gremlin.EnumDescriptor
+}
+
+// ParseDir parses all .proto files below root, imports are resolved relative to root.
+// The New function of every message descriptor returns a *dynamic.Message.
+func ParseDir(root string, ignore []string) (*Schema, error) {
+	return ParseDirWithIncludes(root, ignore, nil)
+}
+
+// ParseDirWithIncludes is ParseDir resolving imports against include paths, like protoc's -I, instead of
+// the root guessed from the files. root must be in one of them, imported files outside of root are added
+// to the schema too.
+func ParseDirWithIncludes(root string, ignore []string, includePaths []string) (*Schema, error) {
+	if ignore == nil {
+		ignore = DefaultIgnorePatterns
+	}
+	files, err := internal.FindAllProtobufFiles(root, ignore)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no proto files found in %v", root)
+	}
+	if err := internal.ParseProtoFiles(files); err != nil {
+		return nil, err
+	}
+	if len(includePaths) > 0 {
+		var errs []error
+		if files, errs = internal.LoadImports(files, includePaths); len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+	}
+	if files, err = internal.AddWellKnownTypes(files); err != nil {
+		return nil, err
+	}
+	if errs := internal.ParseStruct(files); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if errs := internal.ResolveImportsAndReferences(files); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return newSchema(files)
+}
+
+func newSchema(files []*types.ProtoFile) (*Schema, error) {
+	s := &Schema{
+		messages: map[string]*gremlin.MessageDescriptor{},
+		enums:    map[string]*gremlin.EnumDescriptor{},
+	}
+
+	// descriptors are created first so that fields can link types from any file
+	var defs []*types.MessageDefinition
+	var enumDefs []*types.EnumDefinition
+	for _, file := range files {
+		s.Files = append(s.Files, file.RelativePath)
+		for _, e := range file.Enums {
+			desc := &gremlin.EnumDescriptor{FullName: e.Name.String(), File: file.RelativePath}
+			for _, v := range e.Values {
+				desc.Values = append(desc.Values, gremlin.EnumValueDescriptor{Name: v.Name.ProtoName(), Number: int32(v.Value)})
+			}
+			if s.enums[desc.FullName] != nil {
+				return nil, fmt.Errorf("%v: enum %v is defined twice", file.RelativePath, desc.FullName)
+			}
+			s.enums[desc.FullName] = desc
+			enumDefs = append(enumDefs, e)
+		}
+		for _, m := range file.Messages {
+			desc := &gremlin.MessageDescriptor{FullName: m.Name.String(), File: file.RelativePath}
+			for _, r := range m.ExtensionRanges() {
+				desc.ExtensionRanges = append(desc.ExtensionRanges, gremlin.FieldRange{From: gremlin.ProtoWireNumber(r.From), To: gremlin.ProtoWireNumber(r.To)})
+			}
+			desc.New = func() gremlin.ProtoMessage {
+				return dynamic.NewMessage(desc)
+			}
+			if s.messages[desc.FullName] != nil {
+				return nil, fmt.Errorf("%v: message %v is defined twice", file.RelativePath, desc.FullName)
+			}
+			s.messages[desc.FullName] = desc
+			defs = append(defs, m)
+		}
+	}
+
+	for _, m := range defs {
+		desc := s.messages[m.Name.String()]
+		for _, f := range m.Fields {
+			desc.Fields = append(desc.Fields, s.fieldDescriptor(f))
+		}
+		if len(m.Name.LocalPath()) > 0 {
+			if parent := s.messages[m.Name.ToParent().String()]; parent != nil {
+				parent.Messages = append(parent.Messages, desc)
+			}
+		}
+	}
+	for _, e := range enumDefs {
+		if len(e.Name.LocalPath()) > 0 {
+			if parent := s.messages[e.Name.ToParent().String()]; parent != nil {
+				parent.Enums = append(parent.Enums, s.enums[e.Name.String()])
+			}
+		}
+	}
+	return s, nil
+}
+
+var scalarKinds = map[string]gremlin.FieldKind{
+	"string":   gremlin.KindString,
+	"bytes":    gremlin.KindBytes,
+	"bool":     gremlin.KindBool,
+	"double":   gremlin.KindDouble,
+	"float":    gremlin.KindFloat,
+	"int32":    gremlin.KindInt32,
+	"int64":    gremlin.KindInt64,
+	"uint32":   gremlin.KindUint32,
+	"uint64":   gremlin.KindUint64,
+	"sint32":   gremlin.KindSint32,
+	"sint64":   gremlin.KindSint64,
+	"fixed32":  gremlin.KindFixed32,
+	"fixed64":  gremlin.KindFixed64,
+	"sfixed32": gremlin.KindSfixed32,
+	"sfixed64": gremlin.KindSfixed64,
+}
+
+// fieldDescriptor mirrors the descriptors written by the Go generator, map fields are messages
+// with the entry described by MapKey and MapValue.
+func (s *Schema) fieldDescriptor(f *types.MessageFieldDefinition) *gremlin.FieldDescriptor {
+	value := &gremlin.FieldDescriptor{Label: gremlin.LabelOptional}
+	switch {
+	case f.ScalarValueType != "":
+		value.Kind = scalarKinds[f.ScalarValueType]
+	case f.LocalEnumType != nil:
+		value.Kind, value.TypeName = gremlin.KindEnum, f.LocalEnumType.Name.String()
+	case f.ExternalEnumType != nil:
+		value.Kind, value.TypeName = gremlin.KindEnum, f.ExternalEnumType.Name.String()
+	case f.LocalMsgType != nil:
+		value.Kind, value.TypeName = gremlin.KindMessage, f.LocalMsgType.Name.String()
+	default:
+		value.Kind, value.TypeName = gremlin.KindMessage, f.ExternalMsgType.Name.String()
+	}
+	value.MessageType = s.messages[value.TypeName]
+	value.EnumType = s.enums[value.TypeName]
+
+	res := value
+	if f.Map {
+		value.Name, value.JSONName, value.Number = "value", "value", 2
+		res = &gremlin.FieldDescriptor{
+			Kind:     gremlin.KindMessage,
+			MapKey:   &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: scalarKinds[f.MapKeyType], Label: gremlin.LabelOptional},
+			MapValue: value,
+		}
+	}
+	res.Name = f.Name.ProtoName()
+	res.JSONName = f.JSONName()
+	res.Number = gremlin.ProtoWireNumber(f.ProtoDef.Sequence)
+	res.OneOf = f.OneOfGroup
+	switch {
+	case f.Repeated || f.Map:
+		res.Label = gremlin.LabelRepeated
+	case f.Required:
+		res.Label = gremlin.LabelRequired
+	}
+	if f.DefaultValue != nil {
+		res.Default = f.DefaultValue.Constant.Source
+	}
+	return res
+}
+
+// FindMessage returns the message with the given full name, nil if there is none.
+func (s *Schema) FindMessage(fullName string) *gremlin.MessageDescriptor {
+	return s.messages[fullName]
+}
+
+// FindEnum returns the enum with the given full name, nil if there is none.
+func (s *Schema) FindEnum(fullName string) *gremlin.EnumDescriptor {
+	return s.enums[fullName]
+}
+
+// Messages returns all messages, nested ones included, ordered by full name.
+func (s *Schema) Messages() []*gremlin.MessageDescriptor {
+	res := make([]*gremlin.MessageDescriptor, 0, len(s.messages))
+	for _, m := range s.messages {
+		res = append(res, m)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].FullName < res[j].FullName })
+	return res
+}
+
+// Enums returns all enums, nested ones included, ordered by full name.
+func (s *Schema) Enums() []*gremlin.EnumDescriptor {
+	res := make([]*gremlin.EnumDescriptor, 0, len(s.enums))
+	for _, e := range s.enums {
+		res = append(res, e)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].FullName < res[j].FullName })
+	return res
+}
+
+// NewMessage returns an empty dynamic message of the given type, nil if there is none.
+func (s *Schema) NewMessage(fullName string) *dynamic.Message {
+	desc := s.messages[fullName]
+	if desc == nil {
+		return nil
+	}
+	return dynamic.NewMessage(desc)
+}
@@ -0,0 +1,214 @@
+package protoparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+	"github.com/norma-core/norma-core/shared/gremlin_go/dynamic"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testdata"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/map_test"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/protobuf_unittest"
+	_ "github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/test"
+	_ "github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/testpb/wellknown"
+)
+
+func parseTestProto(t *testing.T) *Schema {
+	schema, err := ParseDir("../testproto", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSchemaMatchesGenerated(t *testing.T) {
+	schema := parseTestProto(t)
+	if len(schema.Files) != 12 || len(schema.Messages()) == 0 {
+		t.Fatalf("unexpected schema with files %v", schema.Files)
+	}
+
+	ignore := cmp.Options{
+		cmpopts.IgnoreFields(gremlin.FieldDescriptor{}, "MessageType", "EnumType"),
+		cmpopts.IgnoreFields(gremlin.MessageDescriptor{}, "New", "NewReader"),
+		cmpopts.EquateEmpty(),
+	}
+	for _, desc := range schema.Messages() {
+		generated := gremlin.FindMessage(desc.FullName)
+		if generated == nil {
+			t.Errorf("%v is not generated", desc.FullName)
+			continue
+		}
+		if diff := cmp.Diff(generated, desc, ignore); diff != "" {
+			t.Errorf("%v differs from the generated descriptor (-generated +parsed):\n%v", desc.FullName, diff)
+		}
+	}
+	for _, desc := range schema.Enums() {
+		if diff := cmp.Diff(gremlin.FindEnum(desc.FullName), desc); diff != "" {
+			t.Errorf("%v differs from the generated descriptor (-generated +parsed):\n%v", desc.FullName, diff)
+		}
+	}
+
+	field := schema.FindMessage("protobuf_unittest.TestAllTypes").FieldByName("optional_import_message")
+	if field.MessageType == nil || field.MessageType != schema.FindMessage("protobuf_unittest_import.ImportMessage") {
+		t.Errorf("imported types should be linked, got %+v", field.MessageType)
+	}
+	if _, ok := schema.FindMessage("map_test.TestMap").New().(*dynamic.Message); !ok {
+		t.Errorf("New should return dynamic messages")
+	}
+}
+
+func TestDynamicGoldenMessage(t *testing.T) {
+	schema := parseTestProto(t)
+	content, err := testdata.TestData.ReadFile("golden_message")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := schema.NewMessage("protobuf_unittest.TestAllTypes")
+	if err := m.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.Get("optional_int32"); v != int32(101) {
+		t.Errorf("optional_int32: got %v", v)
+	}
+	if v := m.Get("optional_nested_message").(*dynamic.Message).Get("bb"); v != int32(118) {
+		t.Errorf("optional_nested_message.bb: got %v", v)
+	}
+	if v := m.Get("repeated_string").([]any); len(v) != 2 || v[0] != "215" {
+		t.Errorf("repeated_string: got %v", v)
+	}
+
+	expected := &protobuf_unittest.TestAllTypes{}
+	if err := expected.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Get("optional_foreign_message").(*dynamic.Message).Set("c", int32(7)); err != nil {
+		t.Fatal(err)
+	}
+	expected.OptionalForeignMessage.C = 7
+
+	actual := &protobuf_unittest.TestAllTypes{}
+	if err := actual.Unmarshal(m.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected message after a dynamic round trip (-expected +actual):\n%v", diff)
+	}
+}
+
+func TestDynamicMap(t *testing.T) {
+	schema := parseTestProto(t)
+	src := &map_test.TestMap{
+		Int32ToInt32Field:   map[int32]int32{1: 2, -3: 4},
+		StringToInt32Field:  map[string]int32{"a": 1},
+		Int32ToMessageField: map[int32]*map_test.TestMap_MessageValue{5: {Value: 6}},
+	}
+
+	m := schema.NewMessage("map_test.TestMap")
+	if err := m.Unmarshal(src.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.Get("int32_to_int32_field").(map[any]any); len(v) != 2 || v[int32(-3)] != int32(4) {
+		t.Errorf("int32_to_int32_field: got %v", v)
+	}
+	if v := m.Get("int32_to_message_field").(map[any]any)[int32(5)].(*dynamic.Message).Get("value"); v != int32(6) {
+		t.Errorf("int32_to_message_field: got %v", v)
+	}
+
+	actual := &map_test.TestMap{}
+	if err := actual.Unmarshal(m.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(src, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected message after a dynamic round trip (-expected +actual):\n%v", diff)
+	}
+}
+
+func TestDynamicJSONAndText(t *testing.T) {
+	schema := parseTestProto(t)
+	content, err := testdata.TestData.ReadFile("golden_message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := &protobuf_unittest.TestAllTypes{}
+	if err := generated.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	m := schema.NewMessage("protobuf_unittest.TestAllTypes")
+	if err := m.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedJSON, _ := generated.MarshalJSON()
+	actualJSON, _ := m.MarshalJSON()
+	if string(expectedJSON) != string(actualJSON) {
+		t.Errorf("unexpected JSON\n%s\nexpected\n%s", actualJSON, expectedJSON)
+	}
+	if m.String() != generated.String() {
+		t.Errorf("unexpected text\n%v\nexpected\n%v", m.String(), generated.String())
+	}
+
+	fromJSON := schema.NewMessage("protobuf_unittest.TestAllTypes")
+	if err := fromJSON.UnmarshalJSON(expectedJSON); err != nil {
+		t.Fatal(err)
+	}
+	expectedText, _ := generated.MarshalText()
+	fromText := schema.NewMessage("protobuf_unittest.TestAllTypes")
+	if err := fromText.UnmarshalText(expectedText); err != nil {
+		t.Fatal(err)
+	}
+	for _, parsed := range []*dynamic.Message{fromJSON, fromText} {
+		actual := &protobuf_unittest.TestAllTypes{}
+		if err := actual.Unmarshal(parsed.Marshal()); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(generated, actual); diff != "" {
+			t.Errorf("unexpected message after parsing (-expected +actual):\n%v", diff)
+		}
+	}
+}
+
+func TestParseDirWithIncludes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"api/order.proto": `syntax = "proto3";
+package api;
+import "common/money.proto";
+message Order {
+  common.Money total = 1;
+}
+`,
+		"shared/common/money.proto": `syntax = "proto3";
+package common;
+message Money {
+  int64 units = 1;
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	api := filepath.Join(dir, "api")
+	if _, err := ParseDir(api, nil); err == nil {
+		t.Errorf("imports outside of the root should fail without include paths")
+	}
+	schema, err := ParseDirWithIncludes(api, nil, []string{api, filepath.Join(dir, "shared")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := schema.FindMessage("api.Order")
+	if order == nil || order.Fields[0].MessageType != schema.FindMessage("common.Money") {
+		t.Errorf("imported message was not linked: %v", order)
+	}
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+// runRaw implements the raw subcommand: it prints the wire structure of a message with offsets,
+// lengths and a guess of what every value is. With -type fields are labelled from the schema.
+func runRaw(args []string) error {
+	fs := flag.NewFlagSet("raw", flag.ContinueOnError)
+	schemaFlags := addSchemaFlags(fs, "source path where proto files are located, used with -type")
+	typeName := fs.String("type", "", "full name of the message type used to label fields (optional)")
+	hexDump := fs.Bool("hex", false, "print the bytes of every field next to it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var desc *gremlin.MessageDescriptor
+	if *typeName != "" {
+		schema, err := schemaFlags.parse()
+		if err != nil {
+			return err
+		}
+		if desc = schema.FindMessage(*typeName); desc == nil {
+			return fmt.Errorf("message type %v not found in %v", *typeName, *schemaFlags.src)
+		}
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(os.Stdout)
+	d := &rawDumper{out: out, buf: gremlin.NewReader(data), data: data, hex: *hexDump}
+	err = d.dumpMessage(0, len(data), 0, desc)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+type rawDumper struct {
+	out  *bufio.Writer
+	buf  *gremlin.Reader
+	data []byte
+	hex  bool
+}
+
+var wireTypeNames = map[gremlin.ProtoWireType]string{
+	gremlin.VarIntType:     "varint",
+	gremlin.Fixed64Type:    "fixed64",
+	gremlin.BytesType:      "bytes",
+	gremlin.StartGroupType: "group",
+	gremlin.EndGroupType:   "end group",
+	gremlin.Fixed32Type:    "fixed32",
+}
+
+// line prints one field, header are the bytes shown in the hex column.
+func (d *rawDumper) line(offset int, header []byte, depth int, text string) {
+	fmt.Fprintf(d.out, "%06x  ", offset)
+	if d.hex {
+		first := header
+		if len(first) > 16 {
+			first = first[:16]
+		}
+		fmt.Fprintf(d.out, "%-48s ", hexBytes(first))
+	}
+	fmt.Fprintf(d.out, "%v%v\n", strings.Repeat("  ", depth), text)
+	if d.hex {
+		for i := 16; i < len(header); i += 16 {
+			fmt.Fprintf(d.out, "%06x  %v\n", offset+i, hexBytes(header[i:min(i+16, len(header))]))
+		}
+	}
+}
+
+func hexBytes(b []byte) string {
+	return fmt.Sprintf("% x", b)
+}
+
+// dumpMessage prints the fields between start and end, desc may be nil.
+func (d *rawDumper) dumpMessage(start, end int, depth int, desc *gremlin.MessageDescriptor) error {
+	for offset := start; offset < end; {
+		tag, wire, tagSize, err := d.buf.ReadTagAt(offset)
+		if err == nil && offset+tagSize > end {
+			err = fmt.Errorf("truncated tag")
+		}
+		if err != nil {
+			d.line(offset, d.data[offset:end], depth, fmt.Sprintf("error: %v", err))
+			return fmt.Errorf("offset %v: %w", offset, err)
+		}
+
+		var fd *gremlin.FieldDescriptor
+		label := strconv.Itoa(int(tag))
+		if desc != nil {
+			if fd = desc.FieldByNumber(tag); fd != nil {
+				label += " " + fd.Name
+			} else {
+				label += " (unknown)"
+			}
+		}
+		label += " " + wireTypeNames[wire]
+
+		if wire == gremlin.StartGroupType {
+			d.line(offset, d.data[offset:offset+tagSize], depth, label)
+			next, err := d.dumpGroup(offset+tagSize, end, depth+1)
+			if err != nil {
+				return err
+			}
+			offset = next
+			continue
+		}
+		next, err := d.buf.SkipData(offset+tagSize, wire)
+		if err == nil && (next > end || next <= offset+tagSize) {
+			err = fmt.Errorf("field %v overflows its message", tag)
+		}
+		if err != nil {
+			d.line(offset, d.data[offset:end], depth, fmt.Sprintf("%v: error: %v", label, err))
+			return fmt.Errorf("offset %v: %w", offset, err)
+		}
+
+		valueStart := offset + tagSize
+		switch wire {
+		case gremlin.VarIntType:
+			v, _ := d.buf.SizedReadVarInt(valueStart)
+			d.line(offset, d.data[offset:next], depth, fmt.Sprintf("%v: %v", label, describeVarint(v, fd)))
+		case gremlin.Fixed32Type:
+			v, _ := d.buf.SizedReadFixed32(valueStart)
+			d.line(offset, d.data[offset:next], depth, fmt.Sprintf("%v: %v", label, describeFixed32(v, fd)))
+		case gremlin.Fixed64Type:
+			v, _ := d.buf.SizedReadFixed64(valueStart)
+			d.line(offset, d.data[offset:next], depth, fmt.Sprintf("%v: %v", label, describeFixed64(v, fd)))
+		case gremlin.BytesType:
+			if err := d.dumpBytes(offset, valueStart, next, depth, label, fd); err != nil {
+				return err
+			}
+		default:
+			d.line(offset, d.data[offset:next], depth, label)
+		}
+		offset = next
+	}
+	return nil
+}
+
+// dumpGroup prints the fields of a deprecated group up to its end tag and returns the offset after it.
+func (d *rawDumper) dumpGroup(start, end int, depth int) (int, error) {
+	for offset := start; offset < end; {
+		tag, wire, tagSize, err := d.buf.ReadTagAt(offset)
+		if err == nil && offset+tagSize > end {
+			err = fmt.Errorf("truncated tag")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("offset %v: %w", offset, err)
+		}
+		switch wire {
+		case gremlin.EndGroupType:
+			d.line(offset, d.data[offset:offset+tagSize], depth-1, "end group")
+			return offset + tagSize, nil
+		case gremlin.StartGroupType:
+			d.line(offset, d.data[offset:offset+tagSize], depth, fmt.Sprintf("%v %v", tag, wireTypeNames[wire]))
+			next, err := d.dumpGroup(offset+tagSize, end, depth+1)
+			if err != nil {
+				return 0, err
+			}
+			offset = next
+			continue
+		}
+		next, err := d.buf.SkipData(offset+tagSize, wire)
+		if err != nil || next > end || next <= offset+tagSize {
+			return 0, fmt.Errorf("offset %v: malformed group field", offset)
+		}
+		if err := d.dumpMessage(offset, next, depth, nil); err != nil {
+			return 0, err
+		}
+		offset = next
+	}
+	return 0, fmt.Errorf("offset %v: unterminated group", start)
+}
+
+func (d *rawDumper) dumpBytes(offset, valueStart, next int, depth int, label string, fd *gremlin.FieldDescriptor) error {
+	value := d.buf.ReadMessage(valueStart)
+	contentStart := next - len(value)
+	header := d.data[offset:contentStart]
+	label += fmt.Sprintf(" len=%v", len(value))
+
+	// content which doesn't parse as the message of the schema is guessed like for unknown fields
+	if fd != nil && fd.Kind == gremlin.KindMessage && !d.isMessage(contentStart, next, true) {
+		label += " (not a valid " + fd.TypeName + ")"
+		fd = nil
+	}
+	if fd != nil {
+		switch {
+		case fd.IsMap():
+			entry := &gremlin.MessageDescriptor{FullName: fd.TypeName, Fields: []*gremlin.FieldDescriptor{fd.MapKey, fd.MapValue}}
+			d.line(offset, header, depth, label+": map entry")
+			return d.dumpMessage(contentStart, next, depth+1, entry)
+		case fd.Kind == gremlin.KindMessage:
+			d.line(offset, header, depth, label+": message")
+			return d.dumpMessage(contentStart, next, depth+1, fd.Message())
+		case fd.Kind == gremlin.KindString:
+			d.line(offset, d.data[offset:next], depth, label+": string "+strconv.Quote(string(value)))
+			return nil
+		case fd.Kind == gremlin.KindBytes:
+			d.line(offset, d.data[offset:next], depth, label+": bytes "+shortHex(value))
+			return nil
+		case fd.Label == gremlin.LabelRepeated:
+			d.line(offset, d.data[offset:next], depth, label+": packed "+d.describePacked(contentStart, next, fd))
+			return nil
+		}
+	}
+
+	switch {
+	case len(value) > 0 && isPrintable(value):
+		d.line(offset, d.data[offset:next], depth, label+": string "+strconv.Quote(string(value)))
+	case len(value) > 0 && d.isMessage(contentStart, next, false):
+		d.line(offset, header, depth, label+": message")
+		return d.dumpMessage(contentStart, next, depth+1, nil)
+	case len(value) > 0 && d.isPackedVarints(contentStart, next):
+		d.line(offset, d.data[offset:next], depth, label+": packed varints "+d.describePacked(contentStart, next, nil))
+	default:
+		d.line(offset, d.data[offset:next], depth, label+": bytes "+shortHex(value))
+	}
+	return nil
+}
+
+// isMessage reports whether the bytes parse as fields that end exactly at end,
+// groups are only accepted with groups set, they are not guessed.
+func (d *rawDumper) isMessage(start, end int, groups bool) bool {
+	for offset := start; offset < end; {
+		tag, wire, tagSize, err := d.buf.ReadTagAt(offset)
+		if err != nil || tag == 0 || offset+tagSize > end || wire == gremlin.EndGroupType || (wire == gremlin.StartGroupType && !groups) {
+			return false
+		}
+		next, err := d.buf.SkipData(offset+tagSize, wire)
+		if err != nil || next > end || next <= offset+tagSize {
+			return false
+		}
+		offset = next
+	}
+	return true
+}
+
+func (d *rawDumper) isPackedVarints(start, end int) bool {
+	for offset := start; offset < end; {
+		next, err := d.buf.SkipData(offset, gremlin.VarIntType)
+		if err != nil || next > end {
+			return false
+		}
+		offset = next
+	}
+	return true
+}
+
+// describePacked lists packed values, as varints when fd is nil.
+func (d *rawDumper) describePacked(start, end int, fd *gremlin.FieldDescriptor) string {
+	wire := gremlin.VarIntType
+	if fd != nil {
+		switch fd.Kind {
+		case gremlin.KindFixed32, gremlin.KindSfixed32, gremlin.KindFloat:
+			wire = gremlin.Fixed32Type
+		case gremlin.KindFixed64, gremlin.KindSfixed64, gremlin.KindDouble:
+			wire = gremlin.Fixed64Type
+		}
+	}
+	var values []string
+	for offset := start; offset < end; {
+		next, err := d.buf.SkipData(offset, wire)
+		if err != nil || next > end {
+			values = append(values, "error: truncated value")
+			break
+		}
+		switch wire {
+		case gremlin.Fixed32Type:
+			v, _ := d.buf.SizedReadFixed32(offset)
+			values = append(values, describeFixed32(v, fd))
+		case gremlin.Fixed64Type:
+			v, _ := d.buf.SizedReadFixed64(offset)
+			values = append(values, describeFixed64(v, fd))
+		default:
+			v, _ := d.buf.SizedReadVarInt(offset)
+			if fd == nil {
+				values = append(values, strconv.FormatUint(v, 10))
+			} else {
+				values = append(values, describeVarint(v, fd))
+			}
+		}
+		offset = next
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func describeVarint(v uint64, fd *gremlin.FieldDescriptor) string {
+	if fd != nil {
+		switch fd.Kind {
+		case gremlin.KindInt32:
+			return strconv.Itoa(int(int32(v)))
+		case gremlin.KindInt64:
+			return strconv.FormatInt(int64(v), 10)
+		case gremlin.KindSint32, gremlin.KindSint64:
+			return strconv.FormatInt(int64(v>>1)^-int64(v&1), 10)
+		case gremlin.KindBool:
+			return strconv.FormatBool(v != 0)
+		case gremlin.KindEnum:
+			if enum := fd.Enum(); enum != nil {
+				if ev, ok := enum.ValueByNumber(int32(v)); ok {
+					return ev.Name
+				}
+			}
+			return strconv.Itoa(int(int32(v)))
+		case gremlin.KindUint32, gremlin.KindUint64:
+			return strconv.FormatUint(v, 10)
+		}
+	}
+	res := strconv.FormatUint(v, 10)
+	if int64(v) < 0 {
+		res += fmt.Sprintf(" (int64 %v)", int64(v))
+	}
+	if v&1 == 1 {
+		res += fmt.Sprintf(" (zigzag %v)", int64(v>>1)^-int64(v&1))
+	}
+	return res
+}
+
+func describeFixed32(v uint32, fd *gremlin.FieldDescriptor) string {
+	if fd != nil {
+		switch fd.Kind {
+		case gremlin.KindFloat:
+			return strconv.FormatFloat(float64(math.Float32frombits(v)), 'g', -1, 32)
+		case gremlin.KindSfixed32:
+			return strconv.Itoa(int(int32(v)))
+		case gremlin.KindFixed32:
+			return strconv.FormatUint(uint64(v), 10)
+		}
+	}
+	return fmt.Sprintf("0x%08x (uint %v, int %v, float %v)", v, v, int32(v),
+		strconv.FormatFloat(float64(math.Float32frombits(v)), 'g', -1, 32))
+}
+
+func describeFixed64(v uint64, fd *gremlin.FieldDescriptor) string {
+	if fd != nil {
+		switch fd.Kind {
+		case gremlin.KindDouble:
+			return strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64)
+		case gremlin.KindSfixed64:
+			return strconv.FormatInt(int64(v), 10)
+		case gremlin.KindFixed64:
+			return strconv.FormatUint(v, 10)
+		}
+	}
+	return fmt.Sprintf("0x%016x (uint %v, int %v, double %v)", v, v, int64(v),
+		strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64))
+}
+
+// isPrintable reports whether b looks like text: valid UTF-8 without control characters other than whitespace.
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// shortHex prints up to 32 bytes of b in hex.
+func shortHex(b []byte) string {
+	if len(b) > 32 {
+		return hex.EncodeToString(b[:32]) + "..."
+	}
+	return hex.EncodeToString(b)
+}
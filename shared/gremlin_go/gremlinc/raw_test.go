@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/protoparse"
+)
+
+func dumpRaw(t *testing.T, data []byte, desc *gremlin.MessageDescriptor, hex bool) (string, error) {
+	var sb strings.Builder
+	out := bufio.NewWriter(&sb)
+	d := &rawDumper{out: out, buf: gremlin.NewReader(data), data: data, hex: hex}
+	err := d.dumpMessage(0, len(data), 0, desc)
+	if flushErr := out.Flush(); flushErr != nil {
+		t.Fatal(flushErr)
+	}
+	return sb.String(), err
+}
+
+func TestRawDump(t *testing.T) {
+	w := gremlin.NewWriter(64)
+	w.AppendInt32(1, -5)
+	w.AppendString(14, "hi")
+	w.AppendBytes(18, []byte{8, 3})
+	w.AppendBytes(32, []byte{1, 2})
+	w.AppendFixed32(7, 0x3fc00000)
+	data := w.Bytes()
+
+	out, err := dumpRaw(t, data, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `000000  1 varint: 18446744073709551611 (int64 -5) (zigzag -9223372036854775806)
+00000b  14 bytes len=2: string "hi"
+00000f  18 bytes len=2: message
+000012    1 varint: 3 (zigzag -2)
+000014  32 bytes len=2: packed varints [1, 2]
+000019  7 fixed32: 0x3fc00000 (uint 1069547520, int 1069547520, float 1.5)
+`
+	if out != expected {
+		t.Errorf("unexpected dump\n%v\nexpected\n%v", out, expected)
+	}
+
+	schema, err := protoparse.ParseDir("testproto", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = dumpRaw(t, data, schema.FindMessage("protobuf_unittest.TestAllTypes"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `000000  08 fb ff ff ff ff ff ff ff ff 01                 1 optional_int32 varint: -5
+00000b  72 02 68 69                                      14 optional_string bytes len=2: string "hi"
+00000f  92 01 02                                         18 optional_nested_message bytes len=2: message
+000012  08 03                                              1 bb varint: 3
+000014  82 02 02 01 02                                   32 repeated_int64 bytes len=2: packed [1, 2]
+000019  3d 00 00 c0 3f                                   7 optional_fixed32 fixed32: 1069547520
+`
+	if out != expected {
+		t.Errorf("unexpected labelled dump\n%v\nexpected\n%v", out, expected)
+	}
+
+	if _, err := dumpRaw(t, data[:len(data)-1], nil, false); err == nil {
+		t.Errorf("truncated data should fail")
+	}
+}
+
+func TestRawDumpGroups(t *testing.T) {
+	// 1 group { 2 group { 3: 5 } 4: 1 } 5: 2
+	data := []byte{0x0b, 0x13, 0x18, 0x05, 0x14, 0x20, 0x01, 0x0c, 0x28, 0x02}
+	out, err := dumpRaw(t, data, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `000000  1 group
+000001    2 group
+000002      3 varint: 5 (zigzag -3)
+000004    end group
+000005    4 varint: 1 (zigzag -1)
+000007  end group
+000008  5 varint: 2
+`
+	if out != expected {
+		t.Errorf("unexpected dump\n%v\nexpected\n%v", out, expected)
+	}
+
+	if _, err := dumpRaw(t, data[:5], nil, false); err == nil {
+		t.Errorf("unterminated groups should fail")
+	}
+}
+
+func TestRawDumpInvalidMessage(t *testing.T) {
+	w := gremlin.NewWriter(64)
+	w.AppendBytes(18, []byte{0xff})
+	w.AppendBytes(18, []byte("not a message"))
+	w.AppendInt32(1, 2)
+
+	schema, err := protoparse.ParseDir("testproto", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := dumpRaw(t, w.Bytes(), schema.FindMessage("protobuf_unittest.TestAllTypes"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `000000  18 optional_nested_message bytes len=1 (not a valid protobuf_unittest.TestAllTypes.NestedMessage): bytes ff
+000004  18 optional_nested_message bytes len=13 (not a valid protobuf_unittest.TestAllTypes.NestedMessage): string "not a message"
+000014  1 optional_int32 varint: 2
+`
+	if out != expected {
+		t.Errorf("unexpected dump\n%v\nexpected\n%v", out, expected)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go/gremlinc/protoparse"
+)
+
+// schemaFlags are the flags subcommands locate the schema with.
+type schemaFlags struct {
+	src      *string
+	ignore   *string
+	includes stringList
+}
+
+// addSchemaFlags registers -src, -ignore and -I on fs, srcUsage describes -src.
+func addSchemaFlags(fs *flag.FlagSet, srcUsage string) *schemaFlags {
+	f := &schemaFlags{
+		src:    fs.String("src", ".", srcUsage),
+		ignore: fs.String("ignore", "", ignoreUsage),
+	}
+	fs.Var(&f.includes, "I", includeUsage)
+	return f
+}
+
+// parse parses the schema the flags point at.
+func (f *schemaFlags) parse() (*protoparse.Schema, error) {
+	return protoparse.ParseDirWithIncludes(*f.src, splitIgnorePatterns(*f.ignore), f.includes)
+}
+
+// splitIgnorePatterns parses the -ignore flag, nil selects the default patterns.
+func splitIgnorePatterns(flagValue string) []string {
+	if flagValue == "" {
+		return nil
+	}
+	patterns := strings.Split(flagValue, ",")
+	for i := range patterns {
+		patterns[i] = strings.TrimSpace(patterns[i])
+	}
+	return patterns
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+// runSize implements the size subcommand: it reports the bytes taken by every field path
+// of one message, or of a stream of varint length-delimited messages with -delimited.
+func runSize(args []string) error {
+	fs := flag.NewFlagSet("size", flag.ContinueOnError)
+	schemaFlags := addSchemaFlags(fs, "source path where proto files are located")
+	typeName := fs.String("type", "", "full name of the message type (e.g. pkg.Msg)")
+	delimited := fs.Bool("delimited", false, "read a stream of messages, each prefixed with its varint length")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *typeName == "" {
+		return fmt.Errorf("missing required flag: -type (full name of the message type)")
+	}
+
+	schema, err := schemaFlags.parse()
+	if err != nil {
+		return err
+	}
+	desc := schema.FindMessage(*typeName)
+	if desc == nil {
+		return fmt.Errorf("message type %v not found in %v", *typeName, *schemaFlags.src)
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	sizes := gremlin.NewSizeBreakdown(desc)
+	if !*delimited {
+		if err := sizes.Add(data); err != nil {
+			return err
+		}
+	}
+	for offset := 0; *delimited && offset < len(data); {
+		size, n := binary.Uvarint(data[offset:])
+		if n <= 0 || size > uint64(len(data)-offset-n) {
+			return fmt.Errorf("offset %v: invalid message length", offset)
+		}
+		offset += n
+		if err := sizes.Add(data[offset : offset+int(size)]); err != nil {
+			return fmt.Errorf("message %v: %w", sizes.Messages+1, err)
+		}
+		offset += int(size)
+	}
+	return writeSizeReport(os.Stdout, sizes)
+}
+
+func writeSizeReport(out io.Writer, sizes *gremlin.SizeBreakdown) error {
+	fmt.Fprintf(out, "%v messages, %v bytes", sizes.Messages, sizes.Bytes)
+	if sizes.Messages > 0 {
+		fmt.Fprintf(out, ", %.1f bytes per message", float64(sizes.Bytes)/float64(sizes.Messages))
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "bytes\tshare\tcount\tvalues\ttags\tlengths\tpacked\tunpacked\t\tpath")
+	for _, f := range sizes.Fields() {
+		share := 0.0
+		if sizes.Bytes > 0 {
+			share = 100 * float64(f.Bytes) / float64(sizes.Bytes)
+		}
+		packed, unpacked := "", ""
+		if f.PackedBytes > 0 {
+			packed, unpacked = fmt.Sprint(f.PackedBytes), fmt.Sprint(f.UnpackedBytes)
+		}
+		fmt.Fprintf(w, "%v\t%.1f%%\t%v\t%v\t%v\t%v\t%v\t%v\t\t%v\n",
+			f.Bytes, share, f.Count, f.Values, f.TagBytes, f.LengthBytes, packed, unpacked, f.Path)
+	}
+	return w.Flush()
+}
@@ -3,7 +3,11 @@
 
 package map_test
 
-import gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+import (
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+	io "io"
+	fmt "fmt"
+)
 
 type TestMap_EnumValue int32
 
@@ -29,6 +33,28 @@ func (e TestMap_EnumValue) String() string {
 	}
 }
 
+var TestMap_EnumValue_value = map[string]int32{
+	"FOO": 0,
+	"BAR": 1,
+	"BAZ": 2,
+	"QUX": 3,
+}
+
+var descriptorTestMap_EnumValue = &gremlin.EnumDescriptor{
+	FullName: "map_test.TestMap.EnumValue",
+	File:     "map_test.proto",
+	Values: []gremlin.EnumValueDescriptor{
+		{Name: "FOO", Number: 0},
+		{Name: "BAR", Number: 1},
+		{Name: "BAZ", Number: 2},
+		{Name: "QUX", Number: 3},
+	},
+}
+
+func init() {
+	gremlin.RegisterEnum(descriptorTestMap_EnumValue)
+}
+
 type ReservedAsMapFieldWithEnumValue_SampleEnum int32
 
 const (
@@ -47,6 +73,24 @@ func (e ReservedAsMapFieldWithEnumValue_SampleEnum) String() string {
 	}
 }
 
+var ReservedAsMapFieldWithEnumValue_SampleEnum_value = map[string]int32{
+	"A": 0,
+	"B": 1,
+}
+
+var descriptorReservedAsMapFieldWithEnumValue_SampleEnum = &gremlin.EnumDescriptor{
+	FullName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum",
+	File:     "map_test.proto",
+	Values: []gremlin.EnumValueDescriptor{
+		{Name: "A", Number: 0},
+		{Name: "B", Number: 1},
+	},
+}
+
+func init() {
+	gremlin.RegisterEnum(descriptorReservedAsMapFieldWithEnumValue_SampleEnum)
+}
+
 const (
 	wireTestMap_Int32ToInt32Field gremlin.ProtoWireNumber = 1
 	wireTestMap_Int32ToStringField gremlin.ProtoWireNumber = 2
@@ -58,8 +102,79 @@ const (
 	wireTestMap_Int64ToInt32Field gremlin.ProtoWireNumber = 8
 )
 
+const (
+	FieldTestMap_Int32ToInt32Field gremlin.FieldIndex = 0
+	FieldTestMap_Int32ToStringField gremlin.FieldIndex = 1
+	FieldTestMap_Int32ToBytesField gremlin.FieldIndex = 2
+	FieldTestMap_Int32ToEnumField gremlin.FieldIndex = 3
+	FieldTestMap_Int32ToMessageField gremlin.FieldIndex = 4
+	FieldTestMap_StringToInt32Field gremlin.FieldIndex = 5
+	FieldTestMap_Uint32ToInt32Field gremlin.FieldIndex = 6
+	FieldTestMap_Int64ToInt32Field gremlin.FieldIndex = 7
+)
+
+var descriptorTestMap = &gremlin.MessageDescriptor{
+	FullName: "map_test.TestMap",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "int32_to_int32_field", JSONName: "int32ToInt32Field", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_string_field", JSONName: "int32ToStringField", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_bytes_field", JSONName: "int32ToBytesField", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_enum_field", JSONName: "int32ToEnumField", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.TestMap.EnumValue"},
+		},
+		{Name: "int32_to_message_field", JSONName: "int32ToMessageField", Number: 5, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "map_test.TestMap.MessageValue"},
+		},
+		{Name: "string_to_int32_field", JSONName: "stringToInt32Field", Number: 6, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+		{Name: "uint32_to_int32_field", JSONName: "uint32ToInt32Field", Number: 7, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+		{Name: "int64_to_int32_field", JSONName: "int64ToInt32Field", Number: 8, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt64, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+	},
+	Messages: []*gremlin.MessageDescriptor{descriptorTestMap_MessageValue},
+	Enums: []*gremlin.EnumDescriptor{descriptorTestMap_EnumValue},
+	New: func() gremlin.ProtoMessage {
+		return &TestMap{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewTestMapReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorTestMap)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *TestMapReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestMap
+}
+
 type TestMapReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataInt32ToInt32Field     map[int32]int32
 	dataInt32ToStringField     map[int32]string
@@ -70,23 +185,6 @@ type TestMapReader struct {
 	dataUint32ToInt32Field     map[uint32]int32
 	dataInt64ToInt32Field     map[int64]int32
 
-	offsetInt32ToInt32Field   []int
-	offsetInt32ToStringField   []int
-	offsetInt32ToBytesField   []int
-	offsetInt32ToEnumField   []int
-	offsetInt32ToMessageField   []int
-	offsetStringToInt32Field   []int
-	offsetUint32ToInt32Field   []int
-	offsetInt64ToInt32Field   []int
-
-	parsedInt32ToInt32Field   bool
-	parsedInt32ToStringField   bool
-	parsedInt32ToBytesField   bool
-	parsedInt32ToEnumField   bool
-	parsedInt32ToMessageField   bool
-	parsedStringToInt32Field   bool
-	parsedUint32ToInt32Field   bool
-	parsedInt64ToInt32Field   bool
 }
 
 func NewTestMapReader() *TestMapReader {
@@ -101,53 +199,59 @@ func (m *TestMapReader) GetInt32ToInt32Field() map[int32]int32 {
 }
 
 func (m *TestMapReader) readInt32ToInt32Field() map[int32]int32 {
-	if m.parsedInt32ToInt32Field {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataInt32ToInt32Field
 	}
-	wOffset := m.offsetInt32ToInt32Field
+	wField := FieldTestMap_Int32ToInt32Field
 	
-	var entry = map[int32]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int32]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int32
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToInt32Field = entry
-	m.parsedInt32ToInt32Field = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -159,53 +263,59 @@ func (m *TestMapReader) GetInt32ToStringField() map[int32]string {
 }
 
 func (m *TestMapReader) readInt32ToStringField() map[int32]string {
-	if m.parsedInt32ToStringField {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataInt32ToStringField
 	}
-	wOffset := m.offsetInt32ToStringField
+	wField := FieldTestMap_Int32ToStringField
 	
-	var entry = map[int32]string{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int32]string
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, string](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData string
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry string
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int32
+			var valueData string
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry string
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToStringField = entry
-	m.parsedInt32ToStringField = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -217,53 +327,59 @@ func (m *TestMapReader) GetInt32ToBytesField() map[int32][]byte {
 }
 
 func (m *TestMapReader) readInt32ToBytesField() map[int32][]byte {
-	if m.parsedInt32ToBytesField {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataInt32ToBytesField
 	}
-	wOffset := m.offsetInt32ToBytesField
+	wField := FieldTestMap_Int32ToBytesField
 	
-	var entry = map[int32][]byte{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int32][]byte
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, []byte](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData []byte
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry []byte
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int32
+			var valueData []byte
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry []byte
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToBytesField = entry
-	m.parsedInt32ToBytesField = true
+	m.parsed[0] |= 1 << 2
 	return entry
 }
 
@@ -275,55 +391,61 @@ func (m *TestMapReader) GetInt32ToEnumField() map[int32]TestMap_EnumValue {
 }
 
 func (m *TestMapReader) readInt32ToEnumField() map[int32]TestMap_EnumValue {
-	if m.parsedInt32ToEnumField {
+	if m.parsed[0]&(1 << 3) != 0 {
 		return m.dataInt32ToEnumField
 	}
-	wOffset := m.offsetInt32ToEnumField
+	wField := FieldTestMap_Int32ToEnumField
 	
-	var entry = map[int32]TestMap_EnumValue{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int32]TestMap_EnumValue
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, TestMap_EnumValue](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData TestMap_EnumValue
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry TestMap_EnumValue
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = TestMap_EnumValue(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int32
+			var valueData TestMap_EnumValue
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry TestMap_EnumValue
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = TestMap_EnumValue(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToEnumField = entry
-	m.parsedInt32ToEnumField = true
+	m.parsed[0] |= 1 << 3
 	return entry
 }
 
@@ -335,58 +457,64 @@ func (m *TestMapReader) GetInt32ToMessageField() map[int32]*TestMap_MessageValue
 }
 
 func (m *TestMapReader) readInt32ToMessageField() map[int32]*TestMap_MessageValueReader {
-	if m.parsedInt32ToMessageField {
+	if m.parsed[0]&(1 << 4) != 0 {
 		return m.dataInt32ToMessageField
 	}
-	wOffset := m.offsetInt32ToMessageField
+	wField := FieldTestMap_Int32ToMessageField
 	
-	var entry = map[int32]*TestMap_MessageValueReader{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int32]*TestMap_MessageValueReader
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, *TestMap_MessageValueReader](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData *TestMap_MessageValueReader
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry *TestMap_MessageValueReader
-				var valueEntrySize int
-				if wOffset > 0 {
-					var valueEntryData, valueEntryDataSize = m.buf.SizedReadBytes(wOffset)
-					if len(valueEntryData) > 0 {
-						valueEntry = NewTestMap_MessageValueReader()
-						valueEntry.Unmarshal(valueEntryData)
-					}
-					valueEntrySize = valueEntryDataSize
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int32
+			var valueData *TestMap_MessageValueReader
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry *TestMap_MessageValueReader
+					var valueEntrySize int
+					if wOffset > 0 {
+						var valueEntryData, valueEntryDataSize = m.buf.SizedReadMessage(wOffset)
+						if len(valueEntryData) > 0 {
+							valueEntry = gremlin.New[TestMap_MessageValueReader](m.buf.Arena())
+							valueEntry.UnmarshalWithOptions(valueEntryData, m.buf.Options())
+						}
+						valueEntrySize = valueEntryDataSize
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToMessageField = entry
-	m.parsedInt32ToMessageField = true
+	m.parsed[0] |= 1 << 4
 	return entry
 }
 
@@ -398,53 +526,59 @@ func (m *TestMapReader) GetStringToInt32Field() map[string]int32 {
 }
 
 func (m *TestMapReader) readStringToInt32Field() map[string]int32 {
-	if m.parsedStringToInt32Field {
+	if m.parsed[0]&(1 << 5) != 0 {
 		return m.dataStringToInt32Field
 	}
-	wOffset := m.offsetStringToInt32Field
+	wField := FieldTestMap_StringToInt32Field
 	
-	var entry = map[string]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataStringToInt32Field = entry
-	m.parsedStringToInt32Field = true
+	m.parsed[0] |= 1 << 5
 	return entry
 }
 
@@ -456,53 +590,59 @@ func (m *TestMapReader) GetUint32ToInt32Field() map[uint32]int32 {
 }
 
 func (m *TestMapReader) readUint32ToInt32Field() map[uint32]int32 {
-	if m.parsedUint32ToInt32Field {
+	if m.parsed[0]&(1 << 6) != 0 {
 		return m.dataUint32ToInt32Field
 	}
-	wOffset := m.offsetUint32ToInt32Field
+	wField := FieldTestMap_Uint32ToInt32Field
 	
-	var entry = map[uint32]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[uint32]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[uint32, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData uint32
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry uint32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData uint32
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry uint32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataUint32ToInt32Field = entry
-	m.parsedUint32ToInt32Field = true
+	m.parsed[0] |= 1 << 6
 	return entry
 }
 
@@ -514,58 +654,122 @@ func (m *TestMapReader) GetInt64ToInt32Field() map[int64]int32 {
 }
 
 func (m *TestMapReader) readInt64ToInt32Field() map[int64]int32 {
-	if m.parsedInt64ToInt32Field {
+	if m.parsed[0]&(1 << 7) != 0 {
 		return m.dataInt64ToInt32Field
 	}
-	wOffset := m.offsetInt64ToInt32Field
+	wField := FieldTestMap_Int64ToInt32Field
 	
-	var entry = map[int64]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[int64]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int64, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int64
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int64
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt64(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData int64
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int64
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt64(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt64ToInt32Field = entry
-	m.parsedInt64ToInt32Field = true
+	m.parsed[0] |= 1 << 7
 	return entry
 }
 
 func (m *TestMapReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *TestMapReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestMap_Int32ToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToInt32Field, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Int32ToStringField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToStringField, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Int32ToBytesField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToBytesField, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Int32ToEnumField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToEnumField, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Int32ToMessageField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToMessageField, Offset: int32(offset), Wire: wire})
+		case wireTestMap_StringToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_StringToInt32Field, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Uint32ToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Uint32ToInt32Field, Offset: int32(offset), Wire: wire})
+		case wireTestMap_Int64ToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int64ToInt32Field, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *TestMapReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *TestMapReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = TestMapReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -576,21 +780,37 @@ func (m *TestMapReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireTestMap_Int32ToInt32Field:
-			m.offsetInt32ToInt32Field = append(m.offsetInt32ToInt32Field, offset)
+			if fields.Has(FieldTestMap_Int32ToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToInt32Field, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Int32ToStringField:
-			m.offsetInt32ToStringField = append(m.offsetInt32ToStringField, offset)
+			if fields.Has(FieldTestMap_Int32ToStringField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToStringField, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Int32ToBytesField:
-			m.offsetInt32ToBytesField = append(m.offsetInt32ToBytesField, offset)
+			if fields.Has(FieldTestMap_Int32ToBytesField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToBytesField, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Int32ToEnumField:
-			m.offsetInt32ToEnumField = append(m.offsetInt32ToEnumField, offset)
+			if fields.Has(FieldTestMap_Int32ToEnumField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToEnumField, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Int32ToMessageField:
-			m.offsetInt32ToMessageField = append(m.offsetInt32ToMessageField, offset)
+			if fields.Has(FieldTestMap_Int32ToMessageField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int32ToMessageField, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_StringToInt32Field:
-			m.offsetStringToInt32Field = append(m.offsetStringToInt32Field, offset)
+			if fields.Has(FieldTestMap_StringToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_StringToInt32Field, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Uint32ToInt32Field:
-			m.offsetUint32ToInt32Field = append(m.offsetUint32ToInt32Field, offset)
+			if fields.Has(FieldTestMap_Uint32ToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Uint32ToInt32Field, Offset: int32(offset), Wire: wire})
+			}
 		case wireTestMap_Int64ToInt32Field:
-			m.offsetInt64ToInt32Field = append(m.offsetInt64ToInt32Field, offset)
+			if fields.Has(FieldTestMap_Int64ToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldTestMap_Int64ToInt32Field, Offset: int32(offset), Wire: wire})
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -605,7 +825,7 @@ func (m *TestMapReader) ToStruct() *TestMap {
 	if m == nil {
 		return nil
 	}
-	res := &TestMap{}
+	res := gremlin.New[TestMap](m.buf.Arena())
 	res.Int32ToInt32Field = m.GetInt32ToInt32Field()
 	res.Int32ToStringField = m.GetInt32ToStringField()
 	res.Int32ToBytesField = m.GetInt32ToBytesField()
@@ -615,7 +835,7 @@ func (m *TestMapReader) ToStruct() *TestMap {
 		var data = m.GetInt32ToMessageField()
 		var structData map[int32]*TestMap_MessageValue
 		if len(data) > 0 {
-			structData = make(map[int32]*TestMap_MessageValue, len(data))
+			structData = gremlin.MakeMap[int32, *TestMap_MessageValue](m.buf.Arena(), len(data))
 			for k,v := range data {
 				if v != nil {
 					structData[k] = v.ToStruct()
@@ -631,151 +851,1425 @@ func (m *TestMapReader) ToStruct() *TestMap {
 	return res
 }
 
-func (s *TestMapReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
-}
-
-type TestMap struct {
-	Int32ToInt32Field	map[int32]int32	`json:"int32_to_int32_field,omitempty"`
-	Int32ToStringField	map[int32]string	`json:"int32_to_string_field,omitempty"`
-	Int32ToBytesField	map[int32][]byte	`json:"int32_to_bytes_field,omitempty"`
-	Int32ToEnumField	map[int32]TestMap_EnumValue	`json:"int32_to_enum_field,omitempty"`
-	Int32ToMessageField	map[int32]*TestMap_MessageValue	`json:"int32_to_message_field,omitempty"`
-	StringToInt32Field	map[string]int32	`json:"string_to_int32_field,omitempty"`
-	Uint32ToInt32Field	map[uint32]int32	`json:"uint32_to_int32_field,omitempty"`
-	Int64ToInt32Field	map[int64]int32	`json:"int64_to_int32_field,omitempty"`
-}
-
-func (s *TestMap) Marshal() []byte {
-	if s == nil {
-		return nil
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *TestMapReader) Freeze() {
+	if m == nil {
+		return
 	}
-	size := s.XXX_PbContentSize()
-	if size == 0 {
-		return nil
+	m.readInt32ToInt32Field()
+	m.readInt32ToStringField()
+	m.readInt32ToBytesField()
+	m.readInt32ToEnumField()
+	for _, v := range m.readInt32ToMessageField() {
+		v.Freeze()
 	}
-	res := gremlin.NewWriter(size)
-	s.MarshalTo(res)
-	return res.Bytes()
+	m.readStringToInt32Field()
+	m.readUint32ToInt32Field()
+	m.readInt64ToInt32Field()
 }
 
-func (s *TestMap) MarshalTo(res *gremlin.Writer) {
-	if s == nil {
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *TestMapReader) Detach() {
+	if m == nil {
 		return
 	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
 
-	if len(s.Int32ToInt32Field) > 0 {
-		for k, v := range s.Int32ToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int32ToInt32Field, mapEntrySize)
-			res.AppendInt32(1, k)
-			res.AppendInt32(2, v)
-		}
-	}
-	if len(s.Int32ToStringField) > 0 {
-		for k, v := range s.Int32ToStringField {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
-			valueSize = gremlin.SizeString(v)
-			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int32ToStringField, mapEntrySize)
-			res.AppendInt32(1, k)
-			res.AppendString(2, v)
-		}
-	}
-	if len(s.Int32ToBytesField) > 0 {
-		for k, v := range s.Int32ToBytesField {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
-			valueSize = gremlin.SizeBytes(v)
-			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int32ToBytesField, mapEntrySize)
-			res.AppendInt32(1, k)
-			res.AppendBytes(2, v)
-		}
-	}
-	if len(s.Int32ToEnumField) > 0 {
-		for k, v := range s.Int32ToEnumField {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int32ToEnumField, mapEntrySize)
-			res.AppendInt32(1, k)
-			res.AppendInt32(2, int32(v))
-		}
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *TestMapReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
 	}
-	if len(s.Int32ToMessageField) > 0 {
-		for k, v := range s.Int32ToMessageField {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
-			valueSize = v.XXX_PbContentSize()
-			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
-			
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int32ToMessageField, mapEntrySize)
-			res.AppendInt32(1, k)
-			structSize := v.XXX_PbContentSize()
-			res.AppendBytesTag(2, structSize)
-			v.MarshalTo(res)
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 1) != 0 {
+		for k, v := range m.dataInt32ToStringField {
+			v = d.RebaseString(v)
+			m.dataInt32ToStringField[k] = v
 		}
 	}
-	if len(s.StringToInt32Field) > 0 {
-		for k, v := range s.StringToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_StringToInt32Field, mapEntrySize)
-			res.AppendString(1, k)
-			res.AppendInt32(2, v)
+	if m.parsed[0]&(1 << 2) != 0 {
+		for k, v := range m.dataInt32ToBytesField {
+			v = d.RebaseBytes(v)
+			m.dataInt32ToBytesField[k] = v
 		}
 	}
-	if len(s.Uint32ToInt32Field) > 0 {
-		for k, v := range s.Uint32ToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeUint32(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Uint32ToInt32Field, mapEntrySize)
-			res.AppendUint32(1, k)
-			res.AppendInt32(2, v)
+	if m.parsed[0]&(1 << 4) != 0 {
+		for k, v := range m.dataInt32ToMessageField {
+			v.XXX_Rebase(d)
+			m.dataInt32ToMessageField[k] = v
 		}
 	}
-	if len(s.Int64ToInt32Field) > 0 {
-		for k, v := range s.Int64ToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt64(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireTestMap_Int64ToInt32Field, mapEntrySize)
-			res.AppendInt64(1, k)
-			res.AppendInt32(2, v)
+	if m.parsed[0]&(1 << 5) != 0 {
+		if m.dataStringToInt32Field != nil {
+			rebased := make(map[string]int32, len(m.dataStringToInt32Field))
+			for k, v := range m.dataStringToInt32Field {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataStringToInt32Field = rebased
 		}
 	}
 }
 
-func (s *TestMap) Copy() *TestMap {
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *TestMapReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
 	}
-	res := &TestMap{}
-	res.Int32ToInt32Field = make(map[int32]int32, len(s.Int32ToInt32Field))
-	for k, v := range s.Int32ToInt32Field {
-		res.Int32ToInt32Field[k] = v
-	}
-	res.Int32ToStringField = make(map[int32]string, len(s.Int32ToStringField))
-	for k, v := range s.Int32ToStringField {
-		res.Int32ToStringField[k] = v
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *TestMapReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetInt32ToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToStringField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToStringField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONString(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToBytesField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToBytesField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToEnumField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToEnumField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToMessageField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToMessageField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = value[k].AppendJSON(b)
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetStringToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"stringToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetUint32ToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"uint32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyUint(b, uint64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt64ToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int64ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *TestMapReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeTestMapJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeTestMapJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeTestMapJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeTestMapJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeTestMapJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestMap_Int32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Int32ToStringField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Int32ToBytesField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Int32ToEnumField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Int32ToMessageField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_StringToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Uint32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireTestMap_Int64ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestMap_Int32ToInt32Field:
+			if written[0]&(1 << 0) != 0 {
+				break
+			}
+			written[0] |= 1 << 0
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Int32ToStringField:
+			if written[0]&(1 << 1) != 0 {
+				break
+			}
+			written[0] |= 1 << 1
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToStringField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONString(s.B, buf.ReadString(valueOffset))
+				} else {
+					var mapValue string
+					s.B = gremlin.AppendJSONString(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Int32ToBytesField:
+			if written[0]&(1 << 2) != 0 {
+				break
+			}
+			written[0] |= 1 << 2
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToBytesField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(valueOffset))
+				} else {
+					var mapValue []byte
+					s.B = gremlin.AppendJSONBytes(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Int32ToEnumField:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToEnumField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, TestMap_EnumValue(enumValue).String())
+				} else {
+					var mapValue TestMap_EnumValue
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Int32ToMessageField:
+			if written[0]&(1 << 4) != 0 {
+				break
+			}
+			written[0] |= 1 << 4
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToMessageField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					if err := XXX_TranscodeTestMap_MessageValueJSON(s, buf.ReadMessage(valueOffset)); err != nil {
+						return err
+					}
+				} else {
+					var mapValue *TestMap_MessageValue
+					s.B = mapValue.AppendJSON(s.B)
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_StringToInt32Field:
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"stringToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Uint32ToInt32Field:
+			if written[0]&(1 << 6) != 0 {
+				break
+			}
+			written[0] |= 1 << 6
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"uint32ToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey uint32
+				if keyOffset > 0 {
+					mapKey = buf.ReadUint32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyUint(s.B, uint64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireTestMap_Int64ToInt32Field:
+			if written[0]&(1 << 7) != 0 {
+				break
+			}
+			written[0] |= 1 << 7
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int64ToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int64
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt64(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *TestMapReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetInt32ToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToStringField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_string_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteString(value[k])
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToBytesField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_bytes_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteBytes(value[k])
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToEnumField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_enum_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToMessageField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_message_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.StartMessage()
+			value[k].XXX_WriteText(w)
+			w.EndMessage()
+			w.EndMessage()
+		}
+	}
+	if value := m.GetStringToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("string_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetUint32ToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("uint32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteUint(uint64(k))
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt64ToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int64_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(k)
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *TestMapReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *TestMapReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *TestMapReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
+type TestMap struct {
+	Int32ToInt32Field	map[int32]int32	`json:"int32_to_int32_field,omitempty"`
+	Int32ToStringField	map[int32]string	`json:"int32_to_string_field,omitempty"`
+	Int32ToBytesField	map[int32][]byte	`json:"int32_to_bytes_field,omitempty"`
+	Int32ToEnumField	map[int32]TestMap_EnumValue	`json:"int32_to_enum_field,omitempty"`
+	Int32ToMessageField	map[int32]*TestMap_MessageValue	`json:"int32_to_message_field,omitempty"`
+	StringToInt32Field	map[string]int32	`json:"string_to_int32_field,omitempty"`
+	Uint32ToInt32Field	map[uint32]int32	`json:"uint32_to_int32_field,omitempty"`
+	Int64ToInt32Field	map[int64]int32	`json:"int64_to_int32_field,omitempty"`
+}
+
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *TestMap) Unmarshal(data []byte) error {
+	*s = TestMap{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestMap_Int32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToInt32Field == nil {
+					s.Int32ToInt32Field = make(map[int32]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToInt32Field[mapKey] = mapValue
+			}
+		case wireTestMap_Int32ToStringField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToStringField == nil {
+					s.Int32ToStringField = make(map[int32]string, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue string
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadString(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToStringField[mapKey] = mapValue
+			}
+		case wireTestMap_Int32ToBytesField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToBytesField == nil {
+					s.Int32ToBytesField = make(map[int32][]byte, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue []byte
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadBytes(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToBytesField[mapKey] = mapValue
+			}
+		case wireTestMap_Int32ToEnumField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToEnumField == nil {
+					s.Int32ToEnumField = make(map[int32]TestMap_EnumValue, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue TestMap_EnumValue
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = TestMap_EnumValue(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToEnumField[mapKey] = mapValue
+			}
+		case wireTestMap_Int32ToMessageField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToMessageField == nil {
+					s.Int32ToMessageField = make(map[int32]*TestMap_MessageValue, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue *TestMap_MessageValue
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						if entryData := buf.ReadMessage(entryOffset); len(entryData) > 0 {
+							var entry = &TestMap_MessageValue{}
+							if err := entry.Unmarshal(entryData); err != nil {
+								return err
+							}
+							mapValue = entry
+						} else {
+							mapValue = nil
+						}
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToMessageField[mapKey] = mapValue
+			}
+		case wireTestMap_StringToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.StringToInt32Field == nil {
+					s.StringToInt32Field = make(map[string]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.StringToInt32Field[mapKey] = mapValue
+			}
+		case wireTestMap_Uint32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Uint32ToInt32Field == nil {
+					s.Uint32ToInt32Field = make(map[uint32]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey uint32
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadUint32(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Uint32ToInt32Field[mapKey] = mapValue
+			}
+		case wireTestMap_Int64ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int64ToInt32Field == nil {
+					s.Int64ToInt32Field = make(map[int64]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int64
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt64(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int64ToInt32Field[mapKey] = mapValue
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
+func (s *TestMap) Marshal() []byte {
+	if s == nil {
+		return nil
+	}
+	size := s.XXX_PbContentSize()
+	if size == 0 {
+		return nil
+	}
+	res := gremlin.NewWriter(size)
+	s.MarshalTo(res)
+	return res.Bytes()
+}
+
+func (s *TestMap) MarshalTo(res *gremlin.Writer) {
+	if s == nil {
+		return
+	}
+
+	if len(s.Int32ToInt32Field) > 0 {
+		for k, v := range s.Int32ToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int32ToInt32Field, mapEntrySize)
+			res.AppendInt32(1, k)
+			res.AppendInt32(2, v)
+		}
+	}
+	if len(s.Int32ToStringField) > 0 {
+		for k, v := range s.Int32ToStringField {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
+			valueSize = gremlin.SizeString(v)
+			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int32ToStringField, mapEntrySize)
+			res.AppendInt32(1, k)
+			res.AppendString(2, v)
+		}
+	}
+	if len(s.Int32ToBytesField) > 0 {
+		for k, v := range s.Int32ToBytesField {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
+			valueSize = gremlin.SizeBytes(v)
+			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int32ToBytesField, mapEntrySize)
+			res.AppendInt32(1, k)
+			res.AppendBytes(2, v)
+		}
+	}
+	if len(s.Int32ToEnumField) > 0 {
+		for k, v := range s.Int32ToEnumField {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int32ToEnumField, mapEntrySize)
+			res.AppendInt32(1, k)
+			res.AppendInt32(2, int32(v))
+		}
+	}
+	if len(s.Int32ToMessageField) > 0 {
+		for k, v := range s.Int32ToMessageField {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt32(k)
+			valueSize = v.XXX_PbContentSize()
+			valueSize += gremlin.SizeUint64(uint64(valueSize)) + gremlin.SizeTag(2)
+			
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int32ToMessageField, mapEntrySize)
+			res.AppendInt32(1, k)
+			structSize := v.XXX_PbContentSize()
+			res.AppendBytesTag(2, structSize)
+			v.MarshalTo(res)
+		}
+	}
+	if len(s.StringToInt32Field) > 0 {
+		for k, v := range s.StringToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_StringToInt32Field, mapEntrySize)
+			res.AppendString(1, k)
+			res.AppendInt32(2, v)
+		}
+	}
+	if len(s.Uint32ToInt32Field) > 0 {
+		for k, v := range s.Uint32ToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeUint32(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Uint32ToInt32Field, mapEntrySize)
+			res.AppendUint32(1, k)
+			res.AppendInt32(2, v)
+		}
+	}
+	if len(s.Int64ToInt32Field) > 0 {
+		for k, v := range s.Int64ToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt64(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			mapEntrySize := keySize + valueSize
+			res.AppendBytesTag(wireTestMap_Int64ToInt32Field, mapEntrySize)
+			res.AppendInt64(1, k)
+			res.AppendInt32(2, v)
+		}
+	}
+}
+
+func (s *TestMap) Copy() *TestMap {
+	if s == nil {
+		return nil
+	}
+	res := &TestMap{}
+	res.Int32ToInt32Field = make(map[int32]int32, len(s.Int32ToInt32Field))
+	for k, v := range s.Int32ToInt32Field {
+		res.Int32ToInt32Field[k] = v
+	}
+	res.Int32ToStringField = make(map[int32]string, len(s.Int32ToStringField))
+	for k, v := range s.Int32ToStringField {
+		res.Int32ToStringField[k] = v
 	}
 	res.Int32ToBytesField = make(map[int32][]byte, len(s.Int32ToBytesField))
 	for k, v := range s.Int32ToBytesField {
@@ -898,53 +2392,920 @@ func (s *TestMap) XXX_PbContentSize() int {
 			var mapEntrySize = keySize + valueSize
 			entrySize += mapEntrySize + gremlin.SizeTag(wireTestMap_StringToInt32Field) + gremlin.SizeUint64(uint64(mapEntrySize))
 		}
-		
-		size += entrySize
+		
+		size += entrySize
+	}
+
+	if len(s.Uint32ToInt32Field) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Uint32ToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeUint32(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireTestMap_Uint32ToInt32Field) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	if len(s.Int64ToInt32Field) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Int64ToInt32Field {
+			var keySize, valueSize int
+			keySize = gremlin.SizeTag(1) + gremlin.SizeInt64(k)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireTestMap_Int64ToInt32Field) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *TestMap) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if len(s.Int32ToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.Int32ToInt32Field[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToStringField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToStringField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToStringField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONString(b, s.Int32ToStringField[k])
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToBytesField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToBytesField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToBytesField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, s.Int32ToBytesField[k])
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToEnumField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToEnumField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToEnumField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Int32ToEnumField[k]), s.Int32ToEnumField[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToMessageField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToMessageField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToMessageField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = s.Int32ToMessageField[k].AppendJSON(b)
+		}
+		b = append(b, '}')
+	}
+	if len(s.StringToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"stringToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.StringToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.StringToInt32Field[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Uint32ToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"uint32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Uint32ToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyUint(b, uint64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.Uint32ToInt32Field[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int64ToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int64ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int64ToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.Int64ToInt32Field[k]))
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *TestMap) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *TestMap) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *TestMap) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = TestMap{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "int32ToInt32Field", "int32_to_int32_field":
+			if s.Int32ToInt32Field == nil {
+				s.Int32ToInt32Field = make(map[int32]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToStringField", "int32_to_string_field":
+			if s.Int32ToStringField == nil {
+				s.Int32ToStringField = make(map[int32]string)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToStringField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToBytesField", "int32_to_bytes_field":
+			if s.Int32ToBytesField == nil {
+				s.Int32ToBytesField = make(map[int32][]byte)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue []byte
+				value, err := d.ReadBytes()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToBytesField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToEnumField", "int32_to_enum_field":
+			if s.Int32ToEnumField == nil {
+				s.Int32ToEnumField = make(map[int32]TestMap_EnumValue)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue TestMap_EnumValue
+				value, err := d.ReadEnum(TestMap_EnumValue_value)
+				if err != nil {
+					return err
+				}
+				mapValue = TestMap_EnumValue(value)
+				s.Int32ToEnumField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToMessageField", "int32_to_message_field":
+			if s.Int32ToMessageField == nil {
+				s.Int32ToMessageField = make(map[int32]*TestMap_MessageValue)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue *TestMap_MessageValue
+				value := &TestMap_MessageValue{}
+				if err := value.XXX_DecodeJSON(d); err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToMessageField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "stringToInt32Field", "string_to_int32_field":
+			if s.StringToInt32Field == nil {
+				s.StringToInt32Field = make(map[string]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.StringToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "uint32ToInt32Field", "uint32_to_int32_field":
+			if s.Uint32ToInt32Field == nil {
+				s.Uint32ToInt32Field = make(map[uint32]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONUint(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := uint32(parsedKey)
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Uint32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int64ToInt32Field", "int64_to_int32_field":
+			if s.Int64ToInt32Field == nil {
+				s.Int64ToInt32Field = make(map[int64]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 64)
+				if err != nil {
+					return err
+				}
+				mapKey := int64(parsedKey)
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int64ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *TestMap) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if len(s.Int32ToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToInt32Field) {
+			w.WriteName("int32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteInt(int64(s.Int32ToInt32Field[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToStringField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToStringField) {
+			w.WriteName("int32_to_string_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteString(s.Int32ToStringField[k])
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToBytesField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToBytesField) {
+			w.WriteName("int32_to_bytes_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteBytes(s.Int32ToBytesField[k])
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToEnumField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToEnumField) {
+			w.WriteName("int32_to_enum_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Int32ToEnumField[k]), s.Int32ToEnumField[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToMessageField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToMessageField) {
+			w.WriteName("int32_to_message_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.StartMessage()
+			s.Int32ToMessageField[k].XXX_WriteText(w)
+			w.EndMessage()
+			w.EndMessage()
+		}
+	}
+	if len(s.StringToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.StringToInt32Field) {
+			w.WriteName("string_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(s.StringToInt32Field[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Uint32ToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Uint32ToInt32Field) {
+			w.WriteName("uint32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteUint(uint64(k))
+			w.WriteName("value")
+			w.WriteInt(int64(s.Uint32ToInt32Field[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Int64ToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int64ToInt32Field) {
+			w.WriteName("int64_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(k)
+			w.WriteName("value")
+			w.WriteInt(int64(s.Int64ToInt32Field[k]))
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *TestMap) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *TestMap) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *TestMap) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *TestMap) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *TestMap) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = TestMap{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "int32_to_int32_field":
+			if s.Int32ToInt32Field == nil {
+				s.Int32ToInt32Field = make(map[int32]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_string_field":
+			if s.Int32ToStringField == nil {
+				s.Int32ToStringField = make(map[int32]string)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue string
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToStringField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_bytes_field":
+			if s.Int32ToBytesField == nil {
+				s.Int32ToBytesField = make(map[int32][]byte)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue []byte
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadBytes()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToBytesField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_enum_field":
+			if s.Int32ToEnumField == nil {
+				s.Int32ToEnumField = make(map[int32]TestMap_EnumValue)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue TestMap_EnumValue
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(TestMap_EnumValue_value)
+						if err != nil {
+							return err
+						}
+						mapValue = TestMap_EnumValue(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToEnumField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_message_field":
+			if s.Int32ToMessageField == nil {
+				s.Int32ToMessageField = make(map[int32]*TestMap_MessageValue)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue *TestMap_MessageValue
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value := &TestMap_MessageValue{}
+						if err := value.XXX_DecodeText(d); err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToMessageField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string_to_int32_field":
+			if s.StringToInt32Field == nil {
+				s.StringToInt32Field = make(map[string]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.StringToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "uint32_to_int32_field":
+			if s.Uint32ToInt32Field == nil {
+				s.Uint32ToInt32Field = make(map[uint32]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey uint32
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Uint32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int64_to_int32_field":
+			if s.Int64ToInt32Field == nil {
+				s.Int64ToInt32Field = make(map[int64]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int64
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt64()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int64ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *TestMap) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestMap
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *TestMap) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireTestMap_Int32ToInt32Field:
+		return s.Int32ToInt32Field
+	case wireTestMap_Int32ToStringField:
+		return s.Int32ToStringField
+	case wireTestMap_Int32ToBytesField:
+		return s.Int32ToBytesField
+	case wireTestMap_Int32ToEnumField:
+		return s.Int32ToEnumField
+	case wireTestMap_Int32ToMessageField:
+		return s.Int32ToMessageField
+	case wireTestMap_StringToInt32Field:
+		return s.StringToInt32Field
+	case wireTestMap_Uint32ToInt32Field:
+		return s.Uint32ToInt32Field
+	case wireTestMap_Int64ToInt32Field:
+		return s.Int64ToInt32Field
 	}
+	return nil
+}
 
-	if len(s.Uint32ToInt32Field) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Uint32ToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeUint32(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireTestMap_Uint32ToInt32Field) + gremlin.SizeUint64(uint64(mapEntrySize))
-		}
-		
-		size += entrySize
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *TestMap) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireTestMap_Int32ToInt32Field:
+		v, ok := value.(map[int32]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int32ToInt32Field = v
+	case wireTestMap_Int32ToStringField:
+		v, ok := value.(map[int32]string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int32ToStringField = v
+	case wireTestMap_Int32ToBytesField:
+		v, ok := value.(map[int32][]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int32ToBytesField = v
+	case wireTestMap_Int32ToEnumField:
+		v, ok := value.(map[int32]TestMap_EnumValue)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int32ToEnumField = v
+	case wireTestMap_Int32ToMessageField:
+		v, ok := value.(map[int32]*TestMap_MessageValue)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int32ToMessageField = v
+	case wireTestMap_StringToInt32Field:
+		v, ok := value.(map[string]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.StringToInt32Field = v
+	case wireTestMap_Uint32ToInt32Field:
+		v, ok := value.(map[uint32]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Uint32ToInt32Field = v
+	case wireTestMap_Int64ToInt32Field:
+		v, ok := value.(map[int64]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap, number, value)
+		}
+		s.Int64ToInt32Field = v
+	default:
+		return gremlin.UnknownFieldError(descriptorTestMap, number)
 	}
+	return nil
+}
 
-	if len(s.Int64ToInt32Field) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Int64ToInt32Field {
-			var keySize, valueSize int
-			keySize = gremlin.SizeTag(1) + gremlin.SizeInt64(k)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireTestMap_Int64ToInt32Field) + gremlin.SizeUint64(uint64(mapEntrySize))
-		}
-		
-		size += entrySize
+// TestMapPaths are the field mask paths of map_test.TestMap.
+var TestMapPaths = XXX_NewTestMapPathNames("")
+
+type TestMapPathNames struct {
+	path string
+	Int32ToInt32Field string
+	Int32ToStringField string
+	Int32ToBytesField string
+	Int32ToEnumField string
+	Int32ToMessageField string
+	StringToInt32Field string
+	Uint32ToInt32Field string
+	Int64ToInt32Field string
+}
+
+func XXX_NewTestMapPathNames(path string) TestMapPathNames {
+	return TestMapPathNames{
+		path: path,
+		Int32ToInt32Field: gremlin.JoinPath(path, "int32_to_int32_field"),
+		Int32ToStringField: gremlin.JoinPath(path, "int32_to_string_field"),
+		Int32ToBytesField: gremlin.JoinPath(path, "int32_to_bytes_field"),
+		Int32ToEnumField: gremlin.JoinPath(path, "int32_to_enum_field"),
+		Int32ToMessageField: gremlin.JoinPath(path, "int32_to_message_field"),
+		StringToInt32Field: gremlin.JoinPath(path, "string_to_int32_field"),
+		Uint32ToInt32Field: gremlin.JoinPath(path, "uint32_to_int32_field"),
+		Int64ToInt32Field: gremlin.JoinPath(path, "int64_to_int32_field"),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p TestMapPathNames) Path() string {
+	return p.path
 }
 
 const (
 	wireTestMap_MessageValue_Value gremlin.ProtoWireNumber = 1
 )
 
+const (
+	FieldTestMap_MessageValue_Value gremlin.FieldIndex = 0
+)
+
+var singularFieldsTestMap_MessageValue = gremlin.NewFieldSet(FieldTestMap_MessageValue_Value)
+
+var descriptorTestMap_MessageValue = &gremlin.MessageDescriptor{
+	FullName: "map_test.TestMap.MessageValue",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "value", JSONName: "value", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &TestMap_MessageValue{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewTestMap_MessageValueReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorTestMap_MessageValue)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *TestMap_MessageValueReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestMap_MessageValue
+}
+
 type TestMap_MessageValueReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
 
 	dataValue     int32
 
-	offsetValue   int
-
-	parsedValue   bool
+	offsetValue   int32
 }
 
 func NewTestMap_MessageValueReader() *TestMap_MessageValueReader {
@@ -959,10 +3320,10 @@ func (m *TestMap_MessageValueReader) GetValue() int32 {
 }
 
 func (m *TestMap_MessageValueReader) readValue() int32 {
-	if m.parsedValue {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataValue
 	}
-	wOffset := m.offsetValue
+	wOffset := int(m.offsetValue)
 	
 	var entry int32
 	if wOffset > 0 {
@@ -970,12 +3331,59 @@ func (m *TestMap_MessageValueReader) readValue() int32 {
 	}
 	
 	m.dataValue = entry
-	m.parsedValue = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
 func (m *TestMap_MessageValueReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *TestMap_MessageValueReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestMap_MessageValue_Value:
+			m.offsetValue = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *TestMap_MessageValueReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *TestMap_MessageValueReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = TestMap_MessageValueReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsTestMap_MessageValue) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -986,7 +3394,15 @@ func (m *TestMap_MessageValueReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireTestMap_MessageValue_Value:
-			m.offsetValue = offset
+			if fields.Has(FieldTestMap_MessageValue_Value) {
+				if m.offsetValue == 0 {
+					found++
+				}
+				m.offsetValue = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -1001,12 +3417,46 @@ func (m *TestMap_MessageValueReader) ToStruct() *TestMap_MessageValue {
 	if m == nil {
 		return nil
 	}
-	res := &TestMap_MessageValue{}
+	res := gremlin.New[TestMap_MessageValue](m.buf.Arena())
 	res.Value = m.GetValue()
 
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *TestMap_MessageValueReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readValue()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *TestMap_MessageValueReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *TestMap_MessageValueReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *TestMap_MessageValueReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -1014,10 +3464,172 @@ func (s *TestMap_MessageValueReader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *TestMap_MessageValueReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetValue(); value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(value))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *TestMap_MessageValueReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeTestMap_MessageValueJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeTestMap_MessageValueJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeTestMap_MessageValueJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeTestMap_MessageValueJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeTestMap_MessageValueJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var last [1]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestMap_MessageValue_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			last[0] = offset
+		}
+		offset = end
+	}
+
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestMap_MessageValue_Value:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"value\":"...)
+			s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(offset)))
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *TestMap_MessageValueReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetValue(); value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(value))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *TestMap_MessageValueReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *TestMap_MessageValueReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *TestMap_MessageValueReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type TestMap_MessageValue struct {
 	Value	int32	`json:"value,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *TestMap_MessageValue) Unmarshal(data []byte) error {
+	*s = TestMap_MessageValue{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestMap_MessageValue_Value:
+			if wire != gremlin.VarIntType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			s.Value = buf.ReadInt32(offset)
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *TestMap_MessageValue) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -1025,59 +3637,242 @@ func (s *TestMap_MessageValue) Marshal() []byte {
 	size := s.XXX_PbContentSize()
 	if size == 0 {
 		return nil
-	}
-	res := gremlin.NewWriter(size)
-	s.MarshalTo(res)
-	return res.Bytes()
+	}
+	res := gremlin.NewWriter(size)
+	s.MarshalTo(res)
+	return res.Bytes()
+}
+
+func (s *TestMap_MessageValue) MarshalTo(res *gremlin.Writer) {
+	if s == nil {
+		return
+	}
+
+	if s.Value != 0 {
+		res.AppendInt32(wireTestMap_MessageValue_Value, s.Value)
+	}
+}
+
+func (s *TestMap_MessageValue) Copy() *TestMap_MessageValue {
+	if s == nil {
+		return nil
+	}
+	res := &TestMap_MessageValue{}
+	res.Value = s.Value
+
+	return res
+}
+
+func (s *TestMap_MessageValue) XXX_PbContentSize() int {
+	if s == nil {
+		return 0
+	}
+	var size = 0
+
+	if s.Value != 0 {
+		var entrySize = 0
+		entrySize = gremlin.SizeTag(wireTestMap_MessageValue_Value) + gremlin.SizeInt32(s.Value)
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *TestMap_MessageValue) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.Value != 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"value\":"...)
+		b = gremlin.AppendJSONInt(b, int64(s.Value))
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *TestMap_MessageValue) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *TestMap_MessageValue) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *TestMap_MessageValue) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = TestMap_MessageValue{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *TestMap_MessageValue) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.Value != 0 {
+		w.WriteName("value")
+		w.WriteInt(int64(s.Value))
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *TestMap_MessageValue) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *TestMap_MessageValue) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *TestMap_MessageValue) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *TestMap_MessageValue) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *TestMap_MessageValue) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = TestMap_MessageValue{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "value":
+			value, err := d.ReadInt32()
+			if err != nil {
+				return err
+			}
+			s.Value = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
 }
 
-func (s *TestMap_MessageValue) MarshalTo(res *gremlin.Writer) {
-	if s == nil {
-		return
-	}
+// Descriptor returns the descriptor of the message type.
+func (s *TestMap_MessageValue) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestMap_MessageValue
+}
 
-	if s.Value != 0 {
-		res.AppendInt32(wireTestMap_MessageValue_Value, s.Value)
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *TestMap_MessageValue) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireTestMap_MessageValue_Value:
+		return s.Value
 	}
+	return nil
 }
 
-func (s *TestMap_MessageValue) Copy() *TestMap_MessageValue {
-	if s == nil {
-		return nil
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *TestMap_MessageValue) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireTestMap_MessageValue_Value:
+		v, ok := value.(int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestMap_MessageValue, number, value)
+		}
+		s.Value = v
+	default:
+		return gremlin.UnknownFieldError(descriptorTestMap_MessageValue, number)
 	}
-	res := &TestMap_MessageValue{}
-	res.Value = s.Value
-
-	return res
+	return nil
 }
 
-func (s *TestMap_MessageValue) XXX_PbContentSize() int {
-	if s == nil {
-		return 0
-	}
-	var size = 0
+// TestMap_MessageValuePaths are the field mask paths of map_test.TestMap.MessageValue.
+var TestMap_MessageValuePaths = XXX_NewTestMap_MessageValuePathNames("")
 
-	if s.Value != 0 {
-		var entrySize = 0
-		entrySize = gremlin.SizeTag(wireTestMap_MessageValue_Value) + gremlin.SizeInt32(s.Value)
-		size += entrySize
+type TestMap_MessageValuePathNames struct {
+	path string
+	Value string
+}
+
+func XXX_NewTestMap_MessageValuePathNames(path string) TestMap_MessageValuePathNames {
+	return TestMap_MessageValuePathNames{
+		path: path,
+		Value: gremlin.JoinPath(path, "value"),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p TestMap_MessageValuePathNames) Path() string {
+	return p.path
 }
 
 const (
 	wireTestOnChangeEventPropagation_OptionalMessage gremlin.ProtoWireNumber = 1
 )
 
+const (
+	FieldTestOnChangeEventPropagation_OptionalMessage gremlin.FieldIndex = 0
+)
+
+var singularFieldsTestOnChangeEventPropagation = gremlin.NewFieldSet(FieldTestOnChangeEventPropagation_OptionalMessage)
+
+var descriptorTestOnChangeEventPropagation = &gremlin.MessageDescriptor{
+	FullName: "map_test.TestOnChangeEventPropagation",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "optional_message", JSONName: "optionalMessage", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelOptional, TypeName: "map_test.TestMap"},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &TestOnChangeEventPropagation{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewTestOnChangeEventPropagationReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorTestOnChangeEventPropagation)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *TestOnChangeEventPropagationReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestOnChangeEventPropagation
+}
+
 type TestOnChangeEventPropagationReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
 
 	dataOptionalMessage     *TestMapReader
 
-	offsetOptionalMessage   int
+	offsetOptionalMessage   int32
 
-	parsedOptionalMessage   bool
+	inlineOptionalMessage   TestMapReader
 }
 
 func NewTestOnChangeEventPropagationReader() *TestOnChangeEventPropagationReader {
@@ -1092,27 +3887,71 @@ func (m *TestOnChangeEventPropagationReader) GetOptionalMessage() *TestMapReader
 }
 
 func (m *TestOnChangeEventPropagationReader) readOptionalMessage() *TestMapReader {
-	if m.parsedOptionalMessage {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataOptionalMessage
 	}
-	wOffset := m.offsetOptionalMessage
+	wOffset := int(m.offsetOptionalMessage)
 	
 	var entry *TestMapReader
 	if wOffset > 0 {
-		var entryData = m.buf.ReadBytes(wOffset)
-		if len(entryData) > 0 {
-			entry = NewTestMapReader()
-			entry.Unmarshal(entryData)
-		}
+		entry = &m.inlineOptionalMessage
+		entry.UnmarshalWithOptions(m.buf.ReadMessage(wOffset), m.buf.Options())
 	}
 	
 	m.dataOptionalMessage = entry
-	m.parsedOptionalMessage = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
 func (m *TestOnChangeEventPropagationReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *TestOnChangeEventPropagationReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestOnChangeEventPropagation_OptionalMessage:
+			m.offsetOptionalMessage = int32(offset)
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *TestOnChangeEventPropagationReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *TestOnChangeEventPropagationReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = TestOnChangeEventPropagationReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	stopAfter := 0
+	if opts.StopEarly && fields.IsSubsetOf(singularFieldsTestOnChangeEventPropagation) {
+		stopAfter = fields.Len()
+	}
+	found := 0
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -1123,7 +3962,15 @@ func (m *TestOnChangeEventPropagationReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireTestOnChangeEventPropagation_OptionalMessage:
-			m.offsetOptionalMessage = offset
+			if fields.Has(FieldTestOnChangeEventPropagation_OptionalMessage) {
+				if m.offsetOptionalMessage == 0 {
+					found++
+				}
+				m.offsetOptionalMessage = int32(offset)
+				if found == stopAfter {
+					return nil
+				}
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -1138,7 +3985,7 @@ func (m *TestOnChangeEventPropagationReader) ToStruct() *TestOnChangeEventPropag
 	if m == nil {
 		return nil
 	}
-	res := &TestOnChangeEventPropagation{}
+	res := gremlin.New[TestOnChangeEventPropagation](m.buf.Arena())
 
 	{
 		var data = m.GetOptionalMessage()
@@ -1152,6 +3999,43 @@ func (m *TestOnChangeEventPropagationReader) ToStruct() *TestOnChangeEventPropag
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *TestOnChangeEventPropagationReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readOptionalMessage().Freeze()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *TestOnChangeEventPropagationReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *TestOnChangeEventPropagationReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 0) != 0 {
+		m.dataOptionalMessage.XXX_Rebase(d)
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *TestOnChangeEventPropagationReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -1159,10 +4043,184 @@ func (s *TestOnChangeEventPropagationReader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *TestOnChangeEventPropagationReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetOptionalMessage(); value != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"optionalMessage\":"...)
+		b = value.AppendJSON(b)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *TestOnChangeEventPropagationReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeTestOnChangeEventPropagationJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeTestOnChangeEventPropagationJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeTestOnChangeEventPropagationJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeTestOnChangeEventPropagationJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeTestOnChangeEventPropagationJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	var first, last [1]int
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestOnChangeEventPropagation_OptionalMessage:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			if first[0] == 0 {
+				first[0] = offset
+			}
+			last[0] = offset
+		}
+		offset = end
+	}
+
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireTestOnChangeEventPropagation_OptionalMessage:
+			if offset != last[0] {
+				break
+			}
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"optionalMessage\":"...)
+			buf, offset := buf.MergeMessage(first[0], offset, tag)
+			if err := XXX_TranscodeTestMapJSON(s, buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *TestOnChangeEventPropagationReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetOptionalMessage(); value != nil {
+		w.WriteName("optional_message")
+		w.StartMessage()
+		value.XXX_WriteText(w)
+		w.EndMessage()
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *TestOnChangeEventPropagationReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *TestOnChangeEventPropagationReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *TestOnChangeEventPropagationReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type TestOnChangeEventPropagation struct {
 	OptionalMessage	*TestMap	`json:"optional_message,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *TestOnChangeEventPropagation) Unmarshal(data []byte) error {
+	*s = TestOnChangeEventPropagation{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireTestOnChangeEventPropagation_OptionalMessage:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			var entry = &TestMap{}
+			if err := entry.Unmarshal(buf.ReadMessage(offset)); err != nil {
+				return err
+			}
+			s.OptionalMessage = entry
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *TestOnChangeEventPropagation) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -1170,51 +4228,206 @@ func (s *TestOnChangeEventPropagation) Marshal() []byte {
 	size := s.XXX_PbContentSize()
 	if size == 0 {
 		return nil
-	}
-	res := gremlin.NewWriter(size)
-	s.MarshalTo(res)
-	return res.Bytes()
+	}
+	res := gremlin.NewWriter(size)
+	s.MarshalTo(res)
+	return res.Bytes()
+}
+
+func (s *TestOnChangeEventPropagation) MarshalTo(res *gremlin.Writer) {
+	if s == nil {
+		return
+	}
+
+	if s.OptionalMessage != nil {
+		structSize := s.OptionalMessage.XXX_PbContentSize()
+		res.AppendBytesTag(wireTestOnChangeEventPropagation_OptionalMessage, structSize)
+		s.OptionalMessage.MarshalTo(res)
+	}
+}
+
+func (s *TestOnChangeEventPropagation) Copy() *TestOnChangeEventPropagation {
+	if s == nil {
+		return nil
+	}
+	res := &TestOnChangeEventPropagation{}
+	if s.OptionalMessage != nil {
+		res.OptionalMessage = s.OptionalMessage.Copy()
+	}
+
+	return res
+}
+
+func (s *TestOnChangeEventPropagation) XXX_PbContentSize() int {
+	if s == nil {
+		return 0
+	}
+	var size = 0
+
+	if s.OptionalMessage != nil {
+		var entrySize = 0
+		entrySize = s.OptionalMessage.XXX_PbContentSize()
+		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireTestOnChangeEventPropagation_OptionalMessage)
+		
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *TestOnChangeEventPropagation) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if s.OptionalMessage != nil {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"optionalMessage\":"...)
+		b = s.OptionalMessage.AppendJSON(b)
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *TestOnChangeEventPropagation) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *TestOnChangeEventPropagation) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *TestOnChangeEventPropagation) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = TestOnChangeEventPropagation{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "optionalMessage", "optional_message":
+			value := &TestMap{}
+			if err := value.XXX_DecodeJSON(d); err != nil {
+				return err
+			}
+			s.OptionalMessage = value
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *TestOnChangeEventPropagation) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if s.OptionalMessage != nil {
+		w.WriteName("optional_message")
+		w.StartMessage()
+		s.OptionalMessage.XXX_WriteText(w)
+		w.EndMessage()
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *TestOnChangeEventPropagation) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *TestOnChangeEventPropagation) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *TestOnChangeEventPropagation) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *TestOnChangeEventPropagation) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *TestOnChangeEventPropagation) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = TestOnChangeEventPropagation{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "optional_message":
+			value := &TestMap{}
+			if err := value.XXX_DecodeText(d); err != nil {
+				return err
+			}
+			s.OptionalMessage = value
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
 }
 
-func (s *TestOnChangeEventPropagation) MarshalTo(res *gremlin.Writer) {
-	if s == nil {
-		return
-	}
+// Descriptor returns the descriptor of the message type.
+func (s *TestOnChangeEventPropagation) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorTestOnChangeEventPropagation
+}
 
-	if s.OptionalMessage != nil {
-		structSize := s.OptionalMessage.XXX_PbContentSize()
-		res.AppendBytesTag(wireTestOnChangeEventPropagation_OptionalMessage, structSize)
-		s.OptionalMessage.MarshalTo(res)
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *TestOnChangeEventPropagation) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireTestOnChangeEventPropagation_OptionalMessage:
+		return s.OptionalMessage
 	}
+	return nil
 }
 
-func (s *TestOnChangeEventPropagation) Copy() *TestOnChangeEventPropagation {
-	if s == nil {
-		return nil
-	}
-	res := &TestOnChangeEventPropagation{}
-	if s.OptionalMessage != nil {
-		res.OptionalMessage = s.OptionalMessage.Copy()
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *TestOnChangeEventPropagation) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireTestOnChangeEventPropagation_OptionalMessage:
+		v, ok := value.(*TestMap)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorTestOnChangeEventPropagation, number, value)
+		}
+		s.OptionalMessage = v
+	default:
+		return gremlin.UnknownFieldError(descriptorTestOnChangeEventPropagation, number)
 	}
-
-	return res
+	return nil
 }
 
-func (s *TestOnChangeEventPropagation) XXX_PbContentSize() int {
-	if s == nil {
-		return 0
-	}
-	var size = 0
+// TestOnChangeEventPropagationPaths are the field mask paths of map_test.TestOnChangeEventPropagation.
+var TestOnChangeEventPropagationPaths = XXX_NewTestOnChangeEventPropagationPathNames("")
 
-	if s.OptionalMessage != nil {
-		var entrySize = 0
-		entrySize = s.OptionalMessage.XXX_PbContentSize()
-		entrySize += gremlin.SizeUint64(uint64(entrySize)) + gremlin.SizeTag(wireTestOnChangeEventPropagation_OptionalMessage)
-		
-		size += entrySize
+type TestOnChangeEventPropagationPathNames struct {
+	path string
+	OptionalMessage TestMapPathNames
+}
+
+func XXX_NewTestOnChangeEventPropagationPathNames(path string) TestOnChangeEventPropagationPathNames {
+	return TestOnChangeEventPropagationPathNames{
+		path: path,
+		OptionalMessage: XXX_NewTestMapPathNames(gremlin.JoinPath(path, "optional_message")),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p TestOnChangeEventPropagationPathNames) Path() string {
+	return p.path
 }
 
 const (
@@ -1226,8 +4439,67 @@ const (
 	wireBizarroTestMap_StringToInt32Field gremlin.ProtoWireNumber = 6
 )
 
+const (
+	FieldBizarroTestMap_Int32ToInt32Field gremlin.FieldIndex = 0
+	FieldBizarroTestMap_Int32ToStringField gremlin.FieldIndex = 1
+	FieldBizarroTestMap_Int32ToBytesField gremlin.FieldIndex = 2
+	FieldBizarroTestMap_Int32ToEnumField gremlin.FieldIndex = 3
+	FieldBizarroTestMap_Int32ToMessageField gremlin.FieldIndex = 4
+	FieldBizarroTestMap_StringToInt32Field gremlin.FieldIndex = 5
+)
+
+var descriptorBizarroTestMap = &gremlin.MessageDescriptor{
+	FullName: "map_test.BizarroTestMap",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "int32_to_int32_field", JSONName: "int32ToInt32Field", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_string_field", JSONName: "int32ToStringField", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_bytes_field", JSONName: "int32ToBytesField", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindInt32, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_enum_field", JSONName: "int32ToEnumField", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+		},
+		{Name: "int32_to_message_field", JSONName: "int32ToMessageField", Number: 5, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+		},
+		{Name: "string_to_int32_field", JSONName: "stringToInt32Field", Number: 6, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindBytes, Label: gremlin.LabelOptional},
+		},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &BizarroTestMap{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewBizarroTestMapReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorBizarroTestMap)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *BizarroTestMapReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorBizarroTestMap
+}
+
 type BizarroTestMapReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataInt32ToInt32Field     map[int32][]byte
 	dataInt32ToStringField     map[string]int32
@@ -1236,19 +4508,6 @@ type BizarroTestMapReader struct {
 	dataInt32ToMessageField     map[string][]byte
 	dataStringToInt32Field     map[string][]byte
 
-	offsetInt32ToInt32Field   []int
-	offsetInt32ToStringField   []int
-	offsetInt32ToBytesField   []int
-	offsetInt32ToEnumField   []int
-	offsetInt32ToMessageField   []int
-	offsetStringToInt32Field   []int
-
-	parsedInt32ToInt32Field   bool
-	parsedInt32ToStringField   bool
-	parsedInt32ToBytesField   bool
-	parsedInt32ToEnumField   bool
-	parsedInt32ToMessageField   bool
-	parsedStringToInt32Field   bool
 }
 
 func NewBizarroTestMapReader() *BizarroTestMapReader {
@@ -1263,53 +4522,59 @@ func (m *BizarroTestMapReader) GetInt32ToInt32Field() map[int32][]byte {
 }
 
 func (m *BizarroTestMapReader) readInt32ToInt32Field() map[int32][]byte {
-	if m.parsedInt32ToInt32Field {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataInt32ToInt32Field
 	}
-	wOffset := m.offsetInt32ToInt32Field
+	wField := FieldBizarroTestMap_Int32ToInt32Field
+	
+	var entry map[int32][]byte
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[int32, []byte](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[int32][]byte{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData int32
-		var valueData []byte
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry int32
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry []byte
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData int32
+			var valueData []byte
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry int32
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry []byte
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToInt32Field = entry
-	m.parsedInt32ToInt32Field = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
@@ -1321,53 +4586,59 @@ func (m *BizarroTestMapReader) GetInt32ToStringField() map[string]int32 {
 }
 
 func (m *BizarroTestMapReader) readInt32ToStringField() map[string]int32 {
-	if m.parsedInt32ToStringField {
+	if m.parsed[0]&(1 << 1) != 0 {
 		return m.dataInt32ToStringField
 	}
-	wOffset := m.offsetInt32ToStringField
+	wField := FieldBizarroTestMap_Int32ToStringField
+	
+	var entry map[string]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataInt32ToStringField = entry
-	m.parsedInt32ToStringField = true
+	m.parsed[0] |= 1 << 1
 	return entry
 }
 
@@ -1379,235 +4650,647 @@ func (m *BizarroTestMapReader) GetInt32ToBytesField() map[string]int32 {
 }
 
 func (m *BizarroTestMapReader) readInt32ToBytesField() map[string]int32 {
-	if m.parsedInt32ToBytesField {
+	if m.parsed[0]&(1 << 2) != 0 {
 		return m.dataInt32ToBytesField
 	}
-	wOffset := m.offsetInt32ToBytesField
+	wField := FieldBizarroTestMap_Int32ToBytesField
+	
+	var entry map[string]int32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, int32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData int32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry int32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataInt32ToBytesField = entry
+	m.parsed[0] |= 1 << 2
+	return entry
+}
+
+func (m *BizarroTestMapReader) GetInt32ToEnumField() map[string][]byte {
+	if m == nil {
+		return nil
+	}
+	return m.readInt32ToEnumField()
+}
+
+func (m *BizarroTestMapReader) readInt32ToEnumField() map[string][]byte {
+	if m.parsed[0]&(1 << 3) != 0 {
+		return m.dataInt32ToEnumField
+	}
+	wField := FieldBizarroTestMap_Int32ToEnumField
 	
-	var entry = map[string]int32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string][]byte
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, []byte](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData int32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry int32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadInt32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData []byte
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry []byte
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataInt32ToEnumField = entry
+	m.parsed[0] |= 1 << 3
+	return entry
+}
+
+func (m *BizarroTestMapReader) GetInt32ToMessageField() map[string][]byte {
+	if m == nil {
+		return nil
+	}
+	return m.readInt32ToMessageField()
+}
+
+func (m *BizarroTestMapReader) readInt32ToMessageField() map[string][]byte {
+	if m.parsed[0]&(1 << 4) != 0 {
+		return m.dataInt32ToMessageField
+	}
+	wField := FieldBizarroTestMap_Int32ToMessageField
+	
+	var entry map[string][]byte
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, []byte](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData []byte
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry []byte
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataInt32ToMessageField = entry
+	m.parsed[0] |= 1 << 4
+	return entry
+}
+
+func (m *BizarroTestMapReader) GetStringToInt32Field() map[string][]byte {
+	if m == nil {
+		return nil
+	}
+	return m.readStringToInt32Field()
+}
+
+func (m *BizarroTestMapReader) readStringToInt32Field() map[string][]byte {
+	if m.parsed[0]&(1 << 5) != 0 {
+		return m.dataStringToInt32Field
+	}
+	wField := FieldBizarroTestMap_StringToInt32Field
+	
+	var entry map[string][]byte
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, []byte](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData []byte
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry []byte
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataStringToInt32Field = entry
+	m.parsed[0] |= 1 << 5
+	return entry
+}
+
+func (m *BizarroTestMapReader) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *BizarroTestMapReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireBizarroTestMap_Int32ToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToInt32Field, Offset: int32(offset), Wire: wire})
+		case wireBizarroTestMap_Int32ToStringField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToStringField, Offset: int32(offset), Wire: wire})
+		case wireBizarroTestMap_Int32ToBytesField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToBytesField, Offset: int32(offset), Wire: wire})
+		case wireBizarroTestMap_Int32ToEnumField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToEnumField, Offset: int32(offset), Wire: wire})
+		case wireBizarroTestMap_Int32ToMessageField:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToMessageField, Offset: int32(offset), Wire: wire})
+		case wireBizarroTestMap_StringToInt32Field:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_StringToInt32Field, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *BizarroTestMapReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *BizarroTestMapReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = BizarroTestMapReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireBizarroTestMap_Int32ToInt32Field:
+			if fields.Has(FieldBizarroTestMap_Int32ToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToInt32Field, Offset: int32(offset), Wire: wire})
+			}
+		case wireBizarroTestMap_Int32ToStringField:
+			if fields.Has(FieldBizarroTestMap_Int32ToStringField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToStringField, Offset: int32(offset), Wire: wire})
+			}
+		case wireBizarroTestMap_Int32ToBytesField:
+			if fields.Has(FieldBizarroTestMap_Int32ToBytesField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToBytesField, Offset: int32(offset), Wire: wire})
+			}
+		case wireBizarroTestMap_Int32ToEnumField:
+			if fields.Has(FieldBizarroTestMap_Int32ToEnumField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToEnumField, Offset: int32(offset), Wire: wire})
+			}
+		case wireBizarroTestMap_Int32ToMessageField:
+			if fields.Has(FieldBizarroTestMap_Int32ToMessageField) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_Int32ToMessageField, Offset: int32(offset), Wire: wire})
+			}
+		case wireBizarroTestMap_StringToInt32Field:
+			if fields.Has(FieldBizarroTestMap_StringToInt32Field) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldBizarroTestMap_StringToInt32Field, Offset: int32(offset), Wire: wire})
+			}
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *BizarroTestMapReader) ToStruct() *BizarroTestMap {
+	if m == nil {
+		return nil
+	}
+	res := gremlin.New[BizarroTestMap](m.buf.Arena())
+	res.Int32ToInt32Field = m.GetInt32ToInt32Field()
+	res.Int32ToStringField = m.GetInt32ToStringField()
+	res.Int32ToBytesField = m.GetInt32ToBytesField()
+	res.Int32ToEnumField = m.GetInt32ToEnumField()
+	res.Int32ToMessageField = m.GetInt32ToMessageField()
+	res.StringToInt32Field = m.GetStringToInt32Field()
+
+	return res
+}
+
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *BizarroTestMapReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readInt32ToInt32Field()
+	m.readInt32ToStringField()
+	m.readInt32ToBytesField()
+	m.readInt32ToEnumField()
+	m.readInt32ToMessageField()
+	m.readStringToInt32Field()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *BizarroTestMapReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *BizarroTestMapReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 0) != 0 {
+		for k, v := range m.dataInt32ToInt32Field {
+			v = d.RebaseBytes(v)
+			m.dataInt32ToInt32Field[k] = v
+		}
+	}
+	if m.parsed[0]&(1 << 1) != 0 {
+		if m.dataInt32ToStringField != nil {
+			rebased := make(map[string]int32, len(m.dataInt32ToStringField))
+			for k, v := range m.dataInt32ToStringField {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataInt32ToStringField = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		if m.dataInt32ToBytesField != nil {
+			rebased := make(map[string]int32, len(m.dataInt32ToBytesField))
+			for k, v := range m.dataInt32ToBytesField {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataInt32ToBytesField = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		if m.dataInt32ToEnumField != nil {
+			rebased := make(map[string][]byte, len(m.dataInt32ToEnumField))
+			for k, v := range m.dataInt32ToEnumField {
+				k = d.RebaseString(k)
+				v = d.RebaseBytes(v)
+				rebased[k] = v
+			}
+			m.dataInt32ToEnumField = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 4) != 0 {
+		if m.dataInt32ToMessageField != nil {
+			rebased := make(map[string][]byte, len(m.dataInt32ToMessageField))
+			for k, v := range m.dataInt32ToMessageField {
+				k = d.RebaseString(k)
+				v = d.RebaseBytes(v)
+				rebased[k] = v
+			}
+			m.dataInt32ToMessageField = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 5) != 0 {
+		if m.dataStringToInt32Field != nil {
+			rebased := make(map[string][]byte, len(m.dataStringToInt32Field))
+			for k, v := range m.dataStringToInt32Field {
+				k = d.RebaseString(k)
+				v = d.RebaseBytes(v)
+				rebased[k] = v
+			}
+			m.dataStringToInt32Field = rebased
+		}
 	}
-	
-	m.dataInt32ToBytesField = entry
-	m.parsedInt32ToBytesField = true
-	return entry
 }
 
-func (m *BizarroTestMapReader) GetInt32ToEnumField() map[string][]byte {
-	if m == nil {
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *BizarroTestMapReader) SourceBytes() []byte {
+	if s == nil {
 		return nil
 	}
-	return m.readInt32ToEnumField()
+	return s.buf.Bytes()
 }
 
-func (m *BizarroTestMapReader) readInt32ToEnumField() map[string][]byte {
-	if m.parsedInt32ToEnumField {
-		return m.dataInt32ToEnumField
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *BizarroTestMapReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetInt32ToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToStringField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToStringField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToBytesField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToBytesField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToEnumField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToEnumField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt32ToMessageField(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToMessageField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetStringToInt32Field(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"stringToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, value[k])
+		}
+		b = append(b, '}')
 	}
-	wOffset := m.offsetInt32ToEnumField
-	
-	var entry = map[string][]byte{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData []byte
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry []byte
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+	if sep == '{' {
+		b = append(b, sep)
 	}
-	
-	m.dataInt32ToEnumField = entry
-	m.parsedInt32ToEnumField = true
-	return entry
+	return append(b, '}')
 }
 
-func (m *BizarroTestMapReader) GetInt32ToMessageField() map[string][]byte {
-	if m == nil {
-		return nil
-	}
-	return m.readInt32ToMessageField()
+func (m *BizarroTestMapReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
 }
 
-func (m *BizarroTestMapReader) readInt32ToMessageField() map[string][]byte {
-	if m.parsedInt32ToMessageField {
-		return m.dataInt32ToMessageField
-	}
-	wOffset := m.offsetInt32ToMessageField
-	
-	var entry = map[string][]byte{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData []byte
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry []byte
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+// TranscodeBizarroTestMapJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeBizarroTestMapJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeBizarroTestMapJSON(s, data); err != nil {
+		return err
 	}
-	
-	m.dataInt32ToMessageField = entry
-	m.parsedInt32ToMessageField = true
-	return entry
+	return s.Flush()
 }
 
-func (m *BizarroTestMapReader) GetStringToInt32Field() map[string][]byte {
-	if m == nil {
-		return nil
+// XXX_TranscodeBizarroTestMapJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeBizarroTestMapJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
 	}
-	return m.readStringToInt32Field()
-}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
 
-func (m *BizarroTestMapReader) readStringToInt32Field() map[string][]byte {
-	if m.parsedStringToInt32Field {
-		return m.dataStringToInt32Field
-	}
-	wOffset := m.offsetStringToInt32Field
-	
-	var entry = map[string][]byte{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData []byte
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry []byte
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadBytes(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireBizarroTestMap_Int32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireBizarroTestMap_Int32ToStringField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireBizarroTestMap_Int32ToBytesField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireBizarroTestMap_Int32ToEnumField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireBizarroTestMap_Int32ToMessageField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireBizarroTestMap_StringToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
 	}
-	
-	m.dataStringToInt32Field = entry
-	m.parsedStringToInt32Field = true
-	return entry
-}
 
-func (m *BizarroTestMapReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
-	offset := 0
-	for m.buf.HasNext(offset, 0) {
-		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
 		if err != nil {
 			return err
 		}
@@ -1615,56 +5298,758 @@ func (m *BizarroTestMapReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireBizarroTestMap_Int32ToInt32Field:
-			m.offsetInt32ToInt32Field = append(m.offsetInt32ToInt32Field, offset)
+			if written[0]&(1 << 0) != 0 {
+				break
+			}
+			written[0] |= 1 << 0
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey int32
+				if keyOffset > 0 {
+					mapKey = buf.ReadInt32(keyOffset)
+				}
+				s.B = gremlin.AppendJSONMapKeyInt(s.B, int64(mapKey))
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(valueOffset))
+				} else {
+					var mapValue []byte
+					s.B = gremlin.AppendJSONBytes(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
 		case wireBizarroTestMap_Int32ToStringField:
-			m.offsetInt32ToStringField = append(m.offsetInt32ToStringField, offset)
+			if written[0]&(1 << 1) != 0 {
+				break
+			}
+			written[0] |= 1 << 1
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToStringField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
 		case wireBizarroTestMap_Int32ToBytesField:
-			m.offsetInt32ToBytesField = append(m.offsetInt32ToBytesField, offset)
+			if written[0]&(1 << 2) != 0 {
+				break
+			}
+			written[0] |= 1 << 2
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToBytesField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONInt(s.B, int64(buf.ReadInt32(valueOffset)))
+				} else {
+					var mapValue int32
+					s.B = gremlin.AppendJSONInt(s.B, int64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
 		case wireBizarroTestMap_Int32ToEnumField:
-			m.offsetInt32ToEnumField = append(m.offsetInt32ToEnumField, offset)
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToEnumField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(valueOffset))
+				} else {
+					var mapValue []byte
+					s.B = gremlin.AppendJSONBytes(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
 		case wireBizarroTestMap_Int32ToMessageField:
-			m.offsetInt32ToMessageField = append(m.offsetInt32ToMessageField, offset)
+			if written[0]&(1 << 4) != 0 {
+				break
+			}
+			written[0] |= 1 << 4
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int32ToMessageField\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(valueOffset))
+				} else {
+					var mapValue []byte
+					s.B = gremlin.AppendJSONBytes(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
 		case wireBizarroTestMap_StringToInt32Field:
-			m.offsetStringToInt32Field = append(m.offsetStringToInt32Field, offset)
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"stringToInt32Field\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONBytes(s.B, buf.ReadBytes(valueOffset))
+				} else {
+					var mapValue []byte
+					s.B = gremlin.AppendJSONBytes(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
 		}
 
-		offset, err = m.buf.SkipData(offset, wire)
+		offset, err = buf.SkipData(offset, wire)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
 }
 
-func (m *BizarroTestMapReader) ToStruct() *BizarroTestMap {
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *BizarroTestMapReader) XXX_WriteText(w *gremlin.TextWriter) {
 	if m == nil {
-		return nil
+		return
 	}
-	res := &BizarroTestMap{}
-	res.Int32ToInt32Field = m.GetInt32ToInt32Field()
-	res.Int32ToStringField = m.GetInt32ToStringField()
-	res.Int32ToBytesField = m.GetInt32ToBytesField()
-	res.Int32ToEnumField = m.GetInt32ToEnumField()
-	res.Int32ToMessageField = m.GetInt32ToMessageField()
-	res.StringToInt32Field = m.GetStringToInt32Field()
+	if value := m.GetInt32ToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteBytes(value[k])
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToStringField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_string_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToBytesField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_bytes_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToEnumField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_enum_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(value[k])
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt32ToMessageField(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int32_to_message_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(value[k])
+			w.EndMessage()
+		}
+	}
+	if value := m.GetStringToInt32Field(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("string_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(value[k])
+			w.EndMessage()
+		}
+	}
+}
 
-	return res
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *BizarroTestMapReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
 }
 
-func (s *BizarroTestMapReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
+// String returns the message in the single line protobuf text format.
+func (m *BizarroTestMapReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *BizarroTestMapReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
+type BizarroTestMap struct {
+	Int32ToInt32Field	map[int32][]byte	`json:"int32_to_int32_field,omitempty"`
+	Int32ToStringField	map[string]int32	`json:"int32_to_string_field,omitempty"`
+	Int32ToBytesField	map[string]int32	`json:"int32_to_bytes_field,omitempty"`
+	Int32ToEnumField	map[string][]byte	`json:"int32_to_enum_field,omitempty"`
+	Int32ToMessageField	map[string][]byte	`json:"int32_to_message_field,omitempty"`
+	StringToInt32Field	map[string][]byte	`json:"string_to_int32_field,omitempty"`
+}
+
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *BizarroTestMap) Unmarshal(data []byte) error {
+	*s = BizarroTestMap{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireBizarroTestMap_Int32ToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToInt32Field == nil {
+					s.Int32ToInt32Field = make(map[int32][]byte, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey int32
+				var mapValue []byte
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadInt32(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadBytes(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToInt32Field[mapKey] = mapValue
+			}
+		case wireBizarroTestMap_Int32ToStringField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToStringField == nil {
+					s.Int32ToStringField = make(map[string]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToStringField[mapKey] = mapValue
+			}
+		case wireBizarroTestMap_Int32ToBytesField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToBytesField == nil {
+					s.Int32ToBytesField = make(map[string]int32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue int32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadInt32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToBytesField[mapKey] = mapValue
+			}
+		case wireBizarroTestMap_Int32ToEnumField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToEnumField == nil {
+					s.Int32ToEnumField = make(map[string][]byte, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue []byte
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadBytes(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToEnumField[mapKey] = mapValue
+			}
+		case wireBizarroTestMap_Int32ToMessageField:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int32ToMessageField == nil {
+					s.Int32ToMessageField = make(map[string][]byte, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue []byte
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadBytes(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int32ToMessageField[mapKey] = mapValue
+			}
+		case wireBizarroTestMap_StringToInt32Field:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.StringToInt32Field == nil {
+					s.StringToInt32Field = make(map[string][]byte, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue []byte
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadBytes(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.StringToInt32Field[mapKey] = mapValue
+			}
+		}
+		offset = end
 	}
-	return s.buf.Bytes()
-}
-
-type BizarroTestMap struct {
-	Int32ToInt32Field	map[int32][]byte	`json:"int32_to_int32_field,omitempty"`
-	Int32ToStringField	map[string]int32	`json:"int32_to_string_field,omitempty"`
-	Int32ToBytesField	map[string]int32	`json:"int32_to_bytes_field,omitempty"`
-	Int32ToEnumField	map[string][]byte	`json:"int32_to_enum_field,omitempty"`
-	Int32ToMessageField	map[string][]byte	`json:"int32_to_message_field,omitempty"`
-	StringToInt32Field	map[string][]byte	`json:"string_to_int32_field,omitempty"`
+	return nil
 }
 
 func (s *BizarroTestMap) Marshal() []byte {
@@ -1897,6 +6282,647 @@ func (s *BizarroTestMap) XXX_PbContentSize() int {
 	return size
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *BizarroTestMap) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if len(s.Int32ToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONMapKeyInt(b, int64(k))
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, s.Int32ToInt32Field[k])
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToStringField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToStringField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToStringField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.Int32ToStringField[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToBytesField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToBytesField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToBytesField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONInt(b, int64(s.Int32ToBytesField[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToEnumField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToEnumField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToEnumField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, s.Int32ToEnumField[k])
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int32ToMessageField) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int32ToMessageField\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int32ToMessageField) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, s.Int32ToMessageField[k])
+		}
+		b = append(b, '}')
+	}
+	if len(s.StringToInt32Field) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"stringToInt32Field\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.StringToInt32Field) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONBytes(b, s.StringToInt32Field[k])
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *BizarroTestMap) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *BizarroTestMap) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *BizarroTestMap) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = BizarroTestMap{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "int32ToInt32Field", "int32_to_int32_field":
+			if s.Int32ToInt32Field == nil {
+				s.Int32ToInt32Field = make(map[int32][]byte)
+			}
+			if err := d.ReadObject(func(key string) error {
+				parsedKey, err := gremlin.ParseJSONInt(key, 32)
+				if err != nil {
+					return err
+				}
+				mapKey := int32(parsedKey)
+				var mapValue []byte
+				value, err := d.ReadBytes()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToStringField", "int32_to_string_field":
+			if s.Int32ToStringField == nil {
+				s.Int32ToStringField = make(map[string]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToStringField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToBytesField", "int32_to_bytes_field":
+			if s.Int32ToBytesField == nil {
+				s.Int32ToBytesField = make(map[string]int32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue int32
+				value, err := d.ReadInt32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToBytesField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToEnumField", "int32_to_enum_field":
+			if s.Int32ToEnumField == nil {
+				s.Int32ToEnumField = make(map[string][]byte)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue []byte
+				value, err := d.ReadBytes()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToEnumField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32ToMessageField", "int32_to_message_field":
+			if s.Int32ToMessageField == nil {
+				s.Int32ToMessageField = make(map[string][]byte)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue []byte
+				value, err := d.ReadBytes()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int32ToMessageField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "stringToInt32Field", "string_to_int32_field":
+			if s.StringToInt32Field == nil {
+				s.StringToInt32Field = make(map[string][]byte)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue []byte
+				value, err := d.ReadBytes()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.StringToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *BizarroTestMap) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if len(s.Int32ToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToInt32Field) {
+			w.WriteName("int32_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteInt(int64(k))
+			w.WriteName("value")
+			w.WriteBytes(s.Int32ToInt32Field[k])
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToStringField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToStringField) {
+			w.WriteName("int32_to_string_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(s.Int32ToStringField[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToBytesField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToBytesField) {
+			w.WriteName("int32_to_bytes_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteInt(int64(s.Int32ToBytesField[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToEnumField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToEnumField) {
+			w.WriteName("int32_to_enum_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(s.Int32ToEnumField[k])
+			w.EndMessage()
+		}
+	}
+	if len(s.Int32ToMessageField) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int32ToMessageField) {
+			w.WriteName("int32_to_message_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(s.Int32ToMessageField[k])
+			w.EndMessage()
+		}
+	}
+	if len(s.StringToInt32Field) > 0 {
+		for _, k := range gremlin.SortedKeys(s.StringToInt32Field) {
+			w.WriteName("string_to_int32_field")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteBytes(s.StringToInt32Field[k])
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *BizarroTestMap) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *BizarroTestMap) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *BizarroTestMap) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *BizarroTestMap) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *BizarroTestMap) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = BizarroTestMap{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "int32_to_int32_field":
+			if s.Int32ToInt32Field == nil {
+				s.Int32ToInt32Field = make(map[int32][]byte)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey int32
+				var mapValue []byte
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadBytes()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_string_field":
+			if s.Int32ToStringField == nil {
+				s.Int32ToStringField = make(map[string]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToStringField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_bytes_field":
+			if s.Int32ToBytesField == nil {
+				s.Int32ToBytesField = make(map[string]int32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue int32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadInt32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToBytesField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_enum_field":
+			if s.Int32ToEnumField == nil {
+				s.Int32ToEnumField = make(map[string][]byte)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue []byte
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadBytes()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToEnumField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int32_to_message_field":
+			if s.Int32ToMessageField == nil {
+				s.Int32ToMessageField = make(map[string][]byte)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue []byte
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadBytes()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int32ToMessageField[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string_to_int32_field":
+			if s.StringToInt32Field == nil {
+				s.StringToInt32Field = make(map[string][]byte)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue []byte
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadBytes()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.StringToInt32Field[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *BizarroTestMap) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorBizarroTestMap
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *BizarroTestMap) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireBizarroTestMap_Int32ToInt32Field:
+		return s.Int32ToInt32Field
+	case wireBizarroTestMap_Int32ToStringField:
+		return s.Int32ToStringField
+	case wireBizarroTestMap_Int32ToBytesField:
+		return s.Int32ToBytesField
+	case wireBizarroTestMap_Int32ToEnumField:
+		return s.Int32ToEnumField
+	case wireBizarroTestMap_Int32ToMessageField:
+		return s.Int32ToMessageField
+	case wireBizarroTestMap_StringToInt32Field:
+		return s.StringToInt32Field
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *BizarroTestMap) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireBizarroTestMap_Int32ToInt32Field:
+		v, ok := value.(map[int32][]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.Int32ToInt32Field = v
+	case wireBizarroTestMap_Int32ToStringField:
+		v, ok := value.(map[string]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.Int32ToStringField = v
+	case wireBizarroTestMap_Int32ToBytesField:
+		v, ok := value.(map[string]int32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.Int32ToBytesField = v
+	case wireBizarroTestMap_Int32ToEnumField:
+		v, ok := value.(map[string][]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.Int32ToEnumField = v
+	case wireBizarroTestMap_Int32ToMessageField:
+		v, ok := value.(map[string][]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.Int32ToMessageField = v
+	case wireBizarroTestMap_StringToInt32Field:
+		v, ok := value.(map[string][]byte)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorBizarroTestMap, number, value)
+		}
+		s.StringToInt32Field = v
+	default:
+		return gremlin.UnknownFieldError(descriptorBizarroTestMap, number)
+	}
+	return nil
+}
+
+// BizarroTestMapPaths are the field mask paths of map_test.BizarroTestMap.
+var BizarroTestMapPaths = XXX_NewBizarroTestMapPathNames("")
+
+type BizarroTestMapPathNames struct {
+	path string
+	Int32ToInt32Field string
+	Int32ToStringField string
+	Int32ToBytesField string
+	Int32ToEnumField string
+	Int32ToMessageField string
+	StringToInt32Field string
+}
+
+func XXX_NewBizarroTestMapPathNames(path string) BizarroTestMapPathNames {
+	return BizarroTestMapPathNames{
+		path: path,
+		Int32ToInt32Field: gremlin.JoinPath(path, "int32_to_int32_field"),
+		Int32ToStringField: gremlin.JoinPath(path, "int32_to_string_field"),
+		Int32ToBytesField: gremlin.JoinPath(path, "int32_to_bytes_field"),
+		Int32ToEnumField: gremlin.JoinPath(path, "int32_to_enum_field"),
+		Int32ToMessageField: gremlin.JoinPath(path, "int32_to_message_field"),
+		StringToInt32Field: gremlin.JoinPath(path, "string_to_int32_field"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p BizarroTestMapPathNames) Path() string {
+	return p.path
+}
+
 const (
 	wireReservedAsMapField_If gremlin.ProtoWireNumber = 1
 	wireReservedAsMapField_Const gremlin.ProtoWireNumber = 2
@@ -1904,14 +6930,93 @@ const (
 	wireReservedAsMapField_Class gremlin.ProtoWireNumber = 4
 	wireReservedAsMapField_Int gremlin.ProtoWireNumber = 5
 	wireReservedAsMapField_Void gremlin.ProtoWireNumber = 6
-	wireReservedAsMapField_String gremlin.ProtoWireNumber = 7
+	wireReservedAsMapField_String_ gremlin.ProtoWireNumber = 7
 	wireReservedAsMapField_Package gremlin.ProtoWireNumber = 8
 	wireReservedAsMapField_Enum gremlin.ProtoWireNumber = 9
 	wireReservedAsMapField_Null gremlin.ProtoWireNumber = 10
 )
 
+const (
+	FieldReservedAsMapField_If gremlin.FieldIndex = 0
+	FieldReservedAsMapField_Const gremlin.FieldIndex = 1
+	FieldReservedAsMapField_Private gremlin.FieldIndex = 2
+	FieldReservedAsMapField_Class gremlin.FieldIndex = 3
+	FieldReservedAsMapField_Int gremlin.FieldIndex = 4
+	FieldReservedAsMapField_Void gremlin.FieldIndex = 5
+	FieldReservedAsMapField_String_ gremlin.FieldIndex = 6
+	FieldReservedAsMapField_Package gremlin.FieldIndex = 7
+	FieldReservedAsMapField_Enum gremlin.FieldIndex = 8
+	FieldReservedAsMapField_Null gremlin.FieldIndex = 9
+)
+
+var descriptorReservedAsMapField = &gremlin.MessageDescriptor{
+	FullName: "map_test.ReservedAsMapField",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "if", JSONName: "if", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "const", JSONName: "const", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "private", JSONName: "private", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "class", JSONName: "class", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "int", JSONName: "int", Number: 5, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "void", JSONName: "void", Number: 6, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "string", JSONName: "string", Number: 7, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "package", JSONName: "package", Number: 8, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "enum", JSONName: "enum", Number: 9, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+		{Name: "null", JSONName: "null", Number: 10, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindUint32, Label: gremlin.LabelOptional},
+		},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &ReservedAsMapField{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewReservedAsMapFieldReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorReservedAsMapField)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *ReservedAsMapFieldReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorReservedAsMapField
+}
+
 type ReservedAsMapFieldReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataIf     map[string]uint32
 	dataConst     map[string]uint32
@@ -1919,698 +7024,2416 @@ type ReservedAsMapFieldReader struct {
 	dataClass     map[string]uint32
 	dataInt     map[string]uint32
 	dataVoid     map[string]uint32
-	dataString     map[string]uint32
+	dataString_     map[string]uint32
 	dataPackage     map[string]uint32
 	dataEnum     map[string]uint32
 	dataNull     map[string]uint32
 
-	offsetIf   []int
-	offsetConst   []int
-	offsetPrivate   []int
-	offsetClass   []int
-	offsetInt   []int
-	offsetVoid   []int
-	offsetString   []int
-	offsetPackage   []int
-	offsetEnum   []int
-	offsetNull   []int
-
-	parsedIf   bool
-	parsedConst   bool
-	parsedPrivate   bool
-	parsedClass   bool
-	parsedInt   bool
-	parsedVoid   bool
-	parsedString   bool
-	parsedPackage   bool
-	parsedEnum   bool
-	parsedNull   bool
 }
 
 func NewReservedAsMapFieldReader() *ReservedAsMapFieldReader {
 	return &ReservedAsMapFieldReader{}
 }
 
-func (m *ReservedAsMapFieldReader) GetIf() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetIf() map[string]uint32 {
+	if m == nil {
+		return nil
+	}
+	return m.readIf()
+}
+
+func (m *ReservedAsMapFieldReader) readIf() map[string]uint32 {
+	if m.parsed[0]&(1 << 0) != 0 {
+		return m.dataIf
+	}
+	wField := FieldReservedAsMapField_If
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataIf = entry
+	m.parsed[0] |= 1 << 0
+	return entry
+}
+
+func (m *ReservedAsMapFieldReader) GetConst() map[string]uint32 {
+	if m == nil {
+		return nil
+	}
+	return m.readConst()
+}
+
+func (m *ReservedAsMapFieldReader) readConst() map[string]uint32 {
+	if m.parsed[0]&(1 << 1) != 0 {
+		return m.dataConst
+	}
+	wField := FieldReservedAsMapField_Const
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataConst = entry
+	m.parsed[0] |= 1 << 1
+	return entry
+}
+
+func (m *ReservedAsMapFieldReader) GetPrivate() map[string]uint32 {
+	if m == nil {
+		return nil
+	}
+	return m.readPrivate()
+}
+
+func (m *ReservedAsMapFieldReader) readPrivate() map[string]uint32 {
+	if m.parsed[0]&(1 << 2) != 0 {
+		return m.dataPrivate
+	}
+	wField := FieldReservedAsMapField_Private
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataPrivate = entry
+	m.parsed[0] |= 1 << 2
+	return entry
+}
+
+func (m *ReservedAsMapFieldReader) GetClass() map[string]uint32 {
+	if m == nil {
+		return nil
+	}
+	return m.readClass()
+}
+
+func (m *ReservedAsMapFieldReader) readClass() map[string]uint32 {
+	if m.parsed[0]&(1 << 3) != 0 {
+		return m.dataClass
+	}
+	wField := FieldReservedAsMapField_Class
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataClass = entry
+	m.parsed[0] |= 1 << 3
+	return entry
+}
+
+func (m *ReservedAsMapFieldReader) GetInt() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readIf()
+	return m.readInt()
 }
 
-func (m *ReservedAsMapFieldReader) readIf() map[string]uint32 {
-	if m.parsedIf {
-		return m.dataIf
+func (m *ReservedAsMapFieldReader) readInt() map[string]uint32 {
+	if m.parsed[0]&(1 << 4) != 0 {
+		return m.dataInt
 	}
-	wOffset := m.offsetIf
+	wField := FieldReservedAsMapField_Int
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataIf = entry
-	m.parsedIf = true
+	m.dataInt = entry
+	m.parsed[0] |= 1 << 4
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetConst() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetVoid() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readConst()
+	return m.readVoid()
 }
 
-func (m *ReservedAsMapFieldReader) readConst() map[string]uint32 {
-	if m.parsedConst {
-		return m.dataConst
+func (m *ReservedAsMapFieldReader) readVoid() map[string]uint32 {
+	if m.parsed[0]&(1 << 5) != 0 {
+		return m.dataVoid
 	}
-	wOffset := m.offsetConst
+	wField := FieldReservedAsMapField_Void
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataConst = entry
-	m.parsedConst = true
+	m.dataVoid = entry
+	m.parsed[0] |= 1 << 5
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetPrivate() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetString_() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readPrivate()
+	return m.readString_()
 }
 
-func (m *ReservedAsMapFieldReader) readPrivate() map[string]uint32 {
-	if m.parsedPrivate {
-		return m.dataPrivate
+func (m *ReservedAsMapFieldReader) readString_() map[string]uint32 {
+	if m.parsed[0]&(1 << 6) != 0 {
+		return m.dataString_
 	}
-	wOffset := m.offsetPrivate
+	wField := FieldReservedAsMapField_String_
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataPrivate = entry
-	m.parsedPrivate = true
+	m.dataString_ = entry
+	m.parsed[0] |= 1 << 6
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetClass() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetPackage() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readClass()
+	return m.readPackage()
 }
 
-func (m *ReservedAsMapFieldReader) readClass() map[string]uint32 {
-	if m.parsedClass {
-		return m.dataClass
+func (m *ReservedAsMapFieldReader) readPackage() map[string]uint32 {
+	if m.parsed[0]&(1 << 7) != 0 {
+		return m.dataPackage
 	}
-	wOffset := m.offsetClass
+	wField := FieldReservedAsMapField_Package
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataClass = entry
-	m.parsedClass = true
+	m.dataPackage = entry
+	m.parsed[0] |= 1 << 7
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetInt() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetEnum() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readInt()
+	return m.readEnum()
 }
 
-func (m *ReservedAsMapFieldReader) readInt() map[string]uint32 {
-	if m.parsedInt {
-		return m.dataInt
+func (m *ReservedAsMapFieldReader) readEnum() map[string]uint32 {
+	if m.parsed[0]&(1 << 8) != 0 {
+		return m.dataEnum
 	}
-	wOffset := m.offsetInt
+	wField := FieldReservedAsMapField_Enum
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataInt = entry
-	m.parsedInt = true
+	m.dataEnum = entry
+	m.parsed[0] |= 1 << 8
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetVoid() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) GetNull() map[string]uint32 {
 	if m == nil {
 		return nil
 	}
-	return m.readVoid()
+	return m.readNull()
 }
 
-func (m *ReservedAsMapFieldReader) readVoid() map[string]uint32 {
-	if m.parsedVoid {
-		return m.dataVoid
+func (m *ReservedAsMapFieldReader) readNull() map[string]uint32 {
+	if m.parsed[0]&(1 << 9) != 0 {
+		return m.dataNull
 	}
-	wOffset := m.offsetVoid
+	wField := FieldReservedAsMapField_Null
+	
+	var entry map[string]uint32
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, uint32](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData uint32
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry uint32
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataVoid = entry
-	m.parsedVoid = true
+	m.dataNull = entry
+	m.parsed[0] |= 1 << 9
 	return entry
 }
 
-func (m *ReservedAsMapFieldReader) GetString() map[string]uint32 {
+func (m *ReservedAsMapFieldReader) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *ReservedAsMapFieldReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapField_If:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_If, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Const:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Const, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Private:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Private, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Class:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Class, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Int:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Int, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Void:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Void, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_String_:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_String_, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Package:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Package, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Enum:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Enum, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapField_Null:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Null, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *ReservedAsMapFieldReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *ReservedAsMapFieldReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = ReservedAsMapFieldReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapField_If:
+			if fields.Has(FieldReservedAsMapField_If) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_If, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Const:
+			if fields.Has(FieldReservedAsMapField_Const) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Const, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Private:
+			if fields.Has(FieldReservedAsMapField_Private) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Private, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Class:
+			if fields.Has(FieldReservedAsMapField_Class) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Class, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Int:
+			if fields.Has(FieldReservedAsMapField_Int) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Int, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Void:
+			if fields.Has(FieldReservedAsMapField_Void) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Void, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_String_:
+			if fields.Has(FieldReservedAsMapField_String_) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_String_, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Package:
+			if fields.Has(FieldReservedAsMapField_Package) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Package, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Enum:
+			if fields.Has(FieldReservedAsMapField_Enum) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Enum, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapField_Null:
+			if fields.Has(FieldReservedAsMapField_Null) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapField_Null, Offset: int32(offset), Wire: wire})
+			}
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ReservedAsMapFieldReader) ToStruct() *ReservedAsMapField {
 	if m == nil {
 		return nil
 	}
-	return m.readString()
+	res := gremlin.New[ReservedAsMapField](m.buf.Arena())
+	res.If = m.GetIf()
+	res.Const = m.GetConst()
+	res.Private = m.GetPrivate()
+	res.Class = m.GetClass()
+	res.Int = m.GetInt()
+	res.Void = m.GetVoid()
+	res.String_ = m.GetString_()
+	res.Package = m.GetPackage()
+	res.Enum = m.GetEnum()
+	res.Null = m.GetNull()
+
+	return res
 }
 
-func (m *ReservedAsMapFieldReader) readString() map[string]uint32 {
-	if m.parsedString {
-		return m.dataString
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *ReservedAsMapFieldReader) Freeze() {
+	if m == nil {
+		return
 	}
-	wOffset := m.offsetString
-	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
-	}
-	
-	m.dataString = entry
-	m.parsedString = true
-	return entry
+	m.readIf()
+	m.readConst()
+	m.readPrivate()
+	m.readClass()
+	m.readInt()
+	m.readVoid()
+	m.readString_()
+	m.readPackage()
+	m.readEnum()
+	m.readNull()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *ReservedAsMapFieldReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *ReservedAsMapFieldReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 0) != 0 {
+		if m.dataIf != nil {
+			rebased := make(map[string]uint32, len(m.dataIf))
+			for k, v := range m.dataIf {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataIf = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 1) != 0 {
+		if m.dataConst != nil {
+			rebased := make(map[string]uint32, len(m.dataConst))
+			for k, v := range m.dataConst {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataConst = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		if m.dataPrivate != nil {
+			rebased := make(map[string]uint32, len(m.dataPrivate))
+			for k, v := range m.dataPrivate {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataPrivate = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		if m.dataClass != nil {
+			rebased := make(map[string]uint32, len(m.dataClass))
+			for k, v := range m.dataClass {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataClass = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 4) != 0 {
+		if m.dataInt != nil {
+			rebased := make(map[string]uint32, len(m.dataInt))
+			for k, v := range m.dataInt {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataInt = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 5) != 0 {
+		if m.dataVoid != nil {
+			rebased := make(map[string]uint32, len(m.dataVoid))
+			for k, v := range m.dataVoid {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataVoid = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 6) != 0 {
+		if m.dataString_ != nil {
+			rebased := make(map[string]uint32, len(m.dataString_))
+			for k, v := range m.dataString_ {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataString_ = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 7) != 0 {
+		if m.dataPackage != nil {
+			rebased := make(map[string]uint32, len(m.dataPackage))
+			for k, v := range m.dataPackage {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataPackage = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 8) != 0 {
+		if m.dataEnum != nil {
+			rebased := make(map[string]uint32, len(m.dataEnum))
+			for k, v := range m.dataEnum {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataEnum = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 9) != 0 {
+		if m.dataNull != nil {
+			rebased := make(map[string]uint32, len(m.dataNull))
+			for k, v := range m.dataNull {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataNull = rebased
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *ReservedAsMapFieldReader) SourceBytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *ReservedAsMapFieldReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetIf(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"if\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetConst(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"const\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetPrivate(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"private\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetClass(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"class\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetVoid(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"void\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetString_(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"string\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetPackage(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"package\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetEnum(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"enum\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetNull(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"null\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(value[k]))
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *ReservedAsMapFieldReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
 }
 
-func (m *ReservedAsMapFieldReader) GetPackage() map[string]uint32 {
-	if m == nil {
-		return nil
+// TranscodeReservedAsMapFieldJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeReservedAsMapFieldJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeReservedAsMapFieldJSON(s, data); err != nil {
+		return err
 	}
-	return m.readPackage()
+	return s.Flush()
 }
 
-func (m *ReservedAsMapFieldReader) readPackage() map[string]uint32 {
-	if m.parsedPackage {
-		return m.dataPackage
-	}
-	wOffset := m.offsetPackage
-	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+// XXX_TranscodeReservedAsMapFieldJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeReservedAsMapFieldJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
 	}
-	
-	m.dataPackage = entry
-	m.parsedPackage = true
-	return entry
-}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
 
-func (m *ReservedAsMapFieldReader) GetEnum() map[string]uint32 {
-	if m == nil {
-		return nil
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireReservedAsMapField_If:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Const:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Private:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Class:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Int:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Void:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_String_:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Package:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Enum:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapField_Null:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
 	}
-	return m.readEnum()
-}
 
-func (m *ReservedAsMapFieldReader) readEnum() map[string]uint32 {
-	if m.parsedEnum {
-		return m.dataEnum
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapField_If:
+			if written[0]&(1 << 0) != 0 {
+				break
+			}
+			written[0] |= 1 << 0
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"if\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Const:
+			if written[0]&(1 << 1) != 0 {
+				break
+			}
+			written[0] |= 1 << 1
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"const\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Private:
+			if written[0]&(1 << 2) != 0 {
+				break
+			}
+			written[0] |= 1 << 2
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"private\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Class:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"class\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Int:
+			if written[0]&(1 << 4) != 0 {
+				break
+			}
+			written[0] |= 1 << 4
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Void:
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"void\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_String_:
+			if written[0]&(1 << 6) != 0 {
+				break
+			}
+			written[0] |= 1 << 6
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"string\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Package:
+			if written[0]&(1 << 7) != 0 {
+				break
+			}
+			written[0] |= 1 << 7
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"package\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Enum:
+			if written[0]&(1 << 8) != 0 {
+				break
+			}
+			written[0] |= 1 << 8
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"enum\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapField_Null:
+			if written[0]&(1 << 9) != 0 {
+				break
+			}
+			written[0] |= 1 << 9
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"null\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONUint(s.B, uint64(buf.ReadUint32(valueOffset)))
+				} else {
+					var mapValue uint32
+					s.B = gremlin.AppendJSONUint(s.B, uint64(mapValue))
+				}
+			}
+			s.B = append(s.B, '}')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
 	}
-	wOffset := m.offsetEnum
-	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+	if sep == '{' {
+		s.B = append(s.B, sep)
 	}
-	
-	m.dataEnum = entry
-	m.parsedEnum = true
-	return entry
+	s.B = append(s.B, '}')
+	return s.Check()
 }
 
-func (m *ReservedAsMapFieldReader) GetNull() map[string]uint32 {
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *ReservedAsMapFieldReader) XXX_WriteText(w *gremlin.TextWriter) {
 	if m == nil {
-		return nil
+		return
 	}
-	return m.readNull()
+	if value := m.GetIf(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("if")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetConst(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("const")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetPrivate(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("private")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetClass(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("class")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetVoid(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("void")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetString_(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("string")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetPackage(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("package")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetEnum(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("enum")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+	if value := m.GetNull(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("null")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(value[k]))
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *ReservedAsMapFieldReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *ReservedAsMapFieldReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *ReservedAsMapFieldReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
 }
 
-func (m *ReservedAsMapFieldReader) readNull() map[string]uint32 {
-	if m.parsedNull {
-		return m.dataNull
-	}
-	wOffset := m.offsetNull
-	
-	var entry = map[string]uint32{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData uint32
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry uint32
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadUint32(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
-	}
-	
-	m.dataNull = entry
-	m.parsedNull = true
-	return entry
+type ReservedAsMapField struct {
+	If	map[string]uint32	`json:"if,omitempty"`
+	Const	map[string]uint32	`json:"const,omitempty"`
+	Private	map[string]uint32	`json:"private,omitempty"`
+	Class	map[string]uint32	`json:"class,omitempty"`
+	Int	map[string]uint32	`json:"int,omitempty"`
+	Void	map[string]uint32	`json:"void,omitempty"`
+	String_	map[string]uint32	`json:"string,omitempty"`
+	Package	map[string]uint32	`json:"package,omitempty"`
+	Enum	map[string]uint32	`json:"enum,omitempty"`
+	Null	map[string]uint32	`json:"null,omitempty"`
 }
 
-func (m *ReservedAsMapFieldReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *ReservedAsMapField) Unmarshal(data []byte) error {
+	*s = ReservedAsMapField{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
 	offset := 0
-	for m.buf.HasNext(offset, 0) {
-		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
 		if err != nil {
 			return err
 		}
 
 		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
 		switch tag {
 		case wireReservedAsMapField_If:
-			m.offsetIf = append(m.offsetIf, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.If == nil {
+					s.If = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.If[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Const:
-			m.offsetConst = append(m.offsetConst, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Const == nil {
+					s.Const = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Const[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Private:
-			m.offsetPrivate = append(m.offsetPrivate, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Private == nil {
+					s.Private = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Private[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Class:
-			m.offsetClass = append(m.offsetClass, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Class == nil {
+					s.Class = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Class[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Int:
-			m.offsetInt = append(m.offsetInt, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int == nil {
+					s.Int = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Int[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Void:
-			m.offsetVoid = append(m.offsetVoid, offset)
-		case wireReservedAsMapField_String:
-			m.offsetString = append(m.offsetString, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Void == nil {
+					s.Void = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Void[mapKey] = mapValue
+			}
+		case wireReservedAsMapField_String_:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.String_ == nil {
+					s.String_ = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.String_[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Package:
-			m.offsetPackage = append(m.offsetPackage, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Package == nil {
+					s.Package = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Package[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Enum:
-			m.offsetEnum = append(m.offsetEnum, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Enum == nil {
+					s.Enum = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Enum[mapKey] = mapValue
+			}
 		case wireReservedAsMapField_Null:
-			m.offsetNull = append(m.offsetNull, offset)
-		}
-
-		offset, err = m.buf.SkipData(offset, wire)
-		if err != nil {
-			return err
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Null == nil {
+					s.Null = make(map[string]uint32, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue uint32
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadUint32(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.Null[mapKey] = mapValue
+			}
 		}
+		offset = end
 	}
 	return nil
 }
 
-func (m *ReservedAsMapFieldReader) ToStruct() *ReservedAsMapField {
-	if m == nil {
-		return nil
-	}
-	res := &ReservedAsMapField{}
-	res.If = m.GetIf()
-	res.Const = m.GetConst()
-	res.Private = m.GetPrivate()
-	res.Class = m.GetClass()
-	res.Int = m.GetInt()
-	res.Void = m.GetVoid()
-	res.String = m.GetString()
-	res.Package = m.GetPackage()
-	res.Enum = m.GetEnum()
-	res.Null = m.GetNull()
-
-	return res
-}
-
-func (s *ReservedAsMapFieldReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
-}
-
-type ReservedAsMapField struct {
-	If	map[string]uint32	`json:"if,omitempty"`
-	Const	map[string]uint32	`json:"const,omitempty"`
-	Private	map[string]uint32	`json:"private,omitempty"`
-	Class	map[string]uint32	`json:"class,omitempty"`
-	Int	map[string]uint32	`json:"int,omitempty"`
-	Void	map[string]uint32	`json:"void,omitempty"`
-	String	map[string]uint32	`json:"string,omitempty"`
-	Package	map[string]uint32	`json:"package,omitempty"`
-	Enum	map[string]uint32	`json:"enum,omitempty"`
-	Null	map[string]uint32	`json:"null,omitempty"`
-}
-
 func (s *ReservedAsMapField) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -2701,14 +9524,14 @@ func (s *ReservedAsMapField) MarshalTo(res *gremlin.Writer) {
 			res.AppendUint32(2, v)
 		}
 	}
-	if len(s.String) > 0 {
-		for k, v := range s.String {
+	if len(s.String_) > 0 {
+		for k, v := range s.String_ {
 			var keySize, valueSize int
 			keySize = gremlin.SizeString(k)
 			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
 			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
 			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireReservedAsMapField_String, mapEntrySize)
+			res.AppendBytesTag(wireReservedAsMapField_String_, mapEntrySize)
 			res.AppendString(1, k)
 			res.AppendUint32(2, v)
 		}
@@ -2780,9 +9603,9 @@ func (s *ReservedAsMapField) Copy() *ReservedAsMapField {
 	for k, v := range s.Void {
 		res.Void[k] = v
 	}
-	res.String = make(map[string]uint32, len(s.String))
-	for k, v := range s.String {
-		res.String[k] = v
+	res.String_ = make(map[string]uint32, len(s.String_))
+	for k, v := range s.String_ {
+		res.String_[k] = v
 	}
 	res.Package = make(map[string]uint32, len(s.Package))
 	for k, v := range s.Package {
@@ -2892,71 +9715,1052 @@ func (s *ReservedAsMapField) XXX_PbContentSize() int {
 			var mapEntrySize = keySize + valueSize
 			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Void) + gremlin.SizeUint64(uint64(mapEntrySize))
 		}
-		
-		size += entrySize
+		
+		size += entrySize
+	}
+
+	if len(s.String_) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.String_ {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_String_) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	if len(s.Package) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Package {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Package) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	if len(s.Enum) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Enum {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Enum) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	if len(s.Null) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Null {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Null) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *ReservedAsMapField) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if len(s.If) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"if\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.If) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.If[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Const) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"const\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Const) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Const[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Private) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"private\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Private) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Private[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Class) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"class\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Class) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Class[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Int[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Void) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"void\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Void) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Void[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.String_) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"string\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.String_) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.String_[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Package) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"package\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Package) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Package[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Enum) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"enum\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Enum) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Enum[k]))
+		}
+		b = append(b, '}')
+	}
+	if len(s.Null) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"null\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Null) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONUint(b, uint64(s.Null[k]))
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *ReservedAsMapField) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *ReservedAsMapField) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *ReservedAsMapField) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = ReservedAsMapField{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "if":
+			if s.If == nil {
+				s.If = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.If[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "const":
+			if s.Const == nil {
+				s.Const = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Const[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "private":
+			if s.Private == nil {
+				s.Private = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Private[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "class":
+			if s.Class == nil {
+				s.Class = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Class[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int":
+			if s.Int == nil {
+				s.Int = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Int[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "void":
+			if s.Void == nil {
+				s.Void = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Void[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string":
+			if s.String_ == nil {
+				s.String_ = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.String_[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "package":
+			if s.Package == nil {
+				s.Package = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Package[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "enum":
+			if s.Enum == nil {
+				s.Enum = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Enum[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "null":
+			if s.Null == nil {
+				s.Null = make(map[string]uint32)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue uint32
+				value, err := d.ReadUint32()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.Null[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *ReservedAsMapField) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if len(s.If) > 0 {
+		for _, k := range gremlin.SortedKeys(s.If) {
+			w.WriteName("if")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.If[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Const) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Const) {
+			w.WriteName("const")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Const[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Private) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Private) {
+			w.WriteName("private")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Private[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Class) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Class) {
+			w.WriteName("class")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Class[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.Int) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int) {
+			w.WriteName("int")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Int[k]))
+			w.EndMessage()
+		}
 	}
-
-	if len(s.String) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.String {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_String) + gremlin.SizeUint64(uint64(mapEntrySize))
+	if len(s.Void) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Void) {
+			w.WriteName("void")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Void[k]))
+			w.EndMessage()
+		}
+	}
+	if len(s.String_) > 0 {
+		for _, k := range gremlin.SortedKeys(s.String_) {
+			w.WriteName("string")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.String_[k]))
+			w.EndMessage()
 		}
-		
-		size += entrySize
 	}
-
 	if len(s.Package) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Package {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Package) + gremlin.SizeUint64(uint64(mapEntrySize))
+		for _, k := range gremlin.SortedKeys(s.Package) {
+			w.WriteName("package")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Package[k]))
+			w.EndMessage()
 		}
-		
-		size += entrySize
 	}
-
 	if len(s.Enum) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Enum {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Enum) + gremlin.SizeUint64(uint64(mapEntrySize))
+		for _, k := range gremlin.SortedKeys(s.Enum) {
+			w.WriteName("enum")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Enum[k]))
+			w.EndMessage()
 		}
-		
-		size += entrySize
 	}
-
 	if len(s.Null) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Null {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeUint32(v)
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapField_Null) + gremlin.SizeUint64(uint64(mapEntrySize))
+		for _, k := range gremlin.SortedKeys(s.Null) {
+			w.WriteName("null")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteUint(uint64(s.Null[k]))
+			w.EndMessage()
 		}
-		
-		size += entrySize
 	}
+}
 
-	return size
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *ReservedAsMapField) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *ReservedAsMapField) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *ReservedAsMapField) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *ReservedAsMapField) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *ReservedAsMapField) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = ReservedAsMapField{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "if":
+			if s.If == nil {
+				s.If = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.If[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "const":
+			if s.Const == nil {
+				s.Const = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Const[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "private":
+			if s.Private == nil {
+				s.Private = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Private[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "class":
+			if s.Class == nil {
+				s.Class = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Class[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int":
+			if s.Int == nil {
+				s.Int = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "void":
+			if s.Void == nil {
+				s.Void = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Void[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string":
+			if s.String_ == nil {
+				s.String_ = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.String_[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "package":
+			if s.Package == nil {
+				s.Package = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Package[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "enum":
+			if s.Enum == nil {
+				s.Enum = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Enum[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "null":
+			if s.Null == nil {
+				s.Null = make(map[string]uint32)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue uint32
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadUint32()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Null[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *ReservedAsMapField) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorReservedAsMapField
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *ReservedAsMapField) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireReservedAsMapField_If:
+		return s.If
+	case wireReservedAsMapField_Const:
+		return s.Const
+	case wireReservedAsMapField_Private:
+		return s.Private
+	case wireReservedAsMapField_Class:
+		return s.Class
+	case wireReservedAsMapField_Int:
+		return s.Int
+	case wireReservedAsMapField_Void:
+		return s.Void
+	case wireReservedAsMapField_String_:
+		return s.String_
+	case wireReservedAsMapField_Package:
+		return s.Package
+	case wireReservedAsMapField_Enum:
+		return s.Enum
+	case wireReservedAsMapField_Null:
+		return s.Null
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *ReservedAsMapField) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireReservedAsMapField_If:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.If = v
+	case wireReservedAsMapField_Const:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Const = v
+	case wireReservedAsMapField_Private:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Private = v
+	case wireReservedAsMapField_Class:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Class = v
+	case wireReservedAsMapField_Int:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Int = v
+	case wireReservedAsMapField_Void:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Void = v
+	case wireReservedAsMapField_String_:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.String_ = v
+	case wireReservedAsMapField_Package:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Package = v
+	case wireReservedAsMapField_Enum:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Enum = v
+	case wireReservedAsMapField_Null:
+		v, ok := value.(map[string]uint32)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapField, number, value)
+		}
+		s.Null = v
+	default:
+		return gremlin.UnknownFieldError(descriptorReservedAsMapField, number)
+	}
+	return nil
+}
+
+// ReservedAsMapFieldPaths are the field mask paths of map_test.ReservedAsMapField.
+var ReservedAsMapFieldPaths = XXX_NewReservedAsMapFieldPathNames("")
+
+type ReservedAsMapFieldPathNames struct {
+	path string
+	If string
+	Const string
+	Private string
+	Class string
+	Int string
+	Void string
+	String_ string
+	Package string
+	Enum string
+	Null string
+}
+
+func XXX_NewReservedAsMapFieldPathNames(path string) ReservedAsMapFieldPathNames {
+	return ReservedAsMapFieldPathNames{
+		path: path,
+		If: gremlin.JoinPath(path, "if"),
+		Const: gremlin.JoinPath(path, "const"),
+		Private: gremlin.JoinPath(path, "private"),
+		Class: gremlin.JoinPath(path, "class"),
+		Int: gremlin.JoinPath(path, "int"),
+		Void: gremlin.JoinPath(path, "void"),
+		String_: gremlin.JoinPath(path, "string"),
+		Package: gremlin.JoinPath(path, "package"),
+		Enum: gremlin.JoinPath(path, "enum"),
+		Null: gremlin.JoinPath(path, "null"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p ReservedAsMapFieldPathNames) Path() string {
+	return p.path
 }
 
 const (
@@ -2966,14 +10770,94 @@ const (
 	wireReservedAsMapFieldWithEnumValue_Class gremlin.ProtoWireNumber = 4
 	wireReservedAsMapFieldWithEnumValue_Int gremlin.ProtoWireNumber = 5
 	wireReservedAsMapFieldWithEnumValue_Void gremlin.ProtoWireNumber = 6
-	wireReservedAsMapFieldWithEnumValue_String gremlin.ProtoWireNumber = 7
+	wireReservedAsMapFieldWithEnumValue_String_ gremlin.ProtoWireNumber = 7
 	wireReservedAsMapFieldWithEnumValue_Package gremlin.ProtoWireNumber = 8
 	wireReservedAsMapFieldWithEnumValue_Enum gremlin.ProtoWireNumber = 9
 	wireReservedAsMapFieldWithEnumValue_Null gremlin.ProtoWireNumber = 10
 )
 
+const (
+	FieldReservedAsMapFieldWithEnumValue_If gremlin.FieldIndex = 0
+	FieldReservedAsMapFieldWithEnumValue_Const gremlin.FieldIndex = 1
+	FieldReservedAsMapFieldWithEnumValue_Private gremlin.FieldIndex = 2
+	FieldReservedAsMapFieldWithEnumValue_Class gremlin.FieldIndex = 3
+	FieldReservedAsMapFieldWithEnumValue_Int gremlin.FieldIndex = 4
+	FieldReservedAsMapFieldWithEnumValue_Void gremlin.FieldIndex = 5
+	FieldReservedAsMapFieldWithEnumValue_String_ gremlin.FieldIndex = 6
+	FieldReservedAsMapFieldWithEnumValue_Package gremlin.FieldIndex = 7
+	FieldReservedAsMapFieldWithEnumValue_Enum gremlin.FieldIndex = 8
+	FieldReservedAsMapFieldWithEnumValue_Null gremlin.FieldIndex = 9
+)
+
+var descriptorReservedAsMapFieldWithEnumValue = &gremlin.MessageDescriptor{
+	FullName: "map_test.ReservedAsMapFieldWithEnumValue",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "if", JSONName: "if", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "const", JSONName: "const", Number: 2, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "private", JSONName: "private", Number: 3, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "class", JSONName: "class", Number: 4, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "int", JSONName: "int", Number: 5, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "void", JSONName: "void", Number: 6, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "string", JSONName: "string", Number: 7, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "package", JSONName: "package", Number: 8, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "enum", JSONName: "enum", Number: 9, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+		{Name: "null", JSONName: "null", Number: 10, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindEnum, Label: gremlin.LabelOptional, TypeName: "map_test.ReservedAsMapFieldWithEnumValue.SampleEnum"},
+		},
+	},
+	Enums: []*gremlin.EnumDescriptor{descriptorReservedAsMapFieldWithEnumValue_SampleEnum},
+	New: func() gremlin.ProtoMessage {
+		return &ReservedAsMapFieldWithEnumValue{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewReservedAsMapFieldWithEnumValueReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorReservedAsMapFieldWithEnumValue)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *ReservedAsMapFieldWithEnumValueReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorReservedAsMapFieldWithEnumValue
+}
+
 type ReservedAsMapFieldWithEnumValueReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataIf     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataConst     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
@@ -2981,32 +10865,11 @@ type ReservedAsMapFieldWithEnumValueReader struct {
 	dataClass     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataInt     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataVoid     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
-	dataString     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	dataString_     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataPackage     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataEnum     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 	dataNull     map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
 
-	offsetIf   []int
-	offsetConst   []int
-	offsetPrivate   []int
-	offsetClass   []int
-	offsetInt   []int
-	offsetVoid   []int
-	offsetString   []int
-	offsetPackage   []int
-	offsetEnum   []int
-	offsetNull   []int
-
-	parsedIf   bool
-	parsedConst   bool
-	parsedPrivate   bool
-	parsedClass   bool
-	parsedInt   bool
-	parsedVoid   bool
-	parsedString   bool
-	parsedPackage   bool
-	parsedEnum   bool
-	parsedNull   bool
 }
 
 func NewReservedAsMapFieldWithEnumValueReader() *ReservedAsMapFieldWithEnumValueReader {
@@ -3021,678 +10884,2427 @@ func (m *ReservedAsMapFieldWithEnumValueReader) GetIf() map[string]ReservedAsMap
 }
 
 func (m *ReservedAsMapFieldWithEnumValueReader) readIf() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedIf {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataIf
 	}
-	wOffset := m.offsetIf
+	wField := FieldReservedAsMapFieldWithEnumValue_If
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataIf = entry
-	m.parsedIf = true
+	m.parsed[0] |= 1 << 0
+	return entry
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) GetConst() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m == nil {
+		return nil
+	}
+	return m.readConst()
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) readConst() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 1) != 0 {
+		return m.dataConst
+	}
+	wField := FieldReservedAsMapFieldWithEnumValue_Const
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataConst = entry
+	m.parsed[0] |= 1 << 1
+	return entry
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) GetPrivate() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m == nil {
+		return nil
+	}
+	return m.readPrivate()
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) readPrivate() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 2) != 0 {
+		return m.dataPrivate
+	}
+	wField := FieldReservedAsMapFieldWithEnumValue_Private
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataPrivate = entry
+	m.parsed[0] |= 1 << 2
+	return entry
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) GetClass() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m == nil {
+		return nil
+	}
+	return m.readClass()
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) readClass() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 3) != 0 {
+		return m.dataClass
+	}
+	wField := FieldReservedAsMapFieldWithEnumValue_Class
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataClass = entry
+	m.parsed[0] |= 1 << 3
+	return entry
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) GetInt() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m == nil {
+		return nil
+	}
+	return m.readInt()
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) readInt() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 4) != 0 {
+		return m.dataInt
+	}
+	wField := FieldReservedAsMapFieldWithEnumValue_Int
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataInt = entry
+	m.parsed[0] |= 1 << 4
+	return entry
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) GetVoid() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m == nil {
+		return nil
+	}
+	return m.readVoid()
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) readVoid() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 5) != 0 {
+		return m.dataVoid
+	}
+	wField := FieldReservedAsMapFieldWithEnumValue_Void
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
+	
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
+	}
+	
+	m.dataVoid = entry
+	m.parsed[0] |= 1 << 5
 	return entry
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetConst() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+func (m *ReservedAsMapFieldWithEnumValueReader) GetString_() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
 	if m == nil {
 		return nil
 	}
-	return m.readConst()
+	return m.readString_()
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readConst() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedConst {
-		return m.dataConst
+func (m *ReservedAsMapFieldWithEnumValueReader) readString_() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 6) != 0 {
+		return m.dataString_
 	}
-	wOffset := m.offsetConst
+	wField := FieldReservedAsMapFieldWithEnumValue_String_
 	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
+	
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataConst = entry
-	m.parsedConst = true
+	m.dataString_ = entry
+	m.parsed[0] |= 1 << 6
 	return entry
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetPrivate() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+func (m *ReservedAsMapFieldWithEnumValueReader) GetPackage() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
 	if m == nil {
 		return nil
 	}
-	return m.readPrivate()
+	return m.readPackage()
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readPrivate() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedPrivate {
-		return m.dataPrivate
+func (m *ReservedAsMapFieldWithEnumValueReader) readPackage() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 7) != 0 {
+		return m.dataPackage
 	}
-	wOffset := m.offsetPrivate
+	wField := FieldReservedAsMapFieldWithEnumValue_Package
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataPrivate = entry
-	m.parsedPrivate = true
+	m.dataPackage = entry
+	m.parsed[0] |= 1 << 7
 	return entry
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetClass() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+func (m *ReservedAsMapFieldWithEnumValueReader) GetEnum() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
 	if m == nil {
 		return nil
 	}
-	return m.readClass()
+	return m.readEnum()
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readClass() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedClass {
-		return m.dataClass
+func (m *ReservedAsMapFieldWithEnumValueReader) readEnum() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 8) != 0 {
+		return m.dataEnum
 	}
-	wOffset := m.offsetClass
+	wField := FieldReservedAsMapFieldWithEnumValue_Enum
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataClass = entry
-	m.parsedClass = true
+	m.dataEnum = entry
+	m.parsed[0] |= 1 << 8
 	return entry
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetInt() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+func (m *ReservedAsMapFieldWithEnumValueReader) GetNull() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
 	if m == nil {
 		return nil
 	}
-	return m.readInt()
+	return m.readNull()
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readInt() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedInt {
-		return m.dataInt
+func (m *ReservedAsMapFieldWithEnumValueReader) readNull() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+	if m.parsed[0]&(1 << 9) != 0 {
+		return m.dataNull
 	}
-	wOffset := m.offsetInt
+	wField := FieldReservedAsMapFieldWithEnumValue_Null
+	
+	var entry map[string]ReservedAsMapFieldWithEnumValue_SampleEnum
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, ReservedAsMapFieldWithEnumValue_SampleEnum](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
+					var valueEntrySize int
+					if wOffset > 0 {
+						rawEntry, size := m.buf.SizedReadInt32(wOffset)
+						valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
+						valueEntrySize = size
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
-	m.dataInt = entry
-	m.parsedInt = true
+	m.dataNull = entry
+	m.parsed[0] |= 1 << 9
 	return entry
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetVoid() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+func (m *ReservedAsMapFieldWithEnumValueReader) Unmarshal(data []byte) error {
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *ReservedAsMapFieldWithEnumValueReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapFieldWithEnumValue_If:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_If, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Const:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Const, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Private:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Private, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Class:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Class, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Int:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Int, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Void:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Void, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_String_:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_String_, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Package:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Package, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Enum:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Enum, Offset: int32(offset), Wire: wire})
+		case wireReservedAsMapFieldWithEnumValue_Null:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Null, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *ReservedAsMapFieldWithEnumValueReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *ReservedAsMapFieldWithEnumValueReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = ReservedAsMapFieldWithEnumValueReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapFieldWithEnumValue_If:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_If) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_If, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Const:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Const) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Const, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Private:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Private) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Private, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Class:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Class) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Class, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Int:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Int) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Int, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Void:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Void) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Void, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_String_:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_String_) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_String_, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Package:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Package) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Package, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Enum:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Enum) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Enum, Offset: int32(offset), Wire: wire})
+			}
+		case wireReservedAsMapFieldWithEnumValue_Null:
+			if fields.Has(FieldReservedAsMapFieldWithEnumValue_Null) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldReservedAsMapFieldWithEnumValue_Null, Offset: int32(offset), Wire: wire})
+			}
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) ToStruct() *ReservedAsMapFieldWithEnumValue {
 	if m == nil {
 		return nil
 	}
-	return m.readVoid()
+	res := gremlin.New[ReservedAsMapFieldWithEnumValue](m.buf.Arena())
+	res.If = m.GetIf()
+	res.Const = m.GetConst()
+	res.Private = m.GetPrivate()
+	res.Class = m.GetClass()
+	res.Int = m.GetInt()
+	res.Void = m.GetVoid()
+	res.String_ = m.GetString_()
+	res.Package = m.GetPackage()
+	res.Enum = m.GetEnum()
+	res.Null = m.GetNull()
+
+	return res
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readVoid() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedVoid {
-		return m.dataVoid
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *ReservedAsMapFieldWithEnumValueReader) Freeze() {
+	if m == nil {
+		return
 	}
-	wOffset := m.offsetVoid
-	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+	m.readIf()
+	m.readConst()
+	m.readPrivate()
+	m.readClass()
+	m.readInt()
+	m.readVoid()
+	m.readString_()
+	m.readPackage()
+	m.readEnum()
+	m.readNull()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *ReservedAsMapFieldWithEnumValueReader) Detach() {
+	if m == nil {
+		return
 	}
-	
-	m.dataVoid = entry
-	m.parsedVoid = true
-	return entry
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetString() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *ReservedAsMapFieldWithEnumValueReader) XXX_Rebase(d *gremlin.Detacher) {
 	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 0) != 0 {
+		if m.dataIf != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataIf))
+			for k, v := range m.dataIf {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataIf = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 1) != 0 {
+		if m.dataConst != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataConst))
+			for k, v := range m.dataConst {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataConst = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 2) != 0 {
+		if m.dataPrivate != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataPrivate))
+			for k, v := range m.dataPrivate {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataPrivate = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 3) != 0 {
+		if m.dataClass != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataClass))
+			for k, v := range m.dataClass {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataClass = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 4) != 0 {
+		if m.dataInt != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataInt))
+			for k, v := range m.dataInt {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataInt = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 5) != 0 {
+		if m.dataVoid != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataVoid))
+			for k, v := range m.dataVoid {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataVoid = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 6) != 0 {
+		if m.dataString_ != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataString_))
+			for k, v := range m.dataString_ {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataString_ = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 7) != 0 {
+		if m.dataPackage != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataPackage))
+			for k, v := range m.dataPackage {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataPackage = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 8) != 0 {
+		if m.dataEnum != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataEnum))
+			for k, v := range m.dataEnum {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataEnum = rebased
+		}
+	}
+	if m.parsed[0]&(1 << 9) != 0 {
+		if m.dataNull != nil {
+			rebased := make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(m.dataNull))
+			for k, v := range m.dataNull {
+				k = d.RebaseString(k)
+				rebased[k] = v
+			}
+			m.dataNull = rebased
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
+func (s *ReservedAsMapFieldWithEnumValueReader) SourceBytes() []byte {
+	if s == nil {
 		return nil
 	}
-	return m.readString()
-}
+	return s.buf.Bytes()
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *ReservedAsMapFieldWithEnumValueReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetIf(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"if\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetConst(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"const\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetPrivate(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"private\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetClass(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"class\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetInt(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetVoid(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"void\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetString_(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"string\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetPackage(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"package\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetEnum(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"enum\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if value := m.GetNull(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"null\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(value[k]), value[k].String())
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *ReservedAsMapFieldWithEnumValueReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeReservedAsMapFieldWithEnumValueJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeReservedAsMapFieldWithEnumValueJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeReservedAsMapFieldWithEnumValueJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeReservedAsMapFieldWithEnumValueJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeReservedAsMapFieldWithEnumValueJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireReservedAsMapFieldWithEnumValue_If:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Const:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Private:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Class:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Int:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Void:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_String_:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Package:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Enum:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		case wireReservedAsMapFieldWithEnumValue_Null:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
+	}
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readString() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedString {
-		return m.dataString
-	}
-	wOffset := m.offsetString
-	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
-	}
-	
-	m.dataString = entry
-	m.parsedString = true
-	return entry
-}
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetPackage() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m == nil {
-		return nil
-	}
-	return m.readPackage()
-}
+		offset += tagSize
+		switch tag {
+		case wireReservedAsMapFieldWithEnumValue_If:
+			if written[0]&(1 << 0) != 0 {
+				break
+			}
+			written[0] |= 1 << 0
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"if\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Const:
+			if written[0]&(1 << 1) != 0 {
+				break
+			}
+			written[0] |= 1 << 1
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"const\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Private:
+			if written[0]&(1 << 2) != 0 {
+				break
+			}
+			written[0] |= 1 << 2
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"private\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Class:
+			if written[0]&(1 << 3) != 0 {
+				break
+			}
+			written[0] |= 1 << 3
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"class\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Int:
+			if written[0]&(1 << 4) != 0 {
+				break
+			}
+			written[0] |= 1 << 4
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"int\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Void:
+			if written[0]&(1 << 5) != 0 {
+				break
+			}
+			written[0] |= 1 << 5
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"void\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_String_:
+			if written[0]&(1 << 6) != 0 {
+				break
+			}
+			written[0] |= 1 << 6
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"string\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Package:
+			if written[0]&(1 << 7) != 0 {
+				break
+			}
+			written[0] |= 1 << 7
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"package\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Enum:
+			if written[0]&(1 << 8) != 0 {
+				break
+			}
+			written[0] |= 1 << 8
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"enum\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		case wireReservedAsMapFieldWithEnumValue_Null:
+			if written[0]&(1 << 9) != 0 {
+				break
+			}
+			written[0] |= 1 << 9
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"null\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					enumValue := buf.ReadInt32(valueOffset)
+					s.B = gremlin.AppendJSONEnum(s.B, enumValue, ReservedAsMapFieldWithEnumValue_SampleEnum(enumValue).String())
+				} else {
+					var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+					s.B = gremlin.AppendJSONEnum(s.B, int32(mapValue), mapValue.String())
+				}
+			}
+			s.B = append(s.B, '}')
+		}
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readPackage() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedPackage {
-		return m.dataPackage
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
 	}
-	wOffset := m.offsetPackage
-	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+	if sep == '{' {
+		s.B = append(s.B, sep)
 	}
-	
-	m.dataPackage = entry
-	m.parsedPackage = true
-	return entry
+	s.B = append(s.B, '}')
+	return s.Check()
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetEnum() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *ReservedAsMapFieldWithEnumValueReader) XXX_WriteText(w *gremlin.TextWriter) {
 	if m == nil {
-		return nil
+		return
 	}
-	return m.readEnum()
+	if value := m.GetIf(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("if")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetConst(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("const")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetPrivate(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("private")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetClass(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("class")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetInt(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("int")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetVoid(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("void")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetString_(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("string")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetPackage(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("package")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetEnum(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("enum")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+	if value := m.GetNull(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("null")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(value[k]), value[k].String())
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *ReservedAsMapFieldWithEnumValueReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *ReservedAsMapFieldWithEnumValueReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *ReservedAsMapFieldWithEnumValueReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readEnum() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedEnum {
-		return m.dataEnum
-	}
-	wOffset := m.offsetEnum
-	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
-	}
-	
-	m.dataEnum = entry
-	m.parsedEnum = true
-	return entry
+type ReservedAsMapFieldWithEnumValue struct {
+	If	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"if,omitempty"`
+	Const	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"const,omitempty"`
+	Private	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"private,omitempty"`
+	Class	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"class,omitempty"`
+	Int	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"int,omitempty"`
+	Void	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"void,omitempty"`
+	String_	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"string,omitempty"`
+	Package	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"package,omitempty"`
+	Enum	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"enum,omitempty"`
+	Null	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"null,omitempty"`
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) GetNull() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m == nil {
-		return nil
-	}
-	return m.readNull()
-}
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *ReservedAsMapFieldWithEnumValue) Unmarshal(data []byte) error {
+	*s = ReservedAsMapFieldWithEnumValue{}
 
-func (m *ReservedAsMapFieldWithEnumValueReader) readNull() map[string]ReservedAsMapFieldWithEnumValue_SampleEnum {
-	if m.parsedNull {
-		return m.dataNull
-	}
-	wOffset := m.offsetNull
-	
-	var entry = map[string]ReservedAsMapFieldWithEnumValue_SampleEnum{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
-	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData ReservedAsMapFieldWithEnumValue_SampleEnum
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry ReservedAsMapFieldWithEnumValue_SampleEnum
-				var valueEntrySize int
-				if wOffset > 0 {
-					rawEntry, size := m.buf.SizedReadInt32(wOffset)
-					valueEntry = ReservedAsMapFieldWithEnumValue_SampleEnum(rawEntry)
-					valueEntrySize = size
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
 	}
-	
-	m.dataNull = entry
-	m.parsedNull = true
-	return entry
-}
-
-func (m *ReservedAsMapFieldWithEnumValueReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
 	offset := 0
-	for m.buf.HasNext(offset, 0) {
-		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
 		if err != nil {
 			return err
 		}
 
 		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
 		switch tag {
 		case wireReservedAsMapFieldWithEnumValue_If:
-			m.offsetIf = append(m.offsetIf, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.If == nil {
+					s.If = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.If[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Const:
-			m.offsetConst = append(m.offsetConst, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Const == nil {
+					s.Const = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Const[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Private:
-			m.offsetPrivate = append(m.offsetPrivate, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Private == nil {
+					s.Private = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Private[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Class:
-			m.offsetClass = append(m.offsetClass, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Class == nil {
+					s.Class = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Class[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Int:
-			m.offsetInt = append(m.offsetInt, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Int == nil {
+					s.Int = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Int[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Void:
-			m.offsetVoid = append(m.offsetVoid, offset)
-		case wireReservedAsMapFieldWithEnumValue_String:
-			m.offsetString = append(m.offsetString, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Void == nil {
+					s.Void = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Void[mapKey] = mapValue
+			}
+		case wireReservedAsMapFieldWithEnumValue_String_:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.String_ == nil {
+					s.String_ = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.String_[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Package:
-			m.offsetPackage = append(m.offsetPackage, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Package == nil {
+					s.Package = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Package[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Enum:
-			m.offsetEnum = append(m.offsetEnum, offset)
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Enum == nil {
+					s.Enum = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Enum[mapKey] = mapValue
+			}
 		case wireReservedAsMapFieldWithEnumValue_Null:
-			m.offsetNull = append(m.offsetNull, offset)
-		}
-
-		offset, err = m.buf.SkipData(offset, wire)
-		if err != nil {
-			return err
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.Null == nil {
+					s.Null = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.VarIntType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(buf.ReadInt32(entryOffset))
+					}
+					entryOffset = entryNext
+				}
+				s.Null[mapKey] = mapValue
+			}
 		}
+		offset = end
 	}
 	return nil
 }
 
-func (m *ReservedAsMapFieldWithEnumValueReader) ToStruct() *ReservedAsMapFieldWithEnumValue {
-	if m == nil {
-		return nil
-	}
-	res := &ReservedAsMapFieldWithEnumValue{}
-	res.If = m.GetIf()
-	res.Const = m.GetConst()
-	res.Private = m.GetPrivate()
-	res.Class = m.GetClass()
-	res.Int = m.GetInt()
-	res.Void = m.GetVoid()
-	res.String = m.GetString()
-	res.Package = m.GetPackage()
-	res.Enum = m.GetEnum()
-	res.Null = m.GetNull()
-
-	return res
-}
-
-func (s *ReservedAsMapFieldWithEnumValueReader) SourceBytes() []byte {
-	if s == nil {
-		return nil
-	}
-	return s.buf.Bytes()
-}
-
-type ReservedAsMapFieldWithEnumValue struct {
-	If	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"if,omitempty"`
-	Const	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"const,omitempty"`
-	Private	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"private,omitempty"`
-	Class	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"class,omitempty"`
-	Int	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"int,omitempty"`
-	Void	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"void,omitempty"`
-	String	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"string,omitempty"`
-	Package	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"package,omitempty"`
-	Enum	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"enum,omitempty"`
-	Null	map[string]ReservedAsMapFieldWithEnumValue_SampleEnum	`json:"null,omitempty"`
-}
-
 func (s *ReservedAsMapFieldWithEnumValue) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -3783,14 +13395,14 @@ func (s *ReservedAsMapFieldWithEnumValue) MarshalTo(res *gremlin.Writer) {
 			res.AppendInt32(2, int32(v))
 		}
 	}
-	if len(s.String) > 0 {
-		for k, v := range s.String {
+	if len(s.String_) > 0 {
+		for k, v := range s.String_ {
 			var keySize, valueSize int
 			keySize = gremlin.SizeString(k)
 			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
 			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
 			mapEntrySize := keySize + valueSize
-			res.AppendBytesTag(wireReservedAsMapFieldWithEnumValue_String, mapEntrySize)
+			res.AppendBytesTag(wireReservedAsMapFieldWithEnumValue_String_, mapEntrySize)
 			res.AppendString(1, k)
 			res.AppendInt32(2, int32(v))
 		}
@@ -3862,9 +13474,9 @@ func (s *ReservedAsMapFieldWithEnumValue) Copy() *ReservedAsMapFieldWithEnumValu
 	for k, v := range s.Void {
 		res.Void[k] = v
 	}
-	res.String = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(s.String))
-	for k, v := range s.String {
-		res.String[k] = v
+	res.String_ = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(s.String_))
+	for k, v := range s.String_ {
+		res.String_[k] = v
 	}
 	res.Package = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum, len(s.Package))
 	for k, v := range s.Package {
@@ -3978,16 +13590,16 @@ func (s *ReservedAsMapFieldWithEnumValue) XXX_PbContentSize() int {
 		size += entrySize
 	}
 
-	if len(s.String) > 0 {
+	if len(s.String_) > 0 {
 		var entrySize = 0
 		entrySize = 0
-		for k, v := range s.String {
+		for k, v := range s.String_ {
 			var keySize, valueSize int
 			keySize = gremlin.SizeString(k)
 			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
 			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
 			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapFieldWithEnumValue_String) + gremlin.SizeUint64(uint64(mapEntrySize))
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapFieldWithEnumValue_String_) + gremlin.SizeUint64(uint64(mapEntrySize))
 		}
 		
 		size += entrySize
@@ -4019,40 +13631,1052 @@ func (s *ReservedAsMapFieldWithEnumValue) XXX_PbContentSize() int {
 			var mapEntrySize = keySize + valueSize
 			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapFieldWithEnumValue_Enum) + gremlin.SizeUint64(uint64(mapEntrySize))
 		}
-		
-		size += entrySize
+		
+		size += entrySize
+	}
+
+	if len(s.Null) > 0 {
+		var entrySize = 0
+		entrySize = 0
+		for k, v := range s.Null {
+			var keySize, valueSize int
+			keySize = gremlin.SizeString(k)
+			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
+			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
+			var mapEntrySize = keySize + valueSize
+			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapFieldWithEnumValue_Null) + gremlin.SizeUint64(uint64(mapEntrySize))
+		}
+		
+		size += entrySize
+	}
+
+	return size
+}
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *ReservedAsMapFieldWithEnumValue) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if len(s.If) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"if\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.If) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.If[k]), s.If[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Const) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"const\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Const) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Const[k]), s.Const[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Private) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"private\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Private) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Private[k]), s.Private[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Class) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"class\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Class) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Class[k]), s.Class[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Int) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"int\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Int) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Int[k]), s.Int[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Void) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"void\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Void) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Void[k]), s.Void[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.String_) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"string\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.String_) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.String_[k]), s.String_[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Package) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"package\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Package) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Package[k]), s.Package[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Enum) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"enum\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Enum) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Enum[k]), s.Enum[k].String())
+		}
+		b = append(b, '}')
+	}
+	if len(s.Null) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"null\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.Null) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONEnum(b, int32(s.Null[k]), s.Null[k].String())
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *ReservedAsMapFieldWithEnumValue) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *ReservedAsMapFieldWithEnumValue) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *ReservedAsMapFieldWithEnumValue) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = ReservedAsMapFieldWithEnumValue{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "if":
+			if s.If == nil {
+				s.If = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.If[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "const":
+			if s.Const == nil {
+				s.Const = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Const[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "private":
+			if s.Private == nil {
+				s.Private = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Private[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "class":
+			if s.Class == nil {
+				s.Class = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Class[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int":
+			if s.Int == nil {
+				s.Int = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Int[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "void":
+			if s.Void == nil {
+				s.Void = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Void[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string":
+			if s.String_ == nil {
+				s.String_ = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.String_[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "package":
+			if s.Package == nil {
+				s.Package = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Package[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "enum":
+			if s.Enum == nil {
+				s.Enum = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Enum[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "null":
+			if s.Null == nil {
+				s.Null = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+				if err != nil {
+					return err
+				}
+				mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+				s.Null[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *ReservedAsMapFieldWithEnumValue) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if len(s.If) > 0 {
+		for _, k := range gremlin.SortedKeys(s.If) {
+			w.WriteName("if")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.If[k]), s.If[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Const) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Const) {
+			w.WriteName("const")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Const[k]), s.Const[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Private) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Private) {
+			w.WriteName("private")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Private[k]), s.Private[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Class) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Class) {
+			w.WriteName("class")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Class[k]), s.Class[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Int) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Int) {
+			w.WriteName("int")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Int[k]), s.Int[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Void) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Void) {
+			w.WriteName("void")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Void[k]), s.Void[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.String_) > 0 {
+		for _, k := range gremlin.SortedKeys(s.String_) {
+			w.WriteName("string")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.String_[k]), s.String_[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Package) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Package) {
+			w.WriteName("package")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Package[k]), s.Package[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Enum) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Enum) {
+			w.WriteName("enum")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Enum[k]), s.Enum[k].String())
+			w.EndMessage()
+		}
+	}
+	if len(s.Null) > 0 {
+		for _, k := range gremlin.SortedKeys(s.Null) {
+			w.WriteName("null")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteEnum(int32(s.Null[k]), s.Null[k].String())
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *ReservedAsMapFieldWithEnumValue) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *ReservedAsMapFieldWithEnumValue) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *ReservedAsMapFieldWithEnumValue) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *ReservedAsMapFieldWithEnumValue) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *ReservedAsMapFieldWithEnumValue) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = ReservedAsMapFieldWithEnumValue{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "if":
+			if s.If == nil {
+				s.If = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.If[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "const":
+			if s.Const == nil {
+				s.Const = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Const[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "private":
+			if s.Private == nil {
+				s.Private = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Private[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "class":
+			if s.Class == nil {
+				s.Class = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Class[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "int":
+			if s.Int == nil {
+				s.Int = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Int[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "void":
+			if s.Void == nil {
+				s.Void = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Void[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "string":
+			if s.String_ == nil {
+				s.String_ = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.String_[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "package":
+			if s.Package == nil {
+				s.Package = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Package[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "enum":
+			if s.Enum == nil {
+				s.Enum = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Enum[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		case "null":
+			if s.Null == nil {
+				s.Null = make(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue ReservedAsMapFieldWithEnumValue_SampleEnum
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadEnum(ReservedAsMapFieldWithEnumValue_SampleEnum_value)
+						if err != nil {
+							return err
+						}
+						mapValue = ReservedAsMapFieldWithEnumValue_SampleEnum(value)
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.Null[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *ReservedAsMapFieldWithEnumValue) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorReservedAsMapFieldWithEnumValue
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *ReservedAsMapFieldWithEnumValue) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireReservedAsMapFieldWithEnumValue_If:
+		return s.If
+	case wireReservedAsMapFieldWithEnumValue_Const:
+		return s.Const
+	case wireReservedAsMapFieldWithEnumValue_Private:
+		return s.Private
+	case wireReservedAsMapFieldWithEnumValue_Class:
+		return s.Class
+	case wireReservedAsMapFieldWithEnumValue_Int:
+		return s.Int
+	case wireReservedAsMapFieldWithEnumValue_Void:
+		return s.Void
+	case wireReservedAsMapFieldWithEnumValue_String_:
+		return s.String_
+	case wireReservedAsMapFieldWithEnumValue_Package:
+		return s.Package
+	case wireReservedAsMapFieldWithEnumValue_Enum:
+		return s.Enum
+	case wireReservedAsMapFieldWithEnumValue_Null:
+		return s.Null
 	}
+	return nil
+}
 
-	if len(s.Null) > 0 {
-		var entrySize = 0
-		entrySize = 0
-		for k, v := range s.Null {
-			var keySize, valueSize int
-			keySize = gremlin.SizeString(k)
-			keySize += gremlin.SizeUint64(uint64(keySize)) + gremlin.SizeTag(1)
-			valueSize = gremlin.SizeTag(2) + gremlin.SizeInt32(int32(v))
-			var mapEntrySize = keySize + valueSize
-			entrySize += mapEntrySize + gremlin.SizeTag(wireReservedAsMapFieldWithEnumValue_Null) + gremlin.SizeUint64(uint64(mapEntrySize))
-		}
-		
-		size += entrySize
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *ReservedAsMapFieldWithEnumValue) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireReservedAsMapFieldWithEnumValue_If:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.If = v
+	case wireReservedAsMapFieldWithEnumValue_Const:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Const = v
+	case wireReservedAsMapFieldWithEnumValue_Private:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Private = v
+	case wireReservedAsMapFieldWithEnumValue_Class:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Class = v
+	case wireReservedAsMapFieldWithEnumValue_Int:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Int = v
+	case wireReservedAsMapFieldWithEnumValue_Void:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Void = v
+	case wireReservedAsMapFieldWithEnumValue_String_:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.String_ = v
+	case wireReservedAsMapFieldWithEnumValue_Package:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Package = v
+	case wireReservedAsMapFieldWithEnumValue_Enum:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Enum = v
+	case wireReservedAsMapFieldWithEnumValue_Null:
+		v, ok := value.(map[string]ReservedAsMapFieldWithEnumValue_SampleEnum)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorReservedAsMapFieldWithEnumValue, number, value)
+		}
+		s.Null = v
+	default:
+		return gremlin.UnknownFieldError(descriptorReservedAsMapFieldWithEnumValue, number)
+	}
+	return nil
+}
+
+// ReservedAsMapFieldWithEnumValuePaths are the field mask paths of map_test.ReservedAsMapFieldWithEnumValue.
+var ReservedAsMapFieldWithEnumValuePaths = XXX_NewReservedAsMapFieldWithEnumValuePathNames("")
+
+type ReservedAsMapFieldWithEnumValuePathNames struct {
+	path string
+	If string
+	Const string
+	Private string
+	Class string
+	Int string
+	Void string
+	String_ string
+	Package string
+	Enum string
+	Null string
+}
+
+func XXX_NewReservedAsMapFieldWithEnumValuePathNames(path string) ReservedAsMapFieldWithEnumValuePathNames {
+	return ReservedAsMapFieldWithEnumValuePathNames{
+		path: path,
+		If: gremlin.JoinPath(path, "if"),
+		Const: gremlin.JoinPath(path, "const"),
+		Private: gremlin.JoinPath(path, "private"),
+		Class: gremlin.JoinPath(path, "class"),
+		Int: gremlin.JoinPath(path, "int"),
+		Void: gremlin.JoinPath(path, "void"),
+		String_: gremlin.JoinPath(path, "string"),
+		Package: gremlin.JoinPath(path, "package"),
+		Enum: gremlin.JoinPath(path, "enum"),
+		Null: gremlin.JoinPath(path, "null"),
 	}
+}
 
-	return size
+// Path returns the path of the message itself, empty for the root.
+func (p ReservedAsMapFieldWithEnumValuePathNames) Path() string {
+	return p.path
 }
 
 const (
 	wireMapContainer_MyMap gremlin.ProtoWireNumber = 1
 )
 
+const (
+	FieldMapContainer_MyMap gremlin.FieldIndex = 0
+)
+
+var descriptorMapContainer = &gremlin.MessageDescriptor{
+	FullName: "map_test.MapContainer",
+	File:     "map_test.proto",
+	Fields: []*gremlin.FieldDescriptor{
+		{Name: "my_map", JSONName: "myMap", Number: 1, Kind: gremlin.KindMessage, Label: gremlin.LabelRepeated,
+			MapKey: &gremlin.FieldDescriptor{Name: "key", JSONName: "key", Number: 1, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+			MapValue: &gremlin.FieldDescriptor{Name: "value", JSONName: "value", Number: 2, Kind: gremlin.KindString, Label: gremlin.LabelOptional},
+		},
+	},
+	New: func() gremlin.ProtoMessage {
+		return &MapContainer{}
+	},
+	NewReader: func() gremlin.ProtoReader {
+		return NewMapContainerReader()
+	},
+}
+
+func init() {
+	gremlin.RegisterMessage(descriptorMapContainer)
+}
+
+// Descriptor returns the descriptor of the message type.
+func (m *MapContainerReader) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorMapContainer
+}
+
 type MapContainerReader struct {
-	buf *gremlin.Reader
+	buf gremlin.Reader
+	parsed [1]uint64
+	// occurrences of repeated and map fields, small messages fit into repeatedInline
+	repeated       []gremlin.FieldOffset
+	repeatedInline [8]gremlin.FieldOffset
 
 	dataMyMap     map[string]string
 
-	offsetMyMap   []int
-
-	parsedMyMap   bool
 }
 
 func NewMapContainerReader() *MapContainerReader {
@@ -4067,58 +14691,108 @@ func (m *MapContainerReader) GetMyMap() map[string]string {
 }
 
 func (m *MapContainerReader) readMyMap() map[string]string {
-	if m.parsedMyMap {
+	if m.parsed[0]&(1 << 0) != 0 {
 		return m.dataMyMap
 	}
-	wOffset := m.offsetMyMap
+	wField := FieldMapContainer_MyMap
+	
+	var entry map[string]string
+	if count := gremlin.CountFieldOffsets(m.repeated, wField); count > 0 {
+		entry = gremlin.MakeMap[string, string](m.buf.Arena(), count)
+		for _, fieldOffset := range m.repeated {
+			if fieldOffset.Field != wField {
+				continue
+			}
+			wOffset := int(fieldOffset.Offset)
 	
-	var entry = map[string]string{}
-	for i := range wOffset {
-		wOffset := wOffset[i]
+			entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
+			endOffset := wOffset + entrySizeSize + int(entrySize)
+			wOffset += entrySizeSize
 	
-		entrySize, entrySizeSize := m.buf.SizedReadVarInt(wOffset)
-		endOffset := wOffset + entrySizeSize + int(entrySize)
-		wOffset += entrySizeSize
-		
-		var keyData string
-		var valueData string
-		for wOffset < endOffset {
-			tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
-			wOffset += tagSize
-			if tag == 1 {
-				
-				var keyEntry string
-				var keyEntrySize int
-				if wOffset > 0 {
-					keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += keyEntrySize
-				keyData = keyEntry
-			} else if tag == 2 {
-				
-				var valueEntry string
-				var valueEntrySize int
-				if wOffset > 0 {
-					valueEntry, valueEntrySize = m.buf.SizedReadString(wOffset)
-				}
-				
-				wOffset += valueEntrySize
-				valueData = valueEntry
-			} else {
-				wOffset, _ = m.buf.SkipData(wOffset, wireType)
-			}
-		}
-		entry[keyData] = valueData
+			var keyData string
+			var valueData string
+			for wOffset < endOffset {
+				tag, wireType, tagSize, _ := m.buf.ReadTagAt(wOffset)
+				wOffset += tagSize
+				if tag == 1 {
+					
+					var keyEntry string
+					var keyEntrySize int
+					if wOffset > 0 {
+						keyEntry, keyEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += keyEntrySize
+					keyData = keyEntry
+				} else if tag == 2 {
+					
+					var valueEntry string
+					var valueEntrySize int
+					if wOffset > 0 {
+						valueEntry, valueEntrySize = m.buf.SizedReadString(wOffset)
+					}
+					
+					wOffset += valueEntrySize
+					valueData = valueEntry
+				} else {
+					wOffset, _ = m.buf.SkipData(wOffset, wireType)
+				}
+			}
+			entry[keyData] = valueData
+		}
 	}
 	
 	m.dataMyMap = entry
-	m.parsedMyMap = true
+	m.parsed[0] |= 1 << 0
 	return entry
 }
 
 func (m *MapContainerReader) Unmarshal(data []byte) error {
-	m.buf = gremlin.NewReader(data)
+	return m.UnmarshalWithOptions(data, gremlin.ReaderOptions{})
+}
+
+// UnmarshalWithOptions is Unmarshal with control over buffer aliasing, nested readers inherit the options.
+func (m *MapContainerReader) UnmarshalWithOptions(data []byte, opts gremlin.ReaderOptions) error {
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
+	offset := 0
+	for m.buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireMapContainer_MyMap:
+			m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldMapContainer_MyMap, Offset: int32(offset), Wire: wire})
+		}
+
+		offset, err = m.buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalFields decodes only the fields present in the set, other fields are skipped
+// without recording offsets. Like Unmarshal, the last occurrence of a singular field wins.
+func (m *MapContainerReader) UnmarshalFields(data []byte, fields gremlin.FieldSet) error {
+	return m.UnmarshalFieldsWithOptions(data, fields, gremlin.ReaderOptions{})
+}
+
+// UnmarshalFieldsWithOptions is UnmarshalFields with control over buffering and arenas. With StopEarly
+// and only singular fields requested, decoding stops once each of them was found, a field repeated
+// before that keeps its last occurrence read so far.
+func (m *MapContainerReader) UnmarshalFieldsWithOptions(data []byte, fields gremlin.FieldSet, opts gremlin.ReaderOptions) error {
+	*m = MapContainerReader{}
+	if err := m.buf.InitWithOptions(data, opts); err != nil {
+		return err
+	}
+	m.repeated = m.repeatedInline[:0]
 	offset := 0
 	for m.buf.HasNext(offset, 0) {
 		tag, wire, tagSize, err := m.buf.ReadTagAt(offset)
@@ -4129,7 +14803,9 @@ func (m *MapContainerReader) Unmarshal(data []byte) error {
 		offset += tagSize
 		switch tag {
 		case wireMapContainer_MyMap:
-			m.offsetMyMap = append(m.offsetMyMap, offset)
+			if fields.Has(FieldMapContainer_MyMap) {
+				m.repeated = append(m.repeated, gremlin.FieldOffset{Field: FieldMapContainer_MyMap, Offset: int32(offset), Wire: wire})
+			}
 		}
 
 		offset, err = m.buf.SkipData(offset, wire)
@@ -4144,12 +14820,57 @@ func (m *MapContainerReader) ToStruct() *MapContainer {
 	if m == nil {
 		return nil
 	}
-	res := &MapContainer{}
+	res := gremlin.New[MapContainer](m.buf.Arena())
 	res.MyMap = m.GetMyMap()
 
 	return res
 }
 
+// Freeze materialises every field, including nested readers. Afterwards getters,
+// ToStruct and SourceBytes never write to the reader, so it can be shared between goroutines.
+// The exception is ToStruct on a reader decoded with ReaderOptions.Arena: it allocates from
+// the arena, which is not safe for concurrent use.
+func (m *MapContainerReader) Freeze() {
+	if m == nil {
+		return
+	}
+	m.readMyMap()
+}
+
+// Detach reads every field and copies the bytes still referenced by strings, bytes and nested messages,
+// so the input buffer can be reused. Values returned by getters before Detach still reference it.
+func (m *MapContainerReader) Detach() {
+	if m == nil {
+		return
+	}
+	m.Freeze()
+	d := gremlin.NewDetacher(m.buf.Bytes())
+	m.XXX_Rebase(d)
+	d.Copy()
+	m.XXX_Rebase(d)
+}
+
+// XXX_Rebase passes the values of the reader through d, used by Detach.
+func (m *MapContainerReader) XXX_Rebase(d *gremlin.Detacher) {
+	if m == nil {
+		return
+	}
+	m.buf.Release(d)
+	if m.parsed[0]&(1 << 0) != 0 {
+		if m.dataMyMap != nil {
+			rebased := make(map[string]string, len(m.dataMyMap))
+			for k, v := range m.dataMyMap {
+				k = d.RebaseString(k)
+				v = d.RebaseString(v)
+				rebased[k] = v
+			}
+			m.dataMyMap = rebased
+		}
+	}
+}
+
+// SourceBytes returns the bytes the reader was unmarshalled from. Detach drops them unless the message
+// has extension ranges, they are nil afterwards.
 func (s *MapContainerReader) SourceBytes() []byte {
 	if s == nil {
 		return nil
@@ -4157,10 +14878,276 @@ func (s *MapContainerReader) SourceBytes() []byte {
 	return s.buf.Bytes()
 }
 
+// AppendJSON appends the message in the canonical proto3 JSON mapping, reading fields straight
+// from the buffer. The output is the same as ToStruct().AppendJSON(b).
+func (m *MapContainerReader) AppendJSON(b []byte) []byte {
+	if m == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if value := m.GetMyMap(); len(value) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"myMap\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(value) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONString(b, value[k])
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (m *MapContainerReader) WriteJSON(w io.Writer) error {
+	_, err := w.Write(m.AppendJSON(nil))
+	return err
+}
+
+// TranscodeMapContainerJSON writes the message encoded in data to w as JSON without building a reader or a struct.
+// The wire bytes are checked in a first pass and written in wire order in a second one, a singular field
+// at its last occurrence with earlier occurrences of a message merged into it. For messages produced by
+// Marshal the output is the same as AppendJSON.
+func TranscodeMapContainerJSON(w io.Writer, data []byte) error {
+	s := gremlin.GetJSONStream(w)
+	defer gremlin.PutJSONStream(s)
+	if err := XXX_TranscodeMapContainerJSON(s, data); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// XXX_TranscodeMapContainerJSON appends the message encoded in data to s, used by transcoders of the message and its parents.
+func XXX_TranscodeMapContainerJSON(s *gremlin.JSONStream, data []byte) error {
+	var buf gremlin.Reader
+	// values are written out right away, so strings can reference data
+	if err := buf.InitWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireMapContainer_MyMap:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+		}
+		offset = end
+	}
+
+	var written [1]uint64
+	sep := byte('{')
+	offset = 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		switch tag {
+		case wireMapContainer_MyMap:
+			if written[0]&(1 << 0) != 0 {
+				break
+			}
+			written[0] |= 1 << 0
+			s.B = append(s.B, sep)
+			sep = ','
+			s.B = append(s.B, "\"myMap\":"...)
+			entrySep := byte('{')
+			for entryOffset, entryWire, found := offset, wire, true; found; entryOffset, entryWire, found = buf.NextField(entryOffset, entryWire, tag) {
+				s.B = append(s.B, entrySep)
+				entrySep = ','
+			
+				entrySize, entrySizeSize := buf.SizedReadVarInt(entryOffset)
+				mapOffset := entryOffset + entrySizeSize
+				mapEnd := mapOffset + int(entrySize)
+				keyOffset, valueOffset := 0, 0
+				for mapOffset < mapEnd {
+					mapTag, mapWire, mapTagSize, err := buf.ReadTagAt(mapOffset)
+					if err != nil {
+						return err
+					}
+					mapOffset += mapTagSize
+					mapNext, err := buf.FieldEnd(mapOffset, mapWire)
+					if err != nil {
+						return err
+					}
+					if mapNext > mapEnd {
+						return gremlin.ErrTruncated
+					}
+					switch mapTag {
+					case 1:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						keyOffset = mapOffset
+					case 2:
+						if mapWire != gremlin.BytesType {
+							return gremlin.WireTypeError(mapTag, mapWire)
+						}
+						valueOffset = mapOffset
+					}
+					mapOffset = mapNext
+				}
+			
+				var mapKey string
+				if keyOffset > 0 {
+					mapKey = buf.ReadString(keyOffset)
+				}
+				s.B = gremlin.AppendJSONString(s.B, mapKey)
+				s.B = append(s.B, ':')
+				if valueOffset > 0 {
+					s.B = gremlin.AppendJSONString(s.B, buf.ReadString(valueOffset))
+				} else {
+					var mapValue string
+					s.B = gremlin.AppendJSONString(s.B, mapValue)
+				}
+			}
+			s.B = append(s.B, '}')
+		}
+
+		offset, err = buf.SkipData(offset, wire)
+		if err != nil {
+			return err
+		}
+	}
+	if sep == '{' {
+		s.B = append(s.B, sep)
+	}
+	s.B = append(s.B, '}')
+	return s.Check()
+}
+
+// XXX_WriteText writes the fields of the message to w, reading them straight from the buffer.
+func (m *MapContainerReader) XXX_WriteText(w *gremlin.TextWriter) {
+	if m == nil {
+		return
+	}
+	if value := m.GetMyMap(); len(value) > 0 {
+		for _, k := range gremlin.SortedKeys(value) {
+			w.WriteName("my_map")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteString(value[k])
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (m *MapContainerReader) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	m.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (m *MapContainerReader) String() string {
+	return gremlin.TextString(m.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (m *MapContainerReader) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, m.XXX_WriteText)
+}
+
 type MapContainer struct {
 	MyMap	map[string]string	`json:"my_map,omitempty"`
 }
 
+// Unmarshal decodes data straight into the struct in a single pass, without building a reader.
+// For valid data the result is the same as NewReader().Unmarshal(data) followed by ToStruct(),
+// truncated values and fields with a wrong wire type are an error.
+func (s *MapContainer) Unmarshal(data []byte) error {
+	*s = MapContainer{}
+
+	var buf gremlin.Reader
+	if err := buf.Init(data); err != nil {
+		return err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += tagSize
+		// values are read only once they are known to end inside data
+		end, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case wireMapContainer_MyMap:
+			if wire != gremlin.BytesType {
+				return gremlin.WireTypeError(tag, wire)
+			}
+			{
+				if s.MyMap == nil {
+					s.MyMap = make(map[string]string, buf.CountField(offset, wire, tag, 0))
+				}
+				entrySize, entrySizeSize := buf.SizedReadVarInt(offset)
+				entryOffset := offset + entrySizeSize
+				entryEnd := entryOffset + int(entrySize)
+			
+				var mapKey string
+				var mapValue string
+				for entryOffset < entryEnd {
+					entryTag, entryWire, entryTagSize, err := buf.ReadTagAt(entryOffset)
+					if err != nil {
+						return err
+					}
+					entryOffset += entryTagSize
+					entryNext, err := buf.FieldEnd(entryOffset, entryWire)
+					if err != nil {
+						return err
+					}
+					if entryNext > entryEnd {
+						return gremlin.ErrTruncated
+					}
+					switch entryTag {
+					case 1:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapKey = buf.ReadString(entryOffset)
+					case 2:
+						if entryWire != gremlin.BytesType {
+							return gremlin.WireTypeError(entryTag, entryWire)
+						}
+						mapValue = buf.ReadString(entryOffset)
+					}
+					entryOffset = entryNext
+				}
+				s.MyMap[mapKey] = mapValue
+			}
+		}
+		offset = end
+	}
+	return nil
+}
+
 func (s *MapContainer) Marshal() []byte {
 	if s == nil {
 		return nil
@@ -4231,3 +15218,210 @@ func (s *MapContainer) XXX_PbContentSize() int {
 
 	return size
 }
+
+// AppendJSON appends the message in the canonical proto3 JSON mapping, the same bytes protojson
+// produces. Fields with default values are omitted.
+func (s *MapContainer) AppendJSON(b []byte) []byte {
+	if s == nil {
+		return append(b, "{}"...)
+	}
+	sep := byte('{')
+	if len(s.MyMap) > 0 {
+		b = append(b, sep)
+		sep = ','
+		b = append(b, "\"myMap\":"...)
+		b = append(b, '{')
+		for i, k := range gremlin.SortedKeys(s.MyMap) {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gremlin.AppendJSONString(b, k)
+			b = append(b, ':')
+			b = gremlin.AppendJSONString(b, s.MyMap[k])
+		}
+		b = append(b, '}')
+	}
+	if sep == '{' {
+		b = append(b, sep)
+	}
+	return append(b, '}')
+}
+
+func (s *MapContainer) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// UnmarshalJSON accepts both JSON and proto field names, unknown fields are an error.
+func (s *MapContainer) UnmarshalJSON(data []byte) error {
+	d := gremlin.NewJSONDecoder(data)
+	if err := s.XXX_DecodeJSON(d); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// XXX_DecodeJSON reads the message from d, used by UnmarshalJSON of the message and its parents.
+func (s *MapContainer) XXX_DecodeJSON(d *gremlin.JSONDecoder) error {
+	*s = MapContainer{}
+	return d.ReadObject(func(key string) error {
+		if d.ReadNull() {
+			return nil
+		}
+		switch key {
+		case "myMap", "my_map":
+			if s.MyMap == nil {
+				s.MyMap = make(map[string]string)
+			}
+			if err := d.ReadObject(func(key string) error {
+				mapKey := key
+				var mapValue string
+				value, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				mapValue = value
+				s.MyMap[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(key)
+		}
+		return nil
+	})
+}
+
+// XXX_WriteText writes the fields of the message to w in declaration order, fields with default values are omitted.
+func (s *MapContainer) XXX_WriteText(w *gremlin.TextWriter) {
+	if s == nil {
+		return
+	}
+	if len(s.MyMap) > 0 {
+		for _, k := range gremlin.SortedKeys(s.MyMap) {
+			w.WriteName("my_map")
+			w.StartMessage()
+			w.WriteName("key")
+			w.WriteString(k)
+			w.WriteName("value")
+			w.WriteString(s.MyMap[k])
+			w.EndMessage()
+		}
+	}
+}
+
+// MarshalText returns the message in the multiline protobuf text format, the same text prototext.Format produces.
+func (s *MapContainer) MarshalText() ([]byte, error) {
+	w := gremlin.NewTextWriter(nil, true)
+	s.XXX_WriteText(w)
+	return w.Finish(), nil
+}
+
+// String returns the message in the single line protobuf text format.
+func (s *MapContainer) String() string {
+	return gremlin.TextString(s.XXX_WriteText)
+}
+
+// Format prints the single line text format for %v and the multiline one for %+v.
+func (s *MapContainer) Format(f fmt.State, verb rune) {
+	gremlin.FormatText(f, verb, s.XXX_WriteText)
+}
+
+// UnmarshalText parses the protobuf text format, unknown fields, extensions and Any expansion are an error.
+func (s *MapContainer) UnmarshalText(data []byte) error {
+	return s.XXX_DecodeText(gremlin.NewTextDecoder(data))
+}
+
+// XXX_DecodeText reads the message from d, used by UnmarshalText of the message and its parents.
+func (s *MapContainer) XXX_DecodeText(d *gremlin.TextDecoder) error {
+	*s = MapContainer{}
+	return d.ReadMessage(func(name string) error {
+		switch name {
+		case "my_map":
+			if s.MyMap == nil {
+				s.MyMap = make(map[string]string)
+			}
+			if err := d.ReadRepeated(func() error {
+				var mapKey string
+				var mapValue string
+				if err := d.ReadMessage(func(name string) error {
+					switch name {
+					case "key":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapKey = value
+					case "value":
+						value, err := d.ReadString()
+						if err != nil {
+							return err
+						}
+						mapValue = value
+					default:
+						return d.UnknownField(name)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				s.MyMap[mapKey] = mapValue
+				return nil
+			}); err != nil {
+				return err
+			}
+		default:
+			return d.UnknownField(name)
+		}
+		return nil
+	})
+}
+
+// Descriptor returns the descriptor of the message type.
+func (s *MapContainer) Descriptor() *gremlin.MessageDescriptor {
+	return descriptorMapContainer
+}
+
+// GetField returns the value of the field with the given number, nil if the message has no such field.
+func (s *MapContainer) GetField(number gremlin.ProtoWireNumber) any {
+	switch number {
+	case wireMapContainer_MyMap:
+		return s.MyMap
+	}
+	return nil
+}
+
+// SetField sets the field with the given number, value must have the Go type of the field.
+func (s *MapContainer) SetField(number gremlin.ProtoWireNumber, value any) error {
+	switch number {
+	case wireMapContainer_MyMap:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return gremlin.FieldTypeError(descriptorMapContainer, number, value)
+		}
+		s.MyMap = v
+	default:
+		return gremlin.UnknownFieldError(descriptorMapContainer, number)
+	}
+	return nil
+}
+
+// MapContainerPaths are the field mask paths of map_test.MapContainer.
+var MapContainerPaths = XXX_NewMapContainerPathNames("")
+
+type MapContainerPathNames struct {
+	path string
+	MyMap string
+}
+
+func XXX_NewMapContainerPathNames(path string) MapContainerPathNames {
+	return MapContainerPathNames{
+		path: path,
+		MyMap: gremlin.JoinPath(path, "my_map"),
+	}
+}
+
+// Path returns the path of the message itself, empty for the root.
+func (p MapContainerPathNames) Path() string {
+	return p.path
+}
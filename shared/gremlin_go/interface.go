@@ -8,3 +8,13 @@ type ProtoReader interface {
 type ProtoWriter interface {
 	Marshal() []byte
 }
+
+// ProtoMessage is implemented by generated structs, it gives reflective access to fields by number
+// for generic tooling. Values have the Go type of the struct field.
+type ProtoMessage interface {
+	ProtoWriter
+	Unmarshal(data []byte) error
+	Descriptor() *MessageDescriptor
+	GetField(number ProtoWireNumber) any // nil if the message has no such field
+	SetField(number ProtoWireNumber, value any) error
+}
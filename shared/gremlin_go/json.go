@@ -0,0 +1,208 @@
+package gremlin
+
+import (
+	"cmp"
+	"encoding/base64"
+	"io"
+	"math"
+	"math/bits"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// JSON encoding helpers used by generated code. The output follows the canonical proto3 JSON
+// mapping and matches protojson byte for byte (protojson output compacted with json.Compact).
+
+func AppendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= ' ' && c != '"' && c != '\\' && c < utf8.RuneSelf {
+			i++
+			b = append(b, c)
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && n == 1:
+			// protojson rejects invalid UTF-8, replace it like encoding/json does
+			b = append(b, `�`...)
+		case r == '"' || r == '\\':
+			b = append(b, '\\', byte(r))
+		case r == '\b':
+			b = append(b, '\\', 'b')
+		case r == '\f':
+			b = append(b, '\\', 'f')
+		case r == '\n':
+			b = append(b, '\\', 'n')
+		case r == '\r':
+			b = append(b, '\\', 'r')
+		case r == '\t':
+			b = append(b, '\\', 't')
+		case r < ' ':
+			b = append(b, '\\', 'u')
+			b = append(b, "0000"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			b = strconv.AppendUint(b, uint64(r), 16)
+		default:
+			b = append(b, s[i:i+n]...)
+		}
+		i += n
+	}
+	return append(b, '"')
+}
+
+func AppendJSONBytes(b []byte, v []byte) []byte {
+	b = append(b, '"')
+	b = base64.StdEncoding.AppendEncode(b, v)
+	return append(b, '"')
+}
+
+func AppendJSONBool(b []byte, v bool) []byte {
+	return strconv.AppendBool(b, v)
+}
+
+// AppendJSONInt writes 32-bit integers, they are JSON numbers.
+func AppendJSONInt(b []byte, v int64) []byte {
+	return strconv.AppendInt(b, v, 10)
+}
+
+func AppendJSONUint(b []byte, v uint64) []byte {
+	return strconv.AppendUint(b, v, 10)
+}
+
+// AppendJSONInt64 writes 64-bit integers, they are JSON strings.
+func AppendJSONInt64(b []byte, v int64) []byte {
+	b = append(b, '"')
+	b = strconv.AppendInt(b, v, 10)
+	return append(b, '"')
+}
+
+func AppendJSONUint64(b []byte, v uint64) []byte {
+	b = append(b, '"')
+	b = strconv.AppendUint(b, v, 10)
+	return append(b, '"')
+}
+
+func AppendJSONFloat(b []byte, v float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(v):
+		return append(b, `"NaN"`...)
+	case math.IsInf(v, 1):
+		return append(b, `"Infinity"`...)
+	case math.IsInf(v, -1):
+		return append(b, `"-Infinity"`...)
+	}
+
+	format := byte('f')
+	if abs := math.Abs(v); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, v, format, -1, bitSize)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// AppendJSONEnum writes the name of an enum value, or its number when the value is unknown.
+func AppendJSONEnum(b []byte, v int32, name string) []byte {
+	if name == "" {
+		return strconv.AppendInt(b, int64(v), 10)
+	}
+	return AppendJSONString(b, name)
+}
+
+// SortedKeys returns map keys in the order used by protojson.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func SortedBoolKeys[V any](m map[bool]V) []bool {
+	keys := make([]bool, 0, 2)
+	if _, ok := m[false]; ok {
+		keys = append(keys, false)
+	}
+	if _, ok := m[true]; ok {
+		keys = append(keys, true)
+	}
+	return keys
+}
+
+// AppendJSONMapKeyInt writes an integer map key, JSON object keys are always strings.
+func AppendJSONMapKeyInt(b []byte, v int64) []byte {
+	return AppendJSONInt64(b, v)
+}
+
+func AppendJSONMapKeyUint(b []byte, v uint64) []byte {
+	return AppendJSONUint64(b, v)
+}
+
+func AppendJSONMapKeyBool(b []byte, v bool) []byte {
+	b = append(b, '"')
+	b = strconv.AppendBool(b, v)
+	return append(b, '"')
+}
+
+// jsonStreamChunk is the amount of buffered output after which JSONStream passes it to the writer.
+const jsonStreamChunk = 32 << 10
+
+// JSONStream buffers the output of generated wire to JSON transcoders. Transcoders append to B
+// and call Check after every message, so memory stays bounded for any message size.
+type JSONStream struct {
+	B []byte
+	w io.Writer
+}
+
+var jsonStreamPool = sync.Pool{
+	New: func() any {
+		return &JSONStream{B: make([]byte, 0, jsonStreamChunk)}
+	},
+}
+
+// GetJSONStream returns a pooled stream writing to w, it must be returned with PutJSONStream.
+func GetJSONStream(w io.Writer) *JSONStream {
+	s := jsonStreamPool.Get().(*JSONStream)
+	s.w = w
+	return s
+}
+
+func PutJSONStream(s *JSONStream) {
+	if cap(s.B) > 4*jsonStreamChunk {
+		return // don't keep buffers grown by huge values
+	}
+	s.B = s.B[:0]
+	s.w = nil
+	jsonStreamPool.Put(s)
+}
+
+// Check flushes the buffer once it is larger than a chunk.
+func (s *JSONStream) Check() error {
+	if len(s.B) < jsonStreamChunk {
+		return nil
+	}
+	return s.Flush()
+}
+
+func (s *JSONStream) Flush() error {
+	if len(s.B) == 0 {
+		return nil
+	}
+	_, err := s.w.Write(s.B)
+	s.B = s.B[:0]
+	return err
+}
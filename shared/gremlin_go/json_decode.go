@@ -0,0 +1,586 @@
+package gremlin
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// JSONDecoder reads the canonical proto3 JSON mapping, used by generated UnmarshalJSON.
+// Both JSON names and original proto field names are accepted, as well as
+// 64-bit integers written as numbers and enums written as numbers.
+type JSONDecoder struct {
+	data []byte
+	pos  int
+
+	skipAnyType bool // the next object is an inlined google.protobuf.Any payload
+}
+
+func NewJSONDecoder(data []byte) *JSONDecoder {
+	return &JSONDecoder{data: data}
+}
+
+func (d *JSONDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("json: offset %v: %v", d.pos, fmt.Sprintf(format, args...))
+}
+
+func (d *JSONDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (d *JSONDecoder) peek() byte {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+func (d *JSONDecoder) expect(c byte) error {
+	if d.peek() != c {
+		if d.pos >= len(d.data) {
+			return d.errorf("unexpected end of input, expected %q", c)
+		}
+		return d.errorf("unexpected %q, expected %q", d.data[d.pos], c)
+	}
+	d.pos++
+	return nil
+}
+
+func (d *JSONDecoder) readLiteral(literal string) bool {
+	d.skipSpace()
+	if len(d.data)-d.pos < len(literal) || string(d.data[d.pos:d.pos+len(literal)]) != literal {
+		return false
+	}
+	end := d.pos + len(literal)
+	if end < len(d.data) && isJSONIdentChar(d.data[end]) {
+		return false
+	}
+	d.pos = end
+	return true
+}
+
+func isJSONIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+// End checks that nothing but whitespace is left.
+func (d *JSONDecoder) End() error {
+	d.skipSpace()
+	if d.pos != len(d.data) {
+		return d.errorf("unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// ReadNull consumes a null value, it means the field is not set.
+func (d *JSONDecoder) ReadNull() bool {
+	return d.readLiteral("null")
+}
+
+// ReadObject calls field for every key, field must consume the value.
+func (d *JSONDecoder) ReadObject(field func(key string) error) error {
+	skipAnyType := d.skipAnyType
+	d.skipAnyType = false
+	if err := d.expect('{'); err != nil {
+		return err
+	}
+	if d.peek() == '}' {
+		d.pos++
+		return nil
+	}
+	for {
+		key, err := d.ReadString()
+		if err != nil {
+			return err
+		}
+		if err := d.expect(':'); err != nil {
+			return err
+		}
+		if skipAnyType && key == "@type" {
+			_, err = d.ReadString()
+		} else {
+			err = field(key)
+		}
+		if err != nil {
+			return err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case '}':
+			d.pos++
+			return nil
+		default:
+			return d.errorf("expected ',' or '}' after object value")
+		}
+	}
+}
+
+// ReadArray calls entry for every element, entry must consume the value.
+func (d *JSONDecoder) ReadArray(entry func() error) error {
+	if err := d.expect('['); err != nil {
+		return err
+	}
+	if d.peek() == ']' {
+		d.pos++
+		return nil
+	}
+	for {
+		if err := entry(); err != nil {
+			return err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case ']':
+			d.pos++
+			return nil
+		default:
+			return d.errorf("expected ',' or ']' after array value")
+		}
+	}
+}
+
+func (d *JSONDecoder) UnknownField(key string) error {
+	return d.errorf("unknown field %q", key)
+}
+
+// PeekAnyType returns the "@type" member of the object that comes next without consuming anything,
+// empty if there is none. google.protobuf.Any inlines the fields of the packed message next to
+// "@type", which doesn't have to come first.
+func (d *JSONDecoder) PeekAnyType() (string, error) {
+	start := d.pos
+	defer func() {
+		d.pos = start
+	}()
+	var typeURL string
+	err := d.ReadObject(func(key string) error {
+		if key != "@type" {
+			return d.Skip()
+		}
+		if typeURL != "" {
+			return d.errorf("duplicate field %q", key)
+		}
+		var err error
+		typeURL, err = d.ReadString()
+		if err == nil && typeURL == "" {
+			err = d.errorf("empty %q", key)
+		}
+		return err
+	})
+	return typeURL, err
+}
+
+// SkipAnyType makes the next ReadObject skip the "@type" member, so that the packed message
+// of a google.protobuf.Any can be decoded from the object that holds it.
+func (d *JSONDecoder) SkipAnyType() {
+	d.skipAnyType = true
+}
+
+func (d *JSONDecoder) ReadString() (string, error) {
+	if err := d.expect('"'); err != nil {
+		return "", err
+	}
+	start := d.pos
+	// fast path without escapes
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		if c == '"' {
+			s := string(d.data[start:d.pos])
+			d.pos++
+			if !utf8.ValidString(s) {
+				return "", d.errorf("invalid UTF-8 in string")
+			}
+			return s, nil
+		}
+		if c == '\\' || c < ' ' {
+			break
+		}
+		d.pos++
+	}
+
+	res := append([]byte(nil), d.data[start:d.pos]...)
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch {
+		case c == '"':
+			d.pos++
+			if !utf8.Valid(res) {
+				return "", d.errorf("invalid UTF-8 in string")
+			}
+			return string(res), nil
+		case c < ' ':
+			return "", d.errorf("invalid control character in string")
+		case c != '\\':
+			res = append(res, c)
+			d.pos++
+			continue
+		}
+		if d.pos+1 >= len(d.data) {
+			break
+		}
+		d.pos += 2
+		switch d.data[d.pos-1] {
+		case '"', '\\', '/':
+			res = append(res, d.data[d.pos-1])
+		case 'b':
+			res = append(res, '\b')
+		case 'f':
+			res = append(res, '\f')
+		case 'n':
+			res = append(res, '\n')
+		case 'r':
+			res = append(res, '\r')
+		case 't':
+			res = append(res, '\t')
+		case 'u':
+			r, ok := d.readHex4()
+			if !ok {
+				return "", d.errorf("invalid unicode escape")
+			}
+			if utf16.IsSurrogate(r) {
+				if d.pos+1 < len(d.data) && d.data[d.pos] == '\\' && d.data[d.pos+1] == 'u' {
+					d.pos += 2
+					r2, ok := d.readHex4()
+					if !ok {
+						return "", d.errorf("invalid unicode escape")
+					}
+					r = utf16.DecodeRune(r, r2)
+				} else {
+					r = utf8.RuneError
+				}
+			}
+			res = utf8.AppendRune(res, r)
+		default:
+			return "", d.errorf("invalid escape sequence")
+		}
+	}
+	return "", d.errorf("unterminated string")
+}
+
+func (d *JSONDecoder) readHex4() (rune, bool) {
+	if d.pos+4 > len(d.data) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(d.data[d.pos:d.pos+4]), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	d.pos += 4
+	return rune(v), true
+}
+
+// readNumberToken consumes a JSON number and returns its literal.
+func (d *JSONDecoder) readNumberToken() (string, error) {
+	d.skipSpace()
+	n := jsonNumberLength(d.data[d.pos:])
+	if n == 0 {
+		return "", d.errorf("invalid number")
+	}
+	res := string(d.data[d.pos : d.pos+n])
+	d.pos += n
+	return res, nil
+}
+
+// jsonNumberLength returns the length of the JSON number at the beginning of data, 0 if there is none.
+func jsonNumberLength(data []byte) int {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(data) && data[i] == '0':
+		i++
+	case i < len(data) && data[i] >= '1' && data[i] <= '9':
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+	default:
+		return 0
+	}
+	if i < len(data) && data[i] == '.' {
+		i++
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return 0
+		}
+	}
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return 0
+		}
+	}
+	if i < len(data) && isJSONIdentChar(data[i]) {
+		return 0
+	}
+	return i
+}
+
+// readNumber reads a number written either as a JSON number or as a string.
+func (d *JSONDecoder) readNumber() (string, error) {
+	if d.peek() != '"' {
+		return d.readNumberToken()
+	}
+	s, err := d.ReadString()
+	if err != nil {
+		return "", err
+	}
+	if jsonNumberLength([]byte(s)) != len(s) || len(s) == 0 {
+		return "", d.errorf("invalid number %q", s)
+	}
+	return s, nil
+}
+
+func (d *JSONDecoder) readInt(bitSize int) (int64, error) {
+	lit, err := d.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, err := ParseJSONInt(lit, bitSize)
+	if err != nil {
+		return 0, d.errorf("%v", err)
+	}
+	return v, nil
+}
+
+func (d *JSONDecoder) readUint(bitSize int) (uint64, error) {
+	lit, err := d.readNumber()
+	if err != nil {
+		return 0, err
+	}
+	v, err := ParseJSONUint(lit, bitSize)
+	if err != nil {
+		return 0, d.errorf("%v", err)
+	}
+	return v, nil
+}
+
+func (d *JSONDecoder) ReadInt32() (int32, error) {
+	v, err := d.readInt(32)
+	return int32(v), err
+}
+
+func (d *JSONDecoder) ReadInt64() (int64, error) {
+	return d.readInt(64)
+}
+
+func (d *JSONDecoder) ReadUint32() (uint32, error) {
+	v, err := d.readUint(32)
+	return uint32(v), err
+}
+
+func (d *JSONDecoder) ReadUint64() (uint64, error) {
+	return d.readUint(64)
+}
+
+func (d *JSONDecoder) readFloat(bitSize int) (float64, error) {
+	if d.peek() == '"' {
+		s, err := d.ReadString()
+		if err != nil {
+			return 0, err
+		}
+		switch s {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+		if len(s) == 0 || jsonNumberLength([]byte(s)) != len(s) {
+			return 0, d.errorf("invalid number %q", s)
+		}
+		return d.parseFloat(s, bitSize)
+	}
+	lit, err := d.readNumberToken()
+	if err != nil {
+		return 0, err
+	}
+	return d.parseFloat(lit, bitSize)
+}
+
+func (d *JSONDecoder) parseFloat(lit string, bitSize int) (float64, error) {
+	v, err := strconv.ParseFloat(lit, bitSize)
+	if err != nil {
+		return 0, d.errorf("invalid number %q", lit)
+	}
+	return v, nil
+}
+
+func (d *JSONDecoder) ReadFloat32() (float32, error) {
+	v, err := d.readFloat(32)
+	return float32(v), err
+}
+
+func (d *JSONDecoder) ReadFloat64() (float64, error) {
+	return d.readFloat(64)
+}
+
+func (d *JSONDecoder) ReadBool() (bool, error) {
+	switch {
+	case d.readLiteral("true"):
+		return true, nil
+	case d.readLiteral("false"):
+		return false, nil
+	}
+	return false, d.errorf("expected a boolean")
+}
+
+// ReadBytes accepts standard and URL-safe base64, with or without padding.
+func (d *JSONDecoder) ReadBytes() ([]byte, error) {
+	s, err := d.ReadString()
+	if err != nil {
+		return nil, err
+	}
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	if len(s)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	v, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, d.errorf("invalid base64: %v", err)
+	}
+	return v, nil
+}
+
+// ReadEnum accepts a value name from values or a number.
+func (d *JSONDecoder) ReadEnum(values map[string]int32) (int32, error) {
+	if d.peek() != '"' {
+		return d.ReadInt32()
+	}
+	name, err := d.ReadString()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := values[name]
+	if !ok {
+		return 0, d.errorf("invalid enum value %q", name)
+	}
+	return v, nil
+}
+
+// Skip consumes any value.
+func (d *JSONDecoder) Skip() error {
+	switch d.peek() {
+	case '{':
+		return d.ReadObject(func(string) error {
+			return d.Skip()
+		})
+	case '[':
+		return d.ReadArray(d.Skip)
+	case '"':
+		_, err := d.ReadString()
+		return err
+	case 't', 'f':
+		_, err := d.ReadBool()
+		return err
+	case 'n':
+		if d.ReadNull() {
+			return nil
+		}
+		return d.errorf("invalid value")
+	default:
+		_, err := d.readNumberToken()
+		return err
+	}
+}
+
+// ReadAny decodes any value into nil, bool, float64, string, []any or map[string]any.
+func (d *JSONDecoder) ReadAny() (any, error) {
+	switch d.peek() {
+	case '{':
+		res := map[string]any{}
+		err := d.ReadObject(func(key string) error {
+			value, err := d.ReadAny()
+			res[key] = value
+			return err
+		})
+		return res, err
+	case '[':
+		res := []any{}
+		err := d.ReadArray(func() error {
+			value, err := d.ReadAny()
+			res = append(res, value)
+			return err
+		})
+		return res, err
+	case '"':
+		return d.ReadString()
+	case 't', 'f':
+		return d.ReadBool()
+	case 'n':
+		if d.ReadNull() {
+			return nil, nil
+		}
+		return nil, d.errorf("invalid value")
+	default:
+		lit, err := d.readNumberToken()
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			return nil, d.errorf("invalid number %q", lit)
+		}
+		return v, nil
+	}
+}
+
+// ParseJSONInt parses an integer, exponent notation is accepted as long as the value is integral.
+func ParseJSONInt(lit string, bitSize int) (int64, error) {
+	if v, err := strconv.ParseInt(lit, 10, bitSize); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil || f != math.Trunc(f) || f < -math.Ldexp(1, bitSize-1) || f >= math.Ldexp(1, bitSize-1) {
+		return 0, fmt.Errorf("invalid %v-bit integer %q", bitSize, lit)
+	}
+	return int64(f), nil
+}
+
+func ParseJSONUint(lit string, bitSize int) (uint64, error) {
+	if v, err := strconv.ParseUint(lit, 10, bitSize); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil || f != math.Trunc(f) || f < 0 || f >= math.Ldexp(1, bitSize) {
+		return 0, fmt.Errorf("invalid %v-bit unsigned integer %q", bitSize, lit)
+	}
+	return uint64(f), nil
+}
+
+func ParseJSONBool(lit string) (bool, error) {
+	switch lit {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", lit)
+}
@@ -0,0 +1,209 @@
+package gremlin
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAppendJSONFloat(t *testing.T) {
+	cases := []struct {
+		value   float64
+		bitSize int
+		want    string
+	}{
+		{0, 64, "0"},
+		{1.5, 64, "1.5"},
+		{-2, 64, "-2"},
+		{1e21, 64, "1e+21"},
+		{1e-7, 64, "1e-7"},
+		{123456789, 64, "123456789"},
+		{float64(float32(0.1)), 32, "0.1"},
+		{math.NaN(), 64, `"NaN"`},
+		{math.Inf(1), 64, `"Infinity"`},
+		{math.Inf(-1), 32, `"-Infinity"`},
+	}
+	for _, c := range cases {
+		if got := string(AppendJSONFloat(nil, c.value, c.bitSize)); got != c.want {
+			t.Errorf("AppendJSONFloat(%v, %v) = %v, want %v", c.value, c.bitSize, got, c.want)
+		}
+	}
+}
+
+func TestAppendJSONString(t *testing.T) {
+	cases := map[string]string{
+		"plain":       `"plain"`,
+		"q\"b\\":      `"q\"b\\"`,
+		"\n\t\x01":    `"\n\t\u0001"`,
+		"<&>":         `"<&>"`,
+		"юникод":      `"юникод"`,
+		"bad\xffutf8": `"bad` + "�" + `utf8"`,
+	}
+	for in, want := range cases {
+		if got := string(AppendJSONString(nil, in)); got != want {
+			t.Errorf("AppendJSONString(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestJSONDecoderScalars(t *testing.T) {
+	d := NewJSONDecoder([]byte(` [ "aé😀\n", 12, "-34", 5e2, "18446744073709551615", "NaN", 1.25, true, "AQID", "AQI", "_-8" ] `))
+	var (
+		str   string
+		i32   int32
+		i64   int64
+		u32   uint32
+		u64   uint64
+		f64   float64
+		f32   float32
+		b     bool
+		bytes [][]byte
+	)
+	steps := []func() error{
+		func() (err error) { str, err = d.ReadString(); return },
+		func() (err error) { i32, err = d.ReadInt32(); return },
+		func() (err error) { i64, err = d.ReadInt64(); return },
+		func() (err error) { u32, err = d.ReadUint32(); return },
+		func() (err error) { u64, err = d.ReadUint64(); return },
+		func() (err error) { f64, err = d.ReadFloat64(); return },
+		func() (err error) { f32, err = d.ReadFloat32(); return },
+		func() (err error) { b, err = d.ReadBool(); return },
+	}
+	for range 3 {
+		steps = append(steps, func() error {
+			v, err := d.ReadBytes()
+			bytes = append(bytes, v)
+			return err
+		})
+	}
+	step := 0
+	if err := d.ReadArray(func() error {
+		err := steps[step]()
+		step++
+		return err
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.End(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if str != "aé😀\n" || i32 != 12 || i64 != -34 || u32 != 500 || u64 != math.MaxUint64 || !math.IsNaN(f64) || f32 != 1.25 || !b {
+		t.Errorf("unexpected values: %q %v %v %v %v %v %v %v", str, i32, i64, u32, u64, f64, f32, b)
+	}
+	if string(bytes[0]) != "\x01\x02\x03" || string(bytes[1]) != "\x01\x02" || string(bytes[2]) != "\xff\xef" {
+		t.Errorf("unexpected bytes: %v", bytes)
+	}
+}
+
+func TestJSONDecoderErrors(t *testing.T) {
+	cases := map[string]func(d *JSONDecoder) error{
+		`2147483648`: func(d *JSONDecoder) error { _, err := d.ReadInt32(); return err },
+		`1.5`:        func(d *JSONDecoder) error { _, err := d.ReadInt64(); return err },
+		`-1`:         func(d *JSONDecoder) error { _, err := d.ReadUint32(); return err },
+		`"12x"`:      func(d *JSONDecoder) error { _, err := d.ReadInt32(); return err },
+		`01`:         func(d *JSONDecoder) error { _, err := d.ReadInt32(); return err },
+		`"\x01"`:     func(d *JSONDecoder) error { _, err := d.ReadString(); return err },
+		`"abc`:       func(d *JSONDecoder) error { _, err := d.ReadString(); return err },
+		`"ZZZ"`:      func(d *JSONDecoder) error { _, err := d.ReadEnum(map[string]int32{"A": 1}); return err },
+		`{"a" 1}`:    func(d *JSONDecoder) error { return d.Skip() },
+		`[1,]`:       func(d *JSONDecoder) error { return d.Skip() },
+		`nul`:        func(d *JSONDecoder) error { return d.Skip() },
+		`truex`:      func(d *JSONDecoder) error { _, err := d.ReadBool(); return err },
+	}
+	for in, read := range cases {
+		if err := read(NewJSONDecoder([]byte(in))); err == nil {
+			t.Errorf("expected an error for %v", in)
+		}
+	}
+}
+
+func TestJSONDecoderSkip(t *testing.T) {
+	d := NewJSONDecoder([]byte(`{"a": [1, {"b": null}, "x", -0.5e3, false], "c": {}}`))
+	if err := d.Skip(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.End(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestJSONDecoderReadAny(t *testing.T) {
+	d := NewJSONDecoder([]byte(`{"a": [1, {"b": null}, "x", -0.5e3, false], "c": {}}`))
+	v, err := d.ReadAny()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{"a": []any{1.0, map[string]any{"b": nil}, "x", -500.0, false}, "c": map[string]any{}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected value %#v", v)
+	}
+	if _, err := NewJSONDecoder([]byte(`[1e999]`)).ReadAny(); err == nil {
+		t.Errorf("numbers out of the float64 range should be rejected")
+	}
+}
+
+func TestJSONDecoderAnyType(t *testing.T) {
+	d := NewJSONDecoder([]byte(`{"a": {"@type": "inner"}, "@type": "type.googleapis.com/x.Y", "b": 1}`))
+	typeURL, err := d.PeekAnyType()
+	if err != nil || typeURL != "type.googleapis.com/x.Y" {
+		t.Fatalf("unexpected type %q, %v", typeURL, err)
+	}
+	// nothing was consumed, the next object skips "@type"
+	d.SkipAnyType()
+	var keys []string
+	err = d.ReadObject(func(key string) error {
+		keys = append(keys, key)
+		return d.Skip()
+	})
+	if err != nil || !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("unexpected keys %v, %v", keys, err)
+	}
+	if err := d.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	if typeURL, err := NewJSONDecoder([]byte(`{"a": 1}`)).PeekAnyType(); typeURL != "" || err != nil {
+		t.Errorf("unexpected type %q, %v", typeURL, err)
+	}
+	for _, in := range []string{`{"@type": 1}`, `{"@type": ""}`, `{"@type": "a", "@type": "b"}`, `[]`} {
+		if _, err := NewJSONDecoder([]byte(in)).PeekAnyType(); err == nil {
+			t.Errorf("expected an error for %v", in)
+		}
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	ints := map[int32]bool{10: true, -3: true, 2: true}
+	if keys := SortedKeys(ints); keys[0] != -3 || keys[1] != 2 || keys[2] != 10 {
+		t.Errorf("unexpected order: %v", keys)
+	}
+	if keys := SortedBoolKeys(map[bool]int{true: 1, false: 0}); len(keys) != 2 || keys[0] || !keys[1] {
+		t.Errorf("unexpected order: %v", keys)
+	}
+	if got := string(AppendJSONMapKeyInt(nil, -3)); got != `"-3"` {
+		t.Errorf("unexpected key %v", got)
+	}
+	if v, err := ParseJSONInt("-3", 32); err != nil || v != -3 {
+		t.Errorf("unexpected key %v %v", v, err)
+	}
+	if _, err := ParseJSONUint("4294967296", 32); err == nil {
+		t.Errorf("expected overflow error")
+	}
+}
+
+func TestJSONStreamFlushesChunks(t *testing.T) {
+	var out bytes.Buffer
+	s := GetJSONStream(&out)
+	defer PutJSONStream(s)
+
+	s.B = append(s.B, "small"...)
+	if err := s.Check(); err != nil || out.Len() != 0 {
+		t.Fatalf("small output should stay buffered, written %v, err %v", out.Len(), err)
+	}
+	s.B = append(s.B, make([]byte, jsonStreamChunk)...)
+	if err := s.Check(); err != nil || out.Len() != jsonStreamChunk+5 || len(s.B) != 0 {
+		t.Fatalf("large output should be flushed, written %v, err %v", out.Len(), err)
+	}
+}
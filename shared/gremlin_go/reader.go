@@ -1,5 +1,10 @@
 package gremlin
 
+import (
+	"fmt"
+	"math"
+)
+
 type ProtoWireType int8
 type ProtoWireNumber int32
 
@@ -12,8 +17,34 @@ const (
 	Fixed32Type    ProtoWireType = 5
 )
 
+// BufferMode controls whether strings and bytes returned by readers reference the input buffer.
+type BufferMode int8
+
+const (
+	// BufferCopyStrings copies strings, bytes fields alias the input buffer. This is the default.
+	BufferCopyStrings BufferMode = iota
+	// BufferAlias never copies: strings are built over the input buffer with unsafe.String,
+	// so the buffer must not be modified while the reader or any value read from it is in use.
+	BufferAlias
+	// BufferDetached copies strings and bytes fields, values read from the reader
+	// never reference the input buffer.
+	BufferDetached
+)
+
+type ReaderOptions struct {
+	Buffer BufferMode
+	// Arena, when set, provides child readers, slices and maps for readers and ToStruct.
+	Arena *Arena
+	// StopEarly lets UnmarshalFieldsWithOptions stop once every requested singular field was found.
+	// A field is then read from its last occurrence before the stop, occurrences after it are never
+	// seen, while protobuf takes the last one in the message, so it is only correct for data with no
+	// duplicate fields.
+	StopEarly bool
+}
+
 type Reader struct {
-	buf []byte
+	buf  []byte
+	opts ReaderOptions
 }
 
 func NewReader(data []byte) *Reader {
@@ -22,6 +53,119 @@ func NewReader(data []byte) *Reader {
 	}
 }
 
+// Init points the reader at data. Offsets are kept as int32 by generated readers,
+// so messages larger than 2GB are rejected.
+func (p *Reader) Init(data []byte) error {
+	return p.InitWithOptions(data, ReaderOptions{})
+}
+
+func (p *Reader) InitWithOptions(data []byte, opts ReaderOptions) error {
+	if len(data) > math.MaxInt32 {
+		return fmt.Errorf("message too large: %v bytes", len(data))
+	}
+	p.buf = data
+	p.opts = opts
+	return nil
+}
+
+// Options returns the options the reader was initialised with, nested readers inherit them.
+func (p *Reader) Options() ReaderOptions {
+	return p.opts
+}
+
+func (p *Reader) Arena() *Arena {
+	return p.opts.Arena
+}
+
+// Rebase moves the bytes of the reader with d. Used by generated Detach.
+func (p *Reader) Rebase(d *Detacher) {
+	p.buf = d.RebaseBytes(p.buf)
+}
+
+// Release drops the bytes of the reader once d has moved the values still in use. Used by generated Detach
+// for messages that never read their bytes again after Freeze.
+func (p *Reader) Release(d *Detacher) {
+	if d.moving {
+		p.buf = nil
+	}
+}
+
+// FieldOffset records a single occurrence of a repeated or map field.
+// Generated readers keep all of them in one slice instead of a slice per field.
+type FieldOffset struct {
+	Field  FieldIndex
+	Offset int32
+	Wire   ProtoWireType
+}
+
+// CountFieldOffsets returns the number of occurrences of field in offsets.
+func CountFieldOffsets(offsets []FieldOffset, field FieldIndex) int {
+	var res = 0
+	for i := range offsets {
+		if offsets[i].Field == field {
+			res++
+		}
+	}
+	return res
+}
+
+// CountPacked returns an upper bound of the number of elements stored for a
+// packable field: packed chunks contribute len/minSize, unpacked entries one each.
+func (p *Reader) CountPacked(offsets []FieldOffset, field FieldIndex, minSize int) int {
+	var res = 0
+	for i := range offsets {
+		if offsets[i].Field != field {
+			continue
+		}
+		if offsets[i].Wire == BytesType {
+			res += len(p.ReadMessage(int(offsets[i].Offset))) / minSize
+		} else {
+			res++
+		}
+	}
+	return res
+}
+
+// NextField skips the value at offset and returns the offset and wire type of the next
+// occurrence of tag. Malformed data ends the search, callers scanning the whole message report it.
+func (p *Reader) NextField(offset int, wire ProtoWireType, tag ProtoWireNumber) (int, ProtoWireType, bool) {
+	for {
+		var err error
+		if offset, err = p.SkipData(offset, wire); err != nil || !p.HasNext(offset, 0) {
+			return 0, 0, false
+		}
+		var nextTag ProtoWireNumber
+		var tagSize int
+		if nextTag, wire, tagSize, err = p.ReadTagAt(offset); err != nil {
+			return 0, 0, false
+		}
+		offset += tagSize
+		if nextTag == tag {
+			return offset, wire, true
+		}
+	}
+}
+
+// MergeMessage returns a reader and an offset of a singular message field whose occurrences from first to last
+// are concatenated, which decodes as their merge. A field occurring once is returned as is. Used by generated
+// transcoders, the occurrences must have been checked with FieldEnd.
+func (p *Reader) MergeMessage(first int, last int, tag ProtoWireNumber) (*Reader, int) {
+	if first == last {
+		return p, last
+	}
+	var payload []byte
+	for offset, wire, found := first, BytesType, true; found; offset, wire, found = p.NextField(offset, wire, tag) {
+		payload = append(payload, p.ReadMessage(offset)...)
+		if offset == last {
+			break
+		}
+	}
+	w := NewWriter(SizeVarInt(uint64(len(payload))) + len(payload))
+	w.appendVarInt(uint64(len(payload)))
+	w.AppendRaw(payload)
+	return &Reader{buf: w.Bytes(), opts: p.opts}, 0
+}
+
 func (p *Reader) Bytes() []byte {
 	if p == nil {
 		return nil
@@ -31,3 +31,84 @@ func TestVectorOfEnums(t *testing.T) {
 		}
 	}
 }
+
+func TestBufferModes(t *testing.T) {
+	// length-delimited "abc"
+	data := []byte{3, 'a', 'b', 'c'}
+
+	var buf Reader
+	for _, mode := range []BufferMode{BufferCopyStrings, BufferAlias, BufferDetached} {
+		if err := buf.InitWithOptions(data, ReaderOptions{Buffer: mode}); err != nil {
+			t.Fatalf("failed to init: %v", err)
+		}
+		str := buf.ReadString(0)
+		raw := buf.ReadBytes(0)
+		if str != "abc" || string(raw) != "abc" {
+			t.Fatalf("mode %v: got %q and %q", mode, str, raw)
+		}
+
+		data[1] = 'x'
+		if aliased := str == "xbc"; aliased != (mode == BufferAlias) {
+			t.Errorf("mode %v: unexpected string aliasing, got %q", mode, str)
+		}
+		if aliased := raw[0] == 'x'; aliased != (mode != BufferDetached) {
+			t.Errorf("mode %v: unexpected bytes aliasing, got %q", mode, raw)
+		}
+		data[1] = 'a'
+	}
+}
+
+func TestDetacher(t *testing.T) {
+	from := []byte("hello big world")
+
+	var buf Reader
+	if err := buf.InitWithOptions(from, ReaderOptions{Buffer: BufferAlias}); err != nil {
+		t.Fatalf("failed to init: %v", err)
+	}
+	str := buf.toString(from[10:])
+	raw := from[:5]
+	inner := from[1:4]
+	other := []byte("other")
+
+	d := NewDetacher(from)
+	for pass := 0; pass < 2; pass++ {
+		if pass == 1 {
+			d.Copy()
+		}
+		str = d.RebaseString(str)
+		raw = d.RebaseBytes(raw)
+		inner = d.RebaseBytes(inner)
+		if got := d.RebaseBytes(other); &got[0] != &other[0] {
+			t.Errorf("unrelated slice should not be rebased")
+		}
+	}
+
+	for i := range from {
+		from[i] = '-'
+	}
+	if str != "world" || string(raw) != "hello" || string(inner) != "ell" {
+		t.Errorf("rebased values still reference the old buffer: %q %q %q", str, raw, inner)
+	}
+	// only the referenced bytes are copied, " big " is dropped
+	if len(d.to) != len("helloworld") {
+		t.Errorf("copied %q", d.to)
+	}
+}
+
+func TestNextField(t *testing.T) {
+	w := NewWriter(32)
+	w.AppendInt32(3, 1)
+	w.AppendString(5, "skip")
+	w.AppendInt32(3, 2)
+	w.AppendInt64(4, 7)
+	buf := NewReader(w.Bytes())
+
+	_, wire, tagSize, _ := buf.ReadTagAt(0)
+	var values []int32
+	for offset, wire, found := tagSize, wire, true; found; offset, wire, found = buf.NextField(offset, wire, 3) {
+		values = append(values, buf.ReadInt32(offset))
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+}
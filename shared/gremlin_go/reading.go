@@ -1,8 +1,11 @@
 package gremlin
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math"
+	"unsafe"
 )
 
 func (p *Reader) ReadTagAt(offset int) (ProtoWireNumber, ProtoWireType, int, error) {
@@ -55,6 +58,52 @@ func (p *Reader) SkipData(offset int, protoType ProtoWireType) (int, error) {
 	return 0, fmt.Errorf("invalid wire type while skipping data: %v", protoType)
 }
 
+// ErrTruncated is returned when a value runs past the end of the data holding it.
+var ErrTruncated = errors.New("unexpected end of data")
+
+// WireTypeError is returned when a field is encoded with a wire type its type cannot have.
+func WireTypeError(tag ProtoWireNumber, wire ProtoWireType) error {
+	return fmt.Errorf("field %v: unexpected wire type %v", tag, wire)
+}
+
+// FieldEnd is SkipData that also checks the value ends inside the data, so it can be read safely.
+func (p *Reader) FieldEnd(offset int, wire ProtoWireType) (int, error) {
+	end, err := p.SkipData(offset, wire)
+	if err != nil {
+		return 0, err
+	}
+	if end < offset || end > len(p.buf) {
+		return 0, ErrTruncated
+	}
+	return end, nil
+}
+
+// CountField returns the number of values in the run of occurrences of tag starting at offset. Encoders write
+// all values of a field together, so it is used to presize repeated fields decoded in a single pass. With minSize
+// above zero length-delimited occurrences are packed and count at most len/minSize values.
+func (p *Reader) CountField(offset int, wire ProtoWireType, tag ProtoWireNumber, minSize int) int {
+	var res = 0
+	for {
+		end, err := p.FieldEnd(offset, wire)
+		if err != nil {
+			return res
+		}
+		if wire == BytesType && minSize > 0 {
+			res += (end - offset) / minSize
+		} else {
+			res++
+		}
+		if !p.HasNext(end, 0) {
+			return res
+		}
+		nextTag, nextWire, tagSize, err := p.ReadTagAt(end)
+		if err != nil || nextTag != tag {
+			return res
+		}
+		offset, wire = end+tagSize, nextWire
+	}
+}
+
 func (p *Reader) HasNext(offset int, size int) bool {
 	return offset+size < len(p.buf)
 }
@@ -80,23 +129,47 @@ func (p *Reader) readFixed64At(offset int) uint64 {
 		uint64(p.buf[offset+4])<<32 | uint64(p.buf[offset+5])<<40 | uint64(p.buf[offset+6])<<48 | uint64(p.buf[offset+7])<<56
 }
 
-func (p *Reader) ReadBytes(offset int) []byte {
+// ReadMessage returns length-delimited data without copying, regardless of the buffer mode.
+func (p *Reader) ReadMessage(offset int) []byte {
 	size, sizeSize := p.readVarIntAt(offset)
 	return p.buf[offset+sizeSize : offset+sizeSize+int(size)]
 }
 
-func (p *Reader) SizedReadBytes(offset int) ([]byte, int) {
+func (p *Reader) SizedReadMessage(offset int) ([]byte, int) {
 	size, sizeSize := p.readVarIntAt(offset)
 	return p.buf[offset+sizeSize : offset+sizeSize+int(size)], sizeSize + int(size)
 }
 
+func (p *Reader) ReadBytes(offset int) []byte {
+	v := p.ReadMessage(offset)
+	if p.opts.Buffer == BufferDetached {
+		return bytes.Clone(v)
+	}
+	return v
+}
+
+func (p *Reader) SizedReadBytes(offset int) ([]byte, int) {
+	v, size := p.SizedReadMessage(offset)
+	if p.opts.Buffer == BufferDetached {
+		return bytes.Clone(v), size
+	}
+	return v, size
+}
+
 func (p *Reader) ReadString(offset int) string {
-	return string(p.ReadBytes(offset))
+	return p.toString(p.ReadMessage(offset))
 }
 
 func (p *Reader) SizedReadString(offset int) (string, int) {
-	v, size := p.SizedReadBytes(offset)
-	return string(v), size
+	v, size := p.SizedReadMessage(offset)
+	return p.toString(v), size
+}
+
+func (p *Reader) toString(v []byte) string {
+	if p.opts.Buffer == BufferAlias {
+		return unsafe.String(unsafe.SliceData(v), len(v))
+	}
+	return string(v)
 }
 
 func (p *Reader) ReadVarInt(offset int) uint64 {
@@ -177,6 +250,9 @@ func (p *Reader) ReadFloat32(offset int) float32 {
 }
 
 func (p *Reader) SizedReadFloat32(offset int) (float32, int) {
+	if !p.HasNext(offset, 3) {
+		return 0, -1
+	}
 	v := p.readFixed32At(offset)
 	return math.Float32frombits(uint32(v)), 4
 }
@@ -187,6 +263,9 @@ func (p *Reader) ReadFloat64(offset int) float64 {
 }
 
 func (p *Reader) SizedReadFloat64(offset int) (float64, int) {
+	if !p.HasNext(offset, 7) {
+		return 0, -1
+	}
 	v := p.readFixed64At(offset)
 	return math.Float64frombits(v), 8
 }
@@ -196,6 +275,9 @@ func (p *Reader) ReadFixed32(offset int) uint32 {
 }
 
 func (p *Reader) SizedReadFixed32(offset int) (uint32, int) {
+	if !p.HasNext(offset, 3) {
+		return 0, -1
+	}
 	return uint32(p.readFixed32At(offset)), 4
 }
 
@@ -204,6 +286,9 @@ func (p *Reader) ReadFixed64(offset int) uint64 {
 }
 
 func (p *Reader) SizedReadFixed64(offset int) (uint64, int) {
+	if !p.HasNext(offset, 7) {
+		return 0, -1
+	}
 	return p.readFixed64At(offset), 8
 }
 
@@ -212,6 +297,9 @@ func (p *Reader) ReadSFixed32(offset int) int32 {
 }
 
 func (p *Reader) SizedReadSFixed32(offset int) (int32, int) {
+	if !p.HasNext(offset, 3) {
+		return 0, -1
+	}
 	return int32(p.readFixed32At(offset)), 4
 }
 
@@ -220,6 +308,9 @@ func (p *Reader) ReadSFixed64(offset int) int64 {
 }
 
 func (p *Reader) SizedReadSFixed64(offset int) (int64, int) {
+	if !p.HasNext(offset, 7) {
+		return 0, -1
+	}
 	return int64(p.readFixed64At(offset)), 8
 }
 
@@ -333,3 +424,35 @@ func (p *Reader) readVarIntAt(offset int) (v uint64, n int) {
 	}
 	return 0, -1
 }
+
+// ReadWrapper returns the value field of a wrapper message such as google.protobuf.StringValue encoded in data,
+// read with the Reader method of its type from values of the given wire type. A missing value is the zero value,
+// the last occurrence wins.
+func ReadWrapper[T any](data []byte, opts ReaderOptions, valueWire ProtoWireType, read func(*Reader, int) T) (T, error) {
+	var value T
+	var buf Reader
+	if err := buf.InitWithOptions(data, opts); err != nil {
+		return value, err
+	}
+	offset := 0
+	for buf.HasNext(offset, 0) {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return value, err
+		}
+		offset += tagSize
+		// the value is read only once it is known to be complete
+		next, err := buf.FieldEnd(offset, wire)
+		if err != nil {
+			return value, err
+		}
+		if tag == 1 {
+			if wire != valueWire {
+				return value, WireTypeError(tag, wire)
+			}
+			value = read(&buf, offset)
+		}
+		offset = next
+	}
+	return value, nil
+}
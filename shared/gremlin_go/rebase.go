@@ -0,0 +1,110 @@
+package gremlin
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// rebaseOffset returns the position of [ptr, ptr+size) inside from, or -1 if it lies elsewhere.
+func rebaseOffset(from []byte, ptr *byte, size int) int {
+	if len(from) == 0 || ptr == nil {
+		return -1
+	}
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(from)))
+	p := uintptr(unsafe.Pointer(ptr))
+	if p < start || p+uintptr(size) > start+uintptr(len(from)) {
+		return -1
+	}
+	return int(p - start)
+}
+
+type detachedRange struct {
+	start, end int // position in the input buffer
+	to         int // position in the copy
+}
+
+// Detacher moves values referencing an input buffer to a compact copy of the parts still in use. Generated
+// XXX_Rebase passes every value through it twice: before Copy values are recorded, after Copy they are moved.
+type Detacher struct {
+	from   []byte
+	to     []byte
+	ranges []detachedRange
+	moving bool
+}
+
+func NewDetacher(from []byte) *Detacher {
+	return &Detacher{from: from}
+}
+
+// Copy merges the recorded ranges and copies them next to each other into a new buffer.
+func (d *Detacher) Copy() {
+	slices.SortFunc(d.ranges, func(a, b detachedRange) int {
+		return a.start - b.start
+	})
+	merged := d.ranges[:0]
+	size := 0
+	for _, r := range d.ranges {
+		if last := len(merged) - 1; last >= 0 && r.start <= merged[last].end {
+			if r.end > merged[last].end {
+				size += r.end - merged[last].end
+				merged[last].end = r.end
+			}
+			continue
+		}
+		r.to = size
+		size += r.end - r.start
+		merged = append(merged, r)
+	}
+	d.ranges = merged
+	d.to = make([]byte, 0, size)
+	for _, r := range d.ranges {
+		d.to = append(d.to, d.from[r.start:r.end]...)
+	}
+	d.moving = true
+}
+
+// rebase records [offset, offset+size) before Copy and returns its position in the copy after it.
+func (d *Detacher) rebase(offset int, size int) int {
+	if !d.moving {
+		d.ranges = append(d.ranges, detachedRange{start: offset, end: offset + size})
+		return -1
+	}
+	i, found := slices.BinarySearchFunc(d.ranges, offset, func(r detachedRange, offset int) int {
+		if r.end < offset {
+			return -1
+		}
+		if r.start > offset {
+			return 1
+		}
+		return 0
+	})
+	if !found {
+		return -1
+	}
+	r := d.ranges[i]
+	return r.to + offset - r.start
+}
+
+// RebaseBytes moves v to the copy if it references the input buffer, other values are returned as is.
+func (d *Detacher) RebaseBytes(v []byte) []byte {
+	offset := rebaseOffset(d.from, unsafe.SliceData(v), len(v))
+	if offset < 0 {
+		return v
+	}
+	if to := d.rebase(offset, len(v)); to >= 0 {
+		return d.to[to : to+len(v)]
+	}
+	return v
+}
+
+// RebaseString is RebaseBytes for strings created with BufferAlias.
+func (d *Detacher) RebaseString(v string) string {
+	offset := rebaseOffset(d.from, unsafe.StringData(v), len(v))
+	if offset < 0 || len(v) == 0 {
+		return v
+	}
+	if to := d.rebase(offset, len(v)); to >= 0 {
+		return unsafe.String(&d.to[to], len(v))
+	}
+	return v
+}
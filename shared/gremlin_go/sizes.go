@@ -0,0 +1,196 @@
+package gremlin
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// FieldSizes is the space a field path takes, summed over all analyzed messages.
+type FieldSizes struct {
+	Path   string // dotted field names from the root, unknown fields are #number
+	Count  int    // occurrences on the wire, a packed field counts once per occurrence
+	Values int    // values, every element of a packed field counts
+
+	Bytes       int // total bytes including tags, lengths and nested fields
+	TagBytes    int
+	LengthBytes int
+
+	// PackedBytes and UnpackedBytes are the sizes of repeated scalar fields in each encoding,
+	// whichever encoding was used on the wire.
+	PackedBytes   int
+	UnpackedBytes int
+}
+
+// SizeBreakdown aggregates the bytes per field path of a stream of messages of one type.
+type SizeBreakdown struct {
+	Messages int
+	Bytes    int
+
+	desc   *MessageDescriptor
+	fields map[string]*FieldSizes
+}
+
+func NewSizeBreakdown(desc *MessageDescriptor) *SizeBreakdown {
+	return &SizeBreakdown{desc: desc, fields: map[string]*FieldSizes{}}
+}
+
+// Add analyzes one message, malformed messages are an error and are not counted.
+func (b *SizeBreakdown) Add(data []byte) error {
+	fields := map[string]*FieldSizes{}
+	if err := analyzeSizes(NewReader(data), 0, len(data), "", b.desc, fields); err != nil {
+		return err
+	}
+	b.Messages++
+	b.Bytes += len(data)
+	for path, f := range fields {
+		total := b.fields[path]
+		if total == nil {
+			total = &FieldSizes{Path: path}
+			b.fields[path] = total
+		}
+		total.Count += f.Count
+		total.Values += f.Values
+		total.Bytes += f.Bytes
+		total.TagBytes += f.TagBytes
+		total.LengthBytes += f.LengthBytes
+		total.PackedBytes += f.PackedBytes
+		total.UnpackedBytes += f.UnpackedBytes
+	}
+	return nil
+}
+
+// Fields returns all field paths seen, largest first.
+func (b *SizeBreakdown) Fields() []*FieldSizes {
+	res := make([]*FieldSizes, 0, len(b.fields))
+	for _, f := range b.fields {
+		res = append(res, f)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Bytes != res[j].Bytes {
+			return res[i].Bytes > res[j].Bytes
+		}
+		return res[i].Path < res[j].Path
+	})
+	return res
+}
+
+func sizeFieldPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// packedWireType returns the wire type of the elements of a packable field, BytesType if it is not packable.
+func packedWireType(kind FieldKind) ProtoWireType {
+	switch kind {
+	case KindString, KindBytes, KindMessage:
+		return BytesType
+	case KindFixed32, KindSfixed32, KindFloat:
+		return Fixed32Type
+	case KindFixed64, KindSfixed64, KindDouble:
+		return Fixed64Type
+	default:
+		return VarIntType
+	}
+}
+
+// analyzeSizes adds the fields between start and end to fields, desc is nil for unknown types.
+func analyzeSizes(buf *Reader, start, end int, path string, desc *MessageDescriptor, fields map[string]*FieldSizes) error {
+	// repeated scalars of this message, to compare both encodings once all occurrences are known
+	type repeatedScalar struct {
+		tagSize    int
+		values     int
+		valueBytes int
+	}
+	var repeated map[string]*repeatedScalar
+
+	for offset := start; offset < end; {
+		tag, wire, tagSize, err := buf.ReadTagAt(offset)
+		if err != nil {
+			return fmt.Errorf("%v: offset %v: %w", path, offset, err)
+		}
+		next, err := buf.SkipData(offset+tagSize, wire)
+		if err != nil {
+			return fmt.Errorf("%v: field %v: %w", path, tag, err)
+		}
+		if tag == 0 || next > end || next <= offset+tagSize {
+			return fmt.Errorf("%v: offset %v: malformed field %v", path, offset, tag)
+		}
+
+		var fd *FieldDescriptor
+		if desc != nil {
+			fd = desc.FieldByNumber(tag)
+		}
+		elemWire := BytesType
+		if fd != nil && !fd.IsMap() {
+			elemWire = packedWireType(fd.Kind)
+		}
+		packed := fd != nil && fd.Label == LabelRepeated && elemWire != BytesType && wire == BytesType
+		if fd != nil && wire != elemWire && !packed {
+			fd = nil // wire type mismatch, the field is unknown to readers
+		}
+		fieldPath := sizeFieldPath(path, "#"+strconv.Itoa(int(tag)))
+		if fd != nil {
+			fieldPath = sizeFieldPath(path, fd.Name)
+		}
+
+		f := fields[fieldPath]
+		if f == nil {
+			f = &FieldSizes{Path: fieldPath}
+			fields[fieldPath] = f
+		}
+		f.Count++
+		f.Bytes += next - offset
+		f.TagBytes += tagSize
+
+		valueStart := offset + tagSize
+		var content []byte
+		if wire == BytesType {
+			content = buf.ReadMessage(valueStart)
+			f.LengthBytes += next - valueStart - len(content)
+		}
+		values, valueBytes := 1, next-valueStart
+		if packed {
+			values, valueBytes = 0, len(content)
+			for pos := next - len(content); pos < next; values++ {
+				if pos, err = buf.SkipData(pos, elemWire); err != nil || pos > next {
+					return fmt.Errorf("%v: malformed packed value", fieldPath)
+				}
+			}
+		}
+		f.Values += values
+
+		if fd != nil && fd.Label == LabelRepeated && elemWire != BytesType {
+			if repeated == nil {
+				repeated = map[string]*repeatedScalar{}
+			}
+			r := repeated[fieldPath]
+			if r == nil {
+				r = &repeatedScalar{tagSize: tagSize}
+				repeated[fieldPath] = r
+			}
+			r.values += values
+			r.valueBytes += valueBytes
+		}
+
+		if fd != nil && fd.Kind == KindMessage {
+			nested := fd.Message()
+			if fd.IsMap() {
+				nested = &MessageDescriptor{FullName: fd.TypeName, Fields: []*FieldDescriptor{fd.MapKey, fd.MapValue}}
+			}
+			if err := analyzeSizes(buf, next-len(content), next, fieldPath, nested, fields); err != nil {
+				return err
+			}
+		}
+		offset = next
+	}
+
+	for fieldPath, r := range repeated {
+		f := fields[fieldPath]
+		f.PackedBytes += r.tagSize + SizeVarInt(uint64(r.valueBytes)) + r.valueBytes
+		f.UnpackedBytes += r.values*r.tagSize + r.valueBytes
+	}
+	return nil
+}
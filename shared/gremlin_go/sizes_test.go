@@ -0,0 +1,56 @@
+package gremlin
+
+import "testing"
+
+func TestSizeBreakdown(t *testing.T) {
+	inner := &MessageDescriptor{
+		FullName: "sizes_test.Inner",
+		Fields:   []*FieldDescriptor{{Name: "id", Number: 1, Kind: KindInt32, Label: LabelOptional}},
+	}
+	desc := &MessageDescriptor{
+		FullName: "sizes_test.Outer",
+		Fields: []*FieldDescriptor{
+			{Name: "name", Number: 1, Kind: KindString, Label: LabelOptional},
+			{Name: "values", Number: 2, Kind: KindInt32, Label: LabelRepeated},
+			{Name: "inner", Number: 3, Kind: KindMessage, Label: LabelOptional, TypeName: inner.FullName, MessageType: inner},
+		},
+	}
+
+	w := NewWriter(64)
+	w.AppendString(1, "abc")                // 5 bytes
+	w.AppendBytes(2, []byte{1, 2, 0xac, 2}) // packed, 6 bytes
+	w.AppendInt32(2, 7)                     // unpacked, 2 bytes
+	w.AppendBytes(3, []byte{8, 1, 0x28, 9}) // inner with an unknown field, 6 bytes
+	w.AppendInt32(9, 1)                     // unknown, 2 bytes
+
+	sizes := NewSizeBreakdown(desc)
+	for i := 0; i < 2; i++ {
+		if err := sizes.Add(w.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sizes.Add(w.Bytes()[:5+6+1]); err == nil {
+		t.Errorf("truncated data should fail")
+	}
+	if sizes.Messages != 2 || sizes.Bytes != 42 {
+		t.Fatalf("unexpected totals %v messages, %v bytes", sizes.Messages, sizes.Bytes)
+	}
+
+	expected := []FieldSizes{
+		{Path: "values", Count: 4, Values: 8, Bytes: 16, TagBytes: 4, LengthBytes: 2, PackedBytes: 14, UnpackedBytes: 18},
+		{Path: "inner", Count: 2, Values: 2, Bytes: 12, TagBytes: 2, LengthBytes: 2},
+		{Path: "name", Count: 2, Values: 2, Bytes: 10, TagBytes: 2, LengthBytes: 2},
+		{Path: "#9", Count: 2, Values: 2, Bytes: 4, TagBytes: 2},
+		{Path: "inner.#5", Count: 2, Values: 2, Bytes: 4, TagBytes: 2},
+		{Path: "inner.id", Count: 2, Values: 2, Bytes: 4, TagBytes: 2},
+	}
+	fields := sizes.Fields()
+	if len(fields) != len(expected) {
+		t.Fatalf("unexpected fields %v", len(fields))
+	}
+	for i := range expected {
+		if *fields[i] != expected[i] {
+			t.Errorf("unexpected sizes %+v, expected %+v", *fields[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package gremlin
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
+)
+
+type textToken uint8
+
+const (
+	textNone textToken = iota
+	textName
+	textScalar
+	textMessageOpen
+	textMessageClose
+)
+
+// TextWriter writes the protobuf text format, used by generated code. The layout matches
+// prototext: single line output puts a space between fields, multiline output indents
+// nested messages by two spaces.
+type TextWriter struct {
+	B []byte
+
+	multiline bool
+	indents   []byte
+	last      textToken
+}
+
+func NewTextWriter(b []byte, multiline bool) *TextWriter {
+	return &TextWriter{B: b, multiline: multiline}
+}
+
+// Finish ends the output, multiline text ends with a newline like prototext.
+func (w *TextWriter) Finish() []byte {
+	if w.multiline && len(w.B) > 0 {
+		w.B = append(w.B, '\n')
+	}
+	return w.B
+}
+
+func (w *TextWriter) prepareNext(next textToken) {
+	defer func() {
+		w.last = next
+	}()
+	if !w.multiline {
+		if (w.last == textScalar || w.last == textMessageClose) && next == textName {
+			w.B = append(w.B, ' ')
+		}
+		return
+	}
+	switch {
+	case w.last == textName:
+		w.B = append(w.B, ' ')
+	case w.last == textMessageOpen && next != textMessageClose:
+		w.indents = append(w.indents, "  "...)
+		w.B = append(w.B, '\n')
+		w.B = append(w.B, w.indents...)
+	case w.last == textScalar || w.last == textMessageClose:
+		if next == textMessageClose {
+			w.indents = w.indents[:len(w.indents)-2]
+		}
+		w.B = append(w.B, '\n')
+		w.B = append(w.B, w.indents...)
+	}
+}
+
+func (w *TextWriter) WriteName(name string) {
+	w.prepareNext(textName)
+	w.B = append(w.B, name...)
+	w.B = append(w.B, ':')
+}
+
+func (w *TextWriter) StartMessage() {
+	w.prepareNext(textMessageOpen)
+	w.B = append(w.B, '{')
+}
+
+func (w *TextWriter) EndMessage() {
+	w.prepareNext(textMessageClose)
+	w.B = append(w.B, '}')
+}
+
+func (w *TextWriter) writeLiteral(s string) {
+	w.prepareNext(textScalar)
+	w.B = append(w.B, s...)
+}
+
+func (w *TextWriter) WriteBool(v bool) {
+	if v {
+		w.writeLiteral("true")
+	} else {
+		w.writeLiteral("false")
+	}
+}
+
+func (w *TextWriter) WriteInt(v int64) {
+	w.prepareNext(textScalar)
+	w.B = strconv.AppendInt(w.B, v, 10)
+}
+
+func (w *TextWriter) WriteUint(v uint64) {
+	w.prepareNext(textScalar)
+	w.B = strconv.AppendUint(w.B, v, 10)
+}
+
+func (w *TextWriter) WriteFloat(v float64, bitSize int) {
+	switch {
+	case math.IsNaN(v):
+		w.writeLiteral("nan")
+	case math.IsInf(v, 1):
+		w.writeLiteral("inf")
+	case math.IsInf(v, -1):
+		w.writeLiteral("-inf")
+	default:
+		w.prepareNext(textScalar)
+		w.B = strconv.AppendFloat(w.B, v, 'g', -1, bitSize)
+	}
+}
+
+// WriteEnum writes the name of an enum value, or its number when the value is unknown.
+func (w *TextWriter) WriteEnum(v int32, name string) {
+	if name == "" {
+		w.WriteInt(int64(v))
+		return
+	}
+	w.writeLiteral(name)
+}
+
+func (w *TextWriter) WriteString(s string) {
+	w.prepareNext(textScalar)
+	w.B = appendTextString(w.B, s)
+}
+
+func (w *TextWriter) WriteBytes(v []byte) {
+	w.prepareNext(textScalar)
+	w.B = appendTextString(w.B, string(v))
+}
+
+// appendTextString quotes s with C-style escapes, bytes that are not valid UTF-8 are written as \x escapes.
+func appendTextString(b []byte, s string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= ' ' && c != '"' && c != '\\' && c < 0x7f {
+			b = append(b, c)
+			i++
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && n == 1 {
+			r = rune(c)
+		}
+		switch {
+		case r == '"' || r == '\\':
+			b = append(b, '\\', byte(r))
+		case r == '\n':
+			b = append(b, '\\', 'n')
+		case r == '\r':
+			b = append(b, '\\', 'r')
+		case r == '\t':
+			b = append(b, '\\', 't')
+		case r < ' ' || r == 0x7f || n == 1 && r >= utf8.RuneSelf:
+			b = append(b, '\\', 'x')
+			b = append(b, "00"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			b = strconv.AppendUint(b, uint64(r), 16)
+		case r <= 0x9f:
+			b = append(b, '\\', 'u')
+			b = append(b, "0000"[1+(bits.Len32(uint32(r))-1)/4:]...)
+			b = strconv.AppendUint(b, uint64(r), 16)
+		default:
+			b = append(b, s[i:i+n]...)
+		}
+		i += n
+	}
+	return append(b, '"')
+}
+
+// TextString returns the single line text form written by write, used by generated String methods.
+func TextString(write func(w *TextWriter)) string {
+	w := NewTextWriter(nil, false)
+	write(w)
+	return string(w.Finish())
+}
+
+// FormatText implements fmt.Formatter for generated code: %v and %s print single line text,
+// %+v prints multiline text and %q prints the single line text quoted.
+func FormatText(f fmt.State, verb rune, write func(w *TextWriter)) {
+	switch verb {
+	case 'v', 's', 'q':
+		w := NewTextWriter(nil, verb == 'v' && f.Flag('+'))
+		write(w)
+		out := w.Finish()
+		if verb == 'q' {
+			out = strconv.AppendQuote(nil, string(out))
+		}
+		_, _ = f.Write(out)
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(text)", verb)
+	}
+}
@@ -0,0 +1,387 @@
+package gremlin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextDecoder reads the protobuf text format, used by generated UnmarshalText.
+// Messages may be delimited by braces or angle brackets, fields may be separated
+// by commas or semicolons and repeated fields may use the list syntax.
+// Extensions and expanded Any values are not supported.
+type TextDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func NewTextDecoder(data []byte) *TextDecoder {
+	return &TextDecoder{data: data}
+}
+
+func (d *TextDecoder) errorf(format string, args ...any) error {
+	line, col := 1, 1
+	for _, c := range d.data[:min(d.pos, len(d.data))] {
+		if c == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Errorf("text: line %v:%v: %v", line, col, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips whitespace and # comments.
+func (d *TextDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			d.pos++
+		case '#':
+			for d.pos < len(d.data) && d.data[d.pos] != '\n' {
+				d.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (d *TextDecoder) peek() byte {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+func isTextIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+// readToken reads an identifier or a number literal, including a leading minus sign.
+func (d *TextDecoder) readToken() string {
+	d.skipSpace()
+	start := d.pos
+	if d.pos < len(d.data) && d.data[d.pos] == '-' {
+		d.pos++
+		d.skipSpace()
+	}
+	tokenStart := d.pos
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch {
+		case isTextIdentChar(c) || c == '.':
+		case (c == '+' || c == '-') && d.pos > tokenStart && (d.data[d.pos-1] == 'e' || d.data[d.pos-1] == 'E'):
+		default:
+			goto done
+		}
+		d.pos++
+	}
+done:
+	if d.pos == tokenStart {
+		d.pos = start
+		return ""
+	}
+	if tokenStart != start {
+		return "-" + string(d.data[tokenStart:d.pos])
+	}
+	return string(d.data[tokenStart:d.pos])
+}
+
+// ReadMessage calls field for every field name, field must consume the value.
+// The top-level message is read until the end of input, nested messages must be
+// enclosed in braces or angle brackets.
+func (d *TextDecoder) ReadMessage(field func(name string) error) error {
+	var end byte
+	if d.depth > 0 {
+		switch d.peek() {
+		case '{':
+			end = '}'
+		case '<':
+			end = '>'
+		default:
+			return d.errorf("expected '{' or '<'")
+		}
+		d.pos++
+	}
+	d.depth++
+	defer func() {
+		d.depth--
+	}()
+	for {
+		c := d.peek()
+		if c == end && (end != 0 || d.pos >= len(d.data)) {
+			if end != 0 {
+				d.pos++
+			}
+			return nil
+		}
+		if c == 0 {
+			return d.errorf("unexpected end of input, expected %q", end)
+		}
+		if c == '[' {
+			return d.errorf("extensions and Any expansion are not supported")
+		}
+		start := d.pos
+		for d.pos < len(d.data) && isTextIdentChar(d.data[d.pos]) {
+			d.pos++
+		}
+		if d.pos == start {
+			return d.errorf("unexpected %q, expected a field name", c)
+		}
+		name := string(d.data[start:d.pos])
+		if d.peek() == ':' {
+			d.pos++
+		}
+		if err := field(name); err != nil {
+			return err
+		}
+		if c := d.peek(); c == ',' || c == ';' {
+			d.pos++
+		}
+	}
+}
+
+// ReadRepeated calls entry once for a single value or for every element of a list.
+func (d *TextDecoder) ReadRepeated(entry func() error) error {
+	if d.peek() != '[' {
+		return entry()
+	}
+	d.pos++
+	if d.peek() == ']' {
+		d.pos++
+		return nil
+	}
+	for {
+		if err := entry(); err != nil {
+			return err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case ']':
+			d.pos++
+			return nil
+		default:
+			return d.errorf("expected ',' or ']' after list value")
+		}
+	}
+}
+
+func (d *TextDecoder) UnknownField(name string) error {
+	return d.errorf("unknown field %q", name)
+}
+
+func (d *TextDecoder) readQuoted() ([]byte, error) {
+	var res []byte
+	var found bool
+	for c := d.peek(); c == '"' || c == '\''; c = d.peek() {
+		found = true
+		d.pos++
+		for {
+			if d.pos >= len(d.data) || d.data[d.pos] == '\n' {
+				return nil, d.errorf("unterminated string")
+			}
+			c2 := d.data[d.pos]
+			d.pos++
+			if c2 == c {
+				break
+			}
+			if c2 != '\\' {
+				res = append(res, c2)
+				continue
+			}
+			var err error
+			if res, err = d.readEscape(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !found {
+		return nil, d.errorf("expected a string")
+	}
+	return res, nil
+}
+
+func (d *TextDecoder) readEscape(res []byte) ([]byte, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unterminated string")
+	}
+	c := d.data[d.pos]
+	d.pos++
+	switch c {
+	case 'a':
+		return append(res, '\a'), nil
+	case 'b':
+		return append(res, '\b'), nil
+	case 'f':
+		return append(res, '\f'), nil
+	case 'n':
+		return append(res, '\n'), nil
+	case 'r':
+		return append(res, '\r'), nil
+	case 't':
+		return append(res, '\t'), nil
+	case 'v':
+		return append(res, '\v'), nil
+	case '\\', '\'', '"', '?':
+		return append(res, c), nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		v := uint(c - '0')
+		for i := 0; i < 2 && d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '7'; i++ {
+			v = v*8 + uint(d.data[d.pos]-'0')
+			d.pos++
+		}
+		if v > 0xff {
+			return nil, d.errorf("invalid octal escape")
+		}
+		return append(res, byte(v)), nil
+	case 'x', 'X':
+		v, n := d.readHex(2)
+		if n == 0 {
+			return nil, d.errorf("invalid hex escape")
+		}
+		return append(res, byte(v)), nil
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		v, n := d.readHex(size)
+		if n != size || v > utf8.MaxRune {
+			return nil, d.errorf("invalid unicode escape")
+		}
+		return utf8.AppendRune(res, rune(v)), nil
+	}
+	return nil, d.errorf("invalid escape \\%c", c)
+}
+
+// readHex reads up to size hex digits.
+func (d *TextDecoder) readHex(size int) (uint32, int) {
+	var v uint32
+	var n int
+	for ; n < size && d.pos < len(d.data); n++ {
+		c := d.data[d.pos]
+		switch {
+		case c >= '0' && c <= '9':
+			c -= '0'
+		case c >= 'a' && c <= 'f':
+			c -= 'a' - 10
+		case c >= 'A' && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return v, n
+		}
+		v = v<<4 | uint32(c)
+		d.pos++
+	}
+	return v, n
+}
+
+// ReadString reads one or more adjacent quoted strings, the result must be valid UTF-8.
+func (d *TextDecoder) ReadString() (string, error) {
+	v, err := d.readQuoted()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(v) {
+		return "", d.errorf("invalid UTF-8 in string")
+	}
+	return string(v), nil
+}
+
+func (d *TextDecoder) ReadBytes() ([]byte, error) {
+	return d.readQuoted()
+}
+
+func (d *TextDecoder) readInt(bitSize int) (int64, error) {
+	lit := d.readToken()
+	v, err := strconv.ParseInt(lit, 0, bitSize)
+	if err != nil {
+		return 0, d.errorf("invalid integer %q", lit)
+	}
+	return v, nil
+}
+
+func (d *TextDecoder) readUint(bitSize int) (uint64, error) {
+	lit := d.readToken()
+	v, err := strconv.ParseUint(lit, 0, bitSize)
+	if err != nil {
+		return 0, d.errorf("invalid unsigned integer %q", lit)
+	}
+	return v, nil
+}
+
+func (d *TextDecoder) ReadInt32() (int32, error) {
+	v, err := d.readInt(32)
+	return int32(v), err
+}
+
+func (d *TextDecoder) ReadInt64() (int64, error) {
+	return d.readInt(64)
+}
+
+func (d *TextDecoder) ReadUint32() (uint32, error) {
+	v, err := d.readUint(32)
+	return uint32(v), err
+}
+
+func (d *TextDecoder) ReadUint64() (uint64, error) {
+	return d.readUint(64)
+}
+
+// readFloat accepts decimal and integer literals, an optional f suffix, inf, infinity and nan.
+func (d *TextDecoder) readFloat(bitSize int) (float64, error) {
+	lit := d.readToken()
+	if v, err := strconv.ParseInt(lit, 0, 64); err == nil {
+		return float64(v), nil
+	}
+	number := lit
+	switch strings.ToLower(strings.TrimPrefix(lit, "-")) {
+	case "inf", "infinity", "nan":
+	default:
+		number = strings.TrimSuffix(strings.TrimSuffix(number, "f"), "F")
+	}
+	v, err := strconv.ParseFloat(number, bitSize)
+	if err != nil {
+		return 0, d.errorf("invalid float %q", lit)
+	}
+	return v, nil
+}
+
+func (d *TextDecoder) ReadFloat32() (float32, error) {
+	v, err := d.readFloat(32)
+	return float32(v), err
+}
+
+func (d *TextDecoder) ReadFloat64() (float64, error) {
+	return d.readFloat(64)
+}
+
+func (d *TextDecoder) ReadBool() (bool, error) {
+	switch lit := d.readToken(); lit {
+	case "true", "True", "t", "1":
+		return true, nil
+	case "false", "False", "f", "0":
+		return false, nil
+	default:
+		return false, d.errorf("invalid boolean %q", lit)
+	}
+}
+
+// ReadEnum accepts a value name from values or a number.
+func (d *TextDecoder) ReadEnum(values map[string]int32) (int32, error) {
+	lit := d.readToken()
+	if v, ok := values[lit]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseInt(lit, 0, 32)
+	if err != nil {
+		return 0, d.errorf("invalid enum value %q", lit)
+	}
+	return int32(v), nil
+}
@@ -0,0 +1,98 @@
+package gremlin
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAppendTextString(t *testing.T) {
+	cases := map[string]string{
+		"plain":        `"plain"`,
+		"q\"b\\'":      `"q\"b\\'"`,
+		"\n\r\t":       `"\n\r\t"`,
+		"\x01\x1f\x7f": `"\x01\x1f\x7f"`,
+		"юникод":       `"юникод"`,
+		"\u0085":       `"\u0085"`,
+		"bad\xffutf8":  `"bad\xffutf8"`,
+	}
+	for in, want := range cases {
+		if got := string(appendTextString(nil, in)); got != want {
+			t.Errorf("appendTextString(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestTextWriterLayout(t *testing.T) {
+	write := func(w *TextWriter) {
+		w.WriteName("a")
+		w.WriteFloat(math.Inf(-1), 64)
+		w.WriteName("m")
+		w.StartMessage()
+		w.WriteName("b")
+		w.WriteEnum(7, "")
+		w.EndMessage()
+		w.WriteName("e")
+		w.StartMessage()
+		w.EndMessage()
+		w.WriteName("f")
+		w.WriteFloat(float64(float32(0.1)), 32)
+	}
+	if got, want := TextString(write), `a:-inf m:{b:7} e:{} f:0.1`; got != want {
+		t.Errorf("single line = %v, want %v", got, want)
+	}
+	w := NewTextWriter(nil, true)
+	write(w)
+	want := "a: -inf\nm: {\n  b: 7\n}\ne: {}\nf: 0.1\n"
+	if got := string(w.Finish()); got != want {
+		t.Errorf("multiline = %q, want %q", got, want)
+	}
+}
+
+func TestTextDecoderScalars(t *testing.T) {
+	d := NewTextDecoder([]byte(`1.5F -0x10 nan -Infinity 1e3 "a" 'b' "é\U0001F600\?" False 18446744073709551615`))
+	f, err := d.ReadFloat32()
+	if err != nil || f != 1.5 {
+		t.Fatalf("ReadFloat32 = %v, %v", f, err)
+	}
+	i, err := d.ReadInt32()
+	if err != nil || i != -16 {
+		t.Fatalf("ReadInt32 = %v, %v", i, err)
+	}
+	if v, err := d.ReadFloat64(); err != nil || !math.IsNaN(v) {
+		t.Fatalf("ReadFloat64 = %v, %v", v, err)
+	}
+	if v, err := d.ReadFloat64(); err != nil || !math.IsInf(v, -1) {
+		t.Fatalf("ReadFloat64 = %v, %v", v, err)
+	}
+	if v, err := d.ReadFloat64(); err != nil || v != 1000 {
+		t.Fatalf("ReadFloat64 = %v, %v", v, err)
+	}
+	// adjacent literals are concatenated
+	if s, err := d.ReadString(); err != nil || s != "abé😀?" {
+		t.Fatalf("ReadString = %q, %v", s, err)
+	}
+	if v, err := d.ReadBool(); err != nil || v {
+		t.Fatalf("ReadBool = %v, %v", v, err)
+	}
+	if v, err := d.ReadUint64(); err != nil || v != math.MaxUint64 {
+		t.Fatalf("ReadUint64 = %v, %v", v, err)
+	}
+}
+
+func TestTextDecoderErrors(t *testing.T) {
+	d := NewTextDecoder([]byte("a: 1\nb: x"))
+	err := d.ReadMessage(func(name string) error {
+		_, err := d.ReadInt32()
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 2:") {
+		t.Errorf("expected an error on line 2, got %v", err)
+	}
+	if _, err := NewTextDecoder([]byte(`"unterminated`)).ReadString(); err == nil {
+		t.Errorf("expected an error for an unterminated string")
+	}
+	if _, err := NewTextDecoder([]byte(`-1`)).ReadUint32(); err == nil {
+		t.Errorf("expected an error for a negative unsigned value")
+	}
+}
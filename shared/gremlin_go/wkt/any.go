@@ -0,0 +1,37 @@
+package wkt
+
+import gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+
+// PackAny marshals msg into an Any with the type URL of its descriptor, gremlin.UnpackAny reverses it.
+func PackAny(msg gremlin.ProtoMessage) *Any {
+	return &Any{
+		TypeUrl: gremlin.AnyTypeURL(msg.Descriptor()),
+		Value:   msg.Marshal(),
+	}
+}
+
+// Unpack returns the packed message, see gremlin.UnpackAny.
+func (m *AnyReader) Unpack() (gremlin.ProtoReader, error) {
+	return gremlin.UnpackAny(m)
+}
+
+// GetTypeUrl returns type_url, so that structs can be unpacked like readers.
+func (s *Any) GetTypeUrl() string {
+	if s == nil {
+		return ""
+	}
+	return s.TypeUrl
+}
+
+// GetValue returns value, so that structs can be unpacked like readers.
+func (s *Any) GetValue() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.Value
+}
+
+// Unpack returns the packed message, see gremlin.UnpackAny.
+func (s *Any) Unpack() (gremlin.ProtoReader, error) {
+	return gremlin.UnpackAny(s)
+}
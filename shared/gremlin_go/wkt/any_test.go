@@ -0,0 +1,60 @@
+package wkt
+
+import (
+	"testing"
+
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+func TestPackAny(t *testing.T) {
+	packed := PackAny(&Duration{Seconds: 90, Nanos: 5})
+	if packed.TypeUrl != "type.googleapis.com/google.protobuf.Duration" {
+		t.Errorf("unexpected type URL %v", packed.TypeUrl)
+	}
+
+	reader := NewAnyReader()
+	if err := reader.Unmarshal(packed.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	for _, any := range []gremlin.AnyMessage{reader, packed} {
+		msg, err := gremlin.UnpackAny(any)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, ok := msg.(*DurationReader)
+		if !ok {
+			t.Fatalf("expected a *DurationReader, got %T", msg)
+		}
+		if d.GetSeconds() != 90 || d.GetNanos() != 5 {
+			t.Errorf("unexpected duration %v", d)
+		}
+	}
+
+	// the host of the URL doesn't matter
+	msg, err := gremlin.UnpackAny(&Any{TypeUrl: "example.com/types/google.protobuf.Empty"})
+	if _, ok := msg.(*EmptyReader); !ok || err != nil {
+		t.Errorf("expected an *EmptyReader, got %T, %v", msg, err)
+	}
+}
+
+func TestUnpackUnknownAny(t *testing.T) {
+	unknown := &Any{TypeUrl: "type.googleapis.com/example.Missing", Value: []byte{8, 1}}
+	msg, err := unknown.Unpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, ok := msg.(*gremlin.RawAny)
+	if !ok || raw.TypeURL != unknown.TypeUrl || string(raw.Value) != string(unknown.Value) {
+		t.Errorf("unknown types should come back as raw bytes, got %#v", msg)
+	}
+
+	var unset *AnyReader
+	if msg, err := unset.Unpack(); msg != nil || err != nil {
+		t.Errorf("unset Any should unpack to nil, got %v, %v", msg, err)
+	}
+
+	malformed := &Any{TypeUrl: "type.googleapis.com/google.protobuf.Duration", Value: []byte{8}}
+	if _, err := malformed.Unpack(); err == nil {
+		t.Errorf("malformed values of known types should be an error")
+	}
+}
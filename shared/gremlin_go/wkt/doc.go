@@ -0,0 +1,5 @@
+// Package wkt is the generated code of the well-known types bundled with gremlinc, such as
+// google.protobuf.Timestamp, Duration or Any. Generated files which import google/protobuf/*.proto use it.
+package wkt
+
+//go:generate go run ../gremlinc/cmd/gen-wkt .
@@ -0,0 +1,9 @@
+package wkt
+
+// GetPaths returns paths, so that structs can be used as a gremlin.FieldMask like readers.
+func (s *FieldMask) GetPaths() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Paths
+}
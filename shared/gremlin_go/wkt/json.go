@@ -0,0 +1,420 @@
+package wkt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+// The JSON mappings of the well-known types, the same as protojson writes and reads them. Generated
+// AppendJSON and XXX_DecodeJSON of the types below call them, so fields of other messages use them too.
+// A nil message is written like an empty one. AppendJSON has no way to fail, so values protojson refuses
+// to write are written anyway: timestamps and durations out of range, field mask paths which don't map
+// to lowerCamelCase and back, and Any of types that are not in the registry, which keep their bytes
+// base64 encoded in "value" and are read back from that form.
+
+// valueJSONTypes are the packed types an Any writes in a "value" member, since they are no JSON objects
+// with fields.
+var valueJSONTypes = map[string]bool{
+	"google.protobuf.Any":         true,
+	"google.protobuf.Duration":    true,
+	"google.protobuf.Empty":       true,
+	"google.protobuf.FieldMask":   true,
+	"google.protobuf.ListValue":   true,
+	"google.protobuf.Struct":      true,
+	"google.protobuf.Timestamp":   true,
+	"google.protobuf.Value":       true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// jsonMessage is a generated struct, which packed messages are decoded into.
+type jsonMessage interface {
+	gremlin.ProtoMessage
+	AppendJSON(b []byte) []byte
+	XXX_DecodeJSON(d *gremlin.JSONDecoder) error
+}
+
+// orZero returns s, or an empty message if s is nil.
+func orZero[T any](s *T) *T {
+	if s == nil {
+		return new(T)
+	}
+	return s
+}
+
+// appendTimestampJSON writes an RFC 3339 string in UTC with 0, 3, 6 or 9 fractional digits.
+func appendTimestampJSON(b []byte, s *Timestamp) []byte {
+	s = orZero(s)
+	t := time.Unix(s.Seconds, int64(s.Nanos)).UTC()
+	b = append(b, '"')
+	b = appendFraction(t.AppendFormat(b, "2006-01-02T15:04:05.000000000"))
+	return append(b, `Z"`...)
+}
+
+func decodeTimestampJSON(d *gremlin.JSONDecoder, s *Timestamp) error {
+	str, err := d.ReadString()
+	if err != nil {
+		return err
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return fmt.Errorf("google.protobuf.Timestamp: invalid value %q", str)
+	}
+	s.Seconds, s.Nanos = t.Unix(), int32(t.Nanosecond())
+	return s.CheckValid()
+}
+
+// appendDurationJSON writes the seconds followed by "s", with 0, 3, 6 or 9 fractional digits.
+func appendDurationJSON(b []byte, s *Duration) []byte {
+	s = orZero(s)
+	seconds, nanos := s.Seconds, s.Nanos
+	b = append(b, '"')
+	if seconds < 0 || nanos < 0 {
+		b = append(b, '-')
+		seconds, nanos = -seconds, -nanos
+	}
+	b = strconv.AppendUint(b, uint64(seconds), 10)
+	b = append(b, '.')
+	for div := int32(1e8); div > 0; div /= 10 {
+		b = append(b, byte('0'+nanos/div%10))
+	}
+	b = appendFraction(b)
+	return append(b, `s"`...)
+}
+
+// appendFraction drops trailing groups of three zeros from the nine fractional digits at the end of b.
+func appendFraction(b []byte) []byte {
+	for i := 0; i < 3 && strings.HasSuffix(string(b[len(b)-3:]), "000"); i++ {
+		b = b[:len(b)-3]
+	}
+	if b[len(b)-1] == '.' {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+func decodeDurationJSON(d *gremlin.JSONDecoder, s *Duration) error {
+	str, err := d.ReadString()
+	if err != nil {
+		return err
+	}
+	invalid := fmt.Errorf("google.protobuf.Duration: invalid value %q", str)
+	rest, ok := strings.CutSuffix(str, "s")
+	if !ok {
+		return invalid
+	}
+	negative := strings.HasPrefix(rest, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(rest, "-"), ".")
+	if !isDigits(whole) || len(fraction) > 9 || (fraction != "" && !isDigits(fraction)) || strings.HasSuffix(rest, ".") {
+		return invalid
+	}
+	seconds, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return invalid
+	}
+	nanos := int64(0)
+	if fraction != "" {
+		nanos, _ = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 32)
+	}
+	if negative {
+		seconds, nanos = -seconds, -nanos
+	}
+	s.Seconds, s.Nanos = seconds, int32(nanos)
+	return s.CheckValid()
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// appendFieldMaskJSON writes the paths in lowerCamelCase, joined with commas.
+func appendFieldMaskJSON(b []byte, s *FieldMask) []byte {
+	var mask []byte
+	for i, path := range orZero(s).Paths {
+		if i > 0 {
+			mask = append(mask, ',')
+		}
+		underscore := false
+		for j := 0; j < len(path); j++ {
+			c := path[j]
+			if c == '_' {
+				underscore = true
+				continue
+			}
+			if underscore && c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			mask = append(mask, c)
+			underscore = false
+		}
+	}
+	return gremlin.AppendJSONString(b, string(mask))
+}
+
+func decodeFieldMaskJSON(d *gremlin.JSONDecoder, s *FieldMask) error {
+	str, err := d.ReadString()
+	if err != nil || str == "" {
+		return err
+	}
+	for _, path := range strings.Split(str, ",") {
+		if strings.Contains(path, "_") {
+			return fmt.Errorf("google.protobuf.FieldMask: invalid path %q", path)
+		}
+		var snake []byte
+		for i := 0; i < len(path); i++ {
+			c := path[i]
+			if c >= 'A' && c <= 'Z' {
+				snake = append(snake, '_')
+				c += 'a' - 'A'
+			}
+			snake = append(snake, c)
+		}
+		s.Paths = append(s.Paths, string(snake))
+	}
+	return nil
+}
+
+// appendAnyJSON writes "@type" next to the fields of the packed message, or next to a "value" member
+// for the well-known types.
+func appendAnyJSON(b []byte, s *Any) []byte {
+	s = orZero(s)
+	if s.TypeUrl == "" {
+		return append(b, "{}"...)
+	}
+	b = append(b, `{"@type":`...)
+	b = gremlin.AppendJSONString(b, s.TypeUrl)
+	msg := newAnyPayload(s.TypeUrl)
+	if msg == nil || msg.Unmarshal(s.Value) != nil {
+		b = append(b, `,"value":`...)
+		b = gremlin.AppendJSONBytes(b, s.Value)
+		return append(b, '}')
+	}
+	if valueJSONTypes[msg.Descriptor().FullName] {
+		b = append(b, `,"value":`...)
+		b = msg.AppendJSON(b)
+		return append(b, '}')
+	}
+	start := len(b)
+	b = msg.AppendJSON(b)
+	if len(b)-start == 2 {
+		// no fields
+		return append(b[:start], '}')
+	}
+	b[start] = ','
+	return b
+}
+
+func decodeAnyJSON(d *gremlin.JSONDecoder, s *Any) error {
+	typeURL, err := d.PeekAnyType()
+	if err != nil {
+		return err
+	}
+	if typeURL == "" {
+		return d.ReadObject(d.UnknownField)
+	}
+	msg := newAnyPayload(typeURL)
+	switch {
+	case msg == nil:
+		// the form written for types missing from the registry
+		err = d.ReadObject(func(key string) error {
+			switch key {
+			case "@type":
+				_, err := d.ReadString()
+				return err
+			case "value":
+				value, err := d.ReadBytes()
+				s.Value = value
+				return err
+			}
+			return fmt.Errorf("google.protobuf.Any: unknown type %q", typeURL)
+		})
+	case valueJSONTypes[msg.Descriptor().FullName]:
+		err = d.ReadObject(func(key string) error {
+			switch key {
+			case "@type":
+				_, err := d.ReadString()
+				return err
+			case "value":
+				return msg.XXX_DecodeJSON(d)
+			}
+			return d.UnknownField(key)
+		})
+		s.Value = msg.Marshal()
+	default:
+		d.SkipAnyType()
+		err = msg.XXX_DecodeJSON(d)
+		s.Value = msg.Marshal()
+	}
+	if err != nil {
+		return err
+	}
+	s.TypeUrl = typeURL
+	return nil
+}
+
+// newAnyPayload returns an empty struct of the registered type typeURL refers to, or nil.
+func newAnyPayload(typeURL string) jsonMessage {
+	desc := gremlin.FindMessageByURL(typeURL)
+	if desc == nil || desc.New == nil {
+		return nil
+	}
+	msg, _ := desc.New().(jsonMessage)
+	return msg
+}
+
+func appendEmptyJSON(b []byte, _ *Empty) []byte {
+	return append(b, "{}"...)
+}
+
+func decodeEmptyJSON(d *gremlin.JSONDecoder, _ *Empty) error {
+	return d.ReadObject(d.UnknownField)
+}
+
+// Struct, ListValue and Value are written as the JSON values they hold.
+
+func appendStructJSON(b []byte, s *Struct) []byte {
+	m := NewStructReader()
+	_ = m.Unmarshal(s.Marshal())
+	return AppendValueJSON(b, m)
+}
+
+func decodeStructJSON(d *gremlin.JSONDecoder, s *Struct) error {
+	m, err := XXX_DecodeMapJSON(d)
+	if err != nil {
+		return err
+	}
+	res := gremlin.NewWriter(structSize(m))
+	appendStruct(res, m)
+	return s.Unmarshal(res.Bytes())
+}
+
+func appendListValueJSON(b []byte, s *ListValue) []byte {
+	m := NewListValueReader()
+	_ = m.Unmarshal(s.Marshal())
+	return AppendValueJSON(b, m)
+}
+
+func decodeListValueJSON(d *gremlin.JSONDecoder, s *ListValue) error {
+	l, err := XXX_DecodeSliceJSON(d)
+	if err != nil {
+		return err
+	}
+	res := gremlin.NewWriter(listSize(l))
+	appendList(res, l)
+	return s.Unmarshal(res.Bytes())
+}
+
+func appendValueJSON(b []byte, s *Value) []byte {
+	m := NewValueReader()
+	_ = m.Unmarshal(s.Marshal())
+	return AppendValueJSON(b, m)
+}
+
+func decodeValueJSON(d *gremlin.JSONDecoder, s *Value) error {
+	v, err := d.ReadAny()
+	if err != nil {
+		return err
+	}
+	res := gremlin.NewWriter(valueSize(v))
+	appendValue(res, v)
+	return s.Unmarshal(res.Bytes())
+}
+
+// Wrappers are written as the value they hold.
+
+func appendDoubleValueJSON(b []byte, s *DoubleValue) []byte {
+	return gremlin.AppendJSONFloat(b, orZero(s).Value, 64)
+}
+
+func decodeDoubleValueJSON(d *gremlin.JSONDecoder, s *DoubleValue) (err error) {
+	s.Value, err = d.ReadFloat64()
+	return err
+}
+
+func appendFloatValueJSON(b []byte, s *FloatValue) []byte {
+	return gremlin.AppendJSONFloat(b, float64(orZero(s).Value), 32)
+}
+
+func decodeFloatValueJSON(d *gremlin.JSONDecoder, s *FloatValue) (err error) {
+	s.Value, err = d.ReadFloat32()
+	return err
+}
+
+func appendInt64ValueJSON(b []byte, s *Int64Value) []byte {
+	return gremlin.AppendJSONInt64(b, orZero(s).Value)
+}
+
+func decodeInt64ValueJSON(d *gremlin.JSONDecoder, s *Int64Value) (err error) {
+	s.Value, err = d.ReadInt64()
+	return err
+}
+
+func appendUInt64ValueJSON(b []byte, s *UInt64Value) []byte {
+	return gremlin.AppendJSONUint64(b, orZero(s).Value)
+}
+
+func decodeUInt64ValueJSON(d *gremlin.JSONDecoder, s *UInt64Value) (err error) {
+	s.Value, err = d.ReadUint64()
+	return err
+}
+
+func appendInt32ValueJSON(b []byte, s *Int32Value) []byte {
+	return gremlin.AppendJSONInt(b, int64(orZero(s).Value))
+}
+
+func decodeInt32ValueJSON(d *gremlin.JSONDecoder, s *Int32Value) (err error) {
+	s.Value, err = d.ReadInt32()
+	return err
+}
+
+func appendUInt32ValueJSON(b []byte, s *UInt32Value) []byte {
+	return gremlin.AppendJSONUint(b, uint64(orZero(s).Value))
+}
+
+func decodeUInt32ValueJSON(d *gremlin.JSONDecoder, s *UInt32Value) (err error) {
+	s.Value, err = d.ReadUint32()
+	return err
+}
+
+func appendBoolValueJSON(b []byte, s *BoolValue) []byte {
+	return gremlin.AppendJSONBool(b, orZero(s).Value)
+}
+
+func decodeBoolValueJSON(d *gremlin.JSONDecoder, s *BoolValue) (err error) {
+	s.Value, err = d.ReadBool()
+	return err
+}
+
+func appendStringValueJSON(b []byte, s *StringValue) []byte {
+	return gremlin.AppendJSONString(b, orZero(s).Value)
+}
+
+func decodeStringValueJSON(d *gremlin.JSONDecoder, s *StringValue) (err error) {
+	s.Value, err = d.ReadString()
+	return err
+}
+
+func appendBytesValueJSON(b []byte, s *BytesValue) []byte {
+	return gremlin.AppendJSONBytes(b, orZero(s).Value)
+}
+
+func decodeBytesValueJSON(d *gremlin.JSONDecoder, s *BytesValue) (err error) {
+	s.Value, err = d.ReadBytes()
+	return err
+}
@@ -0,0 +1,658 @@
+package wkt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"unicode/utf8"
+
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+// Conversions between google.protobuf.Struct, Value and ListValue and plain Go values. Decoded
+// values are nil, bool, float64, string, map[string]any and []any, like encoding/json produces.
+// Encoding also accepts the other integer and float types, []byte as a base64 string and
+// NullValue as null. Generated setters reject values of any other type with CheckValue, fields
+// assigned directly write them as null.
+//
+// The generated Value struct keeps the kinds in separate fields and loses null, 0, "" and false,
+// so the conversions go straight between Go values and the wire format.
+
+// CheckStruct reports whether m can be stored in a google.protobuf.Struct and written as JSON.
+func CheckStruct(m map[string]any) error {
+	for key, value := range m {
+		if !utf8.ValidString(key) {
+			return fmt.Errorf("key %q is not valid UTF-8", key)
+		}
+		if err := CheckValue(value); err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// CheckList reports whether l can be stored in a google.protobuf.ListValue and written as JSON.
+func CheckList(l []any) error {
+	for i, value := range l {
+		if err := CheckValue(value); err != nil {
+			return fmt.Errorf("index %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// CheckValue reports whether v can be stored in a google.protobuf.Value and written as JSON, numbers
+// must be finite and strings valid UTF-8.
+func CheckValue(v any) error {
+	v, err := normalize(v)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		return checkNumber(v)
+	case string:
+		if !utf8.ValidString(v) {
+			return fmt.Errorf("string %q is not valid UTF-8", v)
+		}
+	case map[string]any:
+		return CheckStruct(v)
+	case []any:
+		return CheckList(v)
+	}
+	return nil
+}
+
+func checkNumber(v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("number %v has no JSON representation", v)
+	}
+	return nil
+}
+
+// normalize converts v to nil, bool, float64, string, map[string]any or []any.
+func normalize(v any) (any, error) {
+	switch v := v.(type) {
+	case nil, NullValue:
+		return nil, nil
+	case bool, float64, string, map[string]any, []any:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// AsMap converts the struct to a map. Values without a kind and numbers that are not finite are an
+// error, the map still holds every other field then and nil in place of the invalid ones.
+func (m *StructReader) AsMap() (map[string]any, error) {
+	if m == nil {
+		return nil, nil
+	}
+	fields := m.GetFields()
+	res := make(map[string]any, len(fields))
+	var err error
+	for _, key := range gremlin.SortedKeys(fields) {
+		value, valueErr := entryAsInterface(fields[key])
+		if valueErr != nil && err == nil {
+			err = fmt.Errorf("key %q: %w", key, valueErr)
+		}
+		res[key] = value
+	}
+	return res, err
+}
+
+// AsSlice converts the list to a slice, errors are reported like AsMap does.
+func (m *ListValueReader) AsSlice() ([]any, error) {
+	if m == nil {
+		return nil, nil
+	}
+	values := m.GetValues()
+	res := make([]any, len(values))
+	var err error
+	for i, value := range values {
+		var valueErr error
+		res[i], valueErr = entryAsInterface(value)
+		if valueErr != nil && err == nil {
+			err = fmt.Errorf("index %v: %w", i, valueErr)
+		}
+	}
+	return res, err
+}
+
+// AsInterface converts the value to nil, bool, float64, string, map[string]any or []any. A value
+// without a kind is an error, and so is a number that is not finite, which is returned all the same.
+func (m *ValueReader) AsInterface() (any, error) {
+	if m == nil {
+		return nil, nil
+	}
+	switch m.kind() {
+	case wireValue_NullValue:
+		return nil, nil
+	case wireValue_NumberValue:
+		v := m.GetNumberValue()
+		return v, checkNumber(v)
+	case wireValue_StringValue:
+		return m.GetStringValue(), nil
+	case wireValue_BoolValue:
+		return m.GetBoolValue(), nil
+	case wireValue_StructValue:
+		return m.GetStructValue().AsMap()
+	case wireValue_ListValue:
+		return m.GetListValue().AsSlice()
+	}
+	return nil, errNoKind
+}
+
+var errNoKind = errors.New("google.protobuf.Value has no kind set")
+
+// entryAsInterface converts a value of a Struct or ListValue, empty values read as nil readers.
+func entryAsInterface(m *ValueReader) (any, error) {
+	if m == nil {
+		return nil, errNoKind
+	}
+	return m.AsInterface()
+}
+
+// kind returns the number of the kind field set in the value, 0 when there is none. As with other
+// oneofs the last one on the wire wins.
+func (m *ValueReader) kind() gremlin.ProtoWireNumber {
+	offsets := [...]int32{m.offsetNullValue, m.offsetNumberValue, m.offsetStringValue, m.offsetBoolValue, m.offsetStructValue, m.offsetListValue}
+	var kind gremlin.ProtoWireNumber
+	var last int32
+	for i, offset := range offsets {
+		if offset > last {
+			kind, last = gremlin.ProtoWireNumber(i+1), offset
+		}
+	}
+	return kind
+}
+
+// AppendValueJSON appends v in the canonical JSON mapping of google.protobuf.Value, v is a Go value
+// or a Struct, ListValue or Value reader. Keys are sorted like protojson sorts them.
+func AppendValueJSON(b []byte, v any) []byte {
+	switch r := v.(type) {
+	case *StructReader:
+		v, _ = r.AsMap()
+	case *ListValueReader:
+		v, _ = r.AsSlice()
+	case *ValueReader:
+		v, _ = r.AsInterface()
+	}
+	v, _ = normalize(v)
+	switch v := v.(type) {
+	case bool:
+		return gremlin.AppendJSONBool(b, v)
+	case float64:
+		return gremlin.AppendJSONFloat(b, v, 64)
+	case string:
+		return gremlin.AppendJSONString(b, v)
+	case map[string]any:
+		sep := byte('{')
+		for _, key := range gremlin.SortedKeys(v) {
+			b = append(b, sep)
+			sep = ','
+			b = gremlin.AppendJSONString(b, key)
+			b = append(b, ':')
+			b = AppendValueJSON(b, v[key])
+		}
+		if sep == '{' {
+			b = append(b, sep)
+		}
+		return append(b, '}')
+	case []any:
+		b = append(b, '[')
+		for i, value := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = AppendValueJSON(b, value)
+		}
+		return append(b, ']')
+	}
+	return append(b, "null"...)
+}
+
+func structSize(m map[string]any) int {
+	size := 0
+	for key, value := range m {
+		entrySize := gremlin.SizeTag(1) + gremlin.SizeUint64(uint64(len(key))) + len(key) + messageSize(2, valueSize(value))
+		size += messageSize(wireStruct_Fields, entrySize)
+	}
+	return size
+}
+
+func listSize(l []any) int {
+	size := 0
+	for _, value := range l {
+		size += messageSize(wireListValue_Values, valueSize(value))
+	}
+	return size
+}
+
+func valueSize(v any) int {
+	v, _ = normalize(v)
+	switch v := v.(type) {
+	case float64:
+		return gremlin.SizeTag(wireValue_NumberValue) + gremlin.SizeFloat64(v)
+	case string:
+		return gremlin.SizeTag(wireValue_StringValue) + gremlin.SizeUint64(uint64(len(v))) + len(v)
+	case bool:
+		return gremlin.SizeTag(wireValue_BoolValue) + gremlin.SizeBool(v)
+	case map[string]any:
+		return messageSize(wireValue_StructValue, structSize(v))
+	case []any:
+		return messageSize(wireValue_ListValue, listSize(v))
+	}
+	return gremlin.SizeTag(wireValue_NullValue) + gremlin.SizeInt32(0)
+}
+
+func messageSize(tag gremlin.ProtoWireNumber, size int) int {
+	return gremlin.SizeTag(tag) + gremlin.SizeUint64(uint64(size)) + size
+}
+
+func appendStruct(res *gremlin.Writer, m map[string]any) {
+	for _, key := range gremlin.SortedKeys(m) {
+		value := m[key]
+		size := valueSize(value)
+		res.AppendBytesTag(wireStruct_Fields, gremlin.SizeTag(1)+gremlin.SizeUint64(uint64(len(key)))+len(key)+messageSize(2, size))
+		res.AppendString(1, key)
+		res.AppendBytesTag(2, size)
+		appendValue(res, value)
+	}
+}
+
+func appendList(res *gremlin.Writer, l []any) {
+	for _, value := range l {
+		res.AppendBytesTag(wireListValue_Values, valueSize(value))
+		appendValue(res, value)
+	}
+}
+
+func appendValue(res *gremlin.Writer, v any) {
+	v, _ = normalize(v)
+	switch v := v.(type) {
+	case float64:
+		res.AppendFloat64(wireValue_NumberValue, v)
+	case string:
+		res.AppendString(wireValue_StringValue, v)
+	case bool:
+		res.AppendBool(wireValue_BoolValue, v)
+	case map[string]any:
+		res.AppendBytesTag(wireValue_StructValue, structSize(v))
+		appendStruct(res, v)
+	case []any:
+		res.AppendBytesTag(wireValue_ListValue, listSize(v))
+		appendList(res, v)
+	default:
+		res.AppendInt32(wireValue_NullValue, 0)
+	}
+}
+
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return XXX_CopyMap(v)
+	case []any:
+		return XXX_CopySlice(v)
+	case []byte:
+		return bytes.Clone(v)
+	}
+	return v
+}
+
+func writeStructText(w *gremlin.TextWriter, m map[string]any) {
+	for _, key := range gremlin.SortedKeys(m) {
+		w.WriteName("fields")
+		w.StartMessage()
+		w.WriteName("key")
+		w.WriteString(key)
+		w.WriteName("value")
+		w.StartMessage()
+		writeValueText(w, m[key])
+		w.EndMessage()
+		w.EndMessage()
+	}
+}
+
+func writeListText(w *gremlin.TextWriter, l []any) {
+	for _, value := range l {
+		w.WriteName("values")
+		w.StartMessage()
+		writeValueText(w, value)
+		w.EndMessage()
+	}
+}
+
+func writeValueText(w *gremlin.TextWriter, v any) {
+	v, _ = normalize(v)
+	switch v := v.(type) {
+	case float64:
+		w.WriteName("number_value")
+		w.WriteFloat(v, 64)
+	case string:
+		w.WriteName("string_value")
+		w.WriteString(v)
+	case bool:
+		w.WriteName("bool_value")
+		w.WriteBool(v)
+	case map[string]any:
+		w.WriteName("struct_value")
+		w.StartMessage()
+		writeStructText(w, v)
+		w.EndMessage()
+	case []any:
+		w.WriteName("list_value")
+		w.StartMessage()
+		writeListText(w, v)
+		w.EndMessage()
+	default:
+		w.WriteName("null_value")
+		w.WriteEnum(0, NullValue_NULL_VALUE.String())
+	}
+}
+
+func decodeStructText(d *gremlin.TextDecoder) (map[string]any, error) {
+	res := map[string]any{}
+	err := d.ReadMessage(func(name string) error {
+		if name != "fields" {
+			return d.UnknownField(name)
+		}
+		return d.ReadRepeated(func() error {
+			var key string
+			var value any
+			if err := d.ReadMessage(func(name string) error {
+				var err error
+				switch name {
+				case "key":
+					key, err = d.ReadString()
+				case "value":
+					value, err = decodeValueText(d)
+				default:
+					err = d.UnknownField(name)
+				}
+				return err
+			}); err != nil {
+				return err
+			}
+			res[key] = value
+			return nil
+		})
+	})
+	return res, err
+}
+
+func decodeListText(d *gremlin.TextDecoder) ([]any, error) {
+	res := []any{}
+	err := d.ReadMessage(func(name string) error {
+		if name != "values" {
+			return d.UnknownField(name)
+		}
+		return d.ReadRepeated(func() error {
+			value, err := decodeValueText(d)
+			res = append(res, value)
+			return err
+		})
+	})
+	return res, err
+}
+
+func decodeValueText(d *gremlin.TextDecoder) (any, error) {
+	var res any
+	set := false
+	err := d.ReadMessage(func(name string) error {
+		var err error
+		switch name {
+		case "null_value":
+			_, err = d.ReadEnum(NullValue_value)
+			res = nil
+		case "number_value":
+			res, err = d.ReadFloat64()
+		case "string_value":
+			res, err = d.ReadString()
+		case "bool_value":
+			res, err = d.ReadBool()
+		case "struct_value":
+			res, err = decodeStructText(d)
+		case "list_value":
+			res, err = decodeListText(d)
+		default:
+			return d.UnknownField(name)
+		}
+		set = true
+		return err
+	})
+	if err == nil && !set {
+		err = errNoKind
+	}
+	return res, err
+}
+
+// The functions below are used by generated code for fields of the Struct, ListValue and Value
+// types, which structs keep as map[string]any, []any and any. A Value field set to null holds
+// NullValue_NULL_VALUE, since nil means the field is unset.
+
+func XXX_MapSize(m map[string]any) int {
+	return structSize(m)
+}
+
+func XXX_SliceSize(l []any) int {
+	return listSize(l)
+}
+
+func XXX_InterfaceSize(v any) int {
+	return valueSize(v)
+}
+
+func XXX_AppendMap(res *gremlin.Writer, tag gremlin.ProtoWireNumber, m map[string]any) {
+	res.AppendBytesTag(tag, structSize(m))
+	appendStruct(res, m)
+}
+
+func XXX_AppendSlice(res *gremlin.Writer, tag gremlin.ProtoWireNumber, l []any) {
+	res.AppendBytesTag(tag, listSize(l))
+	appendList(res, l)
+}
+
+func XXX_AppendInterface(res *gremlin.Writer, tag gremlin.ProtoWireNumber, v any) {
+	res.AppendBytesTag(tag, valueSize(v))
+	appendValue(res, v)
+}
+
+func XXX_ReadMap(data []byte, opts gremlin.ReaderOptions) (map[string]any, error) {
+	m := NewStructReader()
+	if err := m.UnmarshalWithOptions(data, opts); err != nil {
+		return nil, err
+	}
+	return m.AsMap()
+}
+
+func XXX_ReadSlice(data []byte, opts gremlin.ReaderOptions) ([]any, error) {
+	m := NewListValueReader()
+	if err := m.UnmarshalWithOptions(data, opts); err != nil {
+		return nil, err
+	}
+	return m.AsSlice()
+}
+
+func XXX_ReadInterface(data []byte, opts gremlin.ReaderOptions) (any, error) {
+	m := NewValueReader()
+	if err := m.UnmarshalWithOptions(data, opts); err != nil {
+		return nil, err
+	}
+	v, err := m.AsInterface()
+	if v == nil && err == nil {
+		return NullValue_NULL_VALUE, nil
+	}
+	return v, err
+}
+
+// XXX_MapFromReader converts the reader like AsMap does, invalid values are left nil.
+func XXX_MapFromReader(m *StructReader) map[string]any {
+	res, _ := m.AsMap()
+	return res
+}
+
+func XXX_SliceFromReader(m *ListValueReader) []any {
+	res, _ := m.AsSlice()
+	return res
+}
+
+func XXX_InterfaceFromReader(m *ValueReader) any {
+	if m == nil {
+		return nil
+	}
+	res, _ := m.AsInterface()
+	if res == nil {
+		return NullValue_NULL_VALUE
+	}
+	return res
+}
+
+// XXX_CopyMap returns a deep copy of m.
+func XXX_CopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	res := maps.Clone(m)
+	for key, value := range res {
+		res[key] = copyValue(value)
+	}
+	return res
+}
+
+func XXX_CopySlice(l []any) []any {
+	if l == nil {
+		return nil
+	}
+	res := make([]any, len(l))
+	for i, value := range l {
+		res[i] = copyValue(value)
+	}
+	return res
+}
+
+func XXX_CopyInterface(v any) any {
+	return copyValue(v)
+}
+
+func XXX_DecodeMapJSON(d *gremlin.JSONDecoder) (map[string]any, error) {
+	v, err := d.ReadAny()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.Struct must be a JSON object, got %T", v)
+	}
+	return m, nil
+}
+
+func XXX_DecodeSliceJSON(d *gremlin.JSONDecoder) ([]any, error) {
+	v, err := d.ReadAny()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.ListValue must be a JSON array, got %T", v)
+	}
+	return l, nil
+}
+
+// XXX_DecodeInterfaceJSON reads any value, null never reaches it since it leaves fields unset.
+func XXX_DecodeInterfaceJSON(d *gremlin.JSONDecoder) (any, error) {
+	return d.ReadAny()
+}
+
+func XXX_TranscodeMapJSON(s *gremlin.JSONStream, data []byte) error {
+	m := NewStructReader()
+	if err := m.UnmarshalWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	s.B = AppendValueJSON(s.B, m)
+	return nil
+}
+
+func XXX_TranscodeSliceJSON(s *gremlin.JSONStream, data []byte) error {
+	m := NewListValueReader()
+	if err := m.UnmarshalWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	s.B = AppendValueJSON(s.B, m)
+	return nil
+}
+
+func XXX_TranscodeInterfaceJSON(s *gremlin.JSONStream, data []byte) error {
+	m := NewValueReader()
+	if err := m.UnmarshalWithOptions(data, gremlin.ReaderOptions{Buffer: gremlin.BufferAlias}); err != nil {
+		return err
+	}
+	s.B = AppendValueJSON(s.B, m)
+	return nil
+}
+
+// XXX_WriteMapText writes the fields of a Struct, v is a map or a StructReader.
+func XXX_WriteMapText(w *gremlin.TextWriter, v any) {
+	if m, ok := v.(*StructReader); ok {
+		v, _ = m.AsMap()
+	}
+	m, _ := v.(map[string]any)
+	writeStructText(w, m)
+}
+
+func XXX_WriteSliceText(w *gremlin.TextWriter, v any) {
+	if m, ok := v.(*ListValueReader); ok {
+		v, _ = m.AsSlice()
+	}
+	l, _ := v.([]any)
+	writeListText(w, l)
+}
+
+func XXX_WriteInterfaceText(w *gremlin.TextWriter, v any) {
+	if m, ok := v.(*ValueReader); ok {
+		v, _ = m.AsInterface()
+	}
+	writeValueText(w, v)
+}
+
+func XXX_DecodeMapText(d *gremlin.TextDecoder) (map[string]any, error) {
+	return decodeStructText(d)
+}
+
+func XXX_DecodeSliceText(d *gremlin.TextDecoder) ([]any, error) {
+	return decodeListText(d)
+}
+
+func XXX_DecodeInterfaceText(d *gremlin.TextDecoder) (any, error) {
+	v, err := decodeValueText(d)
+	if v == nil && err == nil {
+		return NullValue_NULL_VALUE, nil
+	}
+	return v, err
+}
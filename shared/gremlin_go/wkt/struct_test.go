@@ -0,0 +1,111 @@
+package wkt
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	gremlin "github.com/norma-core/norma-core/shared/gremlin_go"
+)
+
+func TestStructConversions(t *testing.T) {
+	m := map[string]any{
+		"null":   nil,
+		"zero":   0,
+		"empty":  "",
+		"no":     false,
+		"int":    int64(-3),
+		"float":  float32(1.5),
+		"bytes":  []byte{0xff},
+		"enum":   NullValue_NULL_VALUE,
+		"object": map[string]any{},
+		"list":   []any{"a", 2, map[string]any{"b": true}, []any{}},
+	}
+	want := map[string]any{
+		"null":   nil,
+		"zero":   0.0,
+		"empty":  "",
+		"no":     false,
+		"int":    -3.0,
+		"float":  1.5,
+		"bytes":  "/w==",
+		"enum":   nil,
+		"object": map[string]any{},
+		"list":   []any{"a", 2.0, map[string]any{"b": true}, []any{}},
+	}
+
+	w := gremlin.NewWriter(structSize(m))
+	appendStruct(w, m)
+	data := w.Bytes()
+	if len(data) != structSize(m) {
+		t.Errorf("wrote %v bytes, expected %v", len(data), structSize(m))
+	}
+	reader := NewStructReader()
+	if err := reader.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	got, err := reader.AsMap()
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected map %#v, %v", got, err)
+	}
+
+	wantJSON := `{"bytes":"/w==","empty":"","enum":null,"float":1.5,"int":-3,"list":["a",2,{"b":true},[]],"no":false,"null":null,"object":{},"zero":0}`
+	if got := string(AppendValueJSON(nil, m)); got != wantJSON {
+		t.Errorf("unexpected JSON %v", got)
+	}
+	if got := string(AppendValueJSON(nil, reader)); got != wantJSON {
+		t.Errorf("unexpected reader JSON %v", got)
+	}
+
+	text := gremlin.NewTextWriter(nil, false)
+	writeStructText(text, m)
+	fromText, err := decodeStructText(gremlin.NewTextDecoder(text.Finish()))
+	if err != nil || !reflect.DeepEqual(fromText, want) {
+		t.Errorf("unexpected text round trip %#v, %v", fromText, err)
+	}
+
+	copied := XXX_CopyMap(m)
+	copied["list"].([]any)[0] = "changed"
+	if m["list"].([]any)[0] != "a" {
+		t.Errorf("XXX_CopyMap should copy nested lists")
+	}
+}
+
+func TestStructValidation(t *testing.T) {
+	if err := CheckStruct(map[string]any{"a": []any{1, "b", nil, map[string]any{"c": uint8(1)}}}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, invalid := range []map[string]any{
+		{"nan": math.NaN()},
+		{"inf": []any{math.Inf(1)}},
+		{"utf8": "\xff"},
+		{"\xff": 1},
+		{"type": map[string]any{"chan": make(chan int)}},
+	} {
+		if err := CheckStruct(invalid); err == nil {
+			t.Errorf("%v should be invalid", invalid)
+		}
+	}
+
+	// a value without a kind can't be converted, the other fields still are
+	w := gremlin.NewWriter(64)
+	w.AppendBytesTag(wireStruct_Fields, 5)
+	w.AppendString(1, "a")
+	w.AppendBytesTag(2, 0)
+	appendStruct(w, map[string]any{"b": 1})
+	reader := NewStructReader()
+	if err := reader.Unmarshal(w.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := reader.AsMap()
+	if err == nil {
+		t.Errorf("a value without a kind should be an error")
+	}
+	if !reflect.DeepEqual(got, map[string]any{"a": nil, "b": 1.0}) {
+		t.Errorf("unexpected map %v", got)
+	}
+
+	if _, err := decodeValueText(gremlin.NewTextDecoder([]byte(""))); err == nil {
+		t.Errorf("a value without a kind should be rejected in the text format")
+	}
+}
@@ -0,0 +1,147 @@
+package wkt
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// range of google.protobuf.Timestamp, 0001-01-01T00:00:00Z to 9999-12-31T23:59:59.999999999Z
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+
+	// range of google.protobuf.Duration, about 10000 years
+	maxDurationSeconds = 315576000000
+
+	// longest whole number of seconds that fits into a time.Duration
+	maxGoDurationSeconds = int64(1<<63-1) / int64(time.Second)
+)
+
+// NewTimestamp converts t to a Timestamp, times before year 1 or after year 9999 are an error.
+func NewTimestamp(t time.Time) (*Timestamp, error) {
+	res := &Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+	if err := res.CheckValid(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AsTime converts the timestamp to a time.Time in UTC, a nil timestamp is the zero time.Time.
+func (s *Timestamp) AsTime() (time.Time, error) {
+	if s == nil {
+		return time.Time{}, nil
+	}
+	return timestampAsTime(s.Seconds, s.Nanos)
+}
+
+// CheckValid reports whether the timestamp is in the range of google.protobuf.Timestamp with nanos in [0, 1e9).
+func (s *Timestamp) CheckValid() error {
+	if s == nil {
+		return nil
+	}
+	return checkTimestamp(s.Seconds, s.Nanos)
+}
+
+// AsTime converts the timestamp to a time.Time in UTC, a nil timestamp is the zero time.Time.
+func (m *TimestampReader) AsTime() (time.Time, error) {
+	if m == nil {
+		return time.Time{}, nil
+	}
+	return timestampAsTime(m.GetSeconds(), m.GetNanos())
+}
+
+// CheckValid reports whether the timestamp is in the range of google.protobuf.Timestamp with nanos in [0, 1e9).
+func (m *TimestampReader) CheckValid() error {
+	if m == nil {
+		return nil
+	}
+	return checkTimestamp(m.GetSeconds(), m.GetNanos())
+}
+
+func timestampAsTime(seconds int64, nanos int32) (time.Time, error) {
+	if err := checkTimestamp(seconds, nanos); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, int64(nanos)).UTC(), nil
+}
+
+func checkTimestamp(seconds int64, nanos int32) error {
+	switch {
+	case seconds < minTimestampSeconds:
+		return fmt.Errorf("timestamp (%v, %v) before 0001-01-01", seconds, nanos)
+	case seconds > maxTimestampSeconds:
+		return fmt.Errorf("timestamp (%v, %v) after 9999-12-31", seconds, nanos)
+	case nanos < 0 || nanos >= 1e9:
+		return fmt.Errorf("timestamp (%v, %v) has out of range nanos", seconds, nanos)
+	}
+	return nil
+}
+
+// NewDuration converts d to a Duration, every time.Duration fits.
+func NewDuration(d time.Duration) *Duration {
+	nanos := d.Nanoseconds()
+	return &Duration{Seconds: nanos / 1e9, Nanos: int32(nanos % 1e9)}
+}
+
+// AsDuration converts the duration to a time.Duration, a nil duration is 0. Durations outside
+// of the range of google.protobuf.Duration or time.Duration are an error.
+func (s *Duration) AsDuration() (time.Duration, error) {
+	if s == nil {
+		return 0, nil
+	}
+	return durationAsDuration(s.Seconds, s.Nanos)
+}
+
+// CheckValid reports whether the duration is in the range of google.protobuf.Duration, with nanos
+// in (-1e9, 1e9) and of the same sign as seconds.
+func (s *Duration) CheckValid() error {
+	if s == nil {
+		return nil
+	}
+	return checkDuration(s.Seconds, s.Nanos)
+}
+
+// AsDuration converts the duration to a time.Duration, a nil duration is 0. Durations outside
+// of the range of google.protobuf.Duration or time.Duration are an error.
+func (m *DurationReader) AsDuration() (time.Duration, error) {
+	if m == nil {
+		return 0, nil
+	}
+	return durationAsDuration(m.GetSeconds(), m.GetNanos())
+}
+
+// CheckValid reports whether the duration is in the range of google.protobuf.Duration, with nanos
+// in (-1e9, 1e9) and of the same sign as seconds.
+func (m *DurationReader) CheckValid() error {
+	if m == nil {
+		return nil
+	}
+	return checkDuration(m.GetSeconds(), m.GetNanos())
+}
+
+func durationAsDuration(seconds int64, nanos int32) (time.Duration, error) {
+	if err := checkDuration(seconds, nanos); err != nil {
+		return 0, err
+	}
+	if seconds > maxGoDurationSeconds || seconds < -maxGoDurationSeconds {
+		return 0, fmt.Errorf("duration (%v, %v) overflows time.Duration", seconds, nanos)
+	}
+	d := time.Duration(seconds)*time.Second + time.Duration(nanos)
+	// nanos on top of the longest whole seconds wrap around
+	if (seconds > 0 && d < 0) || (seconds < 0 && d > 0) {
+		return 0, fmt.Errorf("duration (%v, %v) overflows time.Duration", seconds, nanos)
+	}
+	return d, nil
+}
+
+func checkDuration(seconds int64, nanos int32) error {
+	switch {
+	case seconds < -maxDurationSeconds || seconds > maxDurationSeconds:
+		return fmt.Errorf("duration (%v, %v) exceeds 10000 years", seconds, nanos)
+	case nanos <= -1e9 || nanos >= 1e9:
+		return fmt.Errorf("duration (%v, %v) has out of range nanos", seconds, nanos)
+	case (seconds > 0 && nanos < 0) || (seconds < 0 && nanos > 0):
+		return fmt.Errorf("duration (%v, %v) has seconds and nanos of different signs", seconds, nanos)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package wkt
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimestampConversions(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Unix(0, 0).UTC(),
+		time.Date(2024, 2, 29, 12, 30, 15, 123456789, time.UTC),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 500, time.UTC),
+	} {
+		stamp, err := NewTimestamp(want)
+		if err != nil {
+			t.Fatalf("%v: %v", want, err)
+		}
+		got, err := stamp.AsTime()
+		if err != nil || !got.Equal(want) {
+			t.Errorf("%v converted back to %v, %v", want, got, err)
+		}
+
+		reader := NewTimestampReader()
+		if err := reader.Unmarshal(stamp.Marshal()); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := reader.AsTime(); err != nil || !got.Equal(want) {
+			t.Errorf("%v read back as %v, %v", want, got, err)
+		}
+	}
+
+	if _, err := NewTimestamp(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("year 10000 should be out of range")
+	}
+	if _, err := NewTimestamp(time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC)); err == nil {
+		t.Errorf("year 0 should be out of range")
+	}
+	for _, invalid := range []*Timestamp{
+		{Seconds: maxTimestampSeconds + 1},
+		{Seconds: minTimestampSeconds - 1},
+		{Nanos: -1},
+		{Nanos: 1e9},
+	} {
+		if _, err := invalid.AsTime(); err == nil {
+			t.Errorf("%v should be invalid", invalid)
+		}
+	}
+
+	var unset *Timestamp
+	if got, err := unset.AsTime(); err != nil || !got.IsZero() {
+		t.Errorf("nil timestamps should be the zero time, got %v, %v", got, err)
+	}
+}
+
+func TestDurationConversions(t *testing.T) {
+	for _, want := range []time.Duration{0, time.Nanosecond, -1500 * time.Millisecond, 90 * time.Minute, math.MaxInt64, math.MinInt64} {
+		d := NewDuration(want)
+		if err := d.CheckValid(); err != nil {
+			t.Errorf("%v: %v", want, err)
+		}
+		got, err := d.AsDuration()
+		if err != nil || got != want {
+			t.Errorf("%v converted back to %v, %v", want, got, err)
+		}
+
+		reader := NewDurationReader()
+		if err := reader.Unmarshal(d.Marshal()); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := reader.AsDuration(); err != nil || got != want {
+			t.Errorf("%v read back as %v, %v", want, got, err)
+		}
+	}
+
+	for _, invalid := range []*Duration{
+		{Seconds: maxDurationSeconds + 1},
+		{Seconds: -maxDurationSeconds - 1},
+		{Nanos: 1e9},
+		{Seconds: 1, Nanos: -1},
+		{Seconds: -1, Nanos: 1},
+	} {
+		if err := invalid.CheckValid(); err == nil {
+			t.Errorf("%v should be invalid", invalid)
+		}
+	}
+
+	// valid durations that time.Duration can't hold
+	for _, overflow := range []*Duration{
+		{Seconds: maxGoDurationSeconds + 1},
+		{Seconds: maxGoDurationSeconds, Nanos: 999999999},
+		{Seconds: -maxGoDurationSeconds, Nanos: -999999999},
+	} {
+		if err := overflow.CheckValid(); err != nil {
+			t.Errorf("%v should be valid: %v", overflow, err)
+		}
+		if _, err := overflow.AsDuration(); err == nil {
+			t.Errorf("%v should overflow time.Duration", overflow)
+		}
+	}
+}
@@ -43,6 +43,11 @@ func SizeBytes(data []byte) int {
 	return len(data)
 }
 
+// AppendRaw appends data that is already encoded, such as unknown fields.
+func (p *Writer) AppendRaw(data []byte) {
+	p.writeBytes(data)
+}
+
 func (p *Writer) AppendBool(tag ProtoWireNumber, data bool) {
 	p.appendTag(tag, VarIntType)
 	p.AppendBoolWithoutTag(data)